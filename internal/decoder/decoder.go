@@ -18,12 +18,8 @@ import (
 	tfschema "github.com/hashicorp/terraform-schema/schema"
 )
 
-func modulePathContext(mod *state.Module, schemaReader state.SchemaReader, modReader ModuleReader) (*decoder.PathContext, error) {
-	schema, err := schemaForModule(mod, schemaReader, modReader)
-	if err != nil {
-		return nil, err
-	}
-	functions, err := functionsForModule(mod, schemaReader)
+func modulePathContext(mod *state.Module, schemaReader state.SchemaReader, modReader ModuleReader, schemaCache *ModuleSchemaCache) (*decoder.PathContext, error) {
+	schema, functions, err := moduleSchemaAndFunctions(mod, schemaReader, modReader, schemaCache)
 	if err != nil {
 		return nil, err
 	}
@@ -7,21 +7,31 @@ import (
 	"bytes"
 	"compress/gzip"
 	"context"
+	"fmt"
 	"io"
 	"io/fs"
 	"log"
 	"path"
 	"path/filepath"
+	"slices"
+	"strings"
 	"sync"
 	"testing"
 	"testing/fstest"
 
+	"github.com/hashicorp/go-version"
 	"github.com/hashicorp/hcl-lang/decoder"
 	"github.com/hashicorp/hcl-lang/lang"
+	"github.com/hashicorp/hcl/v2"
+	tfjson "github.com/hashicorp/terraform-json"
 	lsctx "github.com/hashicorp/terraform-ls/internal/context"
 	idecoder "github.com/hashicorp/terraform-ls/internal/decoder"
+	"github.com/hashicorp/terraform-ls/internal/job"
 	"github.com/hashicorp/terraform-ls/internal/state"
 	"github.com/hashicorp/terraform-ls/internal/terraform/module"
+	tfaddr "github.com/hashicorp/terraform-registry-address"
+	tfschema "github.com/hashicorp/terraform-schema/schema"
+	"github.com/zclconf/go-cty/cty"
 )
 
 func TestDecoder_CodeLensesForFile_concurrencyBug(t *testing.T) {
@@ -96,6 +106,419 @@ func TestDecoder_CodeLensesForFile_concurrencyBug(t *testing.T) {
 	wg.Wait()
 }
 
+func TestDecoder_CompletionAtPos_providerBlock(t *testing.T) {
+	ss, err := state.NewStateStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testCfg := `terraform {
+  required_providers {
+    aws = {
+      source = "hashicorp/aws"
+    }
+  }
+}
+
+provider "aws" {
+  
+  assume_role {
+    
+  }
+}
+`
+	dirName := "testdir"
+	mapFs := fstest.MapFS{
+		dirName:                           &fstest.MapFile{Mode: fs.ModeDir},
+		path.Join(dirName, "main.tf"):     &fstest.MapFile{Data: []byte(testCfg)},
+		filepath.Join(dirName, "main.tf"): &fstest.MapFile{Data: []byte(testCfg)},
+	}
+
+	ctx := context.Background()
+
+	err = ss.Modules.Add(dirName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx = lsctx.WithDocumentContext(ctx, lsctx.Document{})
+	err = module.ParseModuleConfiguration(ctx, mapFs, ss.Modules, dirName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = module.LoadModuleMetadata(ctx, ss.Modules, dirName)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pAddr := tfaddr.MustParseProviderSource("hashicorp/aws")
+	pSchema := tfschema.ProviderSchemaFromJson(&tfjson.ProviderSchema{
+		ConfigSchema: &tfjson.Schema{
+			Block: &tfjson.SchemaBlock{
+				Attributes: map[string]*tfjson.SchemaAttribute{
+					"region": {
+						AttributeType: cty.String,
+						Optional:      true,
+					},
+				},
+				NestedBlocks: map[string]*tfjson.SchemaBlockType{
+					"assume_role": {
+						NestingMode: tfjson.SchemaNestingModeList,
+						Block: &tfjson.SchemaBlock{
+							Attributes: map[string]*tfjson.SchemaAttribute{
+								"role_arn": {
+									AttributeType: cty.String,
+									Optional:      true,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}, pAddr)
+	err = ss.ProviderSchemas.AddLocalSchema(dirName, pAddr, pSchema)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	d := decoder.NewDecoder(&idecoder.PathReader{
+		ModuleReader: ss.Modules,
+		SchemaReader: ss.ProviderSchemas,
+	})
+	pathDecoder, err := d.Path(lang.Path{
+		Path:       dirName,
+		LanguageID: "terraform",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		name          string
+		pos           hcl.Pos
+		expectedLabel string
+	}{
+		{
+			"provider block body",
+			hcl.Pos{Line: 10, Column: 3, Byte: 110},
+			"region",
+		},
+		{
+			"provider block body nested block",
+			hcl.Pos{Line: 10, Column: 3, Byte: 110},
+			"assume_role",
+		},
+		{
+			"nested block body",
+			hcl.Pos{Line: 12, Column: 5, Byte: 131},
+			"role_arn",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			candidates, err := pathDecoder.CompletionAtPos(ctx, "main.tf", tc.pos)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			for _, c := range candidates.List {
+				if c.Label == tc.expectedLabel {
+					return
+				}
+			}
+			t.Fatalf("expected %q candidate, given: %#v", tc.expectedLabel, candidates.List)
+		})
+	}
+}
+
+func TestDecoder_CompletionAtPos_providerVersionChange(t *testing.T) {
+	ss, err := state.NewStateStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cfgForConstraint := func(constraint string) string {
+		return fmt.Sprintf(`terraform {
+  required_providers {
+    aws = {
+      source  = "hashicorp/aws"
+      version = %q
+    }
+  }
+}
+
+provider "aws" {
+  
+}
+`, constraint)
+	}
+	fsForConstraint := func(dirName, constraint string) fstest.MapFS {
+		cfg := []byte(cfgForConstraint(constraint))
+		return fstest.MapFS{
+			dirName:                           &fstest.MapFile{Mode: fs.ModeDir},
+			path.Join(dirName, "main.tf"):     &fstest.MapFile{Data: cfg},
+			filepath.Join(dirName, "main.tf"): &fstest.MapFile{Data: cfg},
+		}
+	}
+
+	pAddr := tfaddr.MustParseProviderSource("hashicorp/aws")
+	schemaWithAttribute := func(name string) *tfschema.ProviderSchema {
+		return tfschema.ProviderSchemaFromJson(&tfjson.ProviderSchema{
+			ConfigSchema: &tfjson.Schema{
+				Block: &tfjson.SchemaBlock{
+					Attributes: map[string]*tfjson.SchemaAttribute{
+						name: {
+							AttributeType: cty.String,
+							Optional:      true,
+						},
+					},
+				},
+			},
+		}, pAddr)
+	}
+	err = ss.ProviderSchemas.AddPreloadedSchema(pAddr, version.Must(version.NewVersion("4.0.0")), schemaWithAttribute("v4_attr"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = ss.ProviderSchemas.AddPreloadedSchema(pAddr, version.Must(version.NewVersion("5.0.0")), schemaWithAttribute("v5_attr"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dirName := "testdir"
+	ctx := context.Background()
+	ctx = lsctx.WithDocumentContext(ctx, lsctx.Document{})
+	// reflect changes the same way as document changes do
+	ctx = job.WithIgnoreState(ctx, true)
+
+	err = ss.Modules.Add(dirName)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	d := decoder.NewDecoder(&idecoder.PathReader{
+		ModuleReader: ss.Modules,
+		SchemaReader: ss.ProviderSchemas,
+	})
+
+	testCases := []struct {
+		constraint    string
+		expectedLabel string
+	}{
+		{"~> 4.0", "v4_attr"},
+		{"~> 5.0", "v5_attr"},
+		{"< 5.0", "v4_attr"},
+	}
+
+	for _, tc := range testCases {
+		err = module.ParseModuleConfiguration(ctx, fsForConstraint(dirName, tc.constraint), ss.Modules, dirName)
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = module.LoadModuleMetadata(ctx, ss.Modules, dirName)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		pathDecoder, err := d.Path(lang.Path{
+			Path:       dirName,
+			LanguageID: "terraform",
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		cfg := cfgForConstraint(tc.constraint)
+		blockHeader := "provider \"aws\" {\n"
+		pos := hcl.Pos{
+			Line:   11,
+			Column: 3,
+			Byte:   strings.Index(cfg, blockHeader) + len(blockHeader) + 2,
+		}
+
+		candidates, err := pathDecoder.CompletionAtPos(ctx, "main.tf", pos)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		labels := make([]string, 0)
+		for _, c := range candidates.List {
+			labels = append(labels, c.Label)
+		}
+		if !slices.Contains(labels, tc.expectedLabel) {
+			t.Fatalf("%s: expected %q candidate, given: %q", tc.constraint, tc.expectedLabel, labels)
+		}
+	}
+}
+
+func TestDecoder_dataSourceSchema(t *testing.T) {
+	ss, err := state.NewStateStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testCfg := `terraform {
+  required_providers {
+    aws = {
+      source = "hashicorp/aws"
+    }
+  }
+}
+
+data "aws_ami" "test" {
+  
+}
+
+resource "aws_ami" "test" {
+  
+}
+`
+	dirName := "testdir"
+	mapFs := fstest.MapFS{
+		dirName:                           &fstest.MapFile{Mode: fs.ModeDir},
+		path.Join(dirName, "main.tf"):     &fstest.MapFile{Data: []byte(testCfg)},
+		filepath.Join(dirName, "main.tf"): &fstest.MapFile{Data: []byte(testCfg)},
+	}
+
+	ctx := context.Background()
+
+	err = ss.Modules.Add(dirName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx = lsctx.WithDocumentContext(ctx, lsctx.Document{})
+	err = module.ParseModuleConfiguration(ctx, mapFs, ss.Modules, dirName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = module.LoadModuleMetadata(ctx, ss.Modules, dirName)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The data source and the managed resource of the same name
+	// deliberately have different attributes
+	pAddr := tfaddr.MustParseProviderSource("hashicorp/aws")
+	pSchema := tfschema.ProviderSchemaFromJson(&tfjson.ProviderSchema{
+		ResourceSchemas: map[string]*tfjson.Schema{
+			"aws_ami": {
+				Block: &tfjson.SchemaBlock{
+					Attributes: map[string]*tfjson.SchemaAttribute{
+						"name": {
+							AttributeType: cty.String,
+							Required:      true,
+						},
+					},
+				},
+			},
+		},
+		DataSourceSchemas: map[string]*tfjson.Schema{
+			"aws_ami": {
+				Block: &tfjson.SchemaBlock{
+					Attributes: map[string]*tfjson.SchemaAttribute{
+						"most_recent": {
+							AttributeType: cty.Bool,
+							Optional:      true,
+						},
+						"owners": {
+							AttributeType: cty.List(cty.String),
+							Required:      true,
+						},
+						"image_id": {
+							AttributeType: cty.String,
+							Computed:      true,
+						},
+					},
+				},
+			},
+		},
+	}, pAddr)
+	err = ss.ProviderSchemas.AddLocalSchema(dirName, pAddr, pSchema)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	d := decoder.NewDecoder(&idecoder.PathReader{
+		ModuleReader: ss.Modules,
+		SchemaReader: ss.ProviderSchemas,
+	})
+	pathDecoder, err := d.Path(lang.Path{
+		Path:       dirName,
+		LanguageID: "terraform",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	completionCases := []struct {
+		name             string
+		pos              hcl.Pos
+		expectedLabels   []string
+		unexpectedLabels []string
+	}{
+		{
+			"data block body",
+			hcl.Pos{Line: 10, Column: 3, Byte: 117},
+			[]string{"most_recent", "owners"},
+			// read-only attribute and managed resource attribute
+			[]string{"image_id", "name"},
+		},
+		{
+			"resource block body",
+			hcl.Pos{Line: 14, Column: 3, Byte: 151},
+			[]string{"name"},
+			[]string{"most_recent", "owners"},
+		},
+	}
+
+	for _, tc := range completionCases {
+		t.Run(tc.name, func(t *testing.T) {
+			candidates, err := pathDecoder.CompletionAtPos(ctx, "main.tf", tc.pos)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			hasLabel := func(label string) bool {
+				return slices.ContainsFunc(candidates.List, func(c lang.Candidate) bool {
+					return c.Label == label
+				})
+			}
+			for _, label := range tc.expectedLabels {
+				if !hasLabel(label) {
+					t.Fatalf("expected %q candidate, given: %#v", label, candidates.List)
+				}
+			}
+			for _, label := range tc.unexpectedLabels {
+				if hasLabel(label) {
+					t.Fatalf("unexpected %q candidate, given: %#v", label, candidates.List)
+				}
+			}
+		})
+	}
+
+	t.Run("validation", func(t *testing.T) {
+		diags, err := pathDecoder.ValidateFile(ctx, "main.tf")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		summaries := make([]string, 0)
+		for _, diag := range diags {
+			summaries = append(summaries, diag.Summary)
+		}
+		slices.Sort(summaries)
+
+		expectedSummaries := []string{
+			`Required attribute "name" not specified`,
+			`Required attribute "owners" not specified`,
+		}
+		if !slices.Equal(expectedSummaries, summaries) {
+			t.Fatalf("expected diagnostics: %q, given: %q", expectedSummaries, summaries)
+		}
+	})
+}
+
 func gzipCompressBytes(t *testing.T, b []byte) []byte {
 	var compressedBytes bytes.Buffer
 	gw := gzip.NewWriter(&compressedBytes)
@@ -157,3 +580,105 @@ var tfSchemaJSON = `{
 		}
 	}
 }`
+
+func TestDecoder_CompletionAtPos_varsNestedObject(t *testing.T) {
+	ss, err := state.NewStateStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testCfg := `variable "settings" {
+  type = object({
+    name = string
+    network = object({
+      cidr         = string
+      subnet_count = number
+    })
+  })
+}
+`
+	testVarsCfg := `settings = {
+  
+  network = {
+    
+  }
+}
+`
+	dirName := "testdir"
+	mapFs := fstest.MapFS{
+		dirName:                                    &fstest.MapFile{Mode: fs.ModeDir},
+		path.Join(dirName, "main.tf"):              &fstest.MapFile{Data: []byte(testCfg)},
+		filepath.Join(dirName, "main.tf"):          &fstest.MapFile{Data: []byte(testCfg)},
+		path.Join(dirName, "terraform.tfvars"):     &fstest.MapFile{Data: []byte(testVarsCfg)},
+		filepath.Join(dirName, "terraform.tfvars"): &fstest.MapFile{Data: []byte(testVarsCfg)},
+	}
+
+	ctx := context.Background()
+
+	err = ss.Modules.Add(dirName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx = lsctx.WithDocumentContext(ctx, lsctx.Document{})
+	err = module.ParseModuleConfiguration(ctx, mapFs, ss.Modules, dirName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = module.ParseVariables(ctx, mapFs, ss.Modules, dirName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = module.LoadModuleMetadata(ctx, ss.Modules, dirName)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	d := decoder.NewDecoder(&idecoder.PathReader{
+		ModuleReader: ss.Modules,
+		SchemaReader: ss.ProviderSchemas,
+	})
+	pathDecoder, err := d.Path(lang.Path{
+		Path:       dirName,
+		LanguageID: "terraform-vars",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		name           string
+		pos            hcl.Pos
+		expectedLabels []string
+	}{
+		{
+			"object attributes",
+			hcl.Pos{Line: 2, Column: 3, Byte: 15},
+			// network is declared already
+			[]string{"name"},
+		},
+		{
+			"nested object attributes",
+			hcl.Pos{Line: 4, Column: 5, Byte: 34},
+			[]string{"cidr", "subnet_count"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			candidates, err := pathDecoder.CompletionAtPos(ctx, "terraform.tfvars", tc.pos)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			labels := make([]string, 0, len(candidates.List))
+			for _, c := range candidates.List {
+				labels = append(labels, c.Label)
+			}
+			for _, expectedLabel := range tc.expectedLabels {
+				if !slices.Contains(labels, expectedLabel) {
+					t.Fatalf("expected %q candidate, given: %q", expectedLabel, labels)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,161 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package decoder
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/hcl-lang/decoder"
+	"github.com/hashicorp/hcl-lang/lang"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/terraform-ls/internal/state"
+	"github.com/hashicorp/terraform-ls/internal/terraform/ast"
+)
+
+// HoverCache holds hover content of attribute names within a module,
+// such as provider documentation of a resource attribute, so that
+// it doesn't have to be assembled again on every request.
+//
+// Content of attribute names depends only on the module schema,
+// so entries are keyed on the symbol address of the attribute
+// (block types and labels leading to it) and all entries of a module
+// are invalidated whenever its schema is re-assembled in [ModuleSchemaCache].
+type HoverCache struct {
+	schemaCache *ModuleSchemaCache
+
+	mu      sync.RWMutex
+	entries map[string]moduleHoverEntry
+}
+
+type moduleHoverEntry struct {
+	schemaKey string
+	contents  map[string]lang.MarkupContent
+}
+
+func NewHoverCache(schemaCache *ModuleSchemaCache) *HoverCache {
+	return &HoverCache{
+		schemaCache: schemaCache,
+		entries:     make(map[string]moduleHoverEntry),
+	}
+}
+
+// HoverAtPos returns hover data at the given position, as provided
+// by the path decoder, reusing cached content of attribute names.
+// The path decoder is expected to be created for the given module.
+func (c *HoverCache) HoverAtPos(ctx context.Context, d *decoder.PathDecoder, mod *state.Module, filename string, pos hcl.Pos) (*lang.HoverData, error) {
+	schemaKey, ok := c.schemaKey(mod.Path)
+	if !ok {
+		return d.HoverAtPos(ctx, filename, pos)
+	}
+
+	symbol, rng, ok := attributeNameSymbolAtPos(mod.ParsedModuleFiles, filename, pos)
+	if !ok {
+		return d.HoverAtPos(ctx, filename, pos)
+	}
+
+	if content, ok := c.get(mod.Path, schemaKey, symbol); ok {
+		return &lang.HoverData{
+			Content: content,
+			Range:   rng,
+		}, nil
+	}
+
+	data, err := d.HoverAtPos(ctx, filename, pos)
+	if err != nil || data == nil {
+		return data, err
+	}
+	c.set(mod.Path, schemaKey, symbol, data.Content)
+
+	return data, nil
+}
+
+// Remove evicts cached content of the given module, if any.
+func (c *HoverCache) Remove(modPath string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.entries, modPath)
+}
+
+func (c *HoverCache) schemaKey(modPath string) (string, bool) {
+	if c.schemaCache == nil {
+		return "", false
+	}
+	c.schemaCache.mu.RLock()
+	defer c.schemaCache.mu.RUnlock()
+
+	entry, ok := c.schemaCache.entries[modPath]
+	if !ok {
+		return "", false
+	}
+	return entry.key, true
+}
+
+func (c *HoverCache) get(modPath, schemaKey, symbol string) (lang.MarkupContent, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	entry, ok := c.entries[modPath]
+	if !ok || entry.schemaKey != schemaKey {
+		return lang.MarkupContent{}, false
+	}
+	content, ok := entry.contents[symbol]
+	return content, ok
+}
+
+func (c *HoverCache) set(modPath, schemaKey, symbol string, content lang.MarkupContent) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[modPath]
+	if !ok || entry.schemaKey != schemaKey {
+		entry = moduleHoverEntry{
+			schemaKey: schemaKey,
+			contents:  make(map[string]lang.MarkupContent),
+		}
+		c.entries[modPath] = entry
+	}
+	entry.contents[symbol] = content
+}
+
+// attributeNameSymbolAtPos returns the symbol address of the attribute
+// whose name is at the given position, e.g. resource "aws_instance" "web"
+// followed by "ami", along with the range of the whole attribute.
+func attributeNameSymbolAtPos(files ast.ModFiles, filename string, pos hcl.Pos) (string, hcl.Range, bool) {
+	f, ok := files[ast.ModFilename(filename)]
+	if !ok {
+		return "", hcl.Range{}, false
+	}
+	body, ok := f.Body.(*hclsyntax.Body)
+	if !ok {
+		return "", hcl.Range{}, false
+	}
+
+	var symbol strings.Builder
+	for {
+		for name, attr := range body.Attributes {
+			if attr.NameRange.ContainsPos(pos) {
+				fmt.Fprintf(&symbol, "%q", name)
+				return symbol.String(), attr.Range(), true
+			}
+		}
+
+		var nestedBody *hclsyntax.Body
+		for _, block := range body.Blocks {
+			if block.Body.Range().ContainsPos(pos) {
+				fmt.Fprintf(&symbol, "%q%q.", block.Type, block.Labels)
+				nestedBody = block.Body
+				break
+			}
+		}
+		if nestedBody == nil {
+			return "", hcl.Range{}, false
+		}
+		body = nestedBody
+	}
+}
@@ -0,0 +1,166 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package decoder_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/hcl-lang/decoder"
+	"github.com/hashicorp/hcl-lang/lang"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	idecoder "github.com/hashicorp/terraform-ls/internal/decoder"
+	"github.com/hashicorp/terraform-ls/internal/state"
+	"github.com/hashicorp/terraform-ls/internal/terraform/ast"
+	tfaddr "github.com/hashicorp/terraform-registry-address"
+	tfmod "github.com/hashicorp/terraform-schema/module"
+)
+
+func TestHoverCache_matchesFreshHover(t *testing.T) {
+	ss, modPath, pAddr := hoverCacheTestModule(t, `resource "test_resource_0" "foo" {
+  name = "bar"
+}
+`, 1)
+	schemaCache := idecoder.NewModuleSchemaCache()
+	hoverCache := idecoder.NewHoverCache(schemaCache)
+	dec := decoder.NewDecoder(&idecoder.PathReader{
+		ModuleReader: ss.Modules,
+		SchemaReader: ss.ProviderSchemas,
+		SchemaCache:  schemaCache,
+	})
+	ctx := context.Background()
+	pos := hcl.Pos{Line: 2, Column: 4, Byte: 38}
+
+	assertCachedHover := func(t *testing.T) *lang.HoverData {
+		d, err := dec.Path(lang.Path{Path: modPath, LanguageID: "terraform"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		mod, err := ss.Modules.ModuleByPath(modPath)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		expected, err := d.HoverAtPos(ctx, "main.tf", pos)
+		if err != nil {
+			t.Fatal(err)
+		}
+		// first call populates the cache, second one reads from it
+		for i := 0; i < 2; i++ {
+			given, err := hoverCache.HoverAtPos(ctx, d, mod, "main.tf", pos)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(expected, given); diff != "" {
+				t.Fatalf("unexpected hover data (call %d): %s", i, diff)
+			}
+		}
+		return expected
+	}
+
+	before := assertCachedHover(t)
+
+	// locally obtained provider schema takes precedence
+	// over the preloaded one and invalidates cached content
+	ps := testProviderSchema(1)
+	ps.Resources["test_resource_0"].Attributes["name"].Description = lang.PlainText("Name of the resource")
+	err := ss.ProviderSchemas.AddLocalSchema(modPath, pAddr, ps)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	after := assertCachedHover(t)
+	if after.Content == before.Content {
+		t.Fatalf("expected hover content to reflect updated schema, given: %#v", after.Content)
+	}
+}
+
+func BenchmarkHoverCache(b *testing.B) {
+	ss, modPath, _ := hoverCacheTestModule(b, `resource "test_resource_0" "foo" {
+  name = "bar"
+}
+`, 1000)
+	schemaCache := idecoder.NewModuleSchemaCache()
+	dec := decoder.NewDecoder(&idecoder.PathReader{
+		ModuleReader: ss.Modules,
+		SchemaReader: ss.ProviderSchemas,
+		SchemaCache:  schemaCache,
+	})
+	d, err := dec.Path(lang.Path{Path: modPath, LanguageID: "terraform"})
+	if err != nil {
+		b.Fatal(err)
+	}
+	mod, err := ss.Modules.ModuleByPath(modPath)
+	if err != nil {
+		b.Fatal(err)
+	}
+	ctx := context.Background()
+	pos := hcl.Pos{Line: 2, Column: 4, Byte: 38}
+
+	b.Run("uncached", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_, err := d.HoverAtPos(ctx, "main.tf", pos)
+			if err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("cached", func(b *testing.B) {
+		hoverCache := idecoder.NewHoverCache(schemaCache)
+		for i := 0; i < b.N; i++ {
+			_, err := hoverCache.HoverAtPos(ctx, d, mod, "main.tf", pos)
+			if err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func hoverCacheTestModule(tb testing.TB, cfg string, resources int) (*state.StateStore, string, tfaddr.Provider) {
+	ss, err := state.NewStateStore()
+	if err != nil {
+		tb.Fatal(err)
+	}
+
+	modPath := tb.TempDir()
+	err = ss.Modules.Add(modPath)
+	if err != nil {
+		tb.Fatal(err)
+	}
+
+	f, diags := hclsyntax.ParseConfig([]byte(cfg), "main.tf", hcl.InitialPos)
+	if diags.HasErrors() {
+		tb.Fatal(diags)
+	}
+	err = ss.Modules.UpdateParsedModuleFiles(modPath, ast.ModFiles{"main.tf": f}, nil)
+	if err != nil {
+		tb.Fatal(err)
+	}
+
+	pAddr := tfaddr.MustParseProviderSource("hashicorp/test")
+	err = ss.Modules.UpdateMetadata(modPath, &tfmod.Meta{
+		Path: modPath,
+		ProviderRequirements: tfmod.ProviderRequirements{
+			pAddr: version.MustConstraints(version.NewConstraint(">= 1.0")),
+		},
+		ProviderReferences: map[tfmod.ProviderRef]tfaddr.Provider{
+			{LocalName: "test"}: pAddr,
+		},
+	}, nil)
+	if err != nil {
+		tb.Fatal(err)
+	}
+
+	err = ss.ProviderSchemas.AddPreloadedSchema(pAddr, version.Must(version.NewVersion("1.0.0")),
+		testProviderSchema(resources))
+	if err != nil {
+		tb.Fatal(err)
+	}
+
+	return ss, modPath, pAddr
+}
@@ -28,6 +28,10 @@ type ModuleReader interface {
 type PathReader struct {
 	ModuleReader ModuleReader
 	SchemaReader state.SchemaReader
+
+	// SchemaCache is an optional cache of assembled module schemas.
+	// Schemas are assembled for every path context when it is nil.
+	SchemaCache *ModuleSchemaCache
 }
 
 var _ decoder.PathReader = &PathReader{}
@@ -74,7 +78,7 @@ func (mr *PathReader) PathContext(path lang.Path) (*decoder.PathContext, error)
 
 	switch path.LanguageID {
 	case ilsp.Terraform.String():
-		return modulePathContext(mod, mr.SchemaReader, mr.ModuleReader)
+		return modulePathContext(mod, mr.SchemaReader, mr.ModuleReader, mr.SchemaCache)
 	case ilsp.Tfvars.String():
 		return varsPathContext(mod)
 	}
@@ -0,0 +1,311 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package decoder
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/hcl-lang/schema"
+	"github.com/hashicorp/terraform-ls/internal/state"
+	tfaddr "github.com/hashicorp/terraform-registry-address"
+	tfmod "github.com/hashicorp/terraform-schema/module"
+	tfschema "github.com/hashicorp/terraform-schema/schema"
+)
+
+// ModuleSchemaCache holds module schemas and functions assembled
+// for a module path, so that they don't have to be re-assembled
+// (e.g. merged with all provider schemas) on every request.
+//
+// Each entry is keyed on inputs which affect the assembled schema,
+// such as the resolved core version, installed provider versions,
+// obtained provider schemas, module metadata and installed modules.
+// Any change of those inputs invalidates the cached entry.
+// Entries of removed modules are expected to be evicted via [ModuleSchemaCache.Remove].
+//
+// The key is only rebuilt after the module or any other input
+// may have changed, as reported by [schemaInputsWatcher],
+// so that requests in between are served without reading the state.
+type ModuleSchemaCache struct {
+	mu      sync.RWMutex
+	entries map[string]moduleSchemaEntry
+}
+
+type moduleSchemaEntry struct {
+	key       string
+	schema    *schema.BodySchema
+	functions map[string]schema.FunctionSignature
+
+	// mod and watchChs describe inputs the key was last checked
+	// against, entries are considered current until any of them changes.
+	mod      *state.Module
+	watchChs []<-chan struct{}
+}
+
+// current reports whether the key of the entry is known to still
+// represent inputs of the given module, without rebuilding it.
+func (e moduleSchemaEntry) current(mod *state.Module) bool {
+	if e.mod != mod || e.watchChs == nil {
+		return false
+	}
+	for _, ch := range e.watchChs {
+		select {
+		case <-ch:
+			return false
+		default:
+		}
+	}
+	return true
+}
+
+func NewModuleSchemaCache() *ModuleSchemaCache {
+	return &ModuleSchemaCache{
+		entries: make(map[string]moduleSchemaEntry),
+	}
+}
+
+func (c *ModuleSchemaCache) getCurrent(mod *state.Module) (moduleSchemaEntry, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	entry, ok := c.entries[mod.Path]
+	if !ok || !entry.current(mod) {
+		return moduleSchemaEntry{}, false
+	}
+	return entry, true
+}
+
+func (c *ModuleSchemaCache) get(modPath, key string) (moduleSchemaEntry, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	entry, ok := c.entries[modPath]
+	if !ok || entry.key != key {
+		return moduleSchemaEntry{}, false
+	}
+	return entry, true
+}
+
+func (c *ModuleSchemaCache) set(modPath string, entry moduleSchemaEntry) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entries[modPath] = entry
+}
+
+// Remove evicts the cached entry of the given module, if any.
+func (c *ModuleSchemaCache) Remove(modPath string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.entries, modPath)
+}
+
+// schemaRevisionReader is implemented by schema readers which can tell
+// whether the schema of a provider has changed without reading it.
+// Assembled schemas are only cached for such readers.
+type schemaRevisionReader interface {
+	ProviderSchemaRevision(modPath string, addr tfaddr.Provider, vc version.Constraints) (uint64, error)
+}
+
+// schemaInputsWatcher is implemented by module readers which can
+// report changes of any inputs of the assembled schema.
+type schemaInputsWatcher interface {
+	SchemaInputsWatch() ([]<-chan struct{}, error)
+}
+
+// moduleSchemaAndFunctions assembles schema and functions for the given
+// module, or obtains them from the cache, if one is provided.
+func moduleSchemaAndFunctions(mod *state.Module, schemaReader state.SchemaReader, modReader ModuleReader, cache *ModuleSchemaCache) (*schema.BodySchema, map[string]schema.FunctionSignature, error) {
+	if revReader, ok := schemaReader.(schemaRevisionReader); ok && cache != nil {
+		return cache.schemaAndFunctions(mod, schemaReader, revReader, modReader)
+	}
+	return assembleModuleSchema(mod, schemaReader, modReader)
+}
+
+func assembleModuleSchema(mod *state.Module, schemaReader state.SchemaReader, modReader ModuleReader) (*schema.BodySchema, map[string]schema.FunctionSignature, error) {
+	bodySchema, err := schemaForModule(mod, schemaReader, modReader)
+	if err != nil {
+		return nil, nil, err
+	}
+	functions, err := functionsForModule(mod, schemaReader)
+	if err != nil {
+		return nil, nil, err
+	}
+	return bodySchema, functions, nil
+}
+
+// schemaAndFunctions returns schema and functions for the given module,
+// reusing the cached ones if inputs did not change since they were assembled.
+func (c *ModuleSchemaCache) schemaAndFunctions(mod *state.Module, schemaReader state.SchemaReader, revReader schemaRevisionReader, modReader ModuleReader) (*schema.BodySchema, map[string]schema.FunctionSignature, error) {
+	if entry, ok := c.getCurrent(mod); ok {
+		return entry.schema, entry.functions, nil
+	}
+
+	// Changes are watched for before the key is built,
+	// so that none can be missed in between.
+	var watchChs []<-chan struct{}
+	if watcher, ok := modReader.(schemaInputsWatcher); ok {
+		chs, err := watcher.SchemaInputsWatch()
+		if err == nil {
+			watchChs = chs
+		}
+	}
+
+	key := moduleSchemaKey(mod, revReader, modReader)
+	if entry, ok := c.get(mod.Path, key); ok {
+		entry.mod = mod
+		entry.watchChs = watchChs
+		c.set(mod.Path, entry)
+		return entry.schema, entry.functions, nil
+	}
+
+	bodySchema, functions, err := assembleModuleSchema(mod, schemaReader, modReader)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	c.set(mod.Path, moduleSchemaEntry{
+		key:       key,
+		schema:    bodySchema,
+		functions: functions,
+		mod:       mod,
+		watchChs:  watchChs,
+	})
+
+	return bodySchema, functions, nil
+}
+
+// moduleSchemaKey builds a key representing all inputs
+// of [schemaForModule] and [functionsForModule].
+func moduleSchemaKey(mod *state.Module, revReader schemaRevisionReader, modReader ModuleReader) string {
+	var b strings.Builder
+
+	resolvedVersion := tfschema.ResolveVersion(mod.TerraformVersion, mod.Meta.CoreRequirements)
+	fmt.Fprintf(&b, "core:%s;", resolvedVersion)
+
+	installed := make([]string, 0, len(mod.InstalledProviders))
+	for pAddr, pVer := range mod.InstalledProviders {
+		installed = append(installed, fmt.Sprintf("%s@%s", pAddr, pVer))
+	}
+	writeSorted(&b, "installed", installed)
+
+	// Provider schemas are looked up the same way the schema merger does,
+	// so that schemas obtained or replaced in the meantime are reflected.
+	reqs := make([]string, 0, len(mod.Meta.ProviderRequirements))
+	for pAddr, pCons := range mod.Meta.ProviderRequirements {
+		revision, err := revReader.ProviderSchemaRevision(mod.Path, pAddr, pCons)
+		if err != nil {
+			revision = 0
+		}
+		reqs = append(reqs, fmt.Sprintf("%s %s %d", pAddr, pCons, revision))
+	}
+	writeSorted(&b, "requirements", reqs)
+
+	refs := make([]string, 0, len(mod.Meta.ProviderReferences))
+	for ref, pAddr := range mod.Meta.ProviderReferences {
+		refs = append(refs, fmt.Sprintf("%s.%s=%s", ref.LocalName, ref.Alias, pAddr))
+	}
+	writeSorted(&b, "references", refs)
+
+	fmt.Fprintf(&b, "core_requirements:%s;", mod.Meta.CoreRequirements)
+	writeSorted(&b, "filenames", append([]string{}, mod.Meta.Filenames...))
+	writeSorted(&b, "variables", variablesKey(mod.Meta.Variables))
+
+	calls := make([]string, 0, len(mod.Meta.ModuleCalls))
+	for name, mc := range mod.Meta.ModuleCalls {
+		calls = append(calls, moduleCallKey(name, mc, mod.Path, modReader))
+	}
+	writeSorted(&b, "module_calls", calls)
+
+	// Installed modules (as recorded in the module manifest) provide
+	// inputs and outputs of module calls with remote sources.
+	installedCalls := make([]string, 0)
+	modCalls, err := modReader.ModuleCalls(mod.Path)
+	if err == nil {
+		for name, mc := range modCalls.Installed {
+			installedCalls = append(installedCalls, installedModuleCallKey(name, mc, modReader))
+		}
+	}
+	writeSorted(&b, "installed_module_calls", installedCalls)
+
+	return b.String()
+}
+
+func installedModuleCallKey(name string, mc tfmod.InstalledModuleCall, modReader ModuleReader) string {
+	sourceAddr := ""
+	if mc.SourceAddr != nil {
+		sourceAddr = mc.SourceAddr.String()
+	}
+	key := fmt.Sprintf("%s=%s %s %s", name, sourceAddr, mc.Version, mc.Path)
+
+	return localModuleMetaKey(key, mc.Path, modReader)
+}
+
+func moduleCallKey(name string, mc tfmod.DeclaredModuleCall, modPath string, modReader ModuleReader) string {
+	sourceAddr := ""
+	if mc.SourceAddr != nil {
+		sourceAddr = mc.SourceAddr.String()
+	}
+	key := fmt.Sprintf("%s=%s %s", name, sourceAddr, mc.Version)
+
+	// Schema of the module block depends on inputs and outputs
+	// of the called module, which may change independently.
+	switch sourceAddr := mc.SourceAddr.(type) {
+	case tfaddr.Module:
+		modMeta, err := modReader.RegistryModuleMeta(sourceAddr, mc.Version)
+		if err != nil {
+			return key
+		}
+		inputs := make([]string, 0, len(modMeta.Inputs))
+		for _, input := range modMeta.Inputs {
+			inputs = append(inputs, fmt.Sprintf("%s %#v %t", input.Name, input.Type, input.Required))
+		}
+		outputs := make([]string, 0, len(modMeta.Outputs))
+		for _, output := range modMeta.Outputs {
+			outputs = append(outputs, output.Name)
+		}
+		return fmt.Sprintf("%s %s inputs[%s] outputs[%s]", key, modMeta.Version,
+			strings.Join(inputs, ","), strings.Join(outputs, ","))
+	case tfmod.LocalSourceAddr:
+		return localModuleMetaKey(key, filepath.Join(modPath, sourceAddr.String()), modReader)
+	}
+
+	return key
+}
+
+// localModuleMetaKey appends inputs and outputs of the module
+// at the given path to the given key, if the module is known.
+func localModuleMetaKey(key, modPath string, modReader ModuleReader) string {
+	modMeta, err := modReader.LocalModuleMeta(modPath)
+	if err != nil {
+		return key
+	}
+	outputs := make([]string, 0, len(modMeta.Outputs))
+	for name, output := range modMeta.Outputs {
+		outputs = append(outputs, fmt.Sprintf("%s %q %t", name, output.Description, output.IsSensitive))
+	}
+	sort.Strings(outputs)
+	vars := variablesKey(modMeta.Variables)
+	sort.Strings(vars)
+	return fmt.Sprintf("%s inputs[%s] outputs[%s]", key,
+		strings.Join(vars, ","), strings.Join(outputs, ","))
+}
+
+func variablesKey(vars map[string]tfmod.Variable) []string {
+	keys := make([]string, 0, len(vars))
+	for name, v := range vars {
+		keys = append(keys, fmt.Sprintf("%s %#v %q %t %#v", name, v.Type, v.Description, v.IsSensitive, v.DefaultValue))
+	}
+	return keys
+}
+
+func writeSorted(b *strings.Builder, name string, values []string) {
+	sort.Strings(values)
+	fmt.Fprintf(b, "%s:[%s];", name, strings.Join(values, ","))
+}
@@ -0,0 +1,391 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package decoder_test
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/hcl-lang/lang"
+	"github.com/hashicorp/hcl-lang/schema"
+	idecoder "github.com/hashicorp/terraform-ls/internal/decoder"
+	"github.com/hashicorp/terraform-ls/internal/state"
+	"github.com/hashicorp/terraform-ls/internal/terraform/datadir"
+	tfaddr "github.com/hashicorp/terraform-registry-address"
+	tfmod "github.com/hashicorp/terraform-schema/module"
+	tfschema "github.com/hashicorp/terraform-schema/schema"
+	"github.com/zclconf/go-cty/cty"
+)
+
+func TestModuleSchemaCache_invalidatedOnMetadataUpdate(t *testing.T) {
+	ss, err := state.NewStateStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	modPath := t.TempDir()
+	err = ss.Modules.Add(modPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = ss.Modules.UpdateMetadata(modPath, &tfmod.Meta{
+		Path: modPath,
+		Variables: map[string]tfmod.Variable{
+			"foo": {Type: cty.String},
+		},
+	}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pathReader := &idecoder.PathReader{
+		ModuleReader: ss.Modules,
+		SchemaReader: ss.ProviderSchemas,
+		SchemaCache:  idecoder.NewModuleSchemaCache(),
+	}
+	path := lang.Path{Path: modPath, LanguageID: "terraform"}
+
+	first, err := pathReader.PathContext(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := pathReader.PathContext(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if first.Schema != second.Schema {
+		t.Fatal("expected schema to be reused when inputs did not change")
+	}
+
+	err = ss.Modules.UpdateMetadata(modPath, &tfmod.Meta{
+		Path: modPath,
+		Variables: map[string]tfmod.Variable{
+			"foo": {Type: cty.String},
+			"bar": {Type: cty.Number},
+		},
+	}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	third, err := pathReader.PathContext(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if third.Schema == second.Schema {
+		t.Fatal("expected schema to be re-assembled after metadata update")
+	}
+	if _, ok := third.Schema.Blocks["variable"].DependentBody[schema.NewSchemaKey(schema.DependencyKeys{
+		Labels: []schema.LabelDependent{{Index: 0, Value: "bar"}},
+	})]; !ok {
+		t.Fatal("expected re-assembled schema to reflect the new variable")
+	}
+}
+
+func TestModuleSchemaCache_invalidatedOnProviderSchemaChange(t *testing.T) {
+	ss, err := state.NewStateStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	modPath := t.TempDir()
+	err = ss.Modules.Add(modPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pAddr := tfaddr.MustParseProviderSource("hashicorp/test")
+	err = ss.Modules.UpdateMetadata(modPath, &tfmod.Meta{
+		Path: modPath,
+		ProviderRequirements: tfmod.ProviderRequirements{
+			pAddr: version.MustConstraints(version.NewConstraint(">= 1.0")),
+		},
+		ProviderReferences: map[tfmod.ProviderRef]tfaddr.Provider{
+			{LocalName: "test"}: pAddr,
+		},
+	}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pathReader := &idecoder.PathReader{
+		ModuleReader: ss.Modules,
+		SchemaReader: ss.ProviderSchemas,
+		SchemaCache:  idecoder.NewModuleSchemaCache(),
+	}
+	path := lang.Path{Path: modPath, LanguageID: "terraform"}
+
+	before, err := pathReader.PathContext(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = ss.ProviderSchemas.AddPreloadedSchema(pAddr, version.Must(version.NewVersion("1.0.0")),
+		testProviderSchema(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	after, err := pathReader.PathContext(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if after.Schema == before.Schema {
+		t.Fatal("expected schema to be re-assembled after provider schema became available")
+	}
+}
+
+func TestModuleSchemaCache_invalidatedOnModuleInstall(t *testing.T) {
+	ss, err := state.NewStateStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	modPath := t.TempDir()
+	childPath := filepath.Join(modPath, ".terraform", "modules", "child")
+	for _, path := range []string{modPath, childPath} {
+		err = ss.Modules.Add(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = ss.Modules.UpdateMetadata(path, &tfmod.Meta{
+			Path: path,
+		}, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	pathReader := &idecoder.PathReader{
+		ModuleReader: ss.Modules,
+		SchemaReader: ss.ProviderSchemas,
+		SchemaCache:  idecoder.NewModuleSchemaCache(),
+	}
+	path := lang.Path{Path: modPath, LanguageID: "terraform"}
+
+	before, err := pathReader.PathContext(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = ss.Modules.UpdateModManifest(modPath, &datadir.ModuleManifest{
+		Records: []datadir.ModuleRecord{
+			{
+				Key: "child",
+				Dir: filepath.Join(".terraform", "modules", "child"),
+			},
+		},
+	}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	installed, err := pathReader.PathContext(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if installed.Schema == before.Schema {
+		t.Fatal("expected schema to be re-assembled after module was installed")
+	}
+
+	err = ss.Modules.UpdateMetadata(childPath, &tfmod.Meta{
+		Path: childPath,
+		Variables: map[string]tfmod.Variable{
+			"foo": {Type: cty.String},
+		},
+	}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	updated, err := pathReader.PathContext(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if updated.Schema == installed.Schema {
+		t.Fatal("expected schema to be re-assembled after installed module changed")
+	}
+}
+
+func TestModuleSchemaCache_Remove(t *testing.T) {
+	ss, err := state.NewStateStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	modPath := t.TempDir()
+	err = ss.Modules.Add(modPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = ss.Modules.UpdateMetadata(modPath, &tfmod.Meta{
+		Path: modPath,
+	}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	schemaCache := idecoder.NewModuleSchemaCache()
+	pathReader := &idecoder.PathReader{
+		ModuleReader: ss.Modules,
+		SchemaReader: ss.ProviderSchemas,
+		SchemaCache:  schemaCache,
+	}
+	path := lang.Path{Path: modPath, LanguageID: "terraform"}
+
+	before, err := pathReader.PathContext(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	schemaCache.Remove(modPath)
+
+	after, err := pathReader.PathContext(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if after.Schema == before.Schema {
+		t.Fatal("expected schema to be re-assembled after the entry was removed")
+	}
+}
+
+func TestModuleSchema_perRootTerraformVersion(t *testing.T) {
+	ss, err := state.NewStateStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	legacyRoot := t.TempDir()
+	modernRoot := t.TempDir()
+	undiscoveredRoot := t.TempDir()
+	for _, modPath := range []string{legacyRoot, modernRoot, undiscoveredRoot} {
+		err = ss.Modules.Add(modPath)
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = ss.Modules.UpdateMetadata(modPath, &tfmod.Meta{
+			Path: modPath,
+		}, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	err = ss.Modules.UpdateTerraformAndProviderVersions(legacyRoot, version.Must(version.NewVersion("0.12.0")), nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = ss.Modules.UpdateTerraformAndProviderVersions(modernRoot, version.Must(version.NewVersion("1.5.0")), nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pathReader := &idecoder.PathReader{
+		ModuleReader: ss.Modules,
+		SchemaReader: ss.ProviderSchemas,
+		SchemaCache:  idecoder.NewModuleSchemaCache(),
+	}
+
+	// import blocks are only available since Terraform 1.5, so their presence
+	// tells which version the schema of each root was selected for
+	testCases := []struct {
+		modPath        string
+		expectedImport bool
+	}{
+		{legacyRoot, false},
+		{modernRoot, true},
+		// roots without a discovered version fall back to the latest version
+		{undiscoveredRoot, true},
+	}
+	for i, tc := range testCases {
+		pathCtx, err := pathReader.PathContext(lang.Path{Path: tc.modPath, LanguageID: "terraform"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, hasImport := pathCtx.Schema.Blocks["import"]
+		if hasImport != tc.expectedImport {
+			t.Fatalf("%d: expected import block to be present: %t, given: %t", i, tc.expectedImport, hasImport)
+		}
+	}
+}
+
+func BenchmarkModuleSchemaCache(b *testing.B) {
+	ss, err := state.NewStateStore()
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	modPath := b.TempDir()
+	err = ss.Modules.Add(modPath)
+	if err != nil {
+		b.Fatal(err)
+	}
+	pAddr := tfaddr.MustParseProviderSource("hashicorp/test")
+	err = ss.Modules.UpdateMetadata(modPath, &tfmod.Meta{
+		Path: modPath,
+		ProviderRequirements: tfmod.ProviderRequirements{
+			pAddr: version.MustConstraints(version.NewConstraint(">= 1.0")),
+		},
+		ProviderReferences: map[tfmod.ProviderRef]tfaddr.Provider{
+			{LocalName: "test"}: pAddr,
+		},
+	}, nil)
+	if err != nil {
+		b.Fatal(err)
+	}
+	err = ss.ProviderSchemas.AddPreloadedSchema(pAddr, version.Must(version.NewVersion("1.0.0")),
+		testProviderSchema(1000))
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	path := lang.Path{Path: modPath, LanguageID: "terraform"}
+
+	b.Run("uncached", func(b *testing.B) {
+		pathReader := &idecoder.PathReader{
+			ModuleReader: ss.Modules,
+			SchemaReader: ss.ProviderSchemas,
+		}
+		for i := 0; i < b.N; i++ {
+			_, err := pathReader.PathContext(path)
+			if err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("cached", func(b *testing.B) {
+		pathReader := &idecoder.PathReader{
+			ModuleReader: ss.Modules,
+			SchemaReader: ss.ProviderSchemas,
+			SchemaCache:  idecoder.NewModuleSchemaCache(),
+		}
+		for i := 0; i < b.N; i++ {
+			_, err := pathReader.PathContext(path)
+			if err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func testProviderSchema(resources int) *tfschema.ProviderSchema {
+	ps := &tfschema.ProviderSchema{
+		Provider:    &schema.BodySchema{},
+		Resources:   make(map[string]*schema.BodySchema, resources),
+		DataSources: make(map[string]*schema.BodySchema, 0),
+		Functions:   make(map[string]*schema.FunctionSignature, 0),
+	}
+	for i := 0; i < resources; i++ {
+		ps.Resources[fmt.Sprintf("test_resource_%d", i)] = &schema.BodySchema{
+			Attributes: map[string]*schema.AttributeSchema{
+				"name": {
+					Constraint: schema.LiteralType{Type: cty.String},
+					IsRequired: true,
+				},
+			},
+		}
+	}
+	return ps
+}
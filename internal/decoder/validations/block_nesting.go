@@ -0,0 +1,86 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package validations
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/hcl-lang/schema"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+)
+
+const DuplicateBlockSummary = "Duplicate block"
+
+// BlockNestingMode reports nested blocks declared with a multiplicity
+// their nesting mode in the provider schema does not allow, i.e. more than
+// one block nested as single object, or multiple blocks nested as map
+// under the same key. Limits of list and set nested blocks are reported
+// by the MaxBlocks validator instead.
+type BlockNestingMode struct{}
+
+func (bnm BlockNestingMode) Visit(ctx context.Context, node hclsyntax.Node, nodeSchema schema.Schema) (context.Context, hcl.Diagnostics) {
+	var diags hcl.Diagnostics
+
+	body, ok := node.(*hclsyntax.Body)
+	if !ok {
+		return ctx, diags
+	}
+	bodySchema, ok := nodeSchema.(*schema.BodySchema)
+	if !ok || bodySchema == nil {
+		return ctx, diags
+	}
+
+	// first declared block of each type, or of each type and key
+	declared := make(map[string]*hclsyntax.Block, 0)
+
+	for _, block := range body.Blocks {
+		blockSchema, ok := bodySchema.Blocks[block.Type]
+		if !ok {
+			continue
+		}
+
+		switch blockSchema.Type {
+		case schema.BlockTypeObject:
+			if blockSchema.MaxItems != 0 {
+				// already reported as too many blocks
+				continue
+			}
+			first, ok := declared[block.Type]
+			if !ok {
+				declared[block.Type] = block
+				continue
+			}
+			diags = append(diags, &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  DuplicateBlockSummary,
+				Detail: fmt.Sprintf("Only one %q block is allowed. Another was defined at %s.",
+					block.Type, first.TypeRange),
+				Subject: block.TypeRange.Ptr(),
+				Context: block.Range().Ptr(),
+			})
+		case schema.BlockTypeMap:
+			if len(block.Labels) != 1 {
+				continue
+			}
+			key := block.Type + "." + block.Labels[0]
+			first, ok := declared[key]
+			if !ok {
+				declared[key] = block
+				continue
+			}
+			diags = append(diags, &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  DuplicateBlockSummary,
+				Detail: fmt.Sprintf("A %q block with key %q was already defined at %s.",
+					block.Type, block.Labels[0], first.TypeRange),
+				Subject: block.LabelRanges[0].Ptr(),
+				Context: block.Range().Ptr(),
+			})
+		}
+	}
+
+	return ctx, diags
+}
@@ -0,0 +1,120 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package validations
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/hcl-lang/schema"
+	"github.com/hashicorp/hcl-lang/schemacontext"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+)
+
+func TestBlockNestingMode(t *testing.T) {
+	bodySchema := &schema.BodySchema{
+		Blocks: map[string]*schema.BlockSchema{
+			"single": {
+				Type: schema.BlockTypeObject,
+				Body: schema.NewBodySchema(),
+			},
+			"limited_single": {
+				Type:     schema.BlockTypeObject,
+				MaxItems: 1,
+				Body:     schema.NewBodySchema(),
+			},
+			"list": {
+				Type: schema.BlockTypeList,
+				Body: schema.NewBodySchema(),
+			},
+			"map": {
+				Type: schema.BlockTypeMap,
+				Labels: []*schema.LabelSchema{
+					{Name: "key"},
+				},
+				Body: schema.NewBodySchema(),
+			},
+		},
+	}
+
+	tests := []struct {
+		name          string
+		cfg           string
+		expectedLines []int
+	}{
+		{
+			"single-nested block declared once",
+			`single {}
+list {}
+list {}
+`,
+			[]int{},
+		},
+		{
+			"single-nested block declared twice",
+			`single {}
+single {}
+`,
+			[]int{2},
+		},
+		{
+			"single-nested block declared three times",
+			`single {}
+list {}
+single {}
+single {}
+`,
+			[]int{3, 4},
+		},
+		{
+			"single-nested block with max items",
+			`limited_single {}
+limited_single {}
+`,
+			[]int{},
+		},
+		{
+			"map-nested blocks with distinct keys",
+			`map "a" {}
+map "b" {}
+`,
+			[]int{},
+		},
+		{
+			"map-nested blocks with duplicate key",
+			`map "a" {}
+map "b" {}
+map "a" {}
+`,
+			[]int{3},
+		},
+	}
+
+	for i, tt := range tests {
+		t.Run(fmt.Sprintf("%d-%s", i, tt.name), func(t *testing.T) {
+			f, pDiags := hclsyntax.ParseConfig([]byte(tt.cfg), "test.tf", hcl.InitialPos)
+			if len(pDiags) > 0 {
+				t.Fatal(pDiags)
+			}
+
+			ctx := schemacontext.WithBlockNestingLevel(context.Background(), 1)
+			_, diags := BlockNestingMode{}.Visit(ctx, f.Body.(*hclsyntax.Body), bodySchema)
+
+			if len(diags) != len(tt.expectedLines) {
+				t.Fatalf("expected %d diagnostics, %d given: %s", len(tt.expectedLines), len(diags), diags)
+			}
+			for i, diag := range diags {
+				if diag.Summary != DuplicateBlockSummary {
+					t.Fatalf("unexpected summary: %q", diag.Summary)
+				}
+				if diag.Subject.Start.Line != tt.expectedLines[i] {
+					t.Fatalf("expected diagnostic %d on line %d, given: %d",
+						i, tt.expectedLines[i], diag.Subject.Start.Line)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,62 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package validations
+
+import (
+	"context"
+
+	"github.com/hashicorp/hcl-lang/schema"
+	"github.com/hashicorp/hcl-lang/schemacontext"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+)
+
+const InvalidCloudWorkspacesSummary = "Invalid workspaces configuration"
+
+// CloudWorkspaces reports workspaces blocks within the cloud block
+// which set both name and tags, which Terraform rejects as
+// the two workspace mapping strategies are mutually exclusive.
+type CloudWorkspaces struct{}
+
+func (cw CloudWorkspaces) Visit(ctx context.Context, node hclsyntax.Node, nodeSchema schema.Schema) (context.Context, hcl.Diagnostics) {
+	var diags hcl.Diagnostics
+
+	block, ok := node.(*hclsyntax.Block)
+	if !ok || block.Type != "terraform" {
+		return ctx, diags
+	}
+	nestingLvl, nestingOk := schemacontext.BlockNestingLevel(ctx)
+	if !nestingOk || nestingLvl != 0 {
+		return ctx, diags
+	}
+
+	for _, cloudBlock := range block.Body.Blocks {
+		if cloudBlock.Type != "cloud" {
+			continue
+		}
+		for _, wsBlock := range cloudBlock.Body.Blocks {
+			if wsBlock.Type != "workspaces" {
+				continue
+			}
+
+			_, hasName := wsBlock.Body.Attributes["name"]
+			tagsAttr, hasTags := wsBlock.Body.Attributes["tags"]
+			if !hasName || !hasTags {
+				continue
+			}
+
+			diags = append(diags, &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  InvalidCloudWorkspacesSummary,
+				Detail: "Only one of workspace \"tags\" or \"name\" is allowed. " +
+					"Use \"name\" to select a single workspace, or \"tags\" " +
+					"to select any workspaces tagged with all of the given tags.",
+				Subject: tagsAttr.SrcRange.Ptr(),
+				Context: wsBlock.Range().Ptr(),
+			})
+		}
+	}
+
+	return ctx, diags
+}
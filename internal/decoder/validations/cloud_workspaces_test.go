@@ -0,0 +1,68 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package validations
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/hcl-lang/schemacontext"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+)
+
+func TestCloudWorkspaces(t *testing.T) {
+	tests := []struct {
+		name          string
+		workspaces    string
+		expectedValid bool
+	}{
+		{"name only", `name = "prod"`, true},
+		{"tags only", `tags = ["app"]`, true},
+		{"project only", `project = "networking"`, true},
+		{"name and tags", "name = \"prod\"\n      tags = [\"app\"]", false},
+	}
+
+	for i, tt := range tests {
+		t.Run(fmt.Sprintf("%d-%s", i, tt.name), func(t *testing.T) {
+			cfg := fmt.Sprintf(`terraform {
+  cloud {
+    organization = "example"
+    workspaces {
+      %s
+    }
+  }
+}
+`, tt.workspaces)
+			f, pDiags := hclsyntax.ParseConfig([]byte(cfg), "test.tf", hcl.InitialPos)
+			if len(pDiags) > 0 {
+				t.Fatal(pDiags)
+			}
+			block := f.Body.(*hclsyntax.Body).Blocks[0]
+
+			ctx := schemacontext.WithBlockNestingLevel(context.Background(), 0)
+			_, diags := CloudWorkspaces{}.Visit(ctx, block, nil)
+
+			if tt.expectedValid {
+				if len(diags) > 0 {
+					t.Fatalf("expected no diagnostics, given: %s", diags)
+				}
+				return
+			}
+
+			if len(diags) != 1 {
+				t.Fatalf("expected 1 diagnostic, %d given: %s", len(diags), diags)
+			}
+			if diags[0].Summary != InvalidCloudWorkspacesSummary {
+				t.Fatalf("unexpected summary: %q", diags[0].Summary)
+			}
+			wsBlock := block.Body.Blocks[0].Body.Blocks[0]
+			expectedRange := wsBlock.Body.Attributes["tags"].SrcRange
+			if *diags[0].Subject != expectedRange {
+				t.Fatalf("unexpected range: %#v, expected: %#v", *diags[0].Subject, expectedRange)
+			}
+		})
+	}
+}
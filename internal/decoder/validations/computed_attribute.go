@@ -0,0 +1,47 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package validations
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/hcl-lang/schema"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+)
+
+const UnconfigurableAttributeSummary = "Value for unconfigurable attribute"
+
+// ComputedAttribute reports attributes which are computed
+// and not optional in the schema (such as id or arn of resources
+// in provider schemas) and therefore cannot be configured.
+//
+// These are reported as warnings, since the schema may be a bundled
+// one which differs from the version of the provider actually in use.
+type ComputedAttribute struct{}
+
+func (ca ComputedAttribute) Visit(ctx context.Context, node hclsyntax.Node, nodeSchema schema.Schema) (context.Context, hcl.Diagnostics) {
+	var diags hcl.Diagnostics
+
+	attr, ok := node.(*hclsyntax.Attribute)
+	if !ok {
+		return ctx, diags
+	}
+
+	attrSchema, ok := nodeSchema.(*schema.AttributeSchema)
+	if !ok || attrSchema == nil || !attrSchema.IsComputed || attrSchema.IsOptional {
+		return ctx, diags
+	}
+
+	diags = append(diags, &hcl.Diagnostic{
+		Severity: hcl.DiagWarning,
+		Summary:  UnconfigurableAttributeSummary,
+		Detail: fmt.Sprintf("Can't configure a value for %q: its value will be decided "+
+			"automatically based on the result of applying this configuration.", attr.Name),
+		Subject: attr.SrcRange.Ptr(),
+	})
+
+	return ctx, diags
+}
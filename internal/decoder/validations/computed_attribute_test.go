@@ -0,0 +1,83 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package validations
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/hcl-lang/schema"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty/cty"
+)
+
+func TestComputedAttribute(t *testing.T) {
+	tests := []struct {
+		name       string
+		attrSchema schema.Schema
+		want       hcl.Diagnostics
+	}{
+		{
+			name: "computed attribute",
+			attrSchema: &schema.AttributeSchema{
+				Constraint: schema.LiteralType{Type: cty.String},
+				IsComputed: true,
+			},
+			want: hcl.Diagnostics{
+				&hcl.Diagnostic{
+					Severity: hcl.DiagWarning,
+					Summary:  UnconfigurableAttributeSummary,
+					Detail: `Can't configure a value for "arn": its value will be decided ` +
+						"automatically based on the result of applying this configuration.",
+					Subject: &hcl.Range{
+						Filename: "test.tf",
+						Start:    hcl.Pos{Line: 1, Column: 1, Byte: 0},
+						End:      hcl.Pos{Line: 1, Column: 12, Byte: 11},
+					},
+				},
+			},
+		},
+		{
+			name: "optional computed attribute",
+			attrSchema: &schema.AttributeSchema{
+				Constraint: schema.LiteralType{Type: cty.String},
+				IsOptional: true,
+				IsComputed: true,
+			},
+			want: nil,
+		},
+		{
+			name: "optional attribute",
+			attrSchema: &schema.AttributeSchema{
+				Constraint: schema.LiteralType{Type: cty.String},
+				IsOptional: true,
+			},
+			want: nil,
+		},
+		{
+			name:       "unknown attribute",
+			attrSchema: nil,
+			want:       nil,
+		},
+	}
+
+	for i, tt := range tests {
+		t.Run(fmt.Sprintf("%d-%s", i, tt.name), func(t *testing.T) {
+			f, pDiags := hclsyntax.ParseConfig([]byte(`arn = "foo"`), "test.tf", hcl.InitialPos)
+			if len(pDiags) > 0 {
+				t.Fatal(pDiags)
+			}
+			attr := f.Body.(*hclsyntax.Body).Attributes["arn"]
+
+			_, diags := ComputedAttribute{}.Visit(context.Background(), attr, tt.attrSchema)
+
+			if diff := cmp.Diff(tt.want, diags); diff != "" {
+				t.Fatalf("unexpected diagnostics: %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,164 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package validations
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/hcl-lang/decoder"
+	"github.com/hashicorp/hcl-lang/lang"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	tfmod "github.com/hashicorp/terraform-schema/module"
+)
+
+const MissingConfigurationAliasSummary = "Missing provider configuration for module"
+
+// ModuleConfigurationAliasesFunc returns provider configuration aliases
+// declared via configuration_aliases in required_providers of the module
+// called via the given module call, and whether such module is known,
+// i.e. local and already parsed.
+type ModuleConfigurationAliasesFunc func(callName string) ([]tfmod.ProviderRef, bool)
+
+// ModuleConfigurationAliases reports module calls which do not pass
+// provider configurations (via providers) for all configuration aliases
+// declared by the called module, which Terraform rejects.
+func ModuleConfigurationAliases(ctx context.Context, pathCtx *decoder.PathContext, moduleAliases ModuleConfigurationAliasesFunc) lang.DiagnosticsMap {
+	diagsMap := make(lang.DiagnosticsMap)
+
+	for fileName, file := range pathCtx.Files {
+		body, ok := file.Body.(*hclsyntax.Body)
+		if !ok {
+			continue
+		}
+
+		for _, block := range body.Blocks {
+			if block.Type != "module" || len(block.Labels) == 0 {
+				continue
+			}
+			aliases, ok := moduleAliases(block.Labels[0])
+			if !ok || len(aliases) == 0 {
+				continue
+			}
+
+			subject := block.DefRange().Ptr()
+			passed := make(map[tfmod.ProviderRef]bool)
+			if attr, ok := block.Body.Attributes["providers"]; ok {
+				pairs, diags := hcl.ExprMap(attr.Expr)
+				if diags.HasErrors() {
+					// invalid mapping is reported elsewhere
+					continue
+				}
+				for _, pair := range pairs {
+					ref, ok := providerRefForExpr(pair.Key)
+					if !ok {
+						continue
+					}
+					passed[ref] = true
+				}
+				subject = attr.SrcRange.Ptr()
+			}
+
+			for _, alias := range aliases {
+				if passed[alias] {
+					continue
+				}
+				aliasAddr := fmt.Sprintf("%s.%s", alias.LocalName, alias.Alias)
+				diagsMap[fileName] = diagsMap[fileName].Append(&hcl.Diagnostic{
+					Severity: hcl.DiagError,
+					Summary:  MissingConfigurationAliasSummary,
+					Detail: fmt.Sprintf("Module %q declares %s in configuration_aliases, "+
+						"but no provider configuration is passed for it via providers, e.g. %s = %s.example",
+						block.Labels[0], aliasAddr, aliasAddr, alias.LocalName),
+					Subject: subject,
+					Context: block.Range().Ptr(),
+				})
+			}
+		}
+	}
+
+	return diagsMap
+}
+
+// ConfigurationAliases returns provider configuration aliases
+// declared via configuration_aliases in required_providers
+// within the given body of a module file.
+func ConfigurationAliases(body hcl.Body) []tfmod.ProviderRef {
+	aliases := make([]tfmod.ProviderRef, 0)
+
+	syntaxBody, ok := body.(*hclsyntax.Body)
+	if !ok {
+		return aliases
+	}
+
+	for _, tfBlock := range syntaxBody.Blocks {
+		if tfBlock.Type != "terraform" {
+			continue
+		}
+		for _, block := range tfBlock.Body.Blocks {
+			if block.Type != "required_providers" {
+				continue
+			}
+			for _, attr := range block.Body.Attributes {
+				pairs, diags := hcl.ExprMap(attr.Expr)
+				if diags.HasErrors() {
+					continue
+				}
+				for _, pair := range pairs {
+					if hcl.ExprAsKeyword(pair.Key) != "configuration_aliases" {
+						continue
+					}
+					exprs, diags := hcl.ExprList(pair.Value)
+					if diags.HasErrors() {
+						continue
+					}
+					for _, expr := range exprs {
+						ref, ok := providerRefForExpr(expr)
+						if !ok || ref.Alias == "" || ref.LocalName != attr.Name {
+							continue
+						}
+						aliases = append(aliases, ref)
+					}
+				}
+			}
+		}
+	}
+
+	sort.Slice(aliases, func(i, j int) bool {
+		if aliases[i].LocalName != aliases[j].LocalName {
+			return aliases[i].LocalName < aliases[j].LocalName
+		}
+		return aliases[i].Alias < aliases[j].Alias
+	})
+
+	return aliases
+}
+
+// providerRefForExpr interprets the given expression as a reference
+// to a provider configuration, e.g. aws or aws.west
+func providerRefForExpr(expr hcl.Expression) (tfmod.ProviderRef, bool) {
+	traversal, diags := hcl.AbsTraversalForExpr(expr)
+	if diags.HasErrors() {
+		return tfmod.ProviderRef{}, false
+	}
+
+	ref := tfmod.ProviderRef{
+		LocalName: traversal.RootName(),
+	}
+	switch len(traversal) {
+	case 1:
+		return ref, true
+	case 2:
+		aliasStep, ok := traversal[1].(hcl.TraverseAttr)
+		if !ok {
+			return tfmod.ProviderRef{}, false
+		}
+		ref.Alias = aliasStep.Name
+		return ref, true
+	}
+
+	return tfmod.ProviderRef{}, false
+}
@@ -0,0 +1,126 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package validations
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/hcl-lang/decoder"
+	"github.com/hashicorp/hcl-lang/lang"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	tfmod "github.com/hashicorp/terraform-schema/module"
+)
+
+func TestModuleConfigurationAliases(t *testing.T) {
+	moduleAliases := func(callName string) ([]tfmod.ProviderRef, bool) {
+		if callName != "child" {
+			return nil, false
+		}
+		return []tfmod.ProviderRef{
+			{LocalName: "aws", Alias: "west"},
+		}, true
+	}
+
+	tests := []struct {
+		name          string
+		cfg           string
+		wantDiagnosed bool
+	}{
+		{
+			name: "no providers passed",
+			cfg: `module "child" {
+  source = "./child"
+}`,
+			wantDiagnosed: true,
+		},
+		{
+			name: "other provider passed",
+			cfg: `module "child" {
+  source = "./child"
+  providers = {
+    aws = aws.east
+  }
+}`,
+			wantDiagnosed: true,
+		},
+		{
+			name: "alias passed",
+			cfg: `module "child" {
+  source = "./child"
+  providers = {
+    aws.west = aws.usw2
+  }
+}`,
+		},
+		{
+			name: "unknown module",
+			cfg: `module "remote" {
+  source = "hashicorp/consul/aws"
+}`,
+		},
+	}
+
+	for i, tt := range tests {
+		t.Run(fmt.Sprintf("%d-%s", i, tt.name), func(t *testing.T) {
+			f, pDiags := hclsyntax.ParseConfig([]byte(tt.cfg), "test.tf", hcl.InitialPos)
+			if len(pDiags) > 0 {
+				t.Fatal(pDiags)
+			}
+
+			pathCtx := &decoder.PathContext{
+				Files: map[string]*hcl.File{
+					"test.tf": f,
+				},
+			}
+
+			diagsMap := ModuleConfigurationAliases(context.Background(), pathCtx, moduleAliases)
+			if !tt.wantDiagnosed {
+				if diff := cmp.Diff(lang.DiagnosticsMap{}, diagsMap); diff != "" {
+					t.Fatalf("unexpected diagnostics: %s", diff)
+				}
+				return
+			}
+
+			diags := diagsMap["test.tf"]
+			if len(diags) != 1 {
+				t.Fatalf("expected 1 diagnostic, %d given: %#v", len(diags), diags)
+			}
+			if diags[0].Summary != MissingConfigurationAliasSummary {
+				t.Fatalf("expected summary %q, given %q", MissingConfigurationAliasSummary, diags[0].Summary)
+			}
+		})
+	}
+}
+
+func TestConfigurationAliases(t *testing.T) {
+	cfg := `terraform {
+  required_providers {
+    aws = {
+      source                = "hashicorp/aws"
+      configuration_aliases = [aws.west, aws.east]
+    }
+    google = {
+      source = "hashicorp/google"
+    }
+  }
+}
+`
+	f, pDiags := hclsyntax.ParseConfig([]byte(cfg), "test.tf", hcl.InitialPos)
+	if len(pDiags) > 0 {
+		t.Fatal(pDiags)
+	}
+
+	expectedAliases := []tfmod.ProviderRef{
+		{LocalName: "aws", Alias: "east"},
+		{LocalName: "aws", Alias: "west"},
+	}
+	aliases := ConfigurationAliases(f.Body)
+	if diff := cmp.Diff(expectedAliases, aliases); diff != "" {
+		t.Fatalf("unexpected aliases: %s", diff)
+	}
+}
@@ -0,0 +1,71 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package validations
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/hcl-lang/schema"
+	"github.com/hashicorp/hcl-lang/schemacontext"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+)
+
+const InvalidCountForEachSummary = "Invalid combination of \"count\" and \"for_each\""
+
+// CountForEach reports resource, data and module blocks which declare
+// both count and for_each meta-arguments, which Terraform rejects.
+// The diagnostic is attached to whichever of the two is declared later.
+type CountForEach struct{}
+
+func (cfe CountForEach) Visit(ctx context.Context, node hclsyntax.Node, nodeSchema schema.Schema) (context.Context, hcl.Diagnostics) {
+	var diags hcl.Diagnostics
+
+	block, ok := node.(*hclsyntax.Block)
+	if !ok {
+		return ctx, diags
+	}
+	switch block.Type {
+	case "resource", "data", "module":
+	default:
+		return ctx, diags
+	}
+	nestingLvl, nestingOk := schemacontext.BlockNestingLevel(ctx)
+	if !nestingOk || nestingLvl != 0 {
+		return ctx, diags
+	}
+
+	countAttr, hasCount := block.Body.Attributes["count"]
+	forEachAttr, hasForEach := block.Body.Attributes["for_each"]
+	if !hasCount || !hasForEach {
+		return ctx, diags
+	}
+
+	second := forEachAttr
+	if countAttr.SrcRange.Start.Byte > forEachAttr.SrcRange.Start.Byte {
+		second = countAttr
+	}
+
+	diags = append(diags, &hcl.Diagnostic{
+		Severity: hcl.DiagError,
+		Summary:  InvalidCountForEachSummary,
+		Detail: fmt.Sprintf("The \"count\" and \"for_each\" meta-arguments are mutually exclusive, "+
+			"only one should be used to create multiple instances of this %s.", blockDescription(block.Type)),
+		Subject: second.SrcRange.Ptr(),
+		Context: block.Range().Ptr(),
+	})
+
+	return ctx, diags
+}
+
+func blockDescription(blockType string) string {
+	switch blockType {
+	case "data":
+		return "data source"
+	case "module":
+		return "module call"
+	}
+	return blockType
+}
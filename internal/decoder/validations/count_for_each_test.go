@@ -0,0 +1,103 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package validations
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/hcl-lang/schemacontext"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+)
+
+func TestCountForEach(t *testing.T) {
+	tests := []struct {
+		name          string
+		cfg           string
+		expectedAttr  string
+		expectedValid bool
+	}{
+		{
+			"count only",
+			`resource "aws_instance" "web" {
+  count = 2
+}
+`,
+			"",
+			true,
+		},
+		{
+			"for_each only",
+			`module "web" {
+  for_each = toset(["a", "b"])
+}
+`,
+			"",
+			true,
+		},
+		{
+			"resource with both",
+			`resource "aws_instance" "web" {
+  count    = 2
+  for_each = toset(["a", "b"])
+}
+`,
+			"for_each",
+			false,
+		},
+		{
+			"data with for_each first",
+			`data "aws_ami" "web" {
+  for_each = toset(["a", "b"])
+  count    = 2
+}
+`,
+			"count",
+			false,
+		},
+		{
+			"unrelated block",
+			`locals {
+  count    = 2
+  for_each = 3
+}
+`,
+			"",
+			true,
+		},
+	}
+
+	for i, tt := range tests {
+		t.Run(fmt.Sprintf("%d-%s", i, tt.name), func(t *testing.T) {
+			f, pDiags := hclsyntax.ParseConfig([]byte(tt.cfg), "test.tf", hcl.InitialPos)
+			if len(pDiags) > 0 {
+				t.Fatal(pDiags)
+			}
+			block := f.Body.(*hclsyntax.Body).Blocks[0]
+
+			ctx := schemacontext.WithBlockNestingLevel(context.Background(), 0)
+			_, diags := CountForEach{}.Visit(ctx, block, nil)
+
+			if tt.expectedValid {
+				if len(diags) > 0 {
+					t.Fatalf("expected no diagnostics, given: %s", diags)
+				}
+				return
+			}
+
+			if len(diags) != 1 {
+				t.Fatalf("expected 1 diagnostic, %d given: %s", len(diags), diags)
+			}
+			if diags[0].Summary != InvalidCountForEachSummary {
+				t.Fatalf("unexpected summary: %q", diags[0].Summary)
+			}
+			expectedRange := block.Body.Attributes[tt.expectedAttr].SrcRange
+			if *diags[0].Subject != expectedRange {
+				t.Fatalf("unexpected range: %#v, expected: %#v", *diags[0].Subject, expectedRange)
+			}
+		})
+	}
+}
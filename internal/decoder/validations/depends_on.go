@@ -0,0 +1,118 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package validations
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/hcl-lang/decoder"
+	"github.com/hashicorp/hcl-lang/lang"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+)
+
+const InvalidDependsOnSummary = "Invalid depends_on reference"
+
+// DependsOnReferences validates entries of depends_on lists, which must
+// reference whole objects (e.g. resources or modules) declared in the module,
+// rather than attributes of such objects.
+func DependsOnReferences(ctx context.Context, pathCtx *decoder.PathContext) lang.DiagnosticsMap {
+	diagsMap := make(lang.DiagnosticsMap)
+
+	for fileName, file := range pathCtx.Files {
+		body, ok := file.Body.(*hclsyntax.Body)
+		if !ok {
+			continue
+		}
+
+		for _, block := range body.Blocks {
+			attr, ok := block.Body.Attributes["depends_on"]
+			if !ok {
+				continue
+			}
+			tupleExpr, ok := attr.Expr.(*hclsyntax.TupleConsExpr)
+			if !ok {
+				continue
+			}
+
+			for _, expr := range tupleExpr.Exprs {
+				if d := validateDependsOnEntry(pathCtx, expr); d != nil {
+					diagsMap[fileName] = diagsMap[fileName].Append(d)
+				}
+			}
+		}
+	}
+
+	return diagsMap
+}
+
+func validateDependsOnEntry(pathCtx *decoder.PathContext, expr hclsyntax.Expression) *hcl.Diagnostic {
+	traversal, diags := hcl.AbsTraversalForExpr(expr)
+	if diags.HasErrors() {
+		return &hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  InvalidDependsOnSummary,
+			Detail:   "A single static variable reference is required: only attribute access and indexing with constant keys.",
+			Subject:  expr.Range().Ptr(),
+		}
+	}
+
+	// Instance keys (e.g. aws_instance.foo[0]) are allowed,
+	// so we only consider names in the address
+	address := make(lang.Address, 0)
+	for _, tr := range traversal {
+		switch t := tr.(type) {
+		case hcl.TraverseRoot:
+			address = append(address, lang.RootStep{Name: t.Name})
+		case hcl.TraverseAttr:
+			address = append(address, lang.AttrStep{Name: t.Name})
+		}
+	}
+
+	var objectSteps, maxSteps int
+	checkExistence := true
+	switch address[0].String() {
+	case "var", "local":
+		// Existence of variables and local values
+		// is already validated via their reference origins
+		objectSteps, maxSteps = 2, 2
+		checkExistence = false
+	case "module":
+		// module outputs can be referenced too
+		objectSteps, maxSteps = 2, 3
+	case "data":
+		objectSteps, maxSteps = 3, 3
+	case "count", "each", "path", "self", "terraform":
+		return nil
+	default:
+		objectSteps, maxSteps = 2, 2
+	}
+
+	if len(address) > maxSteps {
+		return &hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  InvalidDependsOnSummary,
+			Detail:   "References in depends_on must be to a whole object (resource, etc), not to an attribute of an object.",
+			Subject:  expr.Range().Ptr(),
+		}
+	}
+
+	if !checkExistence || len(address) < objectSteps {
+		return nil
+	}
+
+	objectAddr := address.FirstSteps(uint(objectSteps))
+	for _, target := range pathCtx.ReferenceTargets {
+		if target.Addr.Equals(objectAddr) {
+			return nil
+		}
+	}
+
+	return &hcl.Diagnostic{
+		Severity: hcl.DiagError,
+		Summary:  fmt.Sprintf("No declaration found for %q", objectAddr),
+		Subject:  expr.Range().Ptr(),
+	}
+}
@@ -0,0 +1,148 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package validations
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/hcl-lang/decoder"
+	"github.com/hashicorp/hcl-lang/lang"
+	"github.com/hashicorp/hcl-lang/reference"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+)
+
+func TestDependsOnReferences(t *testing.T) {
+	targets := reference.Targets{
+		{
+			Addr: lang.Address{
+				lang.RootStep{Name: "aws_instance"},
+				lang.AttrStep{Name: "known"},
+			},
+		},
+		{
+			Addr: lang.Address{
+				lang.RootStep{Name: "data"},
+				lang.AttrStep{Name: "aws_ami"},
+				lang.AttrStep{Name: "known"},
+			},
+		},
+		{
+			Addr: lang.Address{
+				lang.RootStep{Name: "module"},
+				lang.AttrStep{Name: "known"},
+			},
+		},
+	}
+
+	tests := []struct {
+		name          string
+		dependsOn     string
+		wantSummary   string
+		wantDiagnosed bool
+	}{
+		{
+			name:      "known resource",
+			dependsOn: "aws_instance.known",
+		},
+		{
+			name:      "known resource instance",
+			dependsOn: "aws_instance.known[0]",
+		},
+		{
+			name:      "known data source",
+			dependsOn: "data.aws_ami.known",
+		},
+		{
+			name:      "known module",
+			dependsOn: "module.known",
+		},
+		{
+			name:      "known module output",
+			dependsOn: "module.known.output",
+		},
+		{
+			name:          "unknown resource",
+			dependsOn:     "aws_instance.unknown",
+			wantSummary:   `No declaration found for "aws_instance.unknown"`,
+			wantDiagnosed: true,
+		},
+		{
+			name:          "unknown module",
+			dependsOn:     "module.unknown",
+			wantSummary:   `No declaration found for "module.unknown"`,
+			wantDiagnosed: true,
+		},
+		{
+			name:          "resource attribute",
+			dependsOn:     "aws_instance.known.id",
+			wantSummary:   InvalidDependsOnSummary,
+			wantDiagnosed: true,
+		},
+		{
+			name:          "resource instance attribute",
+			dependsOn:     "aws_instance.known[0].id",
+			wantSummary:   InvalidDependsOnSummary,
+			wantDiagnosed: true,
+		},
+		{
+			name:          "data source attribute",
+			dependsOn:     "data.aws_ami.known.id",
+			wantSummary:   InvalidDependsOnSummary,
+			wantDiagnosed: true,
+		},
+		{
+			name:          "string",
+			dependsOn:     `"aws_instance.known"`,
+			wantSummary:   InvalidDependsOnSummary,
+			wantDiagnosed: true,
+		},
+	}
+
+	for i, tt := range tests {
+		t.Run(fmt.Sprintf("%d-%s", i, tt.name), func(t *testing.T) {
+			cfg := fmt.Sprintf("resource \"aws_instance\" \"test\" {\n  depends_on = [%s]\n}\n", tt.dependsOn)
+			f, pDiags := hclsyntax.ParseConfig([]byte(cfg), "test.tf", hcl.InitialPos)
+			if len(pDiags) > 0 {
+				t.Fatal(pDiags)
+			}
+
+			pathCtx := &decoder.PathContext{
+				ReferenceTargets: targets,
+				Files: map[string]*hcl.File{
+					"test.tf": f,
+				},
+			}
+
+			diagsMap := DependsOnReferences(context.Background(), pathCtx)
+			if !tt.wantDiagnosed {
+				if diff := cmp.Diff(lang.DiagnosticsMap{}, diagsMap); diff != "" {
+					t.Fatalf("unexpected diagnostics: %s", diff)
+				}
+				return
+			}
+
+			diags := diagsMap["test.tf"]
+			if len(diags) != 1 {
+				t.Fatalf("expected 1 diagnostic, %d given: %#v", len(diags), diags)
+			}
+			if diags[0].Summary != tt.wantSummary {
+				t.Fatalf("expected summary %q, given %q", tt.wantSummary, diags[0].Summary)
+			}
+
+			// the diagnostic points at the entry
+			expectedRange := &hcl.Range{
+				Filename: "test.tf",
+				Start:    hcl.Pos{Line: 2, Column: 17, Byte: 49},
+				End:      hcl.Pos{Line: 2, Column: 17 + len(tt.dependsOn), Byte: 49 + len(tt.dependsOn)},
+			}
+			if diff := cmp.Diff(expectedRange, diags[0].Subject); diff != "" {
+				t.Fatalf("unexpected range: %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,46 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package validations
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/hcl-lang/schema"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	ilsp "github.com/hashicorp/terraform-ls/internal/lsp"
+)
+
+// DeprecatedAttribute reports attributes which are marked as deprecated
+// in the schema, such as provider schema attributes.
+//
+// Provider schemas do not carry a dedicated deprecation message,
+// so the attribute description is reported instead, if there is one.
+// The resulting diagnostics are reported as hints.
+type DeprecatedAttribute struct{}
+
+func (da DeprecatedAttribute) Visit(ctx context.Context, node hclsyntax.Node, nodeSchema schema.Schema) (context.Context, hcl.Diagnostics) {
+	var diags hcl.Diagnostics
+
+	attr, ok := node.(*hclsyntax.Attribute)
+	if !ok {
+		return ctx, diags
+	}
+
+	attrSchema, ok := nodeSchema.(*schema.AttributeSchema)
+	if !ok || attrSchema == nil || !attrSchema.IsDeprecated {
+		return ctx, diags
+	}
+
+	diags = append(diags, &hcl.Diagnostic{
+		Severity: hcl.DiagWarning,
+		Summary:  fmt.Sprintf("%q is deprecated", attr.Name),
+		Detail:   attrSchema.Description.Value,
+		Subject:  attr.SrcRange.Ptr(),
+		Extra:    ilsp.HintDiagnostic{},
+	})
+
+	return ctx, diags
+}
@@ -0,0 +1,78 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package validations
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/hcl-lang/lang"
+	"github.com/hashicorp/hcl-lang/schema"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	ilsp "github.com/hashicorp/terraform-ls/internal/lsp"
+	"github.com/zclconf/go-cty/cty"
+)
+
+func TestDeprecatedAttribute(t *testing.T) {
+	tests := []struct {
+		name       string
+		attrSchema schema.Schema
+		want       hcl.Diagnostics
+	}{
+		{
+			name: "deprecated attribute",
+			attrSchema: &schema.AttributeSchema{
+				Constraint:   schema.LiteralType{Type: cty.String},
+				IsOptional:   true,
+				IsDeprecated: true,
+				Description:  lang.PlainText("Use name instead"),
+			},
+			want: hcl.Diagnostics{
+				&hcl.Diagnostic{
+					Severity: hcl.DiagWarning,
+					Summary:  `"legacy_name" is deprecated`,
+					Detail:   "Use name instead",
+					Subject: &hcl.Range{
+						Filename: "test.tf",
+						Start:    hcl.Pos{Line: 1, Column: 1, Byte: 0},
+						End:      hcl.Pos{Line: 1, Column: 20, Byte: 19},
+					},
+					Extra: ilsp.HintDiagnostic{},
+				},
+			},
+		},
+		{
+			name: "attribute not deprecated",
+			attrSchema: &schema.AttributeSchema{
+				Constraint: schema.LiteralType{Type: cty.String},
+				IsOptional: true,
+			},
+			want: nil,
+		},
+		{
+			name:       "unknown attribute",
+			attrSchema: nil,
+			want:       nil,
+		},
+	}
+
+	for i, tt := range tests {
+		t.Run(fmt.Sprintf("%d-%s", i, tt.name), func(t *testing.T) {
+			f, pDiags := hclsyntax.ParseConfig([]byte(`legacy_name = "foo"`), "test.tf", hcl.InitialPos)
+			if len(pDiags) > 0 {
+				t.Fatal(pDiags)
+			}
+			attr := f.Body.(*hclsyntax.Body).Attributes["legacy_name"]
+
+			_, diags := DeprecatedAttribute{}.Visit(context.Background(), attr, tt.attrSchema)
+
+			if diff := cmp.Diff(tt.want, diags); diff != "" {
+				t.Fatalf("unexpected diagnostics: %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,131 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package validations
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/hcl-lang/decoder"
+	"github.com/hashicorp/hcl-lang/lang"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/convert"
+)
+
+const InvalidImportIDSummary = "Invalid import id"
+
+// ImportIDs validates id expressions of import blocks, which must
+// produce a string and may reference variables and local values.
+// References are checked to be declared, unless these were already
+// collected as reference origins and are validated as such.
+func ImportIDs(ctx context.Context, pathCtx *decoder.PathContext) lang.DiagnosticsMap {
+	diagsMap := make(lang.DiagnosticsMap)
+
+	for fileName, file := range pathCtx.Files {
+		body, ok := file.Body.(*hclsyntax.Body)
+		if !ok {
+			continue
+		}
+
+		for _, block := range body.Blocks {
+			if block.Type != "import" {
+				continue
+			}
+			attr, ok := block.Body.Attributes["id"]
+			if !ok {
+				continue
+			}
+
+			for _, d := range validateImportID(pathCtx, attr.Expr) {
+				diagsMap[fileName] = diagsMap[fileName].Append(d)
+			}
+		}
+	}
+
+	return diagsMap
+}
+
+func validateImportID(pathCtx *decoder.PathContext, expr hclsyntax.Expression) hcl.Diagnostics {
+	var diags hcl.Diagnostics
+
+	for _, traversal := range expr.Variables() {
+		switch traversal.RootName() {
+		case "var", "local":
+		default:
+			continue
+		}
+		if isCollectedOrigin(pathCtx, traversal.SourceRange()) {
+			continue
+		}
+		if _, ok := importIDTargetType(pathCtx, traversal); !ok {
+			addr, err := lang.TraversalToAddress(traversal)
+			if err != nil {
+				continue
+			}
+			diags = append(diags, &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  fmt.Sprintf("No declaration found for %q", addr.FirstSteps(2)),
+				Subject:  traversal.SourceRange().Ptr(),
+			})
+		}
+	}
+
+	valType := cty.DynamicPseudoType
+	description := "a value"
+	if traversal, tDiags := hcl.AbsTraversalForExpr(expr); !tDiags.HasErrors() {
+		if targetType, ok := importIDTargetType(pathCtx, traversal); ok {
+			valType = targetType
+			description = traversalAddress(traversal)
+		}
+	} else if val, vDiags := expr.Value(nil); !vDiags.HasErrors() {
+		valType = val.Type()
+	}
+
+	if !valType.Equals(cty.DynamicPseudoType) && !valType.Equals(cty.String) &&
+		convert.GetConversion(valType, cty.String) == nil {
+		diags = append(diags, &hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  InvalidImportIDSummary,
+			Detail: fmt.Sprintf("The import id must be a string, but %s of type %s is given.",
+				description, valType.FriendlyName()),
+			Subject: expr.Range().Ptr(),
+		})
+	}
+
+	return diags
+}
+
+// importIDTargetType returns type of the variable or local value
+// the given traversal refers to, if one is declared
+func importIDTargetType(pathCtx *decoder.PathContext, traversal hcl.Traversal) (cty.Type, bool) {
+	addr, err := lang.TraversalToAddress(traversal)
+	if err != nil || len(addr) < 2 {
+		return cty.NilType, false
+	}
+
+	objectAddr := addr.FirstSteps(2)
+	for _, target := range pathCtx.ReferenceTargets {
+		if !target.Addr.Equals(objectAddr) {
+			continue
+		}
+		if len(addr) > 2 || target.Type == cty.NilType {
+			// type of nested attributes is not looked up
+			return cty.DynamicPseudoType, true
+		}
+		return target.Type, true
+	}
+
+	return cty.NilType, false
+}
+
+func isCollectedOrigin(pathCtx *decoder.PathContext, rng hcl.Range) bool {
+	for _, origin := range pathCtx.ReferenceOrigins {
+		if origin.OriginRange() == rng {
+			return true
+		}
+	}
+	return false
+}
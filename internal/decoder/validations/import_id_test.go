@@ -0,0 +1,165 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package validations
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/hcl-lang/decoder"
+	"github.com/hashicorp/hcl-lang/lang"
+	"github.com/hashicorp/hcl-lang/reference"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty/cty"
+)
+
+func TestImportIDs(t *testing.T) {
+	targets := reference.Targets{
+		{
+			Addr: lang.Address{
+				lang.RootStep{Name: "var"},
+				lang.AttrStep{Name: "instance_id"},
+			},
+			Type: cty.String,
+		},
+		{
+			Addr: lang.Address{
+				lang.RootStep{Name: "var"},
+				lang.AttrStep{Name: "settings"},
+			},
+			Type: cty.Object(map[string]cty.Type{
+				"id": cty.String,
+			}),
+		},
+		{
+			Addr: lang.Address{
+				lang.RootStep{Name: "local"},
+				lang.AttrStep{Name: "prefix"},
+			},
+			Type: cty.String,
+		},
+	}
+
+	tests := []struct {
+		name        string
+		id          string
+		wantSummary string
+	}{
+		{
+			name: "string literal",
+			id:   `"i-abcd1234"`,
+		},
+		{
+			name: "number literal",
+			id:   `1234`,
+		},
+		{
+			name: "string variable",
+			id:   `var.instance_id`,
+		},
+		{
+			name: "template with local value",
+			id:   `"${local.prefix}-1234"`,
+		},
+		{
+			name: "nested attribute of object variable",
+			id:   `var.settings.id`,
+		},
+		{
+			name:        "object variable",
+			id:          `var.settings`,
+			wantSummary: InvalidImportIDSummary,
+		},
+		{
+			name:        "list literal",
+			id:          `["i-abcd1234"]`,
+			wantSummary: InvalidImportIDSummary,
+		},
+		{
+			name:        "undeclared variable",
+			id:          `var.unknown`,
+			wantSummary: `No declaration found for "var.unknown"`,
+		},
+		{
+			name:        "undeclared local value in template",
+			id:          `"${local.unknown}-1234"`,
+			wantSummary: `No declaration found for "local.unknown"`,
+		},
+	}
+
+	for i, tt := range tests {
+		t.Run(fmt.Sprintf("%d-%s", i, tt.name), func(t *testing.T) {
+			cfg := fmt.Sprintf(`import {
+  to = aws_instance.web
+  id = %s
+}
+`, tt.id)
+			f, pDiags := hclsyntax.ParseConfig([]byte(cfg), "test.tf", hcl.InitialPos)
+			if len(pDiags) > 0 {
+				t.Fatal(pDiags)
+			}
+
+			pathCtx := &decoder.PathContext{
+				Files: map[string]*hcl.File{
+					"test.tf": f,
+				},
+				ReferenceTargets: targets,
+			}
+
+			diagsMap := ImportIDs(context.Background(), pathCtx)
+			if tt.wantSummary == "" {
+				if diff := cmp.Diff(lang.DiagnosticsMap{}, diagsMap); diff != "" {
+					t.Fatalf("unexpected diagnostics: %s", diff)
+				}
+				return
+			}
+
+			diags := diagsMap["test.tf"]
+			if len(diags) != 1 {
+				t.Fatalf("expected 1 diagnostic, %d given: %#v", len(diags), diags)
+			}
+			if diags[0].Summary != tt.wantSummary {
+				t.Fatalf("expected summary %q, given %q", tt.wantSummary, diags[0].Summary)
+			}
+		})
+	}
+}
+
+func TestImportIDs_collectedOrigin(t *testing.T) {
+	cfg := `import {
+  to = aws_instance.web
+  id = var.unknown
+}
+`
+	f, pDiags := hclsyntax.ParseConfig([]byte(cfg), "test.tf", hcl.InitialPos)
+	if len(pDiags) > 0 {
+		t.Fatal(pDiags)
+	}
+	attr := f.Body.(*hclsyntax.Body).Blocks[0].Body.Attributes["id"]
+
+	pathCtx := &decoder.PathContext{
+		Files: map[string]*hcl.File{
+			"test.tf": f,
+		},
+		ReferenceOrigins: reference.Origins{
+			reference.LocalOrigin{
+				Addr: lang.Address{
+					lang.RootStep{Name: "var"},
+					lang.AttrStep{Name: "unknown"},
+				},
+				Range: attr.Expr.Range(),
+			},
+		},
+	}
+
+	// undeclared references collected as origins are reported
+	// by UnreferencedOrigins already
+	diagsMap := ImportIDs(context.Background(), pathCtx)
+	if diff := cmp.Diff(lang.DiagnosticsMap{}, diagsMap); diff != "" {
+		t.Fatalf("unexpected diagnostics: %s", diff)
+	}
+}
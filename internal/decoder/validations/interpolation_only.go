@@ -0,0 +1,63 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package validations
+
+import (
+	"context"
+
+	"github.com/hashicorp/hcl-lang/schema"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	lsctx "github.com/hashicorp/terraform-ls/internal/context"
+	ilsp "github.com/hashicorp/terraform-ls/internal/lsp"
+)
+
+const InterpolationOnlySummary = "Interpolation-only expression"
+
+// InterpolationOnlyExpression reports template expressions which consist
+// of a single interpolation sequence (e.g. "${var.foo}") and can be
+// replaced by the bare expression (e.g. var.foo).
+//
+// The validator is opt-in via enableInterpolationOnlyHints and the
+// resulting diagnostics are reported as hints.
+type InterpolationOnlyExpression struct{}
+
+func (ioe InterpolationOnlyExpression) Visit(ctx context.Context, node hclsyntax.Node, nodeSchema schema.Schema) (context.Context, hcl.Diagnostics) {
+	var diags hcl.Diagnostics
+
+	validationOpts, err := lsctx.ValidationOptions(ctx)
+	if err != nil || !validationOpts.EnableInterpolationOnlyHints {
+		return ctx, diags
+	}
+
+	attr, ok := node.(*hclsyntax.Attribute)
+	if !ok {
+		return ctx, diags
+	}
+
+	for _, expr := range InterpolationOnlyExpressions(attr.Expr) {
+		diags = append(diags, &hcl.Diagnostic{
+			Severity: hcl.DiagWarning,
+			Summary:  InterpolationOnlySummary,
+			Detail:   "Interpolation-only expressions are unnecessary, the wrapped expression can be used directly",
+			Subject:  expr.SrcRange.Ptr(),
+			Extra:    ilsp.HintDiagnostic{},
+		})
+	}
+
+	return ctx, diags
+}
+
+// InterpolationOnlyExpressions returns all templates within the given node
+// which only wrap a single interpolated expression, such as "${var.foo}".
+func InterpolationOnlyExpressions(node hclsyntax.Node) []*hclsyntax.TemplateWrapExpr {
+	exprs := make([]*hclsyntax.TemplateWrapExpr, 0)
+	hclsyntax.VisitAll(node, func(n hclsyntax.Node) hcl.Diagnostics {
+		if expr, ok := n.(*hclsyntax.TemplateWrapExpr); ok {
+			exprs = append(exprs, expr)
+		}
+		return nil
+	})
+	return exprs
+}
@@ -0,0 +1,83 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package validations
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	lsctx "github.com/hashicorp/terraform-ls/internal/context"
+	ilsp "github.com/hashicorp/terraform-ls/internal/lsp"
+	"github.com/hashicorp/terraform-ls/internal/settings"
+)
+
+func TestInterpolationOnlyExpression(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     string
+		enabled bool
+		want    hcl.Diagnostics
+	}{
+		{
+			name:    "interpolation-only",
+			cfg:     `foo = "${var.x}"`,
+			enabled: true,
+			want: hcl.Diagnostics{
+				&hcl.Diagnostic{
+					Severity: hcl.DiagWarning,
+					Summary:  InterpolationOnlySummary,
+					Detail:   "Interpolation-only expressions are unnecessary, the wrapped expression can be used directly",
+					Subject: &hcl.Range{
+						Filename: "test.tf",
+						Start:    hcl.Pos{Line: 1, Column: 7, Byte: 6},
+						End:      hcl.Pos{Line: 1, Column: 17, Byte: 16},
+					},
+					Extra: ilsp.HintDiagnostic{},
+				},
+			},
+		},
+		{
+			name:    "interpolation-only disabled",
+			cfg:     `foo = "${var.x}"`,
+			enabled: false,
+			want:    nil,
+		},
+		{
+			name:    "template with literal",
+			cfg:     `foo = "prefix-${var.x}"`,
+			enabled: true,
+			want:    nil,
+		},
+		{
+			name:    "bare reference",
+			cfg:     `foo = var.x`,
+			enabled: true,
+			want:    nil,
+		},
+	}
+
+	for i, tt := range tests {
+		t.Run(fmt.Sprintf("%d-%s", i, tt.name), func(t *testing.T) {
+			f, pDiags := hclsyntax.ParseConfig([]byte(tt.cfg), "test.tf", hcl.InitialPos)
+			if len(pDiags) > 0 {
+				t.Fatal(pDiags)
+			}
+			attr := f.Body.(*hclsyntax.Body).Attributes["foo"]
+
+			ctx := lsctx.WithValidationOptions(context.Background(), &settings.ValidationOptions{
+				EnableInterpolationOnlyHints: tt.enabled,
+			})
+
+			_, diags := InterpolationOnlyExpression{}.Visit(ctx, attr, nil)
+
+			if diff := cmp.Diff(tt.want, diags); diff != "" {
+				t.Fatalf("unexpected diagnostics: %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,63 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package validations
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/hcl/v2"
+	tfmod "github.com/hashicorp/terraform-schema/module"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// JSONVariables validates a variable file in JSON syntax (*.tfvars.json)
+// against the given declared variables, reporting any undeclared variables
+// and values which are not suitable for the type of their variable.
+//
+// Files in JSON syntax are not supported by the schema-based validation,
+// which only walks files in native syntax.
+func JSONVariables(file *hcl.File, variables map[string]tfmod.Variable) hcl.Diagnostics {
+	var diags hcl.Diagnostics
+
+	attrs, aDiags := file.Body.JustAttributes()
+	if aDiags.HasErrors() {
+		// invalid structure is reported by the parser
+		return diags
+	}
+
+	names := make([]string, 0, len(attrs))
+	for name := range attrs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		attr := attrs[name]
+
+		variable, ok := variables[name]
+		if !ok {
+			diags = append(diags, &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Unexpected attribute",
+				Detail:   fmt.Sprintf("An attribute named %q is not expected here", name),
+				Subject:  attr.NameRange.Ptr(),
+			})
+			continue
+		}
+		if variable.Type == cty.NilType || variable.Type.Equals(cty.DynamicPseudoType) {
+			continue
+		}
+
+		val, vDiags := attr.Expr.Value(nil)
+		if vDiags.HasErrors() || !val.IsWhollyKnown() {
+			continue
+		}
+		if diag, ok := variableValueDiagnostic(name, val, variable.Type, attr.Expr.Range(), attr.Range); ok {
+			diags = append(diags, diag)
+		}
+	}
+
+	return diags
+}
@@ -0,0 +1,87 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package validations
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/json"
+	tfmod "github.com/hashicorp/terraform-schema/module"
+	"github.com/zclconf/go-cty/cty"
+)
+
+func TestJSONVariables(t *testing.T) {
+	variables := map[string]tfmod.Variable{
+		"server": {
+			Type: cty.Object(map[string]cty.Type{
+				"name": cty.String,
+				"port": cty.Number,
+			}),
+		},
+		"untyped": {
+			Type: cty.DynamicPseudoType,
+		},
+	}
+
+	tests := []struct {
+		name string
+		src  string
+		want hcl.Diagnostics
+	}{
+		{
+			name: "valid values",
+			src:  `{"server": {"name": "web", "port": 80}, "untyped": [1, "a"]}`,
+			want: nil,
+		},
+		{
+			name: "undeclared variable and invalid value",
+			src:  `{"server": {"name": "web", "port": "http"}, "region": "eu"}`,
+			want: hcl.Diagnostics{
+				&hcl.Diagnostic{
+					Severity: hcl.DiagError,
+					Summary:  "Unexpected attribute",
+					Detail:   `An attribute named "region" is not expected here`,
+					Subject: &hcl.Range{
+						Filename: "prod.tfvars.json",
+						Start:    hcl.Pos{Line: 1, Column: 45, Byte: 44},
+						End:      hcl.Pos{Line: 1, Column: 53, Byte: 52},
+					},
+				},
+				&hcl.Diagnostic{
+					Severity: hcl.DiagError,
+					Summary:  InvalidVariableValueSummary,
+					Detail:   `The given value is not suitable for var.server: attribute "port": a number is required.`,
+					Subject: &hcl.Range{
+						Filename: "prod.tfvars.json",
+						Start:    hcl.Pos{Line: 1, Column: 12, Byte: 11},
+						End:      hcl.Pos{Line: 1, Column: 43, Byte: 42},
+					},
+					Context: &hcl.Range{
+						Filename: "prod.tfvars.json",
+						Start:    hcl.Pos{Line: 1, Column: 2, Byte: 1},
+						End:      hcl.Pos{Line: 1, Column: 43, Byte: 42},
+					},
+				},
+			},
+		},
+	}
+
+	for i, tt := range tests {
+		t.Run(fmt.Sprintf("%d-%s", i, tt.name), func(t *testing.T) {
+			f, pDiags := json.Parse([]byte(tt.src), "prod.tfvars.json")
+			if len(pDiags) > 0 {
+				t.Fatal(pDiags)
+			}
+
+			diags := JSONVariables(f, variables)
+
+			if diff := cmp.Diff(tt.want, diags); diff != "" {
+				t.Fatalf("unexpected diagnostics: %s", diff)
+			}
+		})
+	}
+}
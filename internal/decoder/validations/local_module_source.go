@@ -0,0 +1,86 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package validations
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/hcl-lang/decoder"
+	"github.com/hashicorp/hcl-lang/lang"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty/cty"
+)
+
+const (
+	MissingLocalModuleSummary = "Module directory not found"
+	EmptyLocalModuleSummary   = "Module directory has no configuration files"
+)
+
+// LocalModuleDirFunc reports whether the directory of a local module
+// with the given source address (e.g. ./sub) exists and whether
+// it contains any module files (*.tf or *.tf.json).
+type LocalModuleDirFunc func(source string) (exists bool, hasModuleFiles bool, err error)
+
+// LocalModuleSources reports module calls with a local source address
+// which points to a directory which does not exist, or which does not
+// contain any module files, e.g. due to a typo in the path.
+func LocalModuleSources(ctx context.Context, pathCtx *decoder.PathContext, moduleDir LocalModuleDirFunc) lang.DiagnosticsMap {
+	diagsMap := make(lang.DiagnosticsMap)
+
+	for fileName, file := range pathCtx.Files {
+		body, ok := file.Body.(*hclsyntax.Body)
+		if !ok {
+			continue
+		}
+
+		for _, block := range body.Blocks {
+			if block.Type != "module" || len(block.Labels) == 0 {
+				continue
+			}
+			attr, ok := block.Body.Attributes["source"]
+			if !ok {
+				continue
+			}
+
+			// Non-static or non-string values are reported elsewhere
+			val, vDiags := attr.Expr.Value(nil)
+			if vDiags.HasErrors() || !val.IsWhollyKnown() || val.IsNull() || !val.Type().Equals(cty.String) {
+				continue
+			}
+			source := val.AsString()
+			if !isLocalModuleSource(source) {
+				continue
+			}
+
+			exists, hasModuleFiles, err := moduleDir(source)
+			if err != nil {
+				continue
+			}
+
+			switch {
+			case !exists:
+				diagsMap[fileName] = diagsMap[fileName].Append(&hcl.Diagnostic{
+					Severity: hcl.DiagError,
+					Summary:  MissingLocalModuleSummary,
+					Detail: fmt.Sprintf("Module %q refers to %s, which does not exist.",
+						block.Labels[0], source),
+					Subject: attr.SrcRange.Ptr(),
+				})
+			case !hasModuleFiles:
+				diagsMap[fileName] = diagsMap[fileName].Append(&hcl.Diagnostic{
+					Severity: hcl.DiagWarning,
+					Summary:  EmptyLocalModuleSummary,
+					Detail: fmt.Sprintf("Module %q refers to %s, which contains no *.tf or *.tf.json files, "+
+						"so the module declares no resources, variables or outputs.",
+						block.Labels[0], source),
+					Subject: attr.SrcRange.Ptr(),
+				})
+			}
+		}
+	}
+
+	return diagsMap
+}
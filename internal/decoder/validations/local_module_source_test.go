@@ -0,0 +1,109 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package validations
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/hcl-lang/decoder"
+	"github.com/hashicorp/hcl-lang/lang"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+)
+
+func TestLocalModuleSources(t *testing.T) {
+	moduleDir := func(source string) (bool, bool, error) {
+		switch source {
+		case "./valid":
+			return true, true, nil
+		case "./empty":
+			return true, false, nil
+		case "./unreadable":
+			return false, false, errors.New("permission denied")
+		}
+		return false, false, nil
+	}
+
+	tests := []struct {
+		name        string
+		cfg         string
+		wantSummary string
+	}{
+		{
+			name: "directory with module files",
+			cfg: `module "child" {
+  source = "./valid"
+}`,
+		},
+		{
+			name: "empty directory",
+			cfg: `module "child" {
+  source = "./empty"
+}`,
+			wantSummary: EmptyLocalModuleSummary,
+		},
+		{
+			name: "missing directory",
+			cfg: `module "child" {
+  source = "../missing"
+}`,
+			wantSummary: MissingLocalModuleSummary,
+		},
+		{
+			name: "unreadable directory",
+			cfg: `module "child" {
+  source = "./unreadable"
+}`,
+		},
+		{
+			name: "registry module",
+			cfg: `module "child" {
+  source = "hashicorp/consul/aws"
+}`,
+		},
+	}
+
+	for i, tt := range tests {
+		t.Run(fmt.Sprintf("%d-%s", i, tt.name), func(t *testing.T) {
+			f, pDiags := hclsyntax.ParseConfig([]byte(tt.cfg), "test.tf", hcl.InitialPos)
+			if len(pDiags) > 0 {
+				t.Fatal(pDiags)
+			}
+
+			pathCtx := &decoder.PathContext{
+				Files: map[string]*hcl.File{
+					"test.tf": f,
+				},
+			}
+
+			diagsMap := LocalModuleSources(context.Background(), pathCtx, moduleDir)
+			if tt.wantSummary == "" {
+				if diff := cmp.Diff(lang.DiagnosticsMap{}, diagsMap); diff != "" {
+					t.Fatalf("unexpected diagnostics: %s", diff)
+				}
+				return
+			}
+
+			diags := diagsMap["test.tf"]
+			if len(diags) != 1 {
+				t.Fatalf("expected 1 diagnostic, %d given: %#v", len(diags), diags)
+			}
+			if diags[0].Summary != tt.wantSummary {
+				t.Fatalf("expected summary %q, given %q", tt.wantSummary, diags[0].Summary)
+			}
+			expectedSubject := &hcl.Range{
+				Filename: "test.tf",
+				Start:    hcl.Pos{Line: 2, Column: 3, Byte: 19},
+				End:      diags[0].Subject.End,
+			}
+			if diff := cmp.Diff(expectedSubject, diags[0].Subject); diff != "" {
+				t.Fatalf("expected diagnostic on the source attribute: %s", diff)
+			}
+		})
+	}
+}
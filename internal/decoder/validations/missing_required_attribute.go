@@ -11,6 +11,7 @@ import (
 	"github.com/hashicorp/hcl-lang/schemacontext"
 	"github.com/hashicorp/hcl/v2"
 	"github.com/hashicorp/hcl/v2/hclsyntax"
+	lsctx "github.com/hashicorp/terraform-ls/internal/context"
 )
 
 type MissingRequiredAttribute struct{}
@@ -30,32 +31,75 @@ func (mra MissingRequiredAttribute) Visit(ctx context.Context, node hclsyntax.No
 		if nodeType.Type == "provider" && (nestingOk && nestingLvl == 0) {
 			ctx = WithUnknownRequiredAttributes(ctx)
 		}
+
+		// Backends may be configured partially, with the remaining attributes
+		// supplied via -backend-config at init time, unless asked otherwise
+		if nodeType.Type == "backend" && (nestingOk && nestingLvl == 1) {
+			if !isStrictBackendValidation(ctx) {
+				ctx = WithUnknownRequiredAttributes(ctx)
+			} else {
+				diags = append(diags, missingBackendAttributes(nodeType, nodeSchema)...)
+			}
+		}
 	case *hclsyntax.Body:
-		if nodeSchema == nil {
+		bodySchema, ok := nodeSchema.(*schema.BodySchema)
+		if !ok || bodySchema == nil {
 			return ctx, diags
 		}
+		diags = append(diags, missingRequiredAttributes(nodeType, bodySchema)...)
+	}
 
-		bodySchema := nodeSchema.(*schema.BodySchema)
-		if bodySchema.Attributes == nil {
-			return ctx, diags
-		}
+	return ctx, diags
+}
+
+func missingRequiredAttributes(body *hclsyntax.Body, bodySchema *schema.BodySchema) hcl.Diagnostics {
+	var diags hcl.Diagnostics
 
-		for name, attr := range bodySchema.Attributes {
-			if attr.IsRequired {
-				_, ok := nodeType.Attributes[name]
-				if !ok {
-					diags = append(diags, &hcl.Diagnostic{
-						Severity: hcl.DiagError,
-						Summary:  fmt.Sprintf("Required attribute %q not specified", name),
-						Detail:   fmt.Sprintf("An attribute named %q is required here", name),
-						Subject:  nodeType.SrcRange.Ptr(),
-					})
-				}
+	for name, attr := range bodySchema.Attributes {
+		if attr.IsRequired {
+			_, ok := body.Attributes[name]
+			if !ok {
+				diags = append(diags, &hcl.Diagnostic{
+					Severity: hcl.DiagError,
+					Summary:  fmt.Sprintf("Required attribute %q not specified", name),
+					Detail:   fmt.Sprintf("An attribute named %q is required here", name),
+					Subject:  body.SrcRange.Ptr(),
+				})
 			}
 		}
 	}
 
-	return ctx, diags
+	return diags
+}
+
+// missingBackendAttributes checks the body of the given backend block
+// against the body of its type. Backend bodies are only declared
+// as dependent bodies, which the walker doesn't provide for the block body,
+// hence they would not be checked otherwise.
+func missingBackendAttributes(block *hclsyntax.Block, nodeSchema schema.Schema) hcl.Diagnostics {
+	blockSchema, ok := nodeSchema.(*schema.BlockSchema)
+	if !ok || blockSchema.Body != nil || len(block.Labels) == 0 {
+		return nil
+	}
+
+	bodySchema, ok := blockSchema.DependentBody[schema.NewSchemaKey(schema.DependencyKeys{
+		Labels: []schema.LabelDependent{
+			{Index: 0, Value: block.Labels[0]},
+		},
+	})]
+	if !ok {
+		return nil
+	}
+
+	return missingRequiredAttributes(block.Body, bodySchema)
+}
+
+func isStrictBackendValidation(ctx context.Context) bool {
+	validationOpts, err := lsctx.ValidationOptions(ctx)
+	if err != nil {
+		return false
+	}
+	return validationOpts.StrictBackendValidation
 }
 
 type unknownRequiredAttrsCtxKey struct{}
@@ -0,0 +1,154 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package validations
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/hcl-lang/decoder"
+	"github.com/hashicorp/hcl-lang/lang"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+)
+
+const (
+	MissingModuleInstanceKeySummary    = "Missing module instance key"
+	UnexpectedModuleInstanceKeySummary = "Unexpected module instance key"
+)
+
+// ModuleInstanceReferences reports references to module calls
+// which do not match the presence of count or for_each in the call,
+// i.e. outputs of a repeated module accessed without an instance key
+// (module.foo.bar) and instance keys of a single module (module.foo[0]).
+func ModuleInstanceReferences(ctx context.Context, pathCtx *decoder.PathContext) lang.DiagnosticsMap {
+	diagsMap := make(lang.DiagnosticsMap)
+
+	// repetition of each declared module call, i.e. "count",
+	// "for_each" or empty for calls with a single instance
+	calls := make(map[string]string, 0)
+	for _, file := range pathCtx.Files {
+		body, ok := file.Body.(*hclsyntax.Body)
+		if !ok {
+			continue
+		}
+		for _, block := range body.Blocks {
+			if block.Type != "module" || len(block.Labels) == 0 {
+				continue
+			}
+			calls[block.Labels[0]] = moduleCallRepetition(block.Body)
+		}
+	}
+	if len(calls) == 0 {
+		return diagsMap
+	}
+
+	for fileName, file := range pathCtx.Files {
+		body, ok := file.Body.(*hclsyntax.Body)
+		if !ok {
+			continue
+		}
+
+		for _, block := range body.Blocks {
+			switch block.Type {
+			case "moved", "removed", "import":
+				// addresses in these blocks may legitimately refer
+				// to instances of a module before a refactoring
+				continue
+			}
+
+			for _, diag := range moduleInstanceDiags(block.Body, calls) {
+				diagsMap[fileName] = diagsMap[fileName].Append(diag)
+			}
+		}
+	}
+
+	return diagsMap
+}
+
+func moduleCallRepetition(body *hclsyntax.Body) string {
+	if _, ok := body.Attributes["count"]; ok {
+		return "count"
+	}
+	if _, ok := body.Attributes["for_each"]; ok {
+		return "for_each"
+	}
+	return ""
+}
+
+func moduleInstanceDiags(body *hclsyntax.Body, calls map[string]string) hcl.Diagnostics {
+	var diags hcl.Diagnostics
+
+	// module.foo may also be indexed by an expression which is not
+	// part of the traversal itself, such as module.foo[var.key]
+	indexed := make(map[*hclsyntax.ScopeTraversalExpr]bool, 0)
+	var traversals []*hclsyntax.ScopeTraversalExpr
+
+	hclsyntax.VisitAll(body, func(node hclsyntax.Node) hcl.Diagnostics {
+		switch n := node.(type) {
+		case *hclsyntax.IndexExpr:
+			if expr, ok := n.Collection.(*hclsyntax.ScopeTraversalExpr); ok {
+				indexed[expr] = true
+			}
+		case *hclsyntax.ScopeTraversalExpr:
+			traversals = append(traversals, n)
+		}
+		return nil
+	})
+
+	for _, expr := range traversals {
+		traversal := expr.Traversal
+		if len(traversal) < 2 || traversal.RootName() != "module" {
+			continue
+		}
+		callStep, ok := traversal[1].(hcl.TraverseAttr)
+		if !ok {
+			continue
+		}
+		repetition, ok := calls[callStep.Name]
+		if !ok {
+			continue
+		}
+
+		var nextStep hcl.Traverser
+		if len(traversal) > 2 {
+			nextStep = traversal[2]
+		}
+		_, hasIndexStep := nextStep.(hcl.TraverseIndex)
+		outputStep, hasOutputStep := nextStep.(hcl.TraverseAttr)
+
+		// an index expression only represents an instance key when
+		// it applies to the module call itself, i.e. module.foo[var.key],
+		// rather than to one of its outputs, i.e. module.foo.ids[var.key]
+		indexedCall := indexed[expr] && len(traversal) == 2
+
+		if repetition == "" && (hasIndexStep || indexedCall) {
+			diags = append(diags, &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  UnexpectedModuleInstanceKeySummary,
+				Detail: fmt.Sprintf("Because module.%s does not have \"count\" or \"for_each\" set, "+
+					"references to it must not include an index key. Remove the bracketed index "+
+					"to refer to the single instance of this module.", callStep.Name),
+				Subject: expr.SrcRange.Ptr(),
+			})
+			continue
+		}
+
+		if repetition != "" && hasOutputStep {
+			example := fmt.Sprintf("module.%s[0].%s", callStep.Name, outputStep.Name)
+			if repetition == "for_each" {
+				example = fmt.Sprintf("module.%s[\"key\"].%s", callStep.Name, outputStep.Name)
+			}
+			diags = append(diags, &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  MissingModuleInstanceKeySummary,
+				Detail: fmt.Sprintf("Because module.%s has %q set, its attributes must be "+
+					"accessed on specific instances, such as %s.", callStep.Name, repetition, example),
+				Subject: expr.SrcRange.Ptr(),
+			})
+		}
+	}
+
+	return diags
+}
@@ -0,0 +1,160 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package validations
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/hcl-lang/decoder"
+	"github.com/hashicorp/hcl-lang/lang"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+)
+
+func TestModuleInstanceReferences(t *testing.T) {
+	modules := `module "single" {
+  source = "./single"
+}
+
+module "counted" {
+  source = "./counted"
+  count  = 2
+}
+
+module "each" {
+  source   = "./each"
+  for_each = toset(["a", "b"])
+}
+`
+
+	tests := []struct {
+		name        string
+		cfg         string
+		wantSummary string
+	}{
+		{
+			name: "index of non-counted module",
+			cfg: `output "test" {
+  value = module.single[0].id
+}`,
+			wantSummary: UnexpectedModuleInstanceKeySummary,
+		},
+		{
+			name: "expression index of non-counted module",
+			cfg: `output "test" {
+  value = module.single[var.key].id
+}`,
+			wantSummary: UnexpectedModuleInstanceKeySummary,
+		},
+		{
+			name: "missing index of counted module",
+			cfg: `output "test" {
+  value = module.counted.id
+}`,
+			wantSummary: MissingModuleInstanceKeySummary,
+		},
+		{
+			name: "missing key of for_each module",
+			cfg: `output "test" {
+  value = "${module.each.id}-suffix"
+}`,
+			wantSummary: MissingModuleInstanceKeySummary,
+		},
+		{
+			name: "non-counted module",
+			cfg: `output "test" {
+  value = module.single.id
+}`,
+		},
+		{
+			name: "output of non-counted module indexed by count",
+			cfg: `resource "aws_instance" "test" {
+  count = 2
+  ami   = module.single.amis[count.index]
+}`,
+		},
+		{
+			name: "output of non-counted module indexed by variable",
+			cfg: `output "test" {
+  value = module.single.tags[var.key]
+}`,
+		},
+		{
+			name: "indexed counted module",
+			cfg: `output "test" {
+  value = module.counted[0].id
+}`,
+		},
+		{
+			name: "expression key of for_each module",
+			cfg: `output "test" {
+  value = module.each[var.key].id
+}`,
+		},
+		{
+			name: "splat of counted module",
+			cfg: `output "test" {
+  value = module.counted[*].id
+}`,
+		},
+		{
+			name: "whole counted module",
+			cfg: `output "test" {
+  value = length(module.counted)
+}`,
+		},
+		{
+			name: "moved instance of non-counted module",
+			cfg: `moved {
+  from = module.single[0]
+  to   = module.single
+}`,
+		},
+		{
+			name: "undeclared module",
+			cfg: `output "test" {
+  value = module.unknown[0].id
+}`,
+		},
+	}
+
+	for i, tt := range tests {
+		t.Run(fmt.Sprintf("%d-%s", i, tt.name), func(t *testing.T) {
+			modFile, pDiags := hclsyntax.ParseConfig([]byte(modules), "main.tf", hcl.InitialPos)
+			if len(pDiags) > 0 {
+				t.Fatal(pDiags)
+			}
+			f, pDiags := hclsyntax.ParseConfig([]byte(tt.cfg), "test.tf", hcl.InitialPos)
+			if len(pDiags) > 0 {
+				t.Fatal(pDiags)
+			}
+
+			pathCtx := &decoder.PathContext{
+				Files: map[string]*hcl.File{
+					"main.tf": modFile,
+					"test.tf": f,
+				},
+			}
+
+			diagsMap := ModuleInstanceReferences(context.Background(), pathCtx)
+			if tt.wantSummary == "" {
+				if diff := cmp.Diff(lang.DiagnosticsMap{}, diagsMap); diff != "" {
+					t.Fatalf("unexpected diagnostics: %s", diff)
+				}
+				return
+			}
+
+			diags := diagsMap["test.tf"]
+			if len(diags) != 1 {
+				t.Fatalf("expected 1 diagnostic, %d given: %#v", len(diags), diags)
+			}
+			if diags[0].Summary != tt.wantSummary {
+				t.Fatalf("expected summary %q, given %q", tt.wantSummary, diags[0].Summary)
+			}
+		})
+	}
+}
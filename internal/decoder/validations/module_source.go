@@ -0,0 +1,141 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package validations
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/hashicorp/hcl-lang/schema"
+	"github.com/hashicorp/hcl-lang/schemacontext"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	tfaddr "github.com/hashicorp/terraform-registry-address"
+	"github.com/zclconf/go-cty/cty"
+)
+
+const InvalidModuleSourceSummary = "Invalid module source address"
+
+// ModuleSourceAddress reports module calls with a source address
+// which Terraform would not be able to install, such as malformed
+// registry addresses or URLs with unsupported schemes.
+type ModuleSourceAddress struct{}
+
+func (msa ModuleSourceAddress) Visit(ctx context.Context, node hclsyntax.Node, nodeSchema schema.Schema) (context.Context, hcl.Diagnostics) {
+	var diags hcl.Diagnostics
+
+	block, ok := node.(*hclsyntax.Block)
+	if !ok || block.Type != "module" {
+		return ctx, diags
+	}
+	nestingLvl, nestingOk := schemacontext.BlockNestingLevel(ctx)
+	if !nestingOk || nestingLvl != 0 {
+		return ctx, diags
+	}
+
+	attr, ok := block.Body.Attributes["source"]
+	if !ok {
+		return ctx, diags
+	}
+
+	// Non-static or non-string values are reported elsewhere
+	val, vDiags := attr.Expr.Value(nil)
+	if vDiags.HasErrors() || !val.IsWhollyKnown() || val.IsNull() || !val.Type().Equals(cty.String) {
+		return ctx, diags
+	}
+
+	detail, ok := checkModuleSource(val.AsString())
+	if !ok {
+		diags = append(diags, &hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  InvalidModuleSourceSummary,
+			Detail:   detail,
+			Subject:  attr.SrcRange.Ptr(),
+		})
+	}
+
+	return ctx, diags
+}
+
+var (
+	moduleSourceLocalPrefixes = []string{
+		"./",
+		"../",
+		".\\",
+		"..\\",
+	}
+
+	// moduleSourceGetters represents schemes supported by Terraform,
+	// either as a URL scheme or as a forced getter (e.g. git::)
+	moduleSourceGetters = map[string]bool{
+		"gcs":   true,
+		"git":   true,
+		"hg":    true,
+		"http":  true,
+		"https": true,
+		"s3":    true,
+	}
+
+	// moduleSourceShorthands represents prefixes of addresses
+	// which are recognized by Terraform without explicit scheme
+	moduleSourceShorthands = []string{
+		"github.com/",
+		"bitbucket.org/",
+		"git@",
+		"www.googleapis.com/storage/",
+	}
+)
+
+// checkModuleSource reports whether the given module source address
+// can be installed by Terraform, along with the reason if it cannot.
+func checkModuleSource(source string) (string, bool) {
+	if source == "" {
+		return "Module source address must not be empty", false
+	}
+
+	if isLocalModuleSource(source) {
+		return "", true
+	}
+
+	// Absolute paths are installed by copying the directory
+	if path.IsAbs(source) || filepath.IsAbs(source) {
+		return "", true
+	}
+
+	if getter, _, ok := strings.Cut(source, "::"); ok {
+		if !moduleSourceGetters[getter] {
+			return fmt.Sprintf("%q is not a supported installation method, "+
+				"supported ones are: gcs, git, hg, http, https and s3", getter), false
+		}
+		return "", true
+	}
+
+	if scheme, _, ok := strings.Cut(source, "://"); ok {
+		if !moduleSourceGetters[scheme] {
+			return fmt.Sprintf("%q is not a supported URL scheme for module sources, "+
+				"an installation method can be forced with a prefix, such as git::%s", scheme, source), false
+		}
+		return "", true
+	}
+
+	for _, prefix := range moduleSourceShorthands {
+		if strings.HasPrefix(source, prefix) {
+			return "", true
+		}
+	}
+	if strings.Contains(source, ".amazonaws.com/") {
+		return "", true
+	}
+
+	_, err := tfaddr.ParseModuleSource(source)
+	if err != nil {
+		return fmt.Sprintf("Local paths must begin with ./ or ../ and registry addresses "+
+			"must be in the form [<HOSTNAME>/]<NAMESPACE>/<NAME>/<PROVIDER>: %s", err), false
+	}
+
+	return "", true
+}
@@ -0,0 +1,71 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package validations
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/hcl-lang/schemacontext"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+)
+
+func TestModuleSourceAddress(t *testing.T) {
+	tests := []struct {
+		name          string
+		source        string
+		expectedValid bool
+	}{
+		{"local path", "./modules/vpc", true},
+		{"parent local path", "../vpc", true},
+		{"absolute path", "/opt/terraform/modules/vpc", true},
+		{"registry address", "hashicorp/consul/aws", true},
+		{"registry address with hostname", "app.terraform.io/example-corp/k8s-cluster/azurerm", true},
+		{"registry address with subdir", "hashicorp/consul/aws//modules/consul-cluster", true},
+		{"github shorthand", "github.com/hashicorp/example", true},
+		{"git over ssh shorthand", "git@github.com:hashicorp/example.git", true},
+		{"forced git getter", "git::ssh://username@example.com/storage.git", true},
+		{"https URL", "https://example.com/vpc-module.zip", true},
+		{"s3 bucket", "s3::https://s3-eu-west-1.amazonaws.com/examplecorp-terraform-modules/vpc.zip", true},
+		{"empty", "", false},
+		{"malformed registry address", "hashicorp/consul", false},
+		{"relative path without prefix", "modules/vpc", false},
+		{"unsupported git URL", "ssh://git@github.com/hashicorp/example.git", false},
+		{"unsupported forced getter", "svn::https://example.com/repo", false},
+	}
+
+	for i, tt := range tests {
+		t.Run(fmt.Sprintf("%d-%s", i, tt.name), func(t *testing.T) {
+			cfg := fmt.Sprintf("module \"test\" {\n  source = %q\n}\n", tt.source)
+			f, pDiags := hclsyntax.ParseConfig([]byte(cfg), "test.tf", hcl.InitialPos)
+			if len(pDiags) > 0 {
+				t.Fatal(pDiags)
+			}
+			block := f.Body.(*hclsyntax.Body).Blocks[0]
+
+			ctx := schemacontext.WithBlockNestingLevel(context.Background(), 0)
+			_, diags := ModuleSourceAddress{}.Visit(ctx, block, nil)
+
+			if tt.expectedValid {
+				if len(diags) > 0 {
+					t.Fatalf("expected no diagnostics, given: %s", diags)
+				}
+				return
+			}
+
+			if len(diags) != 1 {
+				t.Fatalf("expected 1 diagnostic, %d given: %s", len(diags), diags)
+			}
+			if diags[0].Summary != InvalidModuleSourceSummary {
+				t.Fatalf("unexpected summary: %q", diags[0].Summary)
+			}
+			expectedRange := block.Body.Attributes["source"].SrcRange
+			if *diags[0].Subject != expectedRange {
+				t.Fatalf("unexpected range: %#v, expected: %#v", *diags[0].Subject, expectedRange)
+			}
+		})
+	}
+}
@@ -0,0 +1,112 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package validations
+
+import (
+	"context"
+	"strings"
+
+	"github.com/hashicorp/hcl-lang/schema"
+	"github.com/hashicorp/hcl-lang/schemacontext"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	tfaddr "github.com/hashicorp/terraform-registry-address"
+	"github.com/zclconf/go-cty/cty"
+)
+
+const (
+	UnexpectedModuleVersionSummary = "Unexpected module version constraint"
+	MissingModuleVersionSummary    = "Missing module version constraint"
+)
+
+// ModuleVersion reports module calls with a version constraint
+// for a local source, which Terraform rejects, and warns about
+// registry sources without any version constraint.
+type ModuleVersion struct{}
+
+func (mv ModuleVersion) Visit(ctx context.Context, node hclsyntax.Node, nodeSchema schema.Schema) (context.Context, hcl.Diagnostics) {
+	var diags hcl.Diagnostics
+
+	block, ok := node.(*hclsyntax.Block)
+	if !ok || block.Type != "module" {
+		return ctx, diags
+	}
+	nestingLvl, nestingOk := schemacontext.BlockNestingLevel(ctx)
+	if !nestingOk || nestingLvl != 0 {
+		return ctx, diags
+	}
+
+	srcAttr, ok := block.Body.Attributes["source"]
+	if !ok {
+		return ctx, diags
+	}
+
+	// Non-static or non-string values are reported elsewhere
+	val, vDiags := srcAttr.Expr.Value(nil)
+	if vDiags.HasErrors() || !val.IsWhollyKnown() || val.IsNull() || !val.Type().Equals(cty.String) {
+		return ctx, diags
+	}
+	source := val.AsString()
+
+	versionAttr, hasVersion := block.Body.Attributes["version"]
+
+	if isLocalModuleSource(source) {
+		if hasVersion {
+			diags = append(diags, &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  UnexpectedModuleVersionSummary,
+				Detail: "Version constraints are only supported for modules installed from a registry, " +
+					"local modules always use the code in the given directory.",
+				Subject: versionAttr.SrcRange.Ptr(),
+				Context: block.Range().Ptr(),
+			})
+		}
+		return ctx, diags
+	}
+
+	if !hasVersion && isRegistryModuleSource(source) {
+		diags = append(diags, &hcl.Diagnostic{
+			Severity: hcl.DiagWarning,
+			Summary:  MissingModuleVersionSummary,
+			Detail: "Without a version constraint, the latest version of the module is installed, " +
+				"which may introduce breaking changes. Consider adding a version argument.",
+			Subject: srcAttr.SrcRange.Ptr(),
+			Context: block.Range().Ptr(),
+		})
+	}
+
+	return ctx, diags
+}
+
+func isLocalModuleSource(source string) bool {
+	for _, prefix := range moduleSourceLocalPrefixes {
+		if strings.HasPrefix(source, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// isRegistryModuleSource reports whether the given source address
+// refers to a module registry, as opposed to local paths and
+// addresses installed via one of the other supported methods.
+func isRegistryModuleSource(source string) bool {
+	if isLocalModuleSource(source) {
+		return false
+	}
+	if strings.Contains(source, "::") || strings.Contains(source, "://") {
+		return false
+	}
+	for _, prefix := range moduleSourceShorthands {
+		if strings.HasPrefix(source, prefix) {
+			return false
+		}
+	}
+	if strings.Contains(source, ".amazonaws.com/") {
+		return false
+	}
+
+	_, err := tfaddr.ParseModuleSource(source)
+	return err == nil
+}
@@ -0,0 +1,109 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package validations
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/hcl-lang/schemacontext"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+)
+
+func TestModuleVersion(t *testing.T) {
+	tests := []struct {
+		name             string
+		cfg              string
+		expectedSummary  string
+		expectedSeverity hcl.DiagnosticSeverity
+		expectedAttr     string
+	}{
+		{
+			"local source without version",
+			`module "vpc" {
+  source = "./modules/vpc"
+}
+`,
+			"", 0, "",
+		},
+		{
+			"local source with version",
+			`module "vpc" {
+  source  = "../vpc"
+  version = "1.0.0"
+}
+`,
+			UnexpectedModuleVersionSummary, hcl.DiagError, "version",
+		},
+		{
+			"registry source with version",
+			`module "consul" {
+  source  = "hashicorp/consul/aws"
+  version = "~> 0.11"
+}
+`,
+			"", 0, "",
+		},
+		{
+			"registry source without version",
+			`module "consul" {
+  source = "hashicorp/consul/aws"
+}
+`,
+			MissingModuleVersionSummary, hcl.DiagWarning, "source",
+		},
+		{
+			"git source without version",
+			`module "example" {
+  source = "git::https://example.com/vpc.git"
+}
+`,
+			"", 0, "",
+		},
+		{
+			"github source without version",
+			`module "example" {
+  source = "github.com/hashicorp/example"
+}
+`,
+			"", 0, "",
+		},
+	}
+
+	for i, tt := range tests {
+		t.Run(fmt.Sprintf("%d-%s", i, tt.name), func(t *testing.T) {
+			f, pDiags := hclsyntax.ParseConfig([]byte(tt.cfg), "test.tf", hcl.InitialPos)
+			if len(pDiags) > 0 {
+				t.Fatal(pDiags)
+			}
+			block := f.Body.(*hclsyntax.Body).Blocks[0]
+
+			ctx := schemacontext.WithBlockNestingLevel(context.Background(), 0)
+			_, diags := ModuleVersion{}.Visit(ctx, block, nil)
+
+			if tt.expectedSummary == "" {
+				if len(diags) > 0 {
+					t.Fatalf("expected no diagnostics, given: %s", diags)
+				}
+				return
+			}
+
+			if len(diags) != 1 {
+				t.Fatalf("expected 1 diagnostic, %d given: %s", len(diags), diags)
+			}
+			if diags[0].Summary != tt.expectedSummary {
+				t.Fatalf("unexpected summary: %q", diags[0].Summary)
+			}
+			if diags[0].Severity != tt.expectedSeverity {
+				t.Fatalf("unexpected severity: %v", diags[0].Severity)
+			}
+			expectedRange := block.Body.Attributes[tt.expectedAttr].SrcRange
+			if *diags[0].Subject != expectedRange {
+				t.Fatalf("unexpected range: %#v, expected: %#v", *diags[0].Subject, expectedRange)
+			}
+		})
+	}
+}
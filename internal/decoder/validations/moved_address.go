@@ -0,0 +1,153 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package validations
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/hcl-lang/decoder"
+	"github.com/hashicorp/hcl-lang/lang"
+	"github.com/hashicorp/hcl-lang/reference"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+)
+
+// ModuleTargetsFunc returns reference targets of the module called
+// via the given chain of module calls, relative to the validated module,
+// and whether such module is known, i.e. local and already decoded.
+type ModuleTargetsFunc func(callNames []string) (reference.Targets, bool)
+
+// MovedBlockAddresses reports moved blocks moving objects into
+// local module calls (e.g. module.foo.aws_instance.bar), where
+// the destination is not declared within the called module.
+//
+// Only the destination (to) is validated, as the source (from)
+// is expected to be no longer declared in the configuration.
+func MovedBlockAddresses(ctx context.Context, pathCtx *decoder.PathContext, moduleTargets ModuleTargetsFunc) lang.DiagnosticsMap {
+	diagsMap := make(lang.DiagnosticsMap)
+
+	for fileName, file := range pathCtx.Files {
+		body, ok := file.Body.(*hclsyntax.Body)
+		if !ok {
+			continue
+		}
+
+		for _, block := range body.Blocks {
+			if block.Type != "moved" {
+				continue
+			}
+			attr, ok := block.Body.Attributes["to"]
+			if !ok {
+				continue
+			}
+			traversal, tDiags := hcl.AbsTraversalForExpr(attr.Expr)
+			if tDiags.HasErrors() {
+				continue
+			}
+
+			callNames, objectAddr, ok := splitModuleAddress(traversal)
+			if !ok || len(callNames) == 0 {
+				// addresses within the same module are out of scope here
+				continue
+			}
+
+			if len(objectAddr) == 0 {
+				// the destination is the module call itself,
+				// which is declared in the parent module
+				lastIdx := len(callNames) - 1
+				objectAddr = lang.Address{
+					lang.RootStep{Name: "module"},
+					lang.AttrStep{Name: callNames[lastIdx]},
+				}
+				callNames = callNames[:lastIdx]
+			}
+
+			targets, ok := moduleTargets(callNames)
+			if !ok {
+				continue
+			}
+			if targetsContainAddress(targets, objectAddr) {
+				continue
+			}
+
+			address := traversalAddress(traversal)
+			diagsMap[fileName] = diagsMap[fileName].Append(&hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  fmt.Sprintf("No declaration found for %q", address),
+				Detail: fmt.Sprintf("The destination of the moved block must be declared "+
+					"within the called module, but %q was not found there.", objectAddr),
+				Subject: attr.Expr.Range().Ptr(),
+			})
+		}
+	}
+
+	return diagsMap
+}
+
+// splitModuleAddress splits the given absolute traversal into names
+// of module calls and the address of an object within the called module,
+// ignoring any instance keys, e.g. module.foo["a"].aws_instance.bar[0]
+// is split into [foo] and aws_instance.bar.
+func splitModuleAddress(traversal hcl.Traversal) ([]string, lang.Address, bool) {
+	callNames := make([]string, 0)
+	names := make([]string, 0)
+
+	for i := 0; i < len(traversal); i++ {
+		var name string
+		switch step := traversal[i].(type) {
+		case hcl.TraverseRoot:
+			name = step.Name
+		case hcl.TraverseAttr:
+			name = step.Name
+		case hcl.TraverseIndex:
+			continue
+		default:
+			return nil, nil, false
+		}
+
+		if len(names) == 0 && name == "module" {
+			if i+1 >= len(traversal) {
+				return nil, nil, false
+			}
+			callStep, ok := traversal[i+1].(hcl.TraverseAttr)
+			if !ok {
+				return nil, nil, false
+			}
+			callNames = append(callNames, callStep.Name)
+			i++
+			continue
+		}
+
+		names = append(names, name)
+	}
+
+	addr := make(lang.Address, 0, len(names))
+	for i, name := range names {
+		if i == 0 {
+			addr = append(addr, lang.RootStep{Name: name})
+			continue
+		}
+		addr = append(addr, lang.AttrStep{Name: name})
+	}
+
+	return callNames, addr, true
+}
+
+func targetsContainAddress(targets reference.Targets, addr lang.Address) bool {
+	for _, target := range targets {
+		if target.Addr.Equals(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+func traversalAddress(traversal hcl.Traversal) string {
+	addr, err := lang.TraversalToAddress(traversal)
+	if err != nil {
+		return ""
+	}
+	return addr.String()
+}
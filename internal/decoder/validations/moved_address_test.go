@@ -0,0 +1,64 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package validations
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/hcl-lang/lang"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+)
+
+func TestSplitModuleAddress(t *testing.T) {
+	tests := []struct {
+		address           string
+		expectedCallNames []string
+		expectedAddr      lang.Address
+	}{
+		{
+			"aws_instance.web",
+			[]string{},
+			lang.Address{lang.RootStep{Name: "aws_instance"}, lang.AttrStep{Name: "web"}},
+		},
+		{
+			"module.compute.aws_instance.web",
+			[]string{"compute"},
+			lang.Address{lang.RootStep{Name: "aws_instance"}, lang.AttrStep{Name: "web"}},
+		},
+		{
+			`module.compute["a"].module.network.aws_vpc.main[0]`,
+			[]string{"compute", "network"},
+			lang.Address{lang.RootStep{Name: "aws_vpc"}, lang.AttrStep{Name: "main"}},
+		},
+		{
+			"module.compute",
+			[]string{"compute"},
+			lang.Address{},
+		},
+	}
+
+	for i, tt := range tests {
+		t.Run(fmt.Sprintf("%d-%s", i, tt.address), func(t *testing.T) {
+			traversal, diags := hclsyntax.ParseTraversalAbs([]byte(tt.address), "test.tf", hcl.InitialPos)
+			if len(diags) > 0 {
+				t.Fatal(diags)
+			}
+
+			callNames, addr, ok := splitModuleAddress(traversal)
+			if !ok {
+				t.Fatalf("expected %q to be split", tt.address)
+			}
+			if diff := cmp.Diff(tt.expectedCallNames, callNames); diff != "" {
+				t.Fatalf("unexpected call names: %s", diff)
+			}
+			// empty addresses are never equal, hence compared by length first
+			if len(tt.expectedAddr) != len(addr) || (len(addr) > 0 && !tt.expectedAddr.Equals(addr)) {
+				t.Fatalf("unexpected address: %q, expected: %q", addr, tt.expectedAddr)
+			}
+		})
+	}
+}
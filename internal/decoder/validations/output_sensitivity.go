@@ -0,0 +1,122 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package validations
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/hcl-lang/decoder"
+	"github.com/hashicorp/hcl-lang/lang"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	tfmod "github.com/hashicorp/terraform-schema/module"
+	"github.com/zclconf/go-cty/cty"
+)
+
+const SensitiveModuleOutputSummary = "Output refers to sensitive values"
+
+// ModuleOutputsFunc returns outputs declared in the module called
+// via the given module call, and whether such module is known,
+// i.e. local and already parsed.
+type ModuleOutputsFunc func(callName string) (map[string]tfmod.Output, bool)
+
+// SensitiveModuleOutputs reports outputs which are not marked
+// as sensitive, but refer to sensitive outputs of called modules
+// (e.g. module.foo.password), which Terraform rejects.
+func SensitiveModuleOutputs(ctx context.Context, pathCtx *decoder.PathContext, moduleOutputs ModuleOutputsFunc) lang.DiagnosticsMap {
+	diagsMap := make(lang.DiagnosticsMap)
+
+	for fileName, file := range pathCtx.Files {
+		body, ok := file.Body.(*hclsyntax.Body)
+		if !ok {
+			continue
+		}
+
+		for _, block := range body.Blocks {
+			if block.Type != "output" || len(block.Labels) == 0 {
+				continue
+			}
+			valueAttr, ok := block.Body.Attributes["value"]
+			if !ok {
+				continue
+			}
+			if sensitiveAttr, ok := block.Body.Attributes["sensitive"]; ok {
+				val, vDiags := sensitiveAttr.Expr.Value(nil)
+				if vDiags.HasErrors() || !val.IsWhollyKnown() || val.IsNull() ||
+					!val.Type().Equals(cty.Bool) || val.True() {
+					// explicitly sensitive or not statically known
+					continue
+				}
+			}
+
+			for _, traversal := range valueAttr.Expr.Variables() {
+				callName, outputName, ok := moduleOutputReference(traversal)
+				if !ok {
+					continue
+				}
+				outputs, ok := moduleOutputs(callName)
+				if !ok {
+					continue
+				}
+				if !refersToSensitiveOutput(outputs, outputName) {
+					continue
+				}
+
+				diagsMap[fileName] = diagsMap[fileName].Append(&hcl.Diagnostic{
+					Severity: hcl.DiagError,
+					Summary:  SensitiveModuleOutputSummary,
+					Detail: fmt.Sprintf("Output %q refers to %q, which is marked as sensitive "+
+						"in the called module. Add sensitive = true to the output to confirm "+
+						"the intent of exporting sensitive data.", block.Labels[0], traversalAddress(traversal)),
+					Subject: traversal.SourceRange().Ptr(),
+					Context: block.Range().Ptr(),
+				})
+				break
+			}
+		}
+	}
+
+	return diagsMap
+}
+
+// moduleOutputReference returns the name of the module call
+// and of the output the given traversal refers to, e.g. foo and bar
+// for module.foo["a"].bar. The output name is empty for references
+// to the whole module call, such as module.foo.
+func moduleOutputReference(traversal hcl.Traversal) (string, string, bool) {
+	if len(traversal) < 2 || traversal.RootName() != "module" {
+		return "", "", false
+	}
+	callStep, ok := traversal[1].(hcl.TraverseAttr)
+	if !ok {
+		return "", "", false
+	}
+
+	for _, step := range traversal[2:] {
+		switch s := step.(type) {
+		case hcl.TraverseIndex, hcl.TraverseSplat:
+			continue
+		case hcl.TraverseAttr:
+			return callStep.Name, s.Name, true
+		default:
+			return "", "", false
+		}
+	}
+
+	return callStep.Name, "", true
+}
+
+func refersToSensitiveOutput(outputs map[string]tfmod.Output, outputName string) bool {
+	if outputName != "" {
+		output, ok := outputs[outputName]
+		return ok && output.IsSensitive
+	}
+	for _, output := range outputs {
+		if output.IsSensitive {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,85 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package validations
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/hcl-lang/decoder"
+	"github.com/hashicorp/hcl-lang/lang"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	tfaddr "github.com/hashicorp/terraform-registry-address"
+	tfmod "github.com/hashicorp/terraform-schema/module"
+)
+
+const ProviderLocalNameCollisionSummary = "Provider local name collision"
+
+// ProviderTypeFunc reports whether the given provider declares
+// a resource or data source (depending on blockType) of the given type.
+// It returns false as the second value if the schema of the provider
+// is not known.
+type ProviderTypeFunc func(pAddr tfaddr.Provider, blockType, typeName string) (declared bool, ok bool)
+
+// ProviderLocalNameCollisions flags resources and data sources which rely
+// on the provider local name implied by their type prefix (i.e. have no
+// provider argument), where that local name is declared in required_providers
+// with a source of a differently named provider which does not declare
+// the resource type.
+//
+// Local names mapped to providers of a different name which do declare
+// the type (e.g. google = { source = "hashicorp/google-beta" })
+// are valid, as are providers whose schema is not known.
+func ProviderLocalNameCollisions(ctx context.Context, pathCtx *decoder.PathContext, providerRefs map[tfmod.ProviderRef]tfaddr.Provider, providerTypes ProviderTypeFunc) lang.DiagnosticsMap {
+	diagsMap := make(lang.DiagnosticsMap)
+
+	for fileName, file := range pathCtx.Files {
+		body, ok := file.Body.(*hclsyntax.Body)
+		if !ok {
+			continue
+		}
+
+		for _, block := range body.Blocks {
+			if block.Type != "resource" && block.Type != "data" {
+				continue
+			}
+			if len(block.Labels) == 0 || len(block.LabelRanges) == 0 {
+				continue
+			}
+			if _, ok := block.Body.Attributes["provider"]; ok {
+				continue
+			}
+
+			typeName := block.Labels[0]
+			localName, _, found := strings.Cut(typeName, "_")
+			if !found || localName == "" {
+				continue
+			}
+
+			pAddr, ok := providerRefs[tfmod.ProviderRef{LocalName: localName}]
+			if !ok || pAddr.Type == localName {
+				continue
+			}
+
+			declared, ok := providerTypes(pAddr, block.Type, typeName)
+			if !ok || declared {
+				continue
+			}
+
+			diagsMap[fileName] = diagsMap[fileName].Append(&hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  ProviderLocalNameCollisionSummary,
+				Detail: fmt.Sprintf("%q implies provider local name %q, which is declared "+
+					"in required_providers with source %q. The provider does not support %q. "+
+					"Set the provider argument explicitly or declare the provider under a different local name.",
+					typeName, localName, pAddr.ForDisplay(), typeName),
+				Subject: block.LabelRanges[0].Ptr(),
+			})
+		}
+	}
+
+	return diagsMap
+}
@@ -0,0 +1,106 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package validations
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/hcl-lang/decoder"
+	"github.com/hashicorp/hcl-lang/lang"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	tfaddr "github.com/hashicorp/terraform-registry-address"
+	tfmod "github.com/hashicorp/terraform-schema/module"
+)
+
+func TestProviderLocalNameCollisions(t *testing.T) {
+	// required_providers {
+	//   aws    = { source = "acme/cloud" }
+	//   google = { source = "hashicorp/google-beta" }
+	//   azure  = { source = "acme/unknown" }
+	// }
+	providerRefs := map[tfmod.ProviderRef]tfaddr.Provider{
+		{LocalName: "aws"}:    tfaddr.MustParseProviderSource("acme/cloud"),
+		{LocalName: "google"}: tfaddr.MustParseProviderSource("hashicorp/google-beta"),
+		{LocalName: "azure"}:  tfaddr.MustParseProviderSource("acme/unknown"),
+	}
+	providerTypes := func(pAddr tfaddr.Provider, blockType, typeName string) (bool, bool) {
+		switch pAddr.Type {
+		case "cloud":
+			return typeName == "cloud_server", true
+		case "google-beta":
+			return typeName == "google_compute_instance", true
+		}
+		return false, false
+	}
+
+	tests := []struct {
+		name          string
+		cfg           string
+		wantDiagnosed bool
+	}{
+		{
+			name:          "implied local name remapped",
+			cfg:           `resource "aws_instance" "test" {}`,
+			wantDiagnosed: true,
+		},
+		{
+			name:          "implied local name remapped in data source",
+			cfg:           `data "aws_ami" "test" {}`,
+			wantDiagnosed: true,
+		},
+		{
+			name: "explicit provider",
+			cfg: `resource "aws_instance" "test" {
+  provider = awsofficial
+}`,
+		},
+		{
+			name: "implied local name remapped to provider declaring the type",
+			cfg:  `resource "google_compute_instance" "test" {}`,
+		},
+		{
+			name: "implied local name remapped to provider with unknown schema",
+			cfg:  `resource "azure_resource_group" "test" {}`,
+		},
+		{
+			name: "undeclared local name",
+			cfg:  `resource "azurerm_resource_group" "test" {}`,
+		},
+	}
+
+	for i, tt := range tests {
+		t.Run(fmt.Sprintf("%d-%s", i, tt.name), func(t *testing.T) {
+			f, pDiags := hclsyntax.ParseConfig([]byte(tt.cfg), "test.tf", hcl.InitialPos)
+			if len(pDiags) > 0 {
+				t.Fatal(pDiags)
+			}
+
+			pathCtx := &decoder.PathContext{
+				Files: map[string]*hcl.File{
+					"test.tf": f,
+				},
+			}
+
+			diagsMap := ProviderLocalNameCollisions(context.Background(), pathCtx, providerRefs, providerTypes)
+			if !tt.wantDiagnosed {
+				if diff := cmp.Diff(lang.DiagnosticsMap{}, diagsMap); diff != "" {
+					t.Fatalf("unexpected diagnostics: %s", diff)
+				}
+				return
+			}
+
+			diags := diagsMap["test.tf"]
+			if len(diags) != 1 {
+				t.Fatalf("expected 1 diagnostic, %d given: %#v", len(diags), diags)
+			}
+			if diags[0].Summary != ProviderLocalNameCollisionSummary {
+				t.Fatalf("expected summary %q, given %q", ProviderLocalNameCollisionSummary, diags[0].Summary)
+			}
+		})
+	}
+}
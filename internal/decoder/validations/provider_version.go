@@ -0,0 +1,89 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package validations
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/hcl-lang/schema"
+	"github.com/hashicorp/hcl-lang/schemacontext"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty/cty"
+)
+
+const InvalidProviderVersionSummary = "Invalid provider version constraint"
+
+// ProviderVersionConstraint reports version constraints of providers
+// declared in required_providers, which are not valid constraint strings
+// (e.g. ">>1.0") and would be rejected by Terraform during init.
+type ProviderVersionConstraint struct{}
+
+func (pv ProviderVersionConstraint) Visit(ctx context.Context, node hclsyntax.Node, nodeSchema schema.Schema) (context.Context, hcl.Diagnostics) {
+	var diags hcl.Diagnostics
+
+	block, ok := node.(*hclsyntax.Block)
+	if !ok || block.Type != "terraform" {
+		return ctx, diags
+	}
+	nestingLvl, nestingOk := schemacontext.BlockNestingLevel(ctx)
+	if !nestingOk || nestingLvl != 0 {
+		return ctx, diags
+	}
+
+	for _, rpBlock := range block.Body.Blocks {
+		if rpBlock.Type != "required_providers" {
+			continue
+		}
+		for _, attr := range rpBlock.Body.Attributes {
+			versionExpr, ok := providerVersionExpr(attr.Expr)
+			if !ok {
+				continue
+			}
+
+			// Non-static or non-string values are reported elsewhere
+			val, vDiags := versionExpr.Value(nil)
+			if vDiags.HasErrors() || !val.IsWhollyKnown() || val.IsNull() || !val.Type().Equals(cty.String) {
+				continue
+			}
+
+			_, err := version.NewConstraint(val.AsString())
+			if err != nil {
+				diags = append(diags, &hcl.Diagnostic{
+					Severity: hcl.DiagError,
+					Summary:  InvalidProviderVersionSummary,
+					Detail: fmt.Sprintf("Version constraint of provider %q is invalid: %s. "+
+						"Expected a constraint such as \"~> 1.0\" or \">= 1.2.0, < 2.0.0\".",
+						attr.Name, err),
+					Subject: versionExpr.Range().Ptr(),
+					Context: attr.SrcRange.Ptr(),
+				})
+			}
+		}
+	}
+
+	return ctx, diags
+}
+
+// providerVersionExpr returns the expression of the version constraint
+// of a required_providers entry, i.e. either the version attribute
+// of an object, or the legacy string shorthand (aws = "~> 1.0")
+func providerVersionExpr(expr hcl.Expression) (hcl.Expression, bool) {
+	if _, ok := expr.(*hclsyntax.TemplateExpr); ok {
+		return expr, true
+	}
+
+	pairs, diags := hcl.ExprMap(expr)
+	if diags.HasErrors() {
+		return nil, false
+	}
+	for _, pair := range pairs {
+		if hcl.ExprAsKeyword(pair.Key) == "version" {
+			return pair.Value, true
+		}
+	}
+	return nil, false
+}
@@ -0,0 +1,109 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package validations
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/hcl-lang/schemacontext"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+)
+
+func TestProviderVersionConstraint(t *testing.T) {
+	tests := []struct {
+		name          string
+		cfg           string
+		expectedRange *hcl.Range
+	}{
+		{
+			"valid constraint",
+			`terraform {
+  required_providers {
+    aws = {
+      source  = "hashicorp/aws"
+      version = "~> 5.0, != 5.1.0"
+    }
+  }
+}
+`,
+			nil,
+		},
+		{
+			"without constraint",
+			`terraform {
+  required_providers {
+    aws = {
+      source = "hashicorp/aws"
+    }
+  }
+}
+`,
+			nil,
+		},
+		{
+			"malformed constraint",
+			`terraform {
+  required_providers {
+    aws = {
+      source  = "hashicorp/aws"
+      version = ">>1.0"
+    }
+  }
+}
+`,
+			&hcl.Range{
+				Filename: "test.tf",
+				Start:    hcl.Pos{Line: 5, Column: 17, Byte: 95},
+				End:      hcl.Pos{Line: 5, Column: 24, Byte: 102},
+			},
+		},
+		{
+			"malformed legacy constraint",
+			`terraform {
+  required_providers {
+    aws = "1.0 or newer"
+  }
+}
+`,
+			&hcl.Range{
+				Filename: "test.tf",
+				Start:    hcl.Pos{Line: 3, Column: 11, Byte: 45},
+				End:      hcl.Pos{Line: 3, Column: 25, Byte: 59},
+			},
+		},
+	}
+
+	for i, tt := range tests {
+		t.Run(fmt.Sprintf("%d-%s", i, tt.name), func(t *testing.T) {
+			f, pDiags := hclsyntax.ParseConfig([]byte(tt.cfg), "test.tf", hcl.InitialPos)
+			if len(pDiags) > 0 {
+				t.Fatal(pDiags)
+			}
+			block := f.Body.(*hclsyntax.Body).Blocks[0]
+
+			ctx := schemacontext.WithBlockNestingLevel(context.Background(), 0)
+			_, diags := ProviderVersionConstraint{}.Visit(ctx, block, nil)
+
+			if tt.expectedRange == nil {
+				if len(diags) > 0 {
+					t.Fatalf("expected no diagnostics, given: %s", diags)
+				}
+				return
+			}
+
+			if len(diags) != 1 {
+				t.Fatalf("expected 1 diagnostic, %d given: %s", len(diags), diags)
+			}
+			if diags[0].Summary != InvalidProviderVersionSummary {
+				t.Fatalf("unexpected summary: %q", diags[0].Summary)
+			}
+			if *diags[0].Subject != *tt.expectedRange {
+				t.Fatalf("unexpected range: %#v, expected: %#v", *diags[0].Subject, *tt.expectedRange)
+			}
+		})
+	}
+}
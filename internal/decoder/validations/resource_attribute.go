@@ -0,0 +1,141 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package validations
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/hcl-lang/decoder"
+	"github.com/hashicorp/hcl-lang/lang"
+	"github.com/hashicorp/hcl-lang/reference"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/zclconf/go-cty/cty"
+)
+
+const UnsupportedResourceAttributeSummary = "Unsupported attribute"
+
+// ResourceAttributeReferences reports references to attributes
+// of resources and data sources (e.g. aws_instance.example.nonexistent)
+// which are neither attributes nor nested blocks in their schema.
+//
+// References to resources and data sources whose schema
+// is not known are not validated. Since the known schema may be
+// a bundled one which differs from the version of the provider
+// actually in use, these are reported as warnings.
+func ResourceAttributeReferences(ctx context.Context, pathCtx *decoder.PathContext) lang.DiagnosticsMap {
+	diagsMap := make(lang.DiagnosticsMap)
+
+	for _, origin := range pathCtx.ReferenceOrigins {
+		localOrigin, ok := origin.(reference.LocalOrigin)
+		if !ok {
+			continue
+		}
+
+		address := localOrigin.Address()
+		objAddrLen, ok := resourceAddressLength(address)
+		if !ok || len(address) <= objAddrLen {
+			continue
+		}
+		objAddr := address[:objAddrLen]
+
+		typ, ok := resourceDataType(pathCtx.ReferenceTargets, objAddr)
+		if !ok {
+			continue
+		}
+
+		attrAddr, ok := unsupportedAttributeAddress(typ, address, objAddrLen)
+		if !ok {
+			continue
+		}
+
+		lastStep := attrAddr[len(attrAddr)-1].(lang.AttrStep)
+		fileName := origin.OriginRange().Filename
+		diagsMap[fileName] = diagsMap[fileName].Append(&hcl.Diagnostic{
+			Severity: hcl.DiagWarning,
+			Summary:  UnsupportedResourceAttributeSummary,
+			Detail: fmt.Sprintf("%s has no attribute or nested block named %q.",
+				attrAddr[:len(attrAddr)-1], lastStep.Name),
+			Subject: origin.OriginRange().Ptr(),
+		})
+	}
+
+	return diagsMap
+}
+
+// resourceAddressLength returns the number of steps addressing
+// a resource (aws_instance.example) or a data source
+// (data.aws_ami.example) in the given address.
+func resourceAddressLength(address lang.Address) (int, bool) {
+	if len(address) == 0 {
+		return 0, false
+	}
+
+	objAddrLen := 2
+	switch address[0].String() {
+	case "data":
+		objAddrLen = 3
+	case "var", "local", "module", "path", "terraform", "self", "count", "each":
+		return 0, false
+	}
+
+	if len(address) < objAddrLen {
+		return 0, false
+	}
+	for _, step := range address[1:objAddrLen] {
+		if _, ok := step.(lang.AttrStep); !ok {
+			return 0, false
+		}
+	}
+
+	return objAddrLen, true
+}
+
+// resourceDataType returns the type of the declared resource
+// or data source, as inferred from its schema, if known.
+func resourceDataType(targets reference.Targets, objAddr lang.Address) (cty.Type, bool) {
+	for _, target := range targets {
+		if !target.Addr.Equals(objAddr) {
+			continue
+		}
+		if target.Type != cty.NilType && target.Type.IsObjectType() {
+			return target.Type, true
+		}
+	}
+	return cty.NilType, false
+}
+
+// unsupportedAttributeAddress walks the steps of the given address
+// following the resource address through the given type and returns
+// the address up to the first attribute step not found in the type.
+//
+// Walking stops once the type is no longer known precisely
+// (e.g. dynamic types) or when the step is not supported by the type.
+func unsupportedAttributeAddress(typ cty.Type, address lang.Address, objAddrLen int) (lang.Address, bool) {
+	for i := objAddrLen; i < len(address); i++ {
+		switch step := address[i].(type) {
+		case lang.AttrStep:
+			if !typ.IsObjectType() {
+				return nil, false
+			}
+			if !typ.HasAttribute(step.Name) {
+				return address[:i+1], true
+			}
+			typ = typ.AttributeType(step.Name)
+		case lang.IndexStep:
+			if i == objAddrLen {
+				// instance key of a resource with count or for_each
+				continue
+			}
+			if !typ.IsListType() && !typ.IsMapType() && !typ.IsSetType() {
+				return nil, false
+			}
+			typ = typ.ElementType()
+		default:
+			return nil, false
+		}
+	}
+
+	return nil, false
+}
@@ -0,0 +1,216 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package validations
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/hcl-lang/decoder"
+	"github.com/hashicorp/hcl-lang/lang"
+	"github.com/hashicorp/hcl-lang/reference"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/zclconf/go-cty/cty"
+)
+
+func TestResourceAttributeReferences(t *testing.T) {
+	instanceType := cty.Object(map[string]cty.Type{
+		"id":   cty.String,
+		"ami":  cty.String,
+		"tags": cty.Map(cty.String),
+		"root_block_device": cty.List(cty.Object(map[string]cty.Type{
+			"volume_size": cty.Number,
+		})),
+		"dynamic": cty.DynamicPseudoType,
+	})
+	targets := reference.Targets{
+		{
+			Addr: lang.Address{
+				lang.RootStep{Name: "aws_instance"},
+				lang.AttrStep{Name: "web"},
+			},
+			Type: instanceType,
+		},
+		{
+			Addr: lang.Address{
+				lang.RootStep{Name: "data"},
+				lang.AttrStep{Name: "aws_ami"},
+				lang.AttrStep{Name: "ubuntu"},
+			},
+			Type: cty.Object(map[string]cty.Type{
+				"id": cty.String,
+			}),
+		},
+		{
+			// resource with unknown schema
+			Addr: lang.Address{
+				lang.RootStep{Name: "unknown_thing"},
+				lang.AttrStep{Name: "foo"},
+			},
+		},
+	}
+	rng := hcl.Range{
+		Filename: "test.tf",
+		Start:    hcl.Pos{Line: 1, Column: 1, Byte: 0},
+		End:      hcl.Pos{Line: 1, Column: 10, Byte: 9},
+	}
+
+	tests := []struct {
+		name string
+		addr lang.Address
+		want hcl.Diagnostics
+	}{
+		{
+			name: "declared attribute",
+			addr: lang.Address{
+				lang.RootStep{Name: "aws_instance"},
+				lang.AttrStep{Name: "web"},
+				lang.AttrStep{Name: "ami"},
+			},
+		},
+		{
+			name: "nonexistent attribute",
+			addr: lang.Address{
+				lang.RootStep{Name: "aws_instance"},
+				lang.AttrStep{Name: "web"},
+				lang.AttrStep{Name: "nonexistent"},
+			},
+			want: hcl.Diagnostics{
+				&hcl.Diagnostic{
+					Severity: hcl.DiagWarning,
+					Summary:  UnsupportedResourceAttributeSummary,
+					Detail:   "aws_instance.web has no attribute or nested block named \"nonexistent\".",
+					Subject:  rng.Ptr(),
+				},
+			},
+		},
+		{
+			name: "nonexistent attribute of instance",
+			addr: lang.Address{
+				lang.RootStep{Name: "aws_instance"},
+				lang.AttrStep{Name: "web"},
+				lang.IndexStep{Key: cty.NumberIntVal(0)},
+				lang.AttrStep{Name: "nonexistent"},
+			},
+			want: hcl.Diagnostics{
+				&hcl.Diagnostic{
+					Severity: hcl.DiagWarning,
+					Summary:  UnsupportedResourceAttributeSummary,
+					Detail:   "aws_instance.web[0] has no attribute or nested block named \"nonexistent\".",
+					Subject:  rng.Ptr(),
+				},
+			},
+		},
+		{
+			name: "declared attribute of nested block",
+			addr: lang.Address{
+				lang.RootStep{Name: "aws_instance"},
+				lang.AttrStep{Name: "web"},
+				lang.AttrStep{Name: "root_block_device"},
+				lang.IndexStep{Key: cty.NumberIntVal(0)},
+				lang.AttrStep{Name: "volume_size"},
+			},
+		},
+		{
+			name: "nonexistent attribute of nested block",
+			addr: lang.Address{
+				lang.RootStep{Name: "aws_instance"},
+				lang.AttrStep{Name: "web"},
+				lang.AttrStep{Name: "root_block_device"},
+				lang.IndexStep{Key: cty.NumberIntVal(0)},
+				lang.AttrStep{Name: "size"},
+			},
+			want: hcl.Diagnostics{
+				&hcl.Diagnostic{
+					Severity: hcl.DiagWarning,
+					Summary:  UnsupportedResourceAttributeSummary,
+					Detail:   "aws_instance.web.root_block_device[0] has no attribute or nested block named \"size\".",
+					Subject:  rng.Ptr(),
+				},
+			},
+		},
+		{
+			name: "map key",
+			addr: lang.Address{
+				lang.RootStep{Name: "aws_instance"},
+				lang.AttrStep{Name: "web"},
+				lang.AttrStep{Name: "tags"},
+				lang.IndexStep{Key: cty.StringVal("Name")},
+			},
+		},
+		{
+			name: "attribute of dynamic type",
+			addr: lang.Address{
+				lang.RootStep{Name: "aws_instance"},
+				lang.AttrStep{Name: "web"},
+				lang.AttrStep{Name: "dynamic"},
+				lang.AttrStep{Name: "anything"},
+			},
+		},
+		{
+			name: "nonexistent data source attribute",
+			addr: lang.Address{
+				lang.RootStep{Name: "data"},
+				lang.AttrStep{Name: "aws_ami"},
+				lang.AttrStep{Name: "ubuntu"},
+				lang.AttrStep{Name: "nonexistent"},
+			},
+			want: hcl.Diagnostics{
+				&hcl.Diagnostic{
+					Severity: hcl.DiagWarning,
+					Summary:  UnsupportedResourceAttributeSummary,
+					Detail:   "data.aws_ami.ubuntu has no attribute or nested block named \"nonexistent\".",
+					Subject:  rng.Ptr(),
+				},
+			},
+		},
+		{
+			name: "resource with unknown schema",
+			addr: lang.Address{
+				lang.RootStep{Name: "unknown_thing"},
+				lang.AttrStep{Name: "foo"},
+				lang.AttrStep{Name: "nonexistent"},
+			},
+		},
+		{
+			name: "undeclared resource",
+			addr: lang.Address{
+				lang.RootStep{Name: "aws_instance"},
+				lang.AttrStep{Name: "undeclared"},
+				lang.AttrStep{Name: "nonexistent"},
+			},
+		},
+		{
+			name: "variable",
+			addr: lang.Address{
+				lang.RootStep{Name: "var"},
+				lang.AttrStep{Name: "web"},
+				lang.AttrStep{Name: "nonexistent"},
+			},
+		},
+	}
+
+	for i, tt := range tests {
+		t.Run(fmt.Sprintf("%2d-%s", i, tt.name), func(t *testing.T) {
+			ctx := context.Background()
+
+			pathCtx := &decoder.PathContext{
+				ReferenceTargets: targets,
+				ReferenceOrigins: reference.Origins{
+					reference.LocalOrigin{
+						Range: rng,
+						Addr:  tt.addr,
+					},
+				},
+			}
+
+			diags := ResourceAttributeReferences(ctx, pathCtx)
+			if diff := cmp.Diff(tt.want, diags["test.tf"]); diff != "" {
+				t.Fatalf("unexpected diagnostics: %s", diff)
+			}
+		})
+	}
+}
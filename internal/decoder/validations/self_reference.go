@@ -0,0 +1,85 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package validations
+
+import (
+	"context"
+	"slices"
+
+	"github.com/hashicorp/hcl-lang/decoder"
+	"github.com/hashicorp/hcl-lang/lang"
+	"github.com/hashicorp/hcl-lang/schema"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+)
+
+const InvalidSelfReferenceSummary = `Invalid "self" reference`
+
+// SelfReferences flags references to self outside of bodies which
+// enable them in the schema (i.e. provisioner, connection and postcondition
+// blocks). References within such bodies are resolved by the decoder against
+// the enclosing block, while elsewhere they would just be ignored.
+func SelfReferences(ctx context.Context, pathCtx *decoder.PathContext) lang.DiagnosticsMap {
+	diagsMap := make(lang.DiagnosticsMap)
+
+	for fileName, file := range pathCtx.Files {
+		body, ok := file.Body.(*hclsyntax.Body)
+		if !ok {
+			continue
+		}
+
+		diags := invalidSelfReferences(body, pathCtx.Schema, nil, false)
+		if len(diags) > 0 {
+			diagsMap[fileName] = diags
+		}
+	}
+
+	return diagsMap
+}
+
+// dataSourcePostconditionPath is the path of block types leading to
+// postconditions of data sources, which enable self references
+// even though their schema doesn't declare it.
+var dataSourcePostconditionPath = []string{"data", "lifecycle", "postcondition"}
+
+func invalidSelfReferences(body *hclsyntax.Body, bodySchema *schema.BodySchema, blockTypes []string, allowed bool) hcl.Diagnostics {
+	var diags hcl.Diagnostics
+
+	if bodySchema != nil && bodySchema.Extensions != nil && bodySchema.Extensions.SelfRefs {
+		allowed = true
+	}
+	if slices.Equal(blockTypes, dataSourcePostconditionPath) {
+		allowed = true
+	}
+
+	if !allowed {
+		for _, attr := range body.Attributes {
+			for _, traversal := range attr.Expr.Variables() {
+				if traversal.RootName() != "self" {
+					continue
+				}
+				diags = append(diags, &hcl.Diagnostic{
+					Severity: hcl.DiagError,
+					Summary:  InvalidSelfReferenceSummary,
+					Detail: `The "self" object is not available in this context. ` +
+						`This object can be used only in resource provisioner, connection, and postcondition blocks.`,
+					Subject: traversal.SourceRange().Ptr(),
+				})
+			}
+		}
+	}
+
+	for _, block := range body.Blocks {
+		var blockBodySchema *schema.BodySchema
+		if bodySchema != nil {
+			if blockSchema, ok := bodySchema.Blocks[block.Type]; ok {
+				blockBodySchema = blockSchema.Body
+			}
+		}
+		nestedTypes := append(slices.Clip(blockTypes), block.Type)
+		diags = append(diags, invalidSelfReferences(block.Body, blockBodySchema, nestedTypes, allowed)...)
+	}
+
+	return diags
+}
@@ -0,0 +1,130 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package validations
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/hcl-lang/decoder"
+	"github.com/hashicorp/hcl-lang/lang"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	tfschema "github.com/hashicorp/terraform-schema/schema"
+)
+
+func TestSelfReferences(t *testing.T) {
+	coreSchema, err := tfschema.CoreModuleSchemaForVersion(version.Must(version.NewVersion("1.2.0")))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name          string
+		cfg           string
+		wantDiagnosed bool
+	}{
+		{
+			name: "resource postcondition",
+			cfg: `resource "aws_instance" "test" {
+  lifecycle {
+    postcondition {
+      condition     = self.id != ""
+      error_message = "ID must be set."
+    }
+  }
+}`,
+		},
+		{
+			name: "data source postcondition",
+			cfg: `data "aws_ami" "test" {
+  lifecycle {
+    postcondition {
+      condition     = self.id != ""
+      error_message = "ID must be set."
+    }
+  }
+}`,
+		},
+		{
+			name: "provisioner",
+			cfg: `resource "aws_instance" "test" {
+  provisioner "local-exec" {
+    command = "echo ${self.private_ip}"
+  }
+}`,
+		},
+		{
+			name: "connection",
+			cfg: `resource "aws_instance" "test" {
+  connection {
+    host = self.public_ip
+  }
+}`,
+		},
+		{
+			name: "resource precondition",
+			cfg: `resource "aws_instance" "test" {
+  lifecycle {
+    precondition {
+      condition     = self.id != ""
+      error_message = "ID must be set."
+    }
+  }
+}`,
+			wantDiagnosed: true,
+		},
+		{
+			name: "resource attribute",
+			cfg: `resource "aws_instance" "test" {
+  tags = {
+    Name = self.id
+  }
+}`,
+			wantDiagnosed: true,
+		},
+		{
+			name: "output",
+			cfg: `output "test" {
+  value = self.id
+}`,
+			wantDiagnosed: true,
+		},
+	}
+
+	for i, tt := range tests {
+		t.Run(fmt.Sprintf("%d-%s", i, tt.name), func(t *testing.T) {
+			f, pDiags := hclsyntax.ParseConfig([]byte(tt.cfg), "test.tf", hcl.InitialPos)
+			if len(pDiags) > 0 {
+				t.Fatal(pDiags)
+			}
+
+			pathCtx := &decoder.PathContext{
+				Schema: coreSchema,
+				Files: map[string]*hcl.File{
+					"test.tf": f,
+				},
+			}
+
+			diagsMap := SelfReferences(context.Background(), pathCtx)
+			if !tt.wantDiagnosed {
+				if diff := cmp.Diff(lang.DiagnosticsMap{}, diagsMap); diff != "" {
+					t.Fatalf("unexpected diagnostics: %s", diff)
+				}
+				return
+			}
+
+			diags := diagsMap["test.tf"]
+			if len(diags) != 1 {
+				t.Fatalf("expected 1 diagnostic, %d given: %#v", len(diags), diags)
+			}
+			if diags[0].Summary != InvalidSelfReferenceSummary {
+				t.Fatalf("expected summary %q, given %q", InvalidSelfReferenceSummary, diags[0].Summary)
+			}
+		})
+	}
+}
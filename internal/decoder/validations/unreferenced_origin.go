@@ -14,6 +14,13 @@ import (
 	"github.com/hashicorp/hcl/v2"
 )
 
+// knownTerraformReferences represents terraform.* references which
+// are validated. Other terraform.* references (e.g. terraform.applying)
+// may be valid in Terraform versions newer than the known schema.
+var knownTerraformReferences = []string{
+	"terraform.workspace",
+}
+
 func UnreferencedOrigins(ctx context.Context, pathCtx *decoder.PathContext) lang.DiagnosticsMap {
 	diagsMap := make(lang.DiagnosticsMap)
 
@@ -45,13 +52,15 @@ func UnreferencedOrigins(ctx context.Context, pathCtx *decoder.PathContext) lang
 			continue
 		}
 
-		// we only initially validate variables & local values
+		// we only initially validate variables, local values
+		// and built-in references, such as path.module or terraform.workspace
 		// resources and data sources can have unknown schema
 		// and will be researched at a later point
 		// TODO: revisit as part of https://github.com/hashicorp/terraform-ls/issues/1364
-		supported := []string{"var", "local"}
+		supported := []string{"var", "local", "path"}
 		firstStep := address[0].String()
-		if !slices.Contains(supported, firstStep) {
+		if !slices.Contains(supported, firstStep) &&
+			!slices.Contains(knownTerraformReferences, address.String()) {
 			continue
 		}
 
@@ -79,6 +79,52 @@ func TestUnreferencedOrigins(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "unknown terraform attribute",
+			origins: reference.Origins{
+				reference.LocalOrigin{
+					Range: hcl.Range{
+						Filename: "test.tf",
+						Start:    hcl.Pos{},
+						End:      hcl.Pos{},
+					},
+					Addr: lang.Address{
+						lang.RootStep{Name: "terraform"},
+						lang.AttrStep{Name: "applying"},
+					},
+				},
+			},
+			want: lang.DiagnosticsMap{},
+		},
+		{
+			name: "undeclared path attribute",
+			origins: reference.Origins{
+				reference.LocalOrigin{
+					Range: hcl.Range{
+						Filename: "test.tf",
+						Start:    hcl.Pos{},
+						End:      hcl.Pos{},
+					},
+					Addr: lang.Address{
+						lang.RootStep{Name: "path"},
+						lang.AttrStep{Name: "modules"},
+					},
+				},
+			},
+			want: lang.DiagnosticsMap{
+				"test.tf": hcl.Diagnostics{
+					&hcl.Diagnostic{
+						Severity: hcl.DiagError,
+						Summary:  "No declaration found for \"path.modules\"",
+						Subject: &hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{},
+							End:      hcl.Pos{},
+						},
+					},
+				},
+			},
+		},
 		{
 			name: "unsupported variable of complex type",
 			origins: reference.Origins{
@@ -0,0 +1,109 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package validations
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/hcl-lang/schema"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/convert"
+)
+
+const InvalidVariableValueSummary = "Invalid value for input variable"
+
+// VariableValueType reports values assigned to variables in variable
+// files (*.tfvars) which are not suitable for the type constraint
+// declared by the variable, including nested attributes of objects
+// and elements of tuples.
+//
+// Attributes declared as optional() may be omitted. Their defaults
+// do not affect whether a value is suitable, so they are not applied.
+type VariableValueType struct{}
+
+func (vvt VariableValueType) Visit(ctx context.Context, node hclsyntax.Node, nodeSchema schema.Schema) (context.Context, hcl.Diagnostics) {
+	var diags hcl.Diagnostics
+
+	attr, ok := node.(*hclsyntax.Attribute)
+	if !ok {
+		return ctx, diags
+	}
+
+	attrSchema, ok := nodeSchema.(*schema.AttributeSchema)
+	if !ok || attrSchema == nil {
+		return ctx, diags
+	}
+	constraint, ok := attrSchema.Constraint.(schema.LiteralType)
+	if !ok || constraint.Type == cty.NilType || constraint.Type.Equals(cty.DynamicPseudoType) {
+		return ctx, diags
+	}
+
+	val, vDiags := attr.Expr.Value(nil)
+	if vDiags.HasErrors() || !val.IsWhollyKnown() {
+		// invalid expressions are reported elsewhere
+		return ctx, diags
+	}
+
+	if diag, ok := variableValueDiagnostic(attr.Name, val, constraint.Type, attr.Expr.Range(), attr.SrcRange); ok {
+		diags = append(diags, diag)
+	}
+
+	return ctx, diags
+}
+
+// variableValueDiagnostic returns a diagnostic if the given value
+// assigned to the named variable is not suitable for its type
+func variableValueDiagnostic(name string, val cty.Value, varType cty.Type, subject, contextRng hcl.Range) (*hcl.Diagnostic, bool) {
+	_, err := convert.Convert(val, varType)
+	if err == nil {
+		return nil, false
+	}
+
+	return &hcl.Diagnostic{
+		Severity: hcl.DiagError,
+		Summary:  InvalidVariableValueSummary,
+		Detail: fmt.Sprintf("The given value is not suitable for var.%s: %s.",
+			name, formatConversionError(err)),
+		Subject: subject.Ptr(),
+		Context: contextRng.Ptr(),
+	}, true
+}
+
+// formatConversionError prefixes the error with the path
+// to the nested value which is not suitable, if any,
+// e.g. attribute "foo"[0]: a number is required
+func formatConversionError(err error) string {
+	var pathErr cty.PathError
+	if !errors.As(err, &pathErr) || len(pathErr.Path) == 0 {
+		return err.Error()
+	}
+
+	var sb strings.Builder
+	for i, step := range pathErr.Path {
+		switch s := step.(type) {
+		case cty.GetAttrStep:
+			if i == 0 {
+				fmt.Fprintf(&sb, "attribute %q", s.Name)
+				continue
+			}
+			fmt.Fprintf(&sb, ".%s", s.Name)
+		case cty.IndexStep:
+			switch s.Key.Type() {
+			case cty.Number:
+				fmt.Fprintf(&sb, "[%s]", s.Key.AsBigFloat().Text('f', -1))
+			case cty.String:
+				fmt.Fprintf(&sb, "[%q]", s.Key.AsString())
+			default:
+				sb.WriteString("[...]")
+			}
+		}
+	}
+
+	return fmt.Sprintf("%s: %s", sb.String(), pathErr.Error())
+}
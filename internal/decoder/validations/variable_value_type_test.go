@@ -0,0 +1,132 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package validations
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/hcl-lang/schema"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty/cty"
+)
+
+func TestVariableValueType(t *testing.T) {
+	serverType := cty.ObjectWithOptionalAttrs(map[string]cty.Type{
+		"name": cty.String,
+		"port": cty.Number,
+		"tags": cty.List(cty.String),
+	}, []string{"tags"})
+
+	tests := []struct {
+		name    string
+		varType cty.Type
+		src     string
+		want    hcl.Diagnostics
+	}{
+		{
+			name:    "valid object omitting optional attribute",
+			varType: serverType,
+			src:     `server = { name = "web", port = 80 }`,
+			want:    nil,
+		},
+		{
+			name:    "object missing required attribute",
+			varType: serverType,
+			src:     `server = { port = 80 }`,
+			want: hcl.Diagnostics{
+				&hcl.Diagnostic{
+					Severity: hcl.DiagError,
+					Summary:  InvalidVariableValueSummary,
+					Detail:   `The given value is not suitable for var.server: attribute "name" is required.`,
+					Subject: &hcl.Range{
+						Filename: "test.tfvars",
+						Start:    hcl.Pos{Line: 1, Column: 10, Byte: 9},
+						End:      hcl.Pos{Line: 1, Column: 23, Byte: 22},
+					},
+					Context: &hcl.Range{
+						Filename: "test.tfvars",
+						Start:    hcl.Pos{Line: 1, Column: 1, Byte: 0},
+						End:      hcl.Pos{Line: 1, Column: 23, Byte: 22},
+					},
+				},
+			},
+		},
+		{
+			name:    "object with nested attribute of wrong type",
+			varType: serverType,
+			src:     `server = { name = "web", port = "http" }`,
+			want: hcl.Diagnostics{
+				&hcl.Diagnostic{
+					Severity: hcl.DiagError,
+					Summary:  InvalidVariableValueSummary,
+					Detail:   `The given value is not suitable for var.server: attribute "port": a number is required.`,
+					Subject: &hcl.Range{
+						Filename: "test.tfvars",
+						Start:    hcl.Pos{Line: 1, Column: 10, Byte: 9},
+						End:      hcl.Pos{Line: 1, Column: 41, Byte: 40},
+					},
+					Context: &hcl.Range{
+						Filename: "test.tfvars",
+						Start:    hcl.Pos{Line: 1, Column: 1, Byte: 0},
+						End:      hcl.Pos{Line: 1, Column: 41, Byte: 40},
+					},
+				},
+			},
+		},
+		{
+			name:    "tuple with element of wrong type",
+			varType: cty.Tuple([]cty.Type{cty.String, cty.Bool}),
+			src:     `pair = ["a", "yes"]`,
+			want: hcl.Diagnostics{
+				&hcl.Diagnostic{
+					Severity: hcl.DiagError,
+					Summary:  InvalidVariableValueSummary,
+					Detail:   `The given value is not suitable for var.pair: [1]: a bool is required.`,
+					Subject: &hcl.Range{
+						Filename: "test.tfvars",
+						Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
+						End:      hcl.Pos{Line: 1, Column: 20, Byte: 19},
+					},
+					Context: &hcl.Range{
+						Filename: "test.tfvars",
+						Start:    hcl.Pos{Line: 1, Column: 1, Byte: 0},
+						End:      hcl.Pos{Line: 1, Column: 20, Byte: 19},
+					},
+				},
+			},
+		},
+		{
+			name:    "untyped variable",
+			varType: cty.DynamicPseudoType,
+			src:     `server = { port = 80 }`,
+			want:    nil,
+		},
+	}
+
+	for i, tt := range tests {
+		t.Run(fmt.Sprintf("%d-%s", i, tt.name), func(t *testing.T) {
+			f, pDiags := hclsyntax.ParseConfig([]byte(tt.src), "test.tfvars", hcl.InitialPos)
+			if len(pDiags) > 0 {
+				t.Fatal(pDiags)
+			}
+			var attr *hclsyntax.Attribute
+			for _, a := range f.Body.(*hclsyntax.Body).Attributes {
+				attr = a
+			}
+			attrSchema := &schema.AttributeSchema{
+				Constraint: schema.LiteralType{Type: tt.varType},
+			}
+
+			_, diags := VariableValueType{}.Visit(context.Background(), attr, attrSchema)
+
+			if diff := cmp.Diff(tt.want, diags); diff != "" {
+				t.Fatalf("unexpected diagnostics: %s", diff)
+			}
+		})
+	}
+}
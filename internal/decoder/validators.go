@@ -10,11 +10,19 @@ import (
 
 var moduleValidators = []validator.Validator{
 	validator.BlockLabelsLength{},
-	validator.DeprecatedAttribute{},
+	validations.BlockNestingMode{},
+	validations.CloudWorkspaces{},
+	validations.ComputedAttribute{},
+	validations.CountForEach{},
+	validations.DeprecatedAttribute{},
 	validator.DeprecatedBlock{},
+	validations.InterpolationOnlyExpression{},
 	validator.MaxBlocks{},
 	validator.MinBlocks{},
 	validations.MissingRequiredAttribute{},
+	validations.ModuleSourceAddress{},
+	validations.ModuleVersion{},
+	validations.ProviderVersionConstraint{},
 	validator.UnexpectedAttribute{},
 	validator.UnexpectedBlock{},
 }
@@ -22,4 +30,5 @@ var moduleValidators = []validator.Validator{
 var varsValidators = []validator.Validator{
 	validator.UnexpectedAttribute{},
 	validator.UnexpectedBlock{},
+	validations.VariableValueType{},
 }
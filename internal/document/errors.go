@@ -32,3 +32,11 @@ func (e *DocumentNotFound) Is(err error) bool {
 	_, ok := err.(*DocumentNotFound)
 	return ok
 }
+
+type InvalidByteOffsetErr struct {
+	Offset int
+}
+
+func (e *InvalidByteOffsetErr) Error() string {
+	return fmt.Sprintf("invalid byte offset: %d", e.Offset)
+}
@@ -0,0 +1,104 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package document
+
+import (
+	"bytes"
+	"sort"
+	"unicode/utf8"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/terraform-ls/internal/source"
+)
+
+// LineColumn converts between byte offsets within a document
+// and LSP-style positions, whose columns are counted
+// in UTF-16 code units rather than bytes or grapheme clusters.
+type LineColumn struct {
+	lines source.Lines
+}
+
+func NewLineColumn(lines source.Lines) LineColumn {
+	return LineColumn{lines: lines}
+}
+
+// LineColumn returns a converter for positions within the document
+func (d *Document) LineColumn() LineColumn {
+	return NewLineColumn(d.Lines)
+}
+
+// ByteOffset returns the byte offset of the given position
+func (lc LineColumn) ByteOffset(pos Pos) (int, error) {
+	return ByteOffsetForPos(lc.lines, pos)
+}
+
+// PosForByteOffset returns the position of the given byte offset
+func (lc LineColumn) PosForByteOffset(offset int) (Pos, error) {
+	if offset < 0 || len(lc.lines) == 0 {
+		return Pos{}, &InvalidByteOffsetErr{Offset: offset}
+	}
+
+	lastLine := len(lc.lines) - 1
+	if offset > lc.lines[lastLine].Range.End.Byte {
+		return Pos{}, &InvalidByteOffsetErr{Offset: offset}
+	}
+
+	lineIdx := sort.Search(len(lc.lines), func(i int) bool {
+		return lc.lines[i].Range.End.Byte > offset
+	})
+	if lineIdx > lastLine {
+		lineIdx = lastLine
+	}
+	line := lc.lines[lineIdx]
+
+	return Pos{
+		Line:   lineIdx,
+		Column: utf16Len(line.Bytes[:offset-line.Range.Start.Byte]),
+	}, nil
+}
+
+// PosForHCLPos returns the position corresponding to the given HCL
+// position, based on its byte offset. HCL columns (which count grapheme
+// clusters) are only used when the offset is not within the document.
+func (lc LineColumn) PosForHCLPos(pos hcl.Pos) Pos {
+	docPos, err := lc.PosForByteOffset(pos.Byte)
+	if err != nil {
+		return Pos{
+			Line:   pos.Line - 1,
+			Column: pos.Column - 1,
+		}
+	}
+	return docPos
+}
+
+// RangeForHCLRange returns the range corresponding to the given HCL range
+func (lc LineColumn) RangeForHCLRange(rng hcl.Range) Range {
+	return Range{
+		Start: lc.PosForHCLPos(rng.Start),
+		End:   lc.PosForHCLPos(rng.End),
+	}
+}
+
+// LineLength returns the length of the given (zero-indexed) line
+// in UTF-16 code units, excluding any trailing end-of-line markers
+func (lc LineColumn) LineLength(line int) int {
+	if line < 0 || line >= len(lc.lines) {
+		return 0
+	}
+	return utf16Len(bytes.TrimRight(lc.lines[line].Bytes, "\n\r"))
+}
+
+func utf16Len(b []byte) int {
+	length := 0
+	for len(b) > 0 {
+		r, size := utf8.DecodeRune(b)
+		b = b[size:]
+		if r > 0xFFFF {
+			length += 2 // codepoint requires a surrogate pair
+			continue
+		}
+		length++
+	}
+	return length
+}
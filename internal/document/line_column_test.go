@@ -0,0 +1,99 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package document
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/terraform-ls/internal/source"
+)
+
+// 😀 is 4 bytes in UTF-8 and a surrogate pair (2 units) in UTF-16,
+// é is 2 bytes in UTF-8 and a single unit in UTF-16
+const multibyteText = "a = \"😀é\"\nb = 1\n"
+
+func TestLineColumn_PosForByteOffset(t *testing.T) {
+	lc := NewLineColumn(source.MakeSourceLines("test.tf", []byte(multibyteText)))
+
+	testCases := []struct {
+		offset      int
+		expectedPos Pos
+	}{
+		{0, Pos{Line: 0, Column: 0}},
+		{5, Pos{Line: 0, Column: 5}},
+		{9, Pos{Line: 0, Column: 7}},
+		{11, Pos{Line: 0, Column: 8}},
+		{12, Pos{Line: 0, Column: 9}},
+		{13, Pos{Line: 1, Column: 0}},
+		{17, Pos{Line: 1, Column: 4}},
+		{19, Pos{Line: 2, Column: 0}},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%d-%d", i, tc.offset), func(t *testing.T) {
+			pos, err := lc.PosForByteOffset(tc.offset)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tc.expectedPos, pos); diff != "" {
+				t.Fatalf("unexpected position: %s", diff)
+			}
+
+			offset, err := lc.ByteOffset(pos)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if offset != tc.offset {
+				t.Fatalf("expected position %s to round-trip to offset %d, given %d",
+					pos, tc.offset, offset)
+			}
+		})
+	}
+}
+
+func TestLineColumn_PosForByteOffset_invalid(t *testing.T) {
+	lc := NewLineColumn(source.MakeSourceLines("test.tf", []byte(multibyteText)))
+
+	for _, offset := range []int{-1, 20} {
+		_, err := lc.PosForByteOffset(offset)
+		var offsetErr *InvalidByteOffsetErr
+		if !errors.As(err, &offsetErr) {
+			t.Fatalf("expected invalid offset error for %d, given: %#v", offset, err)
+		}
+	}
+}
+
+func TestLineColumn_RangeForHCLRange(t *testing.T) {
+	lc := NewLineColumn(source.MakeSourceLines("test.tf", []byte(multibyteText)))
+
+	// string template "😀é" including quotes, with HCL columns
+	// counting grapheme clusters
+	rng := hcl.Range{
+		Filename: "test.tf",
+		Start:    hcl.Pos{Line: 1, Column: 5, Byte: 4},
+		End:      hcl.Pos{Line: 1, Column: 9, Byte: 12},
+	}
+	expectedRange := Range{
+		Start: Pos{Line: 0, Column: 4},
+		End:   Pos{Line: 0, Column: 9},
+	}
+	if diff := cmp.Diff(expectedRange, lc.RangeForHCLRange(rng)); diff != "" {
+		t.Fatalf("unexpected range: %s", diff)
+	}
+}
+
+func TestLineColumn_LineLength(t *testing.T) {
+	lc := NewLineColumn(source.MakeSourceLines("test.tf", []byte(multibyteText)))
+
+	expectedLengths := []int{9, 5, 0}
+	for line, expectedLength := range expectedLengths {
+		if length := lc.LineLength(line); length != expectedLength {
+			t.Fatalf("expected line %d length %d, given %d", line, expectedLength, length)
+		}
+	}
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/algolia/algoliasearch-client-go/v3/algolia/search"
 	"github.com/hashicorp/terraform-ls/internal/registry"
 	"github.com/hashicorp/terraform-ls/internal/state"
+	"github.com/hashicorp/terraform-ls/internal/terraform/datadir"
 )
 
 type Hooks struct {
@@ -19,4 +20,8 @@ type Hooks struct {
 	RegistryClient registry.Client
 	AlgoliaClient  *search.Client
 	Logger         *log.Logger
+
+	// DataDir represents location of the data directory,
+	// which installed modules are excluded from.
+	DataDir datadir.Dir
 }
@@ -11,7 +11,6 @@ import (
 	"strings"
 
 	"github.com/hashicorp/hcl-lang/decoder"
-	"github.com/hashicorp/terraform-ls/internal/terraform/datadir"
 	"github.com/zclconf/go-cty/cty"
 )
 
@@ -25,7 +24,10 @@ func (h *Hooks) LocalModuleSources(ctx context.Context, value cty.Value) ([]deco
 	}
 
 	for _, mod := range modules {
-		dirName := fmt.Sprintf("%c%s%c", os.PathSeparator, datadir.DataDirName, os.PathSeparator)
+		dirName := fmt.Sprintf("%c%s%c", os.PathSeparator, h.DataDir, os.PathSeparator)
+		if h.DataDir.IsAbs() {
+			dirName = fmt.Sprintf("%s%c", h.DataDir, os.PathSeparator)
+		}
 		if strings.Contains(mod.Path, dirName) {
 			// Skip installed module copies in cache directories
 			continue
@@ -9,7 +9,7 @@ import (
 	lsctx "github.com/hashicorp/terraform-ls/internal/context"
 	"github.com/hashicorp/terraform-ls/internal/document"
 	"github.com/hashicorp/terraform-ls/internal/job"
-	"github.com/hashicorp/terraform-ls/internal/schemas"
+	"github.com/hashicorp/terraform-ls/internal/terraform/ast"
 	"github.com/hashicorp/terraform-ls/internal/terraform/module"
 	op "github.com/hashicorp/terraform-ls/internal/terraform/module/operation"
 )
@@ -54,7 +54,8 @@ func (idx *Indexer) DocumentChanged(ctx context.Context, modHandle document.DirH
 		return ids, err
 	}
 
-	if validationOptions.EnableEnhancedValidation {
+	if validationOptions.IsEnhancedValidationEnabled(modHandle.Path()) &&
+		validationOptions.IsSourceEnabled(ast.SchemaValidationSource) {
 		_, err = idx.jobStore.EnqueueJob(ctx, job.Job{
 			Dir: modHandle,
 			Func: func(ctx context.Context) error {
@@ -69,6 +70,13 @@ func (idx *Indexer) DocumentChanged(ctx context.Context, modHandle document.DirH
 		}
 	}
 
+	// Module files may have been added or removed,
+	// which affects whether variable files are orphaned.
+	err = idx.validateOrphanedVars(ctx, modHandle, job.IDs{parseId, parseVarsId})
+	if err != nil {
+		return ids, err
+	}
+
 	varsRefsId, err := idx.jobStore.EnqueueJob(ctx, job.Job{
 		Dir: modHandle,
 		Func: func(ctx context.Context) error {
@@ -86,6 +94,32 @@ func (idx *Indexer) DocumentChanged(ctx context.Context, modHandle document.DirH
 	return ids, nil
 }
 
+// validateOrphanedVars schedules validation of variable files
+// in the given module, if enabled. Module and variable files
+// are expected to be parsed by the given jobs.
+func (idx *Indexer) validateOrphanedVars(ctx context.Context, modHandle document.DirHandle, dependsOn job.IDs) error {
+	validationOptions, err := lsctx.ValidationOptions(ctx)
+	if err != nil {
+		return err
+	}
+
+	if !validationOptions.ReportOrphanedVarsFiles ||
+		!validationOptions.IsSourceEnabled(ast.OrphanedVariablesSource) {
+		return nil
+	}
+
+	_, err = idx.jobStore.EnqueueJob(ctx, job.Job{
+		Dir: modHandle,
+		Func: func(ctx context.Context) error {
+			return module.OrphanedVarsValidation(ctx, idx.modStore, modHandle.Path())
+		},
+		Type:        op.OpTypeOrphanedVarsValidation.String(),
+		DependsOn:   dependsOn,
+		IgnoreState: true,
+	})
+	return err
+}
+
 func (idx *Indexer) decodeModule(ctx context.Context, modHandle document.DirHandle, dependsOn job.IDs, ignoreState bool) (job.IDs, error) {
 	ids := make(job.IDs, 0)
 
@@ -123,20 +157,23 @@ func (idx *Indexer) decodeModule(ctx context.Context, modHandle document.DirHand
 			eSchemaId, err := idx.jobStore.EnqueueJob(ctx, job.Job{
 				Dir: modHandle,
 				Func: func(ctx context.Context) error {
-					return module.PreloadEmbeddedSchema(ctx, idx.logger, schemas.FS, idx.modStore, idx.schemaStore, modHandle.Path())
+					return module.PreloadEmbeddedSchema(ctx, idx.logger, idx.schemasFS, idx.modStore, idx.schemaStore, modHandle.Path())
 				},
-				Type:        op.OpTypePreloadEmbeddedSchema.String(),
-				IgnoreState: ignoreState,
+				Type:              op.OpTypePreloadEmbeddedSchema.String(),
+				PreferWhenFocused: true,
+				IgnoreState:       ignoreState,
 			})
 			if err != nil {
 				return ids, err
 			}
 			ids = append(ids, eSchemaId)
 
-			if validationOptions.EnableEnhancedValidation {
+			if validationOptions.IsEnhancedValidationEnabled(modHandle.Path()) &&
+				validationOptions.IsSourceEnabled(ast.SchemaValidationSource) {
 				_, err = idx.jobStore.EnqueueJob(ctx, job.Job{
 					Dir: modHandle,
 					Func: func(ctx context.Context) error {
+						ctx = lsctx.WithValidationOptions(ctx, &validationOptions)
 						return module.SchemaModuleValidation(ctx, idx.modStore, idx.schemaStore, modHandle.Path())
 					},
 					Type:        op.OpTypeSchemaModuleValidation.String(),
@@ -176,11 +213,12 @@ func (idx *Indexer) decodeModule(ctx context.Context, modHandle document.DirHand
 			}
 			ids = append(ids, refOriginsId)
 
-			if validationOptions.EnableEnhancedValidation {
+			if validationOptions.IsEnhancedValidationEnabled(modHandle.Path()) &&
+				validationOptions.IsSourceEnabled(ast.ReferenceValidationSource) {
 				_, err = idx.jobStore.EnqueueJob(ctx, job.Job{
 					Dir: modHandle,
 					Func: func(ctx context.Context) error {
-						return module.ReferenceValidation(ctx, idx.modStore, idx.schemaStore, modHandle.Path())
+						return module.ReferenceValidation(ctx, idx.fs, idx.modStore, idx.schemaStore, modHandle.Path())
 					},
 					Type:        op.OpTypeReferenceValidation.String(),
 					DependsOn:   job.IDs{refOriginsId, refTargetsId},
@@ -199,21 +237,43 @@ func (idx *Indexer) decodeModule(ctx context.Context, modHandle document.DirHand
 	}
 	ids = append(ids, metaId)
 
-	// This job may make an HTTP request, and we schedule it in
-	// the low-priority queue, so we don't want to wait for it.
+	// Metrics are not needed for any other features,
+	// so we don't want to wait for them either.
 	_, err = idx.jobStore.EnqueueJob(ctx, job.Job{
 		Dir: modHandle,
 		Func: func(ctx context.Context) error {
-			return module.GetModuleDataFromRegistry(ctx, idx.registryClient,
-				idx.modStore, idx.registryModStore, modHandle.Path())
+			return module.ComputeModuleMetrics(ctx, idx.modStore, modHandle.Path())
 		},
-		Priority:  job.LowPriority,
-		DependsOn: job.IDs{metaId},
-		Type:      op.OpTypeGetModuleDataFromRegistry.String(),
+		Priority:    job.LowPriority,
+		DependsOn:   job.IDs{metaId},
+		Type:        op.OpTypeComputeModuleMetrics.String(),
+		IgnoreState: ignoreState,
 	})
 	if err != nil {
 		return ids, err
 	}
 
+	// This job may make an HTTP request, and we schedule it in
+	// the low-priority queue, so we don't want to wait for it.
+	_, err = idx.FetchRegistryModuleData(ctx, modHandle, job.IDs{metaId})
+	if err != nil {
+		return ids, err
+	}
+
 	return ids, nil
 }
+
+// FetchRegistryModuleData schedules a job obtaining data of registry
+// modules called from the given module, which are not cached yet.
+func (idx *Indexer) FetchRegistryModuleData(ctx context.Context, modHandle document.DirHandle, dependsOn job.IDs) (job.ID, error) {
+	return idx.jobStore.EnqueueJob(ctx, job.Job{
+		Dir: modHandle,
+		Func: func(ctx context.Context) error {
+			return module.GetModuleDataFromRegistry(ctx, idx.registryClient,
+				idx.modStore, idx.registryModStore, modHandle.Path())
+		},
+		Priority:  job.LowPriority,
+		DependsOn: dependsOn,
+		Type:      op.OpTypeGetModuleDataFromRegistry.String(),
+	})
+}
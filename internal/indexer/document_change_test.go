@@ -0,0 +1,52 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package indexer
+
+import (
+	"context"
+	"testing"
+
+	lsctx "github.com/hashicorp/terraform-ls/internal/context"
+	"github.com/hashicorp/terraform-ls/internal/document"
+	"github.com/hashicorp/terraform-ls/internal/filesystem"
+	"github.com/hashicorp/terraform-ls/internal/registry"
+	"github.com/hashicorp/terraform-ls/internal/settings"
+	"github.com/hashicorp/terraform-ls/internal/state"
+	op "github.com/hashicorp/terraform-ls/internal/terraform/module/operation"
+)
+
+func TestDocumentChanged_orphanedVarsValidation(t *testing.T) {
+	ss, err := state.NewStateStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+	modPath := t.TempDir()
+	err = ss.Modules.Add(modPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	js := &recordingJobStore{}
+	fs := filesystem.NewFilesystem(ss.DocumentStore)
+	idx := NewIndexer(fs, ss.Modules, ss.ProviderSchemas, ss.RegistryModules, js, nil, registry.NewClient())
+
+	validationOptions := settings.ValidationOptions{
+		ReportOrphanedVarsFiles: true,
+	}
+	ctx := lsctx.WithValidationOptions(context.Background(), &validationOptions)
+
+	_, err = idx.DocumentChanged(ctx, document.DirHandleFromPath(modPath))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	validationJob, ok := js.jobOfType(op.OpTypeOrphanedVarsValidation.String())
+	if !ok {
+		t.Fatal("expected orphaned variables validation to be queued")
+	}
+	if len(validationJob.DependsOn) != 2 {
+		t.Fatalf("expected validation to depend on parsing of module and variable files, given: %q",
+			validationJob.DependsOn)
+	}
+}
@@ -10,6 +10,7 @@ import (
 	lsctx "github.com/hashicorp/terraform-ls/internal/context"
 	"github.com/hashicorp/terraform-ls/internal/document"
 	"github.com/hashicorp/terraform-ls/internal/job"
+	"github.com/hashicorp/terraform-ls/internal/terraform/ast"
 	"github.com/hashicorp/terraform-ls/internal/terraform/exec"
 	"github.com/hashicorp/terraform-ls/internal/terraform/module"
 	op "github.com/hashicorp/terraform-ls/internal/terraform/module/operation"
@@ -78,7 +79,8 @@ func (idx *Indexer) DocumentOpened(ctx context.Context, modHandle document.DirHa
 		return ids, err
 	}
 
-	if validationOptions.EnableEnhancedValidation {
+	if validationOptions.IsEnhancedValidationEnabled(modHandle.Path()) &&
+		validationOptions.IsSourceEnabled(ast.SchemaValidationSource) {
 		_, err = idx.jobStore.EnqueueJob(ctx, job.Job{
 			Dir: modHandle,
 			Func: func(ctx context.Context) error {
@@ -93,6 +95,11 @@ func (idx *Indexer) DocumentOpened(ctx context.Context, modHandle document.DirHa
 		}
 	}
 
+	err = idx.validateOrphanedVars(ctx, modHandle, job.IDs{parseId, parseVarsId})
+	if err != nil {
+		return ids, err
+	}
+
 	varsRefsId, err := idx.jobStore.EnqueueJob(ctx, job.Job{
 		Dir: modHandle,
 		Func: func(ctx context.Context) error {
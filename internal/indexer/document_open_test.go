@@ -0,0 +1,120 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package indexer
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	lsctx "github.com/hashicorp/terraform-ls/internal/context"
+	"github.com/hashicorp/terraform-ls/internal/document"
+	"github.com/hashicorp/terraform-ls/internal/filesystem"
+	"github.com/hashicorp/terraform-ls/internal/job"
+	"github.com/hashicorp/terraform-ls/internal/registry"
+	"github.com/hashicorp/terraform-ls/internal/settings"
+	"github.com/hashicorp/terraform-ls/internal/state"
+	op "github.com/hashicorp/terraform-ls/internal/terraform/module/operation"
+)
+
+func TestDocumentOpened_enhancedValidationPaths(t *testing.T) {
+	rootDir := t.TempDir()
+	modulesDir := filepath.Join(rootDir, "modules")
+
+	testCases := []struct {
+		name               string
+		modPath            string
+		expectedValidation bool
+	}{
+		{
+			"inside of allowlist",
+			filepath.Join(modulesDir, "foo"),
+			true,
+		},
+		{
+			"outside of allowlist",
+			filepath.Join(rootDir, "envs", "prod"),
+			false,
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%d-%s", i, tc.name), func(t *testing.T) {
+			ss, err := state.NewStateStore()
+			if err != nil {
+				t.Fatal(err)
+			}
+			err = ss.Modules.Add(tc.modPath)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			js := &recordingJobStore{}
+			fs := filesystem.NewFilesystem(ss.DocumentStore)
+			idx := NewIndexer(fs, ss.Modules, ss.ProviderSchemas, ss.RegistryModules, js, nil, registry.NewClient())
+
+			validationOptions := settings.ValidationOptions{
+				EnableEnhancedValidation: true,
+				EnhancedValidationPaths:  []string{filepath.Join(modulesDir, "*")},
+			}
+			ctx := lsctx.WithValidationOptions(context.Background(), &validationOptions)
+
+			_, err = idx.DocumentOpened(ctx, document.DirHandleFromPath(tc.modPath))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			validationQueued := js.hasJobOfType(op.OpTypeSchemaVarsValidation.String())
+			if validationQueued != tc.expectedValidation {
+				t.Fatalf("expected validation job to be queued: %t, queued: %t",
+					tc.expectedValidation, validationQueued)
+			}
+		})
+	}
+}
+
+// recordingJobStore is a job store which records
+// enqueued jobs without ever executing them
+type recordingJobStore struct {
+	mu   sync.Mutex
+	jobs []job.Job
+}
+
+func (js *recordingJobStore) EnqueueJob(ctx context.Context, newJob job.Job) (job.ID, error) {
+	js.mu.Lock()
+	defer js.mu.Unlock()
+
+	js.jobs = append(js.jobs, newJob)
+	return job.ID(fmt.Sprintf("%d", len(js.jobs))), nil
+}
+
+func (js *recordingJobStore) WaitForJobs(ctx context.Context, ids ...job.ID) error {
+	return nil
+}
+
+func (js *recordingJobStore) hasJobOfType(jobType string) bool {
+	js.mu.Lock()
+	defer js.mu.Unlock()
+
+	for _, j := range js.jobs {
+		if j.Type == jobType {
+			return true
+		}
+	}
+	return false
+}
+
+func (js *recordingJobStore) jobOfType(jobType string) (job.Job, bool) {
+	js.mu.Lock()
+	defer js.mu.Unlock()
+
+	for _, j := range js.jobs {
+		if j.Type == jobType {
+			return j, true
+		}
+	}
+	return job.Job{}, false
+}
@@ -4,12 +4,15 @@
 package indexer
 
 import (
+	"io/fs"
 	"io/ioutil"
 	"log"
 
 	"github.com/hashicorp/terraform-ls/internal/job"
 	"github.com/hashicorp/terraform-ls/internal/registry"
+	"github.com/hashicorp/terraform-ls/internal/schemas"
 	"github.com/hashicorp/terraform-ls/internal/state"
+	"github.com/hashicorp/terraform-ls/internal/terraform/datadir"
 	"github.com/hashicorp/terraform-ls/internal/terraform/exec"
 )
 
@@ -22,6 +25,8 @@ type Indexer struct {
 	jobStore         job.JobStore
 	tfExecFactory    exec.ExecutorFactory
 	registryClient   registry.Client
+	schemasFS        fs.ReadDirFS
+	dataDir          datadir.Dir
 }
 
 func NewIndexer(fs ReadOnlyFS, modStore *state.ModuleStore, schemaStore *state.ProviderSchemaStore,
@@ -38,6 +43,7 @@ func NewIndexer(fs ReadOnlyFS, modStore *state.ModuleStore, schemaStore *state.P
 		jobStore:         jobStore,
 		tfExecFactory:    tfExec,
 		registryClient:   registryClient,
+		schemasFS:        schemas.FS,
 		logger:           discardLogger,
 	}
 }
@@ -46,6 +52,18 @@ func (idx *Indexer) SetLogger(logger *log.Logger) {
 	idx.logger = logger
 }
 
+// SetEmbeddedSchemasFS replaces the filesystem which provider schemas
+// are preloaded from, e.g. to test newer provider schemas than the bundled ones.
+func (idx *Indexer) SetEmbeddedSchemasFS(schemasFS fs.ReadDirFS) {
+	idx.schemasFS = schemasFS
+}
+
+// SetDataDir sets location of the data directory, which is used
+// to find module manifests and lock files of indexed modules.
+func (idx *Indexer) SetDataDir(dataDir datadir.Dir) {
+	idx.dataDir = dataDir
+}
+
 type Collector interface {
 	CollectJobId(jobId job.ID)
 }
@@ -12,7 +12,6 @@ import (
 	"github.com/hashicorp/go-multierror"
 	"github.com/hashicorp/terraform-ls/internal/document"
 	"github.com/hashicorp/terraform-ls/internal/job"
-	"github.com/hashicorp/terraform-ls/internal/schemas"
 	"github.com/hashicorp/terraform-ls/internal/state"
 	"github.com/hashicorp/terraform-ls/internal/terraform/module"
 	op "github.com/hashicorp/terraform-ls/internal/terraform/module/operation"
@@ -20,6 +19,13 @@ import (
 )
 
 func (idx *Indexer) decodeInstalledModuleCalls(ctx context.Context, modHandle document.DirHandle, ignoreState bool) (job.IDs, error) {
+	return idx.decodeInstalledModuleCallsByKey(ctx, modHandle, nil, ignoreState)
+}
+
+// decodeInstalledModuleCallsByKey indexes modules installed for module
+// calls with the given keys (as recorded in the module manifest),
+// or for all installed module calls if keys is nil.
+func (idx *Indexer) decodeInstalledModuleCallsByKey(ctx context.Context, modHandle document.DirHandle, keys map[string]bool, ignoreState bool) (job.IDs, error) {
 	jobIds := make(job.IDs, 0)
 
 	moduleCalls, err := idx.modStore.ModuleCalls(modHandle.Path())
@@ -30,7 +36,10 @@ func (idx *Indexer) decodeInstalledModuleCalls(ctx context.Context, modHandle do
 	var errs *multierror.Error
 
 	idx.logger.Printf("indexing installed module calls: %d", len(moduleCalls.Installed))
-	for _, mc := range moduleCalls.Installed {
+	for key, mc := range moduleCalls.Installed {
+		if keys != nil && !keys[key] {
+			continue
+		}
 		fi, err := os.Stat(mc.Path)
 		if err != nil || !fi.IsDir() {
 			multierror.Append(errs, err)
@@ -137,11 +146,12 @@ func (idx *Indexer) decodeModuleAtPath(ctx context.Context, modHandle document.D
 		eSchemaId, err := idx.jobStore.EnqueueJob(ctx, job.Job{
 			Dir: modHandle,
 			Func: func(ctx context.Context) error {
-				return module.PreloadEmbeddedSchema(ctx, idx.logger, schemas.FS, idx.modStore, idx.schemaStore, modHandle.Path())
+				return module.PreloadEmbeddedSchema(ctx, idx.logger, idx.schemasFS, idx.modStore, idx.schemaStore, modHandle.Path())
 			},
-			Type:        op.OpTypePreloadEmbeddedSchema.String(),
-			DependsOn:   job.IDs{metaId},
-			IgnoreState: ignoreState,
+			Type:              op.OpTypePreloadEmbeddedSchema.String(),
+			PreferWhenFocused: true,
+			DependsOn:         job.IDs{metaId},
+			IgnoreState:       ignoreState,
 		})
 		if err != nil {
 			multierror.Append(errs, err)
@@ -5,11 +5,13 @@ package indexer
 
 import (
 	"context"
+	"fmt"
+	"sort"
+	"strings"
 
 	"github.com/hashicorp/go-multierror"
 	"github.com/hashicorp/terraform-ls/internal/document"
 	"github.com/hashicorp/terraform-ls/internal/job"
-	"github.com/hashicorp/terraform-ls/internal/schemas"
 	"github.com/hashicorp/terraform-ls/internal/terraform/datadir"
 	"github.com/hashicorp/terraform-ls/internal/terraform/exec"
 	"github.com/hashicorp/terraform-ls/internal/terraform/module"
@@ -20,6 +22,20 @@ func (idx *Indexer) WalkedModule(ctx context.Context, modHandle document.DirHand
 	ids := make(job.IDs, 0)
 	var errs *multierror.Error
 
+	fingerprint, err := dirFingerprint(idx.fs, idx.dataDir, modHandle.Path())
+	if err != nil {
+		idx.logger.Printf("failed to fingerprint %q: %s", modHandle.Path(), err)
+	} else {
+		if idx.isIndexedWithFingerprint(modHandle.Path(), fingerprint) {
+			idx.logger.Printf("skipping indexing of unchanged module %q", modHandle.Path())
+			return ids, nil
+		}
+		err = idx.modStore.UpdateWalkedDirFingerprint(modHandle.Path(), fingerprint)
+		if err != nil {
+			errs = multierror.Append(errs, err)
+		}
+	}
+
 	refCollectionDeps := make(job.IDs, 0)
 	providerVersionDeps := make(job.IDs, 0)
 
@@ -55,6 +71,19 @@ func (idx *Indexer) WalkedModule(ctx context.Context, modHandle document.DirHand
 			refCollectionDeps = append(refCollectionDeps, metaId)
 			providerVersionDeps = append(providerVersionDeps, metaId)
 		}
+
+		_, err = idx.jobStore.EnqueueJob(ctx, job.Job{
+			Dir:  modHandle,
+			Type: op.OpTypeComputeModuleMetrics.String(),
+			Func: func(ctx context.Context) error {
+				return module.ComputeModuleMetrics(ctx, idx.modStore, modHandle.Path())
+			},
+			Priority:  job.LowPriority,
+			DependsOn: job.IDs{metaId},
+		})
+		if err != nil {
+			errs = multierror.Append(errs, err)
+		}
 	}
 
 	parseVarsId, err := idx.jobStore.EnqueueJob(ctx, job.Job{
@@ -87,7 +116,7 @@ func (idx *Indexer) WalkedModule(ctx context.Context, modHandle document.DirHand
 		}
 	}
 
-	dataDir := datadir.WalkDataDirOfModule(idx.fs, modHandle.Path())
+	dataDir := datadir.WalkDataDirOfModule(idx.fs, idx.dataDir, modHandle.Path())
 	idx.logger.Printf("parsed datadir: %#v", dataDir)
 
 	var modManifestId job.ID
@@ -97,7 +126,7 @@ func (idx *Indexer) WalkedModule(ctx context.Context, modHandle document.DirHand
 		modManifestId, err = idx.jobStore.EnqueueJob(ctx, job.Job{
 			Dir: modHandle,
 			Func: func(ctx context.Context) error {
-				return module.ParseModuleManifest(ctx, idx.fs, idx.modStore, modHandle.Path())
+				return module.ParseModuleManifest(ctx, idx.fs, idx.dataDir, idx.modStore, modHandle.Path())
 			},
 			Type: op.OpTypeParseModuleManifest.String(),
 			Defer: func(ctx context.Context, jobErr error) (job.IDs, error) {
@@ -119,7 +148,7 @@ func (idx *Indexer) WalkedModule(ctx context.Context, modHandle document.DirHand
 		pSchemaVerId, err := idx.jobStore.EnqueueJob(ctx, job.Job{
 			Dir: modHandle,
 			Func: func(ctx context.Context) error {
-				return module.ParseProviderVersions(ctx, idx.fs, idx.modStore, modHandle.Path())
+				return module.ParseProviderVersions(ctx, idx.fs, idx.dataDir, idx.modStore, modHandle.Path())
 			},
 			Type:      op.OpTypeParseProviderVersions.String(),
 			DependsOn: providerVersionDeps,
@@ -138,8 +167,9 @@ func (idx *Indexer) WalkedModule(ctx context.Context, modHandle document.DirHand
 				ctx = exec.WithExecutorFactory(ctx, idx.tfExecFactory)
 				return module.ObtainSchema(ctx, idx.modStore, idx.schemaStore, modHandle.Path())
 			},
-			Type:      op.OpTypeObtainSchema.String(),
-			DependsOn: dependsOn,
+			Type:              op.OpTypeObtainSchema.String(),
+			PreferWhenFocused: true,
+			DependsOn:         dependsOn,
 		})
 		if err != nil {
 			errs = multierror.Append(errs, err)
@@ -152,15 +182,16 @@ func (idx *Indexer) WalkedModule(ctx context.Context, modHandle document.DirHand
 	eSchemaId, err := idx.jobStore.EnqueueJob(ctx, job.Job{
 		Dir: modHandle,
 		Func: func(ctx context.Context) error {
-			return module.PreloadEmbeddedSchema(ctx, idx.logger, schemas.FS, idx.modStore, idx.schemaStore, modHandle.Path())
+			return module.PreloadEmbeddedSchema(ctx, idx.logger, idx.schemasFS, idx.modStore, idx.schemaStore, modHandle.Path())
 		},
 		// This could theoretically also depend on ObtainSchema to avoid
 		// attempt to preload the same schema twice but we avoid that dependency
 		// as obtaining schema via CLI often takes a long time (multiple
 		// seconds) and this would then defeat the main benefit
 		// of preloaded schemas which can be loaded in miliseconds.
-		DependsOn: providerVersionDeps,
-		Type:      op.OpTypePreloadEmbeddedSchema.String(),
+		DependsOn:         providerVersionDeps,
+		Type:              op.OpTypePreloadEmbeddedSchema.String(),
+		PreferWhenFocused: true,
 	})
 	if err != nil {
 		return ids, err
@@ -178,3 +209,58 @@ func (idx *Indexer) WalkedModule(ctx context.Context, modHandle document.DirHand
 
 	return ids, errs.ErrorOrNil()
 }
+
+// isIndexedWithFingerprint reports whether the module at the given path
+// was fully indexed, and the directory did not change since it was walked
+// within the current session (fingerprints are not persisted).
+func (idx *Indexer) isIndexedWithFingerprint(modPath, fingerprint string) bool {
+	mod, err := idx.modStore.ModuleByPath(modPath)
+	if err != nil {
+		return false
+	}
+
+	return mod.WalkedDirFingerprint == fingerprint &&
+		mod.MetaState == op.OpStateLoaded &&
+		mod.RefTargetsState == op.OpStateLoaded &&
+		mod.RefOriginsState == op.OpStateLoaded &&
+		mod.VarsRefOriginsState == op.OpStateLoaded
+}
+
+// dirFingerprint represents modification times and sizes of all files
+// within the module directory, along with the relevant files
+// in the data directory, such as the module manifest.
+func dirFingerprint(fs ReadOnlyFS, dir datadir.Dir, modPath string) (string, error) {
+	entries, err := fs.ReadDir(modPath)
+	if err != nil {
+		return "", err
+	}
+
+	files := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return "", err
+		}
+		files = append(files, fmt.Sprintf("%s:%d:%d", entry.Name(),
+			info.ModTime().UnixNano(), info.Size()))
+	}
+
+	dataDir := datadir.WalkDataDirOfModule(fs, dir, modPath)
+	for _, path := range []string{dataDir.ModuleManifestPath, dataDir.PluginLockFilePath} {
+		if path == "" {
+			continue
+		}
+		info, err := fs.Stat(path)
+		if err != nil {
+			return "", err
+		}
+		files = append(files, fmt.Sprintf("%s:%d:%d", path,
+			info.ModTime().UnixNano(), info.Size()))
+	}
+
+	sort.Strings(files)
+	return strings.Join(files, ";"), nil
+}
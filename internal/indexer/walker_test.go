@@ -0,0 +1,130 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package indexer
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	"github.com/hashicorp/go-version"
+	lsctx "github.com/hashicorp/terraform-ls/internal/context"
+	"github.com/hashicorp/terraform-ls/internal/document"
+	"github.com/hashicorp/terraform-ls/internal/filesystem"
+	"github.com/hashicorp/terraform-ls/internal/registry"
+	"github.com/hashicorp/terraform-ls/internal/state"
+	op "github.com/hashicorp/terraform-ls/internal/terraform/module/operation"
+	tfaddr "github.com/hashicorp/terraform-registry-address"
+)
+
+func TestWalkedModule_embeddedSchemasFS(t *testing.T) {
+	modPath := t.TempDir()
+	cfg := []byte(`terraform {
+  required_providers {
+    fake = {
+      source  = "acme/fake"
+      version = "1.0.0"
+    }
+  }
+}
+`)
+	err := os.WriteFile(filepath.Join(modPath, "main.tf"), cfg, 0o755)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// schema which is not bundled with the language server
+	schemaJSON := []byte(`{
+	"format_version": "1.0",
+	"provider_schemas": {
+		"registry.terraform.io/acme/fake": {
+			"provider": {
+				"version": 0,
+				"block": {
+					"attributes": {
+						"injected": {
+							"type": "string",
+							"optional": true
+						}
+					}
+				}
+			}
+		}
+	}
+}`)
+	var compressed bytes.Buffer
+	gw := gzip.NewWriter(&compressed)
+	_, err = gw.Write(schemaJSON)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = gw.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	schemasFS := fstest.MapFS{
+		"data":                                       &fstest.MapFile{Mode: fs.ModeDir},
+		"data/registry.terraform.io":                 &fstest.MapFile{Mode: fs.ModeDir},
+		"data/registry.terraform.io/acme":            &fstest.MapFile{Mode: fs.ModeDir},
+		"data/registry.terraform.io/acme/fake":       &fstest.MapFile{Mode: fs.ModeDir},
+		"data/registry.terraform.io/acme/fake/1.0.0": &fstest.MapFile{Mode: fs.ModeDir},
+		"data/registry.terraform.io/acme/fake/1.0.0/schema.json.gz": &fstest.MapFile{
+			Data: compressed.Bytes(),
+		},
+	}
+
+	ss, err := state.NewStateStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = ss.Modules.Add(modPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	js := &recordingJobStore{}
+	fsys := filesystem.NewFilesystem(ss.DocumentStore)
+	idx := NewIndexer(fsys, ss.Modules, ss.ProviderSchemas, ss.RegistryModules, js, nil, registry.NewClient())
+	idx.SetEmbeddedSchemasFS(schemasFS)
+
+	ctx := lsctx.WithDocumentContext(context.Background(), lsctx.Document{})
+	_, err = idx.WalkedModule(ctx, document.DirHandleFromPath(modPath))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// jobs are executed in the order they were enqueued,
+	// which satisfies their dependencies
+	jobTypes := []string{
+		op.OpTypeParseModuleConfiguration.String(),
+		op.OpTypeLoadModuleMetadata.String(),
+		op.OpTypePreloadEmbeddedSchema.String(),
+	}
+	for _, jobType := range jobTypes {
+		j, ok := js.jobOfType(jobType)
+		if !ok {
+			t.Fatalf("expected %s job to be queued", jobType)
+		}
+		err = j.Func(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	pAddr := tfaddr.MustParseProviderSource("acme/fake")
+	vc := version.MustConstraints(version.NewConstraint("1.0.0"))
+	ps, err := ss.ProviderSchemas.ProviderSchema(modPath, pAddr, vc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := ps.Provider.Attributes["injected"]; !ok {
+		t.Fatalf("expected schema from injected filesystem, given: %#v", ps.Provider)
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/hashicorp/go-multierror"
 	"github.com/hashicorp/terraform-ls/internal/document"
 	"github.com/hashicorp/terraform-ls/internal/job"
+	"github.com/hashicorp/terraform-ls/internal/terraform/datadir"
 	"github.com/hashicorp/terraform-ls/internal/terraform/exec"
 	"github.com/hashicorp/terraform-ls/internal/terraform/module"
 	op "github.com/hashicorp/terraform-ls/internal/terraform/module/operation"
@@ -17,15 +18,46 @@ import (
 func (idx *Indexer) ModuleManifestChanged(ctx context.Context, modHandle document.DirHandle) (job.IDs, error) {
 	ids := make(job.IDs, 0)
 
+	// The manifest as it was before the change, so that only modules
+	// which were installed in a different version or from a different
+	// source are indexed after parsing
+	var prevManifest *datadir.ModuleManifest
+
 	modManifestId, err := idx.jobStore.EnqueueJob(ctx, job.Job{
 		Dir: modHandle,
 		Func: func(ctx context.Context) error {
-			return module.ParseModuleManifest(ctx, idx.fs, idx.modStore, modHandle.Path())
+			mod, err := idx.modStore.ModuleByPath(modHandle.Path())
+			if err != nil {
+				return err
+			}
+			prevManifest = mod.ModManifest
+
+			return module.ParseModuleManifest(ctx, idx.fs, idx.dataDir, idx.modStore, modHandle.Path())
 		},
 		Type:        op.OpTypeParseModuleManifest.String(),
 		IgnoreState: true,
 		Defer: func(ctx context.Context, jobErr error) (job.IDs, error) {
-			return idx.decodeInstalledModuleCalls(ctx, modHandle, true)
+			mod, err := idx.modStore.ModuleByPath(modHandle.Path())
+			if err != nil {
+				return nil, err
+			}
+			if mod.ModManifest == nil {
+				return job.IDs{}, nil
+			}
+
+			changedRecords := mod.ModManifest.ChangedRecords(prevManifest)
+			if len(changedRecords) == 0 {
+				// Modules may have been reinstalled with identical records
+				// (e.g. via terraform get -update, or from a branch of
+				// a git repository), so we cannot tell which ones changed
+				return idx.decodeInstalledModuleCalls(ctx, modHandle, true)
+			}
+
+			changedKeys := make(map[string]bool, 0)
+			for _, record := range changedRecords {
+				changedKeys[record.Key] = true
+			}
+			return idx.decodeInstalledModuleCallsByKey(ctx, modHandle, changedKeys, true)
 		},
 	})
 	if err != nil {
@@ -44,7 +76,7 @@ func (idx *Indexer) PluginLockChanged(ctx context.Context, modHandle document.Di
 	pSchemaVerId, err := idx.jobStore.EnqueueJob(ctx, job.Job{
 		Dir: modHandle,
 		Func: func(ctx context.Context) error {
-			return module.ParseProviderVersions(ctx, idx.fs, idx.modStore, modHandle.Path())
+			return module.ParseProviderVersions(ctx, idx.fs, idx.dataDir, idx.modStore, modHandle.Path())
 		},
 		IgnoreState: true,
 		Type:        op.OpTypeParseProviderVersions.String(),
@@ -62,9 +94,10 @@ func (idx *Indexer) PluginLockChanged(ctx context.Context, modHandle document.Di
 			ctx = exec.WithExecutorFactory(ctx, idx.tfExecFactory)
 			return module.ObtainSchema(ctx, idx.modStore, idx.schemaStore, modHandle.Path())
 		},
-		IgnoreState: true,
-		Type:        op.OpTypeObtainSchema.String(),
-		DependsOn:   dependsOn,
+		IgnoreState:       true,
+		Type:              op.OpTypeObtainSchema.String(),
+		PreferWhenFocused: true,
+		DependsOn:         dependsOn,
 	})
 	if err != nil {
 		errs = multierror.Append(errs, err)
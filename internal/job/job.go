@@ -40,6 +40,11 @@ type Job struct {
 	// whether to ignore existing state, i.e. whether to invalidate cache.
 	// It is up to [Func] to read this flag from ctx and reflect it.
 	IgnoreState bool
+
+	// PreferWhenFocused indicates that the job should be dispatched
+	// ahead of other jobs of the same priority when Dir contains
+	// the document which the user most recently interacted with.
+	PreferWhenFocused bool
 }
 
 // DeferFunc represents a deferred function scheduling more jobs
@@ -56,6 +61,8 @@ func (job Job) Copy() Job {
 		Defer:       job.Defer,
 		IgnoreState: job.IgnoreState,
 		DependsOn:   job.DependsOn.Copy(),
+
+		PreferWhenFocused: job.PreferWhenFocused,
 	}
 }
 
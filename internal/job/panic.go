@@ -0,0 +1,22 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package job
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-ls/internal/document"
+)
+
+// PanicErr represents a panic recovered from a job,
+// which is reported as the error of that job.
+type PanicErr struct {
+	Dir   document.DirHandle
+	Type  string
+	Value interface{}
+}
+
+func (e PanicErr) Error() string {
+	return fmt.Sprintf("%s: %s job panicked: %v", e.Dir.URI, e.Type, e.Value)
+}
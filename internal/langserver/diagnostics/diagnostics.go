@@ -5,11 +5,15 @@ package diagnostics
 
 import (
 	"context"
+	"fmt"
 	"log"
 	"path/filepath"
+	"sort"
 	"sync"
+	"time"
 
 	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/terraform-ls/internal/document"
 	ilsp "github.com/hashicorp/terraform-ls/internal/lsp"
 	lsp "github.com/hashicorp/terraform-ls/internal/protocol"
 	"github.com/hashicorp/terraform-ls/internal/terraform/ast"
@@ -26,6 +30,11 @@ type ClientNotifier interface {
 	Notify(ctx context.Context, method string, params interface{}) error
 }
 
+// LineColumnReader provides position conversion for open documents
+type LineColumnReader interface {
+	LineColumn(dh document.Handle) (document.LineColumn, error)
+}
+
 // Notifier is a type responsible for queueing HCL diagnostics to be converted
 // and sent to the client
 type Notifier struct {
@@ -33,18 +42,42 @@ type Notifier struct {
 	diags          chan diagContext
 	clientNotifier ClientNotifier
 	closeDiagsOnce sync.Once
+
+	// lineColumns is used to report ranges within open documents
+	// with UTF-16 based columns, nil means HCL columns are used as-is
+	lineColumns LineColumnReader
+
+	// interval limits how often diagnostics are published
+	// for a single document, zero means no limit
+	interval time.Duration
+
+	// maxPerFile limits the number of diagnostics published
+	// for a single file, zero means no limit
+	maxPerFile int
 }
 
-func NewNotifier(clientNotifier ClientNotifier, logger *log.Logger) *Notifier {
+func NewNotifier(clientNotifier ClientNotifier, logger *log.Logger, interval time.Duration) *Notifier {
 	n := &Notifier{
 		logger:         logger,
 		diags:          make(chan diagContext, 50),
 		clientNotifier: clientNotifier,
+		interval:       interval,
 	}
 	go n.notify()
 	return n
 }
 
+func (n *Notifier) SetLineColumnReader(lineColumns LineColumnReader) {
+	n.lineColumns = lineColumns
+}
+
+// SetMaxDiagnosticsPerFile limits the number of diagnostics published
+// for a single file. Any diagnostics over the limit are replaced
+// by a single diagnostic indicating the truncation.
+func (n *Notifier) SetMaxDiagnosticsPerFile(max int) {
+	n.maxPerFile = max
+}
+
 // PublishHCLDiags accepts a map of HCL diagnostics per file and queues them for publishing.
 // A dir path is passed which is joined with the filename keys of the map, to form a file URI.
 func (n *Notifier) PublishHCLDiags(ctx context.Context, dirPath string, diags Diagnostics) {
@@ -58,30 +91,135 @@ func (n *Notifier) PublishHCLDiags(ctx context.Context, dirPath string, diags Di
 	}
 
 	for filename, ds := range diags {
+		path := filepath.Join(dirPath, filename)
+		lc, hasLineColumn := n.lineColumn(path)
+
+		// Sources are ordered, such that diagnostics from parsing
+		// are retained first when the number of diagnostics is limited
+		sources := make([]ast.DiagnosticSource, 0, len(ds))
+		for source := range ds {
+			sources = append(sources, source)
+		}
+		sort.Slice(sources, func(i, j int) bool {
+			return sources[i] < sources[j]
+		})
+
 		fileDiags := make([]lsp.Diagnostic, 0)
-		for source, diags := range ds {
+		for _, source := range sources {
+			diags := ds[source]
+			if hasLineColumn {
+				fileDiags = append(fileDiags, ilsp.HCLDiagsToLSPInDocument(diags, source.String(), lc)...)
+				continue
+			}
 			fileDiags = append(fileDiags, ilsp.HCLDiagsToLSP(diags, source.String())...)
 		}
+		fileDiags = n.truncate(fileDiags)
 
 		n.diags <- diagContext{
 			ctx:   ctx,
-			uri:   lsp.DocumentURI(uri.FromPath(filepath.Join(dirPath, filename))),
+			uri:   lsp.DocumentURI(uri.FromPath(path)),
 			diags: fileDiags,
 		}
 	}
 }
 
+// truncate limits the given diagnostics to the configured maximum,
+// appending a diagnostic which reports the number of omitted ones
+func (n *Notifier) truncate(diags []lsp.Diagnostic) []lsp.Diagnostic {
+	if n.maxPerFile == 0 || len(diags) <= n.maxPerFile {
+		return diags
+	}
+
+	truncated := diags[:n.maxPerFile:n.maxPerFile]
+	return append(truncated, lsp.Diagnostic{
+		Severity: lsp.SeverityWarning,
+		Source:   diags[0].Source,
+		Message: fmt.Sprintf("Too many diagnostics: only the first %d of %d are reported",
+			n.maxPerFile, len(diags)),
+	})
+}
+
+func (n *Notifier) lineColumn(path string) (document.LineColumn, bool) {
+	if n.lineColumns == nil {
+		return document.LineColumn{}, false
+	}
+	lc, err := n.lineColumns.LineColumn(document.HandleFromPath(path))
+	if err != nil {
+		return document.LineColumn{}, false
+	}
+	return lc, true
+}
+
 func (n *Notifier) notify() {
-	for d := range n.diags {
-		if err := n.clientNotifier.Notify(d.ctx, "textDocument/publishDiagnostics", lsp.PublishDiagnosticsParams{
-			URI:         d.uri,
-			Diagnostics: d.diags,
-		}); err != nil {
-			n.logger.Printf("Error pushing diagnostics: %s", err)
+	if n.interval == 0 {
+		for d := range n.diags {
+			n.publish(d)
+		}
+		return
+	}
+
+	// Diagnostics received within the interval since the last publish
+	// for the same document are held back, and only the latest of them
+	// gets published once the interval elapses.
+	pending := make(map[lsp.DocumentURI]diagContext, 0)
+	lastPublished := make(map[lsp.DocumentURI]time.Time, 0)
+	var flushCh <-chan time.Time
+
+	for {
+		select {
+		case d, ok := <-n.diags:
+			if !ok {
+				for _, pd := range pending {
+					n.publish(pd)
+				}
+				return
+			}
+
+			_, isPending := pending[d.uri]
+			if !isPending && time.Since(lastPublished[d.uri]) >= n.interval {
+				n.publish(d)
+				lastPublished[d.uri] = time.Now()
+				continue
+			}
+			pending[d.uri] = d
+		case <-flushCh:
+			flushCh = nil
+			now := time.Now()
+			for uri, pd := range pending {
+				if now.Sub(lastPublished[uri]) >= n.interval {
+					n.publish(pd)
+					lastPublished[uri] = now
+					delete(pending, uri)
+				}
+			}
+			for uri, t := range lastPublished {
+				if _, ok := pending[uri]; !ok && now.Sub(t) >= n.interval {
+					delete(lastPublished, uri)
+				}
+			}
+		}
+
+		if flushCh == nil && len(pending) > 0 {
+			wait := n.interval
+			for uri := range pending {
+				if w := n.interval - time.Since(lastPublished[uri]); w < wait {
+					wait = w
+				}
+			}
+			flushCh = time.After(wait)
 		}
 	}
 }
 
+func (n *Notifier) publish(d diagContext) {
+	if err := n.clientNotifier.Notify(d.ctx, "textDocument/publishDiagnostics", lsp.PublishDiagnosticsParams{
+		URI:         d.uri,
+		Diagnostics: d.diags,
+	}); err != nil {
+		n.logger.Printf("Error pushing diagnostics: %s", err)
+	}
+}
+
 type Diagnostics map[string]map[ast.DiagnosticSource]hcl.Diagnostics
 
 func NewDiagnostics() Diagnostics {
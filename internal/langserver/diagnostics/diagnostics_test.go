@@ -5,19 +5,23 @@ package diagnostics
 
 import (
 	"context"
+	"fmt"
 	"io/ioutil"
 	"log"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/hashicorp/hcl/v2"
+	lsp "github.com/hashicorp/terraform-ls/internal/protocol"
 	"github.com/hashicorp/terraform-ls/internal/terraform/ast"
 )
 
 var discardLogger = log.New(ioutil.Discard, "", 0)
 
 func TestDiags_Closes(t *testing.T) {
-	n := NewNotifier(noopNotifier{}, discardLogger)
+	n := NewNotifier(noopNotifier{}, discardLogger, 0)
 
 	diags := NewDiagnostics()
 	diags.Append(ast.HCLParsingSource, map[string]hcl.Diagnostics{
@@ -44,7 +48,7 @@ func TestPublish_DoesNotSendAfterClose(t *testing.T) {
 		}
 	}()
 
-	n := NewNotifier(noopNotifier{}, discardLogger)
+	n := NewNotifier(noopNotifier{}, discardLogger, 0)
 
 	diags := NewDiagnostics()
 	diags.Append(ast.TerraformValidateSource, map[string]hcl.Diagnostics{
@@ -60,6 +64,104 @@ func TestPublish_DoesNotSendAfterClose(t *testing.T) {
 	n.PublishHCLDiags(ctx, t.TempDir(), diags)
 }
 
+func TestPublish_coalescesWithinInterval(t *testing.T) {
+	interval := 50 * time.Millisecond
+	rn := &recordingNotifier{}
+	n := NewNotifier(rn, discardLogger, interval)
+
+	dirPath := t.TempDir()
+	start := time.Now()
+	for i := 0; i < 100; i++ {
+		diags := NewDiagnostics()
+		diags.Append(ast.HCLParsingSource, map[string]hcl.Diagnostics{
+			"main.tf": {
+				{
+					Severity: hcl.DiagError,
+					Summary:  fmt.Sprintf("diagnostic %d", i),
+				},
+			},
+		})
+		n.PublishHCLDiags(context.Background(), dirPath, diags)
+	}
+	elapsed := time.Since(start)
+
+	// the latest diagnostics must be published once edits stop
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		published := rn.Published()
+		if len(published) > 0 {
+			last := published[len(published)-1]
+			if len(last.Diagnostics) == 1 && last.Diagnostics[0].Message == "diagnostic 99" {
+				break
+			}
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("expected latest diagnostics to be published, got %#v", published)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	// allow for the first immediate publish and the final flush
+	maxPublishes := int(elapsed/interval) + 2
+	if count := len(rn.Published()); count > maxPublishes {
+		t.Fatalf("expected at most %d publishes, got %d", maxPublishes, count)
+	}
+}
+
+func TestPublish_truncatesPerFile(t *testing.T) {
+	rn := &recordingNotifier{}
+	n := NewNotifier(rn, discardLogger, 0)
+	n.SetMaxDiagnosticsPerFile(100)
+
+	parseDiags := make(hcl.Diagnostics, 0)
+	for i := 0; i < 1000; i++ {
+		parseDiags = append(parseDiags, &hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  fmt.Sprintf("parse error %d", i),
+		})
+	}
+	diags := NewDiagnostics()
+	diags.Append(ast.HCLParsingSource, map[string]hcl.Diagnostics{
+		"main.tf": parseDiags,
+	})
+	diags.Append(ast.SchemaValidationSource, map[string]hcl.Diagnostics{
+		"main.tf": {
+			{
+				Severity: hcl.DiagError,
+				Summary:  "schema error",
+			},
+		},
+	})
+	n.PublishHCLDiags(context.Background(), t.TempDir(), diags)
+
+	deadline := time.Now().Add(2 * time.Second)
+	for len(rn.Published()) == 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("expected diagnostics to be published")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	published := rn.Published()[0].Diagnostics
+	if len(published) != 101 {
+		t.Fatalf("expected 101 diagnostics, got %d", len(published))
+	}
+	for i, d := range published[:100] {
+		expectedMsg := fmt.Sprintf("parse error %d", i)
+		if d.Message != expectedMsg {
+			t.Fatalf("expected diagnostic %d to be %q, got %q", i, expectedMsg, d.Message)
+		}
+	}
+	summary := published[100]
+	expectedSummary := "Too many diagnostics: only the first 100 of 1001 are reported"
+	if summary.Message != expectedSummary {
+		t.Fatalf("expected summary %q, got %q", expectedSummary, summary.Message)
+	}
+	if summary.Severity != lsp.SeverityWarning {
+		t.Fatalf("expected summary to be a warning, got %v", summary.Severity)
+	}
+}
+
 func TestDiagnostics_Append(t *testing.T) {
 	diags := NewDiagnostics()
 	diags.Append(ast.HCLParsingSource, map[string]hcl.Diagnostics{
@@ -141,3 +243,21 @@ type noopNotifier struct{}
 func (noopNotifier) Notify(ctx context.Context, method string, params interface{}) error {
 	return nil
 }
+
+type recordingNotifier struct {
+	mu        sync.Mutex
+	published []lsp.PublishDiagnosticsParams
+}
+
+func (rn *recordingNotifier) Notify(ctx context.Context, method string, params interface{}) error {
+	rn.mu.Lock()
+	defer rn.mu.Unlock()
+	rn.published = append(rn.published, params.(lsp.PublishDiagnosticsParams))
+	return nil
+}
+
+func (rn *recordingNotifier) Published() []lsp.PublishDiagnosticsParams {
+	rn.mu.Lock()
+	defer rn.mu.Unlock()
+	return append([]lsp.PublishDiagnosticsParams{}, rn.published...)
+}
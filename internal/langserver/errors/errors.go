@@ -14,5 +14,9 @@ func EnrichTfExecError(err error) error {
 		return e.New("Terraform (CLI) is required. " +
 			"Please install Terraform or make it available in $PATH")
 	}
+	if module.IsTerraformExecutorMissing(err) {
+		return e.New("No Terraform executor is available to run the command. " +
+			"Please make sure Terraform (CLI) is installed and configured")
+	}
 	return err
 }
@@ -0,0 +1,111 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/creachadair/jrpc2"
+	"github.com/hashicorp/terraform-ls/internal/document"
+	"github.com/hashicorp/terraform-ls/internal/state"
+	"github.com/hashicorp/terraform-ls/internal/uri"
+	tfschema "github.com/hashicorp/terraform-schema/schema"
+)
+
+const activeSchemaVersion = 0
+
+type activeSchemaParams struct {
+	URI string `json:"uri"`
+}
+
+type activeSchemaResponse struct {
+	FormatVersion int `json:"v"`
+
+	// CoreVersion is the Terraform version the core schema is chosen for,
+	// which is either the discovered version or one resolved
+	// from version constraints, if no version was discovered.
+	CoreVersion           string `json:"core_version"`
+	CoreVersionDiscovered bool   `json:"core_version_discovered"`
+
+	Providers []activeProviderSchema `json:"providers"`
+}
+
+type activeProviderSchema struct {
+	Address     string `json:"address"`
+	Constraints string `json:"constraints"`
+
+	// Version of the provider the schema was obtained for,
+	// empty if unknown or if no schema is available
+	Version string `json:"version"`
+
+	// Source is either "embedded" for schemas bundled with the server,
+	// "cli" for schemas obtained via Terraform CLI, or "none"
+	Source string `json:"source"`
+}
+
+// ActiveSchema returns the Terraform and provider versions of the schema
+// used for the given document, along with the source of each provider schema,
+// e.g. to debug unexpected completion candidates.
+// It is only available when the debug option is enabled.
+func (svc *service) ActiveSchema(ctx context.Context, params activeSchemaParams) (activeSchemaResponse, error) {
+	response := activeSchemaResponse{
+		FormatVersion: activeSchemaVersion,
+		Providers:     make([]activeProviderSchema, 0),
+	}
+
+	if !svc.debugMode {
+		return response, fmt.Errorf("%w: debug requests require the debug option to be enabled",
+			jrpc2.MethodNotFound.Err())
+	}
+
+	if !uri.IsURIValid(params.URI) {
+		return response, fmt.Errorf("%w: URI %q is not valid", jrpc2.InvalidParams.Err(), params.URI)
+	}
+
+	dh := document.HandleFromURI(params.URI)
+	mod, err := svc.modStore.ModuleByPath(dh.Dir.Path())
+	if err != nil {
+		return response, err
+	}
+
+	response.CoreVersion = tfschema.ResolveVersion(mod.TerraformVersion, mod.Meta.CoreRequirements).String()
+	response.CoreVersionDiscovered = mod.TerraformVersion != nil
+
+	for pAddr, pCons := range mod.Meta.ProviderRequirements {
+		provider := activeProviderSchema{
+			Address:     pAddr.String(),
+			Constraints: pCons.String(),
+			Source:      "none",
+		}
+
+		ps, err := svc.stateStore.ProviderSchemas.SelectedProviderSchema(mod.Path, pAddr, pCons)
+		if err != nil {
+			var noSchemaErr *state.NoSchemaError
+			if !errors.As(err, &noSchemaErr) {
+				return response, err
+			}
+		}
+		if ps != nil {
+			if ps.Version != nil {
+				provider.Version = ps.Version.String()
+			}
+			switch ps.Source.(type) {
+			case state.PreloadedSchemaSource:
+				provider.Source = "embedded"
+			case state.LocalSchemaSource:
+				provider.Source = "cli"
+			}
+		}
+
+		response.Providers = append(response.Providers, provider)
+	}
+	sort.SliceStable(response.Providers, func(i, j int) bool {
+		return response.Providers[i].Address < response.Providers[j].Address
+	})
+
+	return response, nil
+}
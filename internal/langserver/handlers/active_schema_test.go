@@ -0,0 +1,122 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/terraform-ls/internal/document"
+	"github.com/hashicorp/terraform-ls/internal/langserver"
+	"github.com/hashicorp/terraform-ls/internal/state"
+	"github.com/hashicorp/terraform-ls/internal/terraform/exec"
+	"github.com/hashicorp/terraform-ls/internal/walker"
+	tfmod "github.com/hashicorp/terraform-schema/module"
+	tfschema "github.com/hashicorp/terraform-schema/schema"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestLangServer_activeSchema(t *testing.T) {
+	rootDir := document.DirHandleFromPath(t.TempDir())
+	modDir := document.DirHandleFromPath(t.TempDir())
+
+	ss, err := state.NewStateStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	awsAddr := state.NewDefaultProvider("aws")
+	googleAddr := state.NewDefaultProvider("google")
+
+	err = ss.Modules.Add(modDir.Path())
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = ss.Modules.UpdateMetadata(modDir.Path(), &tfmod.Meta{
+		Path: modDir.Path(),
+		ProviderRequirements: tfmod.ProviderRequirements{
+			awsAddr:    version.MustConstraints(version.NewConstraint("~> 5.0")),
+			googleAddr: version.MustConstraints(version.NewConstraint(">= 4.0")),
+		},
+	}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = ss.Modules.UpdateTerraformAndProviderVersions(modDir.Path(),
+		version.Must(version.NewVersion("1.5.0")), nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = ss.ProviderSchemas.AddPreloadedSchema(awsAddr,
+		version.Must(version.NewVersion("5.10.0")), &tfschema.ProviderSchema{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	wc := walker.NewWalkerCollector()
+
+	ls := langserver.NewLangServerMock(t, NewMockSession(&MockSessionInput{
+		TerraformCalls: &exec.TerraformMockCalls{
+			PerWorkDir: map[string][]*mock.Call{
+				rootDir.Path(): validTfMockCalls(),
+			},
+		},
+		StateStore:      ss,
+		WalkerCollector: wc,
+	}))
+	stop := ls.Start(t)
+	defer stop()
+
+	ls.Call(t, &langserver.CallRequest{
+		Method: "initialize",
+		ReqParams: fmt.Sprintf(`{
+		"capabilities": {},
+		"rootUri": %q,
+		"processId": 12345,
+		"initializationOptions": {
+			"debug": true
+		}
+	}`, rootDir.URI)})
+	waitForWalkerPath(t, ss, wc, rootDir)
+	ls.Notify(t, &langserver.CallRequest{
+		Method:    "initialized",
+		ReqParams: "{}",
+	})
+
+	rsp := ls.Call(t, &langserver.CallRequest{
+		Method:    "terraform-ls/debug/activeSchema",
+		ReqParams: fmt.Sprintf(`{"uri": "%s/main.tf"}`, modDir.URI),
+	})
+
+	var response activeSchemaResponse
+	err = json.Unmarshal(rsp.Result, &response)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedResponse := activeSchemaResponse{
+		FormatVersion:         activeSchemaVersion,
+		CoreVersion:           "1.5.0",
+		CoreVersionDiscovered: true,
+		Providers: []activeProviderSchema{
+			{
+				Address:     "registry.terraform.io/hashicorp/aws",
+				Constraints: "~> 5.0",
+				Version:     "5.10.0",
+				Source:      "embedded",
+			},
+			{
+				Address:     "registry.terraform.io/hashicorp/google",
+				Constraints: ">= 4.0",
+				Source:      "none",
+			},
+		},
+	}
+	if diff := cmp.Diff(expectedResponse, response); diff != "" {
+		t.Fatalf("unexpected response: %s", diff)
+	}
+}
@@ -0,0 +1,189 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"sort"
+
+	"github.com/creachadair/jrpc2"
+	"github.com/hashicorp/hcl/v2"
+	ilsp "github.com/hashicorp/terraform-ls/internal/lsp"
+	lsp "github.com/hashicorp/terraform-ls/internal/protocol"
+	"github.com/hashicorp/terraform-ls/internal/uri"
+)
+
+// PrepareCallHierarchy returns the module of the given document
+// as the root of a call hierarchy, where modules call other modules
+// via module blocks.
+func (svc *service) PrepareCallHierarchy(ctx context.Context, params lsp.CallHierarchyPrepareParams) ([]lsp.CallHierarchyItem, error) {
+	dh := ilsp.HandleFromDocumentURI(params.TextDocument.URI)
+	doc, err := svc.stateStore.DocumentStore.GetDocument(dh)
+	if err != nil {
+		return nil, err
+	}
+
+	modPath := doc.Dir.Path()
+	_, err = svc.modStore.ModuleByPath(modPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return []lsp.CallHierarchyItem{
+		moduleCallHierarchyItem(modPath, filepath.Join(modPath, doc.Filename), nil),
+	}, nil
+}
+
+// IncomingCalls returns modules which call the module
+// represented by the given item.
+func (svc *service) IncomingCalls(ctx context.Context, params lsp.CallHierarchyIncomingCallsParams) ([]lsp.CallHierarchyIncomingCall, error) {
+	modPath, err := modulePathFromCallHierarchyItem(params.Item)
+	if err != nil {
+		return nil, err
+	}
+
+	callers, err := svc.modStore.CallersOfModule(modPath)
+	if err != nil {
+		return nil, err
+	}
+
+	calls := make([]lsp.CallHierarchyIncomingCall, 0)
+	for _, caller := range callers {
+		modCalls, err := svc.modStore.ModuleCalls(caller.Path)
+		if err != nil {
+			return nil, err
+		}
+
+		for name, mc := range modCalls.Declared {
+			if mc.RangePtr == nil {
+				continue
+			}
+			calledPath, ok := svc.calledModulePath(caller.Path, name)
+			if !ok || filepath.Clean(calledPath) != filepath.Clean(modPath) {
+				continue
+			}
+
+			callerFile := mc.RangePtr.Filename
+			if !filepath.IsAbs(callerFile) {
+				callerFile = filepath.Join(caller.Path, callerFile)
+			}
+			calls = append(calls, lsp.CallHierarchyIncomingCall{
+				From:       moduleCallHierarchyItem(caller.Path, callerFile, mc.RangePtr),
+				FromRanges: []lsp.Range{ilsp.HCLRangeToLSP(*mc.RangePtr)},
+			})
+		}
+	}
+
+	sort.SliceStable(calls, func(i, j int) bool {
+		if calls[i].From.URI != calls[j].From.URI {
+			return calls[i].From.URI < calls[j].From.URI
+		}
+		return calls[i].From.Range.Start.Line < calls[j].From.Range.Start.Line
+	})
+
+	return calls, nil
+}
+
+// OutgoingCalls returns modules called by the module
+// represented by the given item.
+func (svc *service) OutgoingCalls(ctx context.Context, params lsp.CallHierarchyOutgoingCallsParams) ([]lsp.CallHierarchyOutgoingCall, error) {
+	modPath, err := modulePathFromCallHierarchyItem(params.Item)
+	if err != nil {
+		return nil, err
+	}
+
+	modCalls, err := svc.modStore.ModuleCalls(modPath)
+	if err != nil {
+		return nil, err
+	}
+
+	calls := make([]lsp.CallHierarchyOutgoingCall, 0)
+	for name, mc := range modCalls.Declared {
+		calledPath, ok := svc.calledModulePath(modPath, name)
+		if !ok {
+			continue
+		}
+		calledMod, err := svc.modStore.ModuleByPath(calledPath)
+		if err != nil {
+			// module is not indexed (yet)
+			continue
+		}
+
+		fromRanges := make([]lsp.Range, 0)
+		if mc.RangePtr != nil {
+			fromRanges = append(fromRanges, ilsp.HCLRangeToLSP(*mc.RangePtr))
+		}
+
+		calls = append(calls, lsp.CallHierarchyOutgoingCall{
+			To:         moduleCallHierarchyItem(calledPath, representativeFile(calledPath, calledMod.Meta.Filenames), nil),
+			FromRanges: fromRanges,
+		})
+	}
+
+	sort.SliceStable(calls, func(i, j int) bool {
+		return calls[i].To.URI < calls[j].To.URI
+	})
+
+	return calls, nil
+}
+
+type callHierarchyItemData struct {
+	Path string `json:"path"`
+}
+
+// moduleCallHierarchyItem represents a module as a call hierarchy item,
+// located in the given file. The item points to the given range
+// (typically a module block) if provided, or to the beginning
+// of the file otherwise.
+func moduleCallHierarchyItem(modPath, filePath string, rng *hcl.Range) lsp.CallHierarchyItem {
+	itemRange := lsp.Range{}
+	if rng != nil {
+		itemRange = ilsp.HCLRangeToLSP(*rng)
+	}
+
+	return lsp.CallHierarchyItem{
+		Name:           filepath.Base(modPath),
+		Kind:           lsp.Module,
+		Detail:         modPath,
+		URI:            lsp.DocumentURI(uri.FromPath(filePath)),
+		Range:          itemRange,
+		SelectionRange: itemRange,
+		Data: callHierarchyItemData{
+			Path: modPath,
+		},
+	}
+}
+
+func modulePathFromCallHierarchyItem(item lsp.CallHierarchyItem) (string, error) {
+	// data is decoded as a generic map as part of the request
+	data, ok := item.Data.(map[string]interface{})
+	if !ok {
+		return "", fmt.Errorf("%w: unexpected call hierarchy item data: %#v",
+			jrpc2.InvalidParams.Err(), item.Data)
+	}
+	modPath, ok := data["path"].(string)
+	if !ok || modPath == "" {
+		return "", fmt.Errorf("%w: expected module path in call hierarchy item data",
+			jrpc2.InvalidParams.Err())
+	}
+	return modPath, nil
+}
+
+// representativeFile returns the path to main.tf if the module
+// contains it, or the first of its files otherwise.
+func representativeFile(modPath string, filenames []string) string {
+	if len(filenames) == 0 {
+		return modPath
+	}
+	sorted := append([]string{}, filenames...)
+	sort.Strings(sorted)
+	for _, filename := range sorted {
+		if filename == "main.tf" {
+			return filepath.Join(modPath, filename)
+		}
+	}
+	return filepath.Join(modPath, sorted[0])
+}
@@ -0,0 +1,283 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-ls/internal/document"
+	"github.com/hashicorp/terraform-ls/internal/langserver"
+	lsp "github.com/hashicorp/terraform-ls/internal/protocol"
+	"github.com/hashicorp/terraform-ls/internal/state"
+	"github.com/hashicorp/terraform-ls/internal/terraform/exec"
+	"github.com/hashicorp/terraform-ls/internal/uri"
+	"github.com/hashicorp/terraform-ls/internal/walker"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestLangServer_callHierarchy_incomingCalls(t *testing.T) {
+	rootDir := t.TempDir()
+	baseDir := filepath.Join(rootDir, "base")
+
+	createModuleCalling(t, "../base", filepath.Join(rootDir, "dev"))
+	createModuleCalling(t, "../base", filepath.Join(rootDir, "prod"))
+	createBaseModule(t, baseDir)
+
+	ss, err := state.NewStateStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+	wc := walker.NewWalkerCollector()
+
+	ls := langserver.NewLangServerMock(t, NewMockSession(&MockSessionInput{
+		TerraformCalls: &exec.TerraformMockCalls{
+			PerWorkDir: map[string][]*mock.Call{
+				rootDir: validTfMockCalls(),
+			},
+		},
+		StateStore:      ss,
+		WalkerCollector: wc,
+	}))
+	stop := ls.Start(t)
+	defer stop()
+
+	rootHandle := document.DirHandleFromPath(rootDir)
+	ls.Call(t, &langserver.CallRequest{
+		Method: "initialize",
+		ReqParams: fmt.Sprintf(`{
+	    "capabilities": {},
+	    "rootUri": %q,
+		"processId": 12345
+	}`, rootHandle.URI)})
+	waitForWalkerPath(t, ss, wc, rootHandle)
+	ls.Notify(t, &langserver.CallRequest{
+		Method:    "initialized",
+		ReqParams: "{}",
+	})
+
+	docPath := filepath.Join(baseDir, "main.tf")
+	content, err := os.ReadFile(docPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ls.Call(t, &langserver.CallRequest{
+		Method: "textDocument/didOpen",
+		ReqParams: fmt.Sprintf(`{
+		"textDocument": {
+			"version": 0,
+			"languageId": "terraform",
+			"text": %q,
+			"uri": %q
+		}
+	}`, string(content), uri.FromPath(docPath))})
+	waitForAllJobs(t, ss)
+
+	rsp := ls.Call(t, &langserver.CallRequest{
+		Method: "textDocument/prepareCallHierarchy",
+		ReqParams: fmt.Sprintf(`{
+		"textDocument": {
+			"uri": %q
+		},
+		"position": {
+			"line": 0,
+			"character": 0
+		}
+	}`, uri.FromPath(docPath))})
+	var items []lsp.CallHierarchyItem
+	err = json.Unmarshal(rsp.Result, &items)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(items) != 1 {
+		t.Fatalf("expected exactly 1 item, given: %#v", items)
+	}
+	item := items[0]
+	if item.Detail != baseDir {
+		t.Fatalf("expected item for %q, given: %q", baseDir, item.Detail)
+	}
+
+	rsp = ls.Call(t, &langserver.CallRequest{
+		Method:    "callHierarchy/incomingCalls",
+		ReqParams: fmt.Sprintf(`{"item": %s}`, callHierarchyItemJSON(t, item)),
+	})
+	var calls []lsp.CallHierarchyIncomingCall
+	err = json.Unmarshal(rsp.Result, &calls)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	moduleBlockRange := lsp.Range{
+		Start: lsp.Position{Line: 1, Character: 15},
+		End:   lsp.Position{Line: 3, Character: 1},
+	}
+	expectedCalls := []callHierarchyCall{
+		{
+			URI:        uri.FromPath(filepath.Join(rootDir, "dev", "module.tf")),
+			Detail:     filepath.Join(rootDir, "dev"),
+			FromRanges: []lsp.Range{moduleBlockRange},
+		},
+		{
+			URI:        uri.FromPath(filepath.Join(rootDir, "prod", "module.tf")),
+			Detail:     filepath.Join(rootDir, "prod"),
+			FromRanges: []lsp.Range{moduleBlockRange},
+		},
+	}
+	givenCalls := make([]callHierarchyCall, 0, len(calls))
+	for _, call := range calls {
+		givenCalls = append(givenCalls, callHierarchyCall{
+			URI:        string(call.From.URI),
+			Detail:     call.From.Detail,
+			FromRanges: call.FromRanges,
+		})
+	}
+	if diff := cmp.Diff(expectedCalls, givenCalls); diff != "" {
+		t.Fatalf("unexpected incoming calls: %s", diff)
+	}
+}
+
+func TestLangServer_callHierarchy_outgoingCalls(t *testing.T) {
+	rootDir := t.TempDir()
+	baseDir := filepath.Join(rootDir, "base")
+	devDir := filepath.Join(rootDir, "dev")
+
+	createModuleCalling(t, "../base", devDir)
+	createBaseModule(t, baseDir)
+
+	ss, err := state.NewStateStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+	wc := walker.NewWalkerCollector()
+
+	ls := langserver.NewLangServerMock(t, NewMockSession(&MockSessionInput{
+		TerraformCalls: &exec.TerraformMockCalls{
+			PerWorkDir: map[string][]*mock.Call{
+				rootDir: validTfMockCalls(),
+			},
+		},
+		StateStore:      ss,
+		WalkerCollector: wc,
+	}))
+	stop := ls.Start(t)
+	defer stop()
+
+	rootHandle := document.DirHandleFromPath(rootDir)
+	ls.Call(t, &langserver.CallRequest{
+		Method: "initialize",
+		ReqParams: fmt.Sprintf(`{
+	    "capabilities": {},
+	    "rootUri": %q,
+		"processId": 12345
+	}`, rootHandle.URI)})
+	waitForWalkerPath(t, ss, wc, rootHandle)
+	ls.Notify(t, &langserver.CallRequest{
+		Method:    "initialized",
+		ReqParams: "{}",
+	})
+
+	docPath := filepath.Join(devDir, "module.tf")
+	content, err := os.ReadFile(docPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ls.Call(t, &langserver.CallRequest{
+		Method: "textDocument/didOpen",
+		ReqParams: fmt.Sprintf(`{
+		"textDocument": {
+			"version": 0,
+			"languageId": "terraform",
+			"text": %q,
+			"uri": %q
+		}
+	}`, string(content), uri.FromPath(docPath))})
+	waitForAllJobs(t, ss)
+
+	rsp := ls.Call(t, &langserver.CallRequest{
+		Method: "textDocument/prepareCallHierarchy",
+		ReqParams: fmt.Sprintf(`{
+		"textDocument": {
+			"uri": %q
+		},
+		"position": {
+			"line": 0,
+			"character": 0
+		}
+	}`, uri.FromPath(docPath))})
+	var items []lsp.CallHierarchyItem
+	err = json.Unmarshal(rsp.Result, &items)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(items) != 1 {
+		t.Fatalf("expected exactly 1 item, given: %#v", items)
+	}
+	item := items[0]
+	if item.Detail != devDir {
+		t.Fatalf("expected item for %q, given: %q", devDir, item.Detail)
+	}
+
+	rsp = ls.Call(t, &langserver.CallRequest{
+		Method:    "callHierarchy/outgoingCalls",
+		ReqParams: fmt.Sprintf(`{"item": %s}`, callHierarchyItemJSON(t, item)),
+	})
+	var calls []lsp.CallHierarchyOutgoingCall
+	err = json.Unmarshal(rsp.Result, &calls)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedCalls := []callHierarchyCall{
+		{
+			URI:    uri.FromPath(filepath.Join(baseDir, "main.tf")),
+			Detail: baseDir,
+			FromRanges: []lsp.Range{
+				{
+					Start: lsp.Position{Line: 1, Character: 15},
+					End:   lsp.Position{Line: 3, Character: 1},
+				},
+			},
+		},
+	}
+	givenCalls := make([]callHierarchyCall, 0, len(calls))
+	for _, call := range calls {
+		givenCalls = append(givenCalls, callHierarchyCall{
+			URI:        string(call.To.URI),
+			Detail:     call.To.Detail,
+			FromRanges: call.FromRanges,
+		})
+	}
+	if diff := cmp.Diff(expectedCalls, givenCalls); diff != "" {
+		t.Fatalf("unexpected outgoing calls: %s", diff)
+	}
+}
+
+type callHierarchyCall struct {
+	URI        string
+	Detail     string
+	FromRanges []lsp.Range
+}
+
+func createBaseModule(t *testing.T, modPath string) {
+	err := os.MkdirAll(modPath, 0755)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = os.WriteFile(filepath.Join(modPath, "main.tf"), []byte("variable \"name\" {}\n"), 0755)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func callHierarchyItemJSON(t *testing.T, item lsp.CallHierarchyItem) string {
+	b, err := json.Marshal(item)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(b)
+}
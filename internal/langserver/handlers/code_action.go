@@ -26,18 +26,19 @@ func (svc *service) textDocumentCodeAction(ctx context.Context, params lsp.CodeA
 	var ca []lsp.CodeAction
 
 	// For action definitions, refer to https://code.visualstudio.com/api/references/vscode-api#CodeActionKind
-	// We only support format type code actions at the moment, and do not want to format without the client asking for
-	// them, so exit early here if nothing is requested.
-	if len(params.Context.Only) == 0 {
-		svc.logger.Printf("No code action requested, exiting")
-		return ca, nil
+	// We do not want to format without the client asking for it, so only
+	// quick fixes and refactorings are offered if no particular kind is requested.
+	only := params.Context.Only
+	if len(only) == 0 {
+		svc.logger.Printf("No code action requested, offering quick fixes and refactorings")
+		only = []lsp.CodeActionKind{lsp.QuickFix, lsp.Refactor}
 	}
 
-	for _, o := range params.Context.Only {
+	for _, o := range only {
 		svc.logger.Printf("Code actions requested: %q", o)
 	}
 
-	wantedCodeActions := ilsp.SupportedCodeActions.Only(params.Context.Only)
+	wantedCodeActions := ilsp.SupportedCodeActions.Only(only)
 	if len(wantedCodeActions) == 0 {
 		return nil, fmt.Errorf("could not find a supported code action to execute for %s, wanted %v",
 			params.TextDocument.URI, params.Context.Only)
@@ -74,6 +75,24 @@ func (svc *service) textDocumentCodeAction(ctx context.Context, params lsp.CodeA
 					},
 				},
 			})
+		case lsp.QuickFix:
+			fixes, err := svc.quickFixes(ctx, dh, doc, params.Range)
+			if err != nil {
+				return ca, err
+			}
+			ca = append(ca, fixes...)
+		case lsp.RefactorExtract:
+			refactorings, err := svc.extractToLocalRefactorings(dh, doc, params.Range)
+			if err != nil {
+				return ca, err
+			}
+			ca = append(ca, refactorings...)
+		case lsp.RefactorRewrite:
+			refactorings, err := svc.countToForEachRefactorings(dh, doc, params.Range)
+			if err != nil {
+				return ca, err
+			}
+			ca = append(ca, refactorings...)
 		}
 	}
 
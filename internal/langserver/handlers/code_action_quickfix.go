@@ -0,0 +1,308 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/hcl-lang/lang"
+	"github.com/hashicorp/hcl-lang/schema"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	lsctx "github.com/hashicorp/terraform-ls/internal/context"
+	idecoder "github.com/hashicorp/terraform-ls/internal/decoder"
+	"github.com/hashicorp/terraform-ls/internal/decoder/validations"
+	"github.com/hashicorp/terraform-ls/internal/document"
+	ilsp "github.com/hashicorp/terraform-ls/internal/lsp"
+	lsp "github.com/hashicorp/terraform-ls/internal/protocol"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// quickFixes returns all quick fixes applicable to the given range
+// of the document
+func (svc *service) quickFixes(ctx context.Context, dh document.Handle, doc *document.Document, rng lsp.Range) ([]lsp.CodeAction, error) {
+	var ca []lsp.CodeAction
+
+	body, hclRng, ok, err := parseForCodeAction(doc, rng)
+	if err != nil || !ok {
+		return ca, err
+	}
+
+	fixes, err := svc.interpolationOnlyQuickFixes(ctx, dh, doc, body, hclRng)
+	if err != nil {
+		return ca, err
+	}
+	ca = append(ca, fixes...)
+
+	ca = append(ca, svc.missingModuleInputsQuickFixes(dh, doc, body, hclRng)...)
+
+	return ca, nil
+}
+
+// interpolationOnlyQuickFixes offers to unwrap any interpolation-only
+// expressions (e.g. "${var.foo}") within the given range
+func (svc *service) interpolationOnlyQuickFixes(ctx context.Context, dh document.Handle, doc *document.Document, body *hclsyntax.Body, rng hcl.Range) ([]lsp.CodeAction, error) {
+	var ca []lsp.CodeAction
+
+	validationOpts, err := lsctx.ValidationOptions(ctx)
+	if err != nil {
+		return ca, err
+	}
+	if !validationOpts.EnableInterpolationOnlyHints {
+		return ca, nil
+	}
+
+	for _, expr := range validations.InterpolationOnlyExpressions(body) {
+		if !rangeIntersects(expr.SrcRange, rng) {
+			continue
+		}
+
+		wrappedRng := expr.Wrapped.Range()
+		ca = append(ca, lsp.CodeAction{
+			Title: "Unwrap interpolation-only expression",
+			Kind:  lsp.QuickFix,
+			Edit: lsp.WorkspaceEdit{
+				Changes: map[lsp.DocumentURI][]lsp.TextEdit{
+					lsp.DocumentURI(dh.FullURI()): {
+						{
+							Range:   ilsp.HCLRangeToLSP(expr.SrcRange),
+							NewText: string(wrappedRng.SliceBytes(doc.Text)),
+						},
+					},
+				},
+			},
+			IsPreferred: true,
+		})
+	}
+
+	return ca, nil
+}
+
+// missingModuleInputsQuickFixes offers to add any required inputs
+// missing in module blocks within the given range,
+// as long as inputs of the called module are known
+func (svc *service) missingModuleInputsQuickFixes(dh document.Handle, doc *document.Document, body *hclsyntax.Body, rng hcl.Range) []lsp.CodeAction {
+	var ca []lsp.CodeAction
+
+	pathReader := &idecoder.PathReader{
+		ModuleReader: svc.modStore,
+		SchemaReader: svc.schemaStore,
+	}
+	pathCtx, err := pathReader.PathContext(lang.Path{
+		Path:       dh.Dir.Path(),
+		LanguageID: ilsp.Terraform.String(),
+	})
+	if err != nil || pathCtx.Schema == nil {
+		return ca
+	}
+	modSchema, ok := pathCtx.Schema.Blocks["module"]
+	if !ok {
+		return ca
+	}
+
+	for _, block := range body.Blocks {
+		if block.Type != "module" || len(block.Labels) != 1 {
+			continue
+		}
+		if !rangeIntersects(block.Range(), rng) {
+			continue
+		}
+
+		inputs, ok := moduleInputsSchema(modSchema, block)
+		if !ok {
+			continue
+		}
+
+		missingInputs := make([]string, 0)
+		for name, input := range inputs.Attributes {
+			if _, ok := block.Body.Attributes[name]; !ok && input.IsRequired {
+				missingInputs = append(missingInputs, name)
+			}
+		}
+		if len(missingInputs) == 0 {
+			continue
+		}
+		sort.Strings(missingInputs)
+
+		ca = append(ca, lsp.CodeAction{
+			Title: fmt.Sprintf("Add missing inputs to module %q", block.Labels[0]),
+			Kind:  lsp.QuickFix,
+			Edit: lsp.WorkspaceEdit{
+				Changes: map[lsp.DocumentURI][]lsp.TextEdit{
+					lsp.DocumentURI(dh.FullURI()): {
+						missingInputsEdit(doc.Text, block, inputs, missingInputs),
+					},
+				},
+			},
+			IsPreferred: true,
+		})
+	}
+
+	return ca
+}
+
+// moduleInputsSchema returns schema of inputs for the module block
+// based on its (static) source address
+func moduleInputsSchema(modSchema *schema.BlockSchema, block *hclsyntax.Block) (*schema.BodySchema, bool) {
+	sourceAttr, ok := block.Body.Attributes["source"]
+	if !ok {
+		return nil, false
+	}
+	source, diags := sourceAttr.Expr.Value(nil)
+	if diags.HasErrors() || !source.IsWhollyKnown() || source.IsNull() || source.Type() != cty.String {
+		return nil, false
+	}
+
+	key := schema.NewSchemaKey(schema.DependencyKeys{
+		Attributes: []schema.AttributeDependent{
+			{
+				Name: "source",
+				Expr: schema.ExpressionValue{
+					Static: source,
+				},
+			},
+		},
+	})
+	inputs, ok := modSchema.DependentBody[key]
+	if !ok || inputs == nil {
+		return nil, false
+	}
+	return inputs, true
+}
+
+// missingInputsEdit produces an edit inserting the given inputs
+// with placeholder values at the end of the module block
+func missingInputsEdit(src []byte, block *hclsyntax.Block, inputs *schema.BodySchema, missingInputs []string) lsp.TextEdit {
+	attributes := make([]string, 0, len(missingInputs))
+	for _, name := range missingInputs {
+		attributes = append(attributes, fmt.Sprintf("%s = %s", name,
+			placeholderForConstraint(inputs.Attributes[name].Constraint)))
+	}
+	return appendAttributesEdit(src, block, attributes)
+}
+
+// appendAttributesEdit produces an edit inserting the given attributes
+// at the end of the block, indented one level deeper than the block itself
+func appendAttributesEdit(src []byte, block *hclsyntax.Block, attributes []string) lsp.TextEdit {
+	blockIndent := lineIndent(src, block.TypeRange.Start.Byte)
+	closeBrace := block.CloseBraceRange.Start
+
+	var newText strings.Builder
+	insertPos := closeBrace
+	lineStart := bytes.LastIndexByte(src[:closeBrace.Byte], '\n') + 1
+	if len(bytes.TrimSpace(src[lineStart:closeBrace.Byte])) == 0 {
+		// closing brace is on its own line, so we insert above it
+		insertPos = hcl.Pos{
+			Line:   closeBrace.Line,
+			Column: 1,
+			Byte:   lineStart,
+		}
+	} else {
+		newText.WriteString("\n")
+	}
+
+	for _, attr := range attributes {
+		fmt.Fprintf(&newText, "%s  %s\n", blockIndent, attr)
+	}
+
+	if insertPos == closeBrace {
+		newText.WriteString(blockIndent)
+	}
+
+	return lsp.TextEdit{
+		Range: lsp.Range{
+			Start: ilsp.HCLPosToLSP(insertPos),
+			End:   ilsp.HCLPosToLSP(insertPos),
+		},
+		NewText: newText.String(),
+	}
+}
+
+// placeholderForConstraint returns an empty value
+// matching the type of the given constraint
+func placeholderForConstraint(cons schema.Constraint) string {
+	var typ cty.Type
+	switch c := cons.(type) {
+	case schema.AnyExpression:
+		typ = c.OfType
+	case schema.OneOf:
+		if len(c) > 0 {
+			return placeholderForConstraint(c[0])
+		}
+	}
+
+	switch {
+	case typ == cty.String:
+		return `""`
+	case typ == cty.Number:
+		return "0"
+	case typ == cty.Bool:
+		return "false"
+	case typ.IsListType(), typ.IsSetType(), typ.IsTupleType():
+		return "[]"
+	case typ.IsMapType(), typ.IsObjectType():
+		return "{}"
+	}
+	return "null"
+}
+
+// lineIndent returns the leading whitespace
+// of the line containing the given byte offset
+func lineIndent(src []byte, offset int) string {
+	lineStart := bytes.LastIndexByte(src[:offset], '\n') + 1
+	lineEnd := lineStart
+	for lineEnd < len(src) && (src[lineEnd] == ' ' || src[lineEnd] == '\t') {
+		lineEnd++
+	}
+	return string(src[lineStart:lineEnd])
+}
+
+// parseForCodeAction parses the given (Terraform) document
+// and converts the requested range for use with the AST
+func parseForCodeAction(doc *document.Document, rng lsp.Range) (*hclsyntax.Body, hcl.Range, bool, error) {
+	if doc.LanguageID != ilsp.Terraform.String() {
+		return nil, hcl.Range{}, false, nil
+	}
+
+	// We parse the document here rather than using the AST from the
+	// module store, as that may not reflect the latest changes yet.
+	file, _ := hclsyntax.ParseConfig(doc.Text, doc.Filename, hcl.InitialPos)
+	body, ok := file.Body.(*hclsyntax.Body)
+	if !ok {
+		return nil, hcl.Range{}, false, nil
+	}
+
+	hclRng, err := hclRangeFromLSP(rng, doc)
+	if err != nil {
+		return nil, hcl.Range{}, false, err
+	}
+
+	return body, hclRng, true, nil
+}
+
+func hclRangeFromLSP(rng lsp.Range, doc *document.Document) (hcl.Range, error) {
+	start, err := ilsp.HCLPositionFromLspPosition(rng.Start, doc)
+	if err != nil {
+		return hcl.Range{}, err
+	}
+	end, err := ilsp.HCLPositionFromLspPosition(rng.End, doc)
+	if err != nil {
+		return hcl.Range{}, err
+	}
+	return hcl.Range{
+		Filename: doc.Filename,
+		Start:    start,
+		End:      end,
+	}, nil
+}
+
+// rangeIntersects reports whether the given (possibly empty)
+// range, such as cursor position, intersects with r
+func rangeIntersects(r, rng hcl.Range) bool {
+	return r.Overlaps(rng) || r.ContainsPos(rng.Start)
+}
@@ -0,0 +1,413 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package handlers
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/terraform-ls/internal/document"
+	ilsp "github.com/hashicorp/terraform-ls/internal/lsp"
+	lsp "github.com/hashicorp/terraform-ls/internal/protocol"
+	"github.com/hashicorp/terraform-ls/internal/terraform/ast"
+)
+
+// extractToLocalRefactorings offers to extract the selected expression
+// into a local value and reference that local value instead
+func (svc *service) extractToLocalRefactorings(dh document.Handle, doc *document.Document, rng lsp.Range) ([]lsp.CodeAction, error) {
+	var ca []lsp.CodeAction
+
+	body, hclRng, ok, err := parseForCodeAction(doc, rng)
+	if err != nil || !ok {
+		return ca, err
+	}
+
+	expr, ok := selectedExpression(body, doc.Text, hclRng)
+	if !ok || !isExtractableExpression(body, expr) {
+		return ca, nil
+	}
+
+	name := uniqueLocalName(localNameForExpression(body, expr), svc.localNames(dh, body))
+	exprRng := expr.Range()
+	attribute := fmt.Sprintf("%s = %s", name, exprRng.SliceBytes(doc.Text))
+
+	edits := []lsp.TextEdit{
+		localsEdit(doc.Text, body, exprRng, attribute),
+		{
+			Range:   ilsp.HCLRangeToLSP(exprRng),
+			NewText: fmt.Sprintf("local.%s", name),
+		},
+	}
+
+	ca = append(ca, lsp.CodeAction{
+		Title: "Extract to local value",
+		Kind:  lsp.RefactorExtract,
+		Edit: lsp.WorkspaceEdit{
+			Changes: map[lsp.DocumentURI][]lsp.TextEdit{
+				lsp.DocumentURI(dh.FullURI()): edits,
+			},
+		},
+	})
+
+	return ca, nil
+}
+
+// selectedExpression returns the outermost expression
+// which exactly matches the given range, ignoring any surrounding whitespace
+func selectedExpression(body *hclsyntax.Body, src []byte, rng hcl.Range) (hclsyntax.Expression, bool) {
+	start, end := rng.Start.Byte, rng.End.Byte
+	if end > len(src) {
+		end = len(src)
+	}
+	for start < end && isWhitespace(src[start]) {
+		start++
+	}
+	for end > start && isWhitespace(src[end-1]) {
+		end--
+	}
+	if start == end {
+		return nil, false
+	}
+
+	// Parts of templates (e.g. the literal within "foo") and object keys
+	// cannot be extracted without changing the meaning of the code.
+	skipped := make(map[hclsyntax.Node]bool, 0)
+	var selected hclsyntax.Expression
+	hclsyntax.VisitAll(body, func(node hclsyntax.Node) hcl.Diagnostics {
+		switch n := node.(type) {
+		case *hclsyntax.TemplateExpr:
+			for _, part := range n.Parts {
+				skipped[part] = true
+			}
+		case *hclsyntax.ObjectConsKeyExpr:
+			skipped[n] = true
+			skipped[n.Wrapped] = true
+		}
+
+		expr, ok := node.(hclsyntax.Expression)
+		if !ok || selected != nil || skipped[node] {
+			return nil
+		}
+		exprRng := expr.Range()
+		if exprRng.Start.Byte == start && exprRng.End.Byte == end {
+			selected = expr
+		}
+		return nil
+	})
+
+	return selected, selected != nil
+}
+
+// isExtractableExpression reports whether the expression can be moved
+// into a local value without changing its meaning
+func isExtractableExpression(body *hclsyntax.Body, expr hclsyntax.Expression) bool {
+	for _, traversal := range expr.Variables() {
+		switch traversal.RootName() {
+		case "count", "each", "self":
+			return false
+		}
+	}
+
+	exprRng := expr.Range()
+	for _, block := range body.Blocks {
+		if !block.Range().ContainsOffset(exprRng.Start.Byte) {
+			continue
+		}
+		switch block.Type {
+		case "terraform", "variable", "moved", "removed":
+			// references are not allowed in these blocks
+			return false
+		}
+	}
+
+	extractable := true
+	hclsyntax.VisitAll(body, func(node hclsyntax.Node) hcl.Diagnostics {
+		switch n := node.(type) {
+		case *hclsyntax.ForExpr:
+			// the expression may refer to the iterator
+			if n.Range().ContainsOffset(exprRng.Start.Byte) && n.Range() != exprRng {
+				extractable = false
+			}
+		case *hclsyntax.Block:
+			// the expression may refer to the dynamic block iterator
+			if n.Type == "dynamic" && n.Range().ContainsOffset(exprRng.Start.Byte) {
+				extractable = false
+			}
+		}
+		return nil
+	})
+
+	return extractable
+}
+
+// localNameForExpression derives name of the local value
+// from the (innermost) attribute the expression is assigned to
+func localNameForExpression(body *hclsyntax.Body, expr hclsyntax.Expression) string {
+	name := "value"
+	exprRng := expr.Range()
+	hclsyntax.VisitAll(body, func(node hclsyntax.Node) hcl.Diagnostics {
+		attr, ok := node.(*hclsyntax.Attribute)
+		if ok && attr.Expr.Range().ContainsOffset(exprRng.Start.Byte) {
+			name = attr.Name
+		}
+		return nil
+	})
+	return name
+}
+
+// localNames returns names of all local values declared within the module,
+// using the given body for the current document
+func (svc *service) localNames(dh document.Handle, body *hclsyntax.Body) map[string]bool {
+	names := make(map[string]bool, 0)
+	collectLocalNames(body, names)
+
+	mod, err := svc.modStore.ModuleByPath(dh.Dir.Path())
+	if err != nil {
+		return names
+	}
+	for filename, file := range mod.ParsedModuleFiles {
+		if filename == ast.ModFilename(dh.Filename) {
+			continue
+		}
+		if fileBody, ok := file.Body.(*hclsyntax.Body); ok {
+			collectLocalNames(fileBody, names)
+		}
+	}
+
+	return names
+}
+
+func collectLocalNames(body *hclsyntax.Body, names map[string]bool) {
+	for _, block := range body.Blocks {
+		if block.Type != "locals" {
+			continue
+		}
+		for name := range block.Body.Attributes {
+			names[name] = true
+		}
+	}
+}
+
+func uniqueLocalName(name string, existingNames map[string]bool) string {
+	if !existingNames[name] {
+		return name
+	}
+	for i := 2; ; i++ {
+		candidate := fmt.Sprintf("%s_%d", name, i)
+		if !existingNames[candidate] {
+			return candidate
+		}
+	}
+}
+
+// localsEdit produces an edit adding the attribute to the first
+// locals block in the file, or declaring a new locals block
+// above the block containing the extracted expression
+func localsEdit(src []byte, body *hclsyntax.Body, exprRng hcl.Range, attribute string) lsp.TextEdit {
+	for _, block := range body.Blocks {
+		if block.Type == "locals" {
+			return appendAttributesEdit(src, block, []string{attribute})
+		}
+	}
+
+	insertPos := hcl.Pos{Line: 1, Column: 1, Byte: 0}
+	for _, block := range body.Blocks {
+		if block.Range().ContainsOffset(exprRng.Start.Byte) {
+			insertPos = hcl.Pos{
+				Line:   block.TypeRange.Start.Line,
+				Column: 1,
+				Byte:   block.TypeRange.Start.Byte - len(lineIndent(src, block.TypeRange.Start.Byte)),
+			}
+			break
+		}
+	}
+
+	return lsp.TextEdit{
+		Range: lsp.Range{
+			Start: ilsp.HCLPosToLSP(insertPos),
+			End:   ilsp.HCLPosToLSP(insertPos),
+		},
+		NewText: fmt.Sprintf("locals {\n  %s\n}\n\n", attribute),
+	}
+}
+
+func isWhitespace(b byte) bool {
+	return b == ' ' || b == '\t' || b == '\n' || b == '\r'
+}
+
+// countToForEachRefactorings offers to rewrite blocks using
+// count = length(collection) along with collection[count.index]
+// to for_each = toset(collection) along with each.value.
+//
+// No refactoring is offered if count.index is used in any other way
+// or if the block is referenced anywhere within the module, since
+// such references would no longer be valid.
+func (svc *service) countToForEachRefactorings(dh document.Handle, doc *document.Document, rng lsp.Range) ([]lsp.CodeAction, error) {
+	var ca []lsp.CodeAction
+
+	body, hclRng, ok, err := parseForCodeAction(doc, rng)
+	if err != nil || !ok {
+		return ca, err
+	}
+
+	for _, block := range body.Blocks {
+		if !rangeIntersects(block.Range(), hclRng) {
+			continue
+		}
+
+		edits, ok := countToForEachEdits(doc.Text, block)
+		if !ok {
+			continue
+		}
+
+		addr, ok := blockAddress(block)
+		if !ok || svc.isReferenced(dh, body, addr) {
+			continue
+		}
+
+		ca = append(ca, lsp.CodeAction{
+			Title: "Convert count to for_each",
+			Kind:  lsp.RefactorRewrite,
+			Edit: lsp.WorkspaceEdit{
+				Changes: map[lsp.DocumentURI][]lsp.TextEdit{
+					lsp.DocumentURI(dh.FullURI()): edits,
+				},
+			},
+		})
+	}
+
+	return ca, nil
+}
+
+func countToForEachEdits(src []byte, block *hclsyntax.Block) ([]lsp.TextEdit, bool) {
+	countAttr, ok := block.Body.Attributes["count"]
+	if !ok {
+		return nil, false
+	}
+	if _, ok := block.Body.Attributes["for_each"]; ok {
+		return nil, false
+	}
+
+	lengthCall, ok := countAttr.Expr.(*hclsyntax.FunctionCallExpr)
+	if !ok || lengthCall.Name != "length" || len(lengthCall.Args) != 1 || lengthCall.ExpandFinal {
+		return nil, false
+	}
+	collection, ok := lengthCall.Args[0].(*hclsyntax.ScopeTraversalExpr)
+	if !ok {
+		return nil, false
+	}
+	collectionSrc := string(collection.Range().SliceBytes(src))
+
+	edits := []lsp.TextEdit{
+		{
+			Range:   ilsp.HCLRangeToLSP(countAttr.SrcRange),
+			NewText: fmt.Sprintf("for_each = toset(%s)", collectionSrc),
+		},
+	}
+
+	safe := true
+	replacedKeys := make(map[hclsyntax.Node]bool, 0)
+	hclsyntax.VisitAll(block.Body, func(node hclsyntax.Node) hcl.Diagnostics {
+		switch n := node.(type) {
+		case *hclsyntax.IndexExpr:
+			coll, ok := n.Collection.(*hclsyntax.ScopeTraversalExpr)
+			if !ok || string(coll.Range().SliceBytes(src)) != collectionSrc {
+				return nil
+			}
+			if !isCountIndex(n.Key) {
+				return nil
+			}
+			replacedKeys[n.Key] = true
+			edits = append(edits, lsp.TextEdit{
+				Range:   ilsp.HCLRangeToLSP(n.Range()),
+				NewText: "each.value",
+			})
+		case *hclsyntax.ScopeTraversalExpr:
+			if n.Traversal.RootName() == "count" && !replacedKeys[n] {
+				safe = false
+			}
+		}
+		return nil
+	})
+
+	return edits, safe
+}
+
+func isCountIndex(expr hclsyntax.Expression) bool {
+	traversalExpr, ok := expr.(*hclsyntax.ScopeTraversalExpr)
+	if !ok || len(traversalExpr.Traversal) != 2 || traversalExpr.Traversal.RootName() != "count" {
+		return false
+	}
+	attr, ok := traversalExpr.Traversal[1].(hcl.TraverseAttr)
+	return ok && attr.Name == "index"
+}
+
+// blockAddress returns the address by which the block
+// can be referenced, e.g. data.aws_ami.example
+func blockAddress(block *hclsyntax.Block) ([]string, bool) {
+	switch block.Type {
+	case "resource":
+		if len(block.Labels) == 2 {
+			return block.Labels, true
+		}
+	case "data":
+		if len(block.Labels) == 2 {
+			return append([]string{"data"}, block.Labels...), true
+		}
+	case "module":
+		if len(block.Labels) == 1 {
+			return []string{"module", block.Labels[0]}, true
+		}
+	}
+	return nil, false
+}
+
+// isReferenced reports whether there are any references to the given
+// address within the module, using the given body for the current document
+func (svc *service) isReferenced(dh document.Handle, body *hclsyntax.Body, addr []string) bool {
+	if hasReferenceTo(body, addr) {
+		return true
+	}
+
+	mod, err := svc.modStore.ModuleByPath(dh.Dir.Path())
+	if err != nil {
+		return false
+	}
+	for filename, file := range mod.ParsedModuleFiles {
+		if filename == ast.ModFilename(dh.Filename) {
+			continue
+		}
+		if fileBody, ok := file.Body.(*hclsyntax.Body); ok && hasReferenceTo(fileBody, addr) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func hasReferenceTo(body *hclsyntax.Body, addr []string) bool {
+	referenced := false
+	hclsyntax.VisitAll(body, func(node hclsyntax.Node) hcl.Diagnostics {
+		traversalExpr, ok := node.(*hclsyntax.ScopeTraversalExpr)
+		if ok && traversalHasPrefix(traversalExpr.Traversal, addr) {
+			referenced = true
+		}
+		return nil
+	})
+	return referenced
+}
+
+func traversalHasPrefix(traversal hcl.Traversal, addr []string) bool {
+	if len(traversal) < len(addr) || traversal.RootName() != addr[0] {
+		return false
+	}
+	for i, name := range addr[1:] {
+		attr, ok := traversal[i+1].(hcl.TraverseAttr)
+		if !ok || attr.Name != name {
+			return false
+		}
+	}
+	return true
+}
@@ -5,6 +5,7 @@ package handlers
 
 import (
 	"fmt"
+	"path/filepath"
 	"testing"
 
 	"github.com/hashicorp/go-version"
@@ -362,3 +363,420 @@ func TestLangServer_codeAction_no_code_action_requested(t *testing.T) {
 		})
 	}
 }
+
+func TestLangServer_codeAction_interpolationOnly(t *testing.T) {
+	tmpDir := TempDir(t)
+
+	ss, err := state.NewStateStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+	wc := walker.NewWalkerCollector()
+
+	ls := langserver.NewLangServerMock(t, NewMockSession(&MockSessionInput{
+		TerraformCalls: &exec.TerraformMockCalls{
+			PerWorkDir: map[string][]*mock.Call{
+				tmpDir.Path(): validTfMockCalls(),
+			},
+		},
+		StateStore:      ss,
+		WalkerCollector: wc,
+	}))
+	stop := ls.Start(t)
+	defer stop()
+
+	ls.Call(t, &langserver.CallRequest{
+		Method: "initialize",
+		ReqParams: fmt.Sprintf(`{
+	    "capabilities": {},
+	    "rootUri": %q,
+	    "processId": 12345,
+	    "initializationOptions": {
+	        "validation": {
+	            "enableInterpolationOnlyHints": true
+	        }
+	    }
+	}`, tmpDir.URI)})
+	waitForWalkerPath(t, ss, wc, tmpDir)
+	ls.Notify(t, &langserver.CallRequest{
+		Method:    "initialized",
+		ReqParams: "{}",
+	})
+	ls.Call(t, &langserver.CallRequest{
+		Method: "textDocument/didOpen",
+		ReqParams: fmt.Sprintf(`{
+		"textDocument": {
+			"version": 0,
+			"languageId": "terraform",
+			"text": "locals {\n  foo = \"${var.x}\"\n}\n",
+			"uri": "%s/main.tf"
+		}
+	}`, tmpDir.URI)})
+	waitForAllJobs(t, ss)
+
+	ls.CallAndExpectResponse(t, &langserver.CallRequest{
+		Method: "textDocument/codeAction",
+		ReqParams: fmt.Sprintf(`{
+			"textDocument": { "uri": "%s/main.tf" },
+			"range": {
+				"start": { "line": 1, "character": 10 },
+				"end": { "line": 1, "character": 10 }
+			},
+			"context": { "diagnostics": [], "only": ["quickfix"] }
+		}`, tmpDir.URI)}, fmt.Sprintf(`{
+			"jsonrpc": "2.0",
+			"id": 3,
+			"result": [
+				{
+					"title": "Unwrap interpolation-only expression",
+					"kind": "quickfix",
+					"isPreferred": true,
+					"edit": {
+						"changes": {
+							"%s/main.tf": [
+								{
+									"range": {
+										"start": { "line": 1, "character": 8 },
+										"end": { "line": 1, "character": 18 }
+									},
+									"newText": "var.x"
+								}
+							]
+						}
+					}
+				}
+			]
+		}`, tmpDir.URI))
+}
+
+func TestLangServer_codeAction_missingModuleInputs(t *testing.T) {
+	tmpDir := TempDir(t)
+
+	writeContentToFile(t, filepath.Join(tmpDir.Path(), "submodule", "main.tf"), `variable "required" {
+  type = string
+}
+
+variable "required_list" {
+  type = list(string)
+}
+
+variable "optional" {
+  default = "foo"
+}
+`)
+	mainCfg := `module "refname" {
+  source   = "./submodule"
+  required = "bar"
+}
+`
+	writeContentToFile(t, filepath.Join(tmpDir.Path(), "main.tf"), mainCfg)
+
+	ss, err := state.NewStateStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+	wc := walker.NewWalkerCollector()
+
+	ls := langserver.NewLangServerMock(t, NewMockSession(&MockSessionInput{
+		TerraformCalls: &exec.TerraformMockCalls{
+			PerWorkDir: map[string][]*mock.Call{
+				tmpDir.Path(): validTfMockCalls(),
+			},
+		},
+		StateStore:      ss,
+		WalkerCollector: wc,
+	}))
+	stop := ls.Start(t)
+	defer stop()
+
+	ls.Call(t, &langserver.CallRequest{
+		Method: "initialize",
+		ReqParams: fmt.Sprintf(`{
+	    "capabilities": {},
+	    "rootUri": %q,
+	    "processId": 12345
+	}`, tmpDir.URI)})
+	waitForWalkerPath(t, ss, wc, tmpDir)
+	ls.Notify(t, &langserver.CallRequest{
+		Method:    "initialized",
+		ReqParams: "{}",
+	})
+	ls.Call(t, &langserver.CallRequest{
+		Method: "textDocument/didOpen",
+		ReqParams: fmt.Sprintf(`{
+		"textDocument": {
+			"version": 0,
+			"languageId": "terraform",
+			"text": %q,
+			"uri": "%s/main.tf"
+		}
+	}`, mainCfg, tmpDir.URI)})
+	waitForAllJobs(t, ss)
+
+	ls.CallAndExpectResponse(t, &langserver.CallRequest{
+		Method: "textDocument/codeAction",
+		ReqParams: fmt.Sprintf(`{
+			"textDocument": { "uri": "%s/main.tf" },
+			"range": {
+				"start": { "line": 0, "character": 2 },
+				"end": { "line": 0, "character": 2 }
+			},
+			"context": { "diagnostics": [], "only": ["quickfix"] }
+		}`, tmpDir.URI)}, fmt.Sprintf(`{
+			"jsonrpc": "2.0",
+			"id": 3,
+			"result": [
+				{
+					"title": "Add missing inputs to module \"refname\"",
+					"kind": "quickfix",
+					"isPreferred": true,
+					"edit": {
+						"changes": {
+							"%s/main.tf": [
+								{
+									"range": {
+										"start": { "line": 3, "character": 0 },
+										"end": { "line": 3, "character": 0 }
+									},
+									"newText": "  required_list = []\n"
+								}
+							]
+						}
+					}
+				}
+			]
+		}`, tmpDir.URI))
+}
+
+func TestLangServer_codeAction_extractToLocal(t *testing.T) {
+	tmpDir := TempDir(t)
+
+	ss, err := state.NewStateStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+	wc := walker.NewWalkerCollector()
+
+	ls := langserver.NewLangServerMock(t, NewMockSession(&MockSessionInput{
+		TerraformCalls: &exec.TerraformMockCalls{
+			PerWorkDir: map[string][]*mock.Call{
+				tmpDir.Path(): validTfMockCalls(),
+			},
+		},
+		StateStore:      ss,
+		WalkerCollector: wc,
+	}))
+	stop := ls.Start(t)
+	defer stop()
+
+	ls.Call(t, &langserver.CallRequest{
+		Method: "initialize",
+		ReqParams: fmt.Sprintf(`{
+	    "capabilities": {},
+	    "rootUri": %q,
+	    "processId": 12345
+	}`, tmpDir.URI)})
+	waitForWalkerPath(t, ss, wc, tmpDir)
+	ls.Notify(t, &langserver.CallRequest{
+		Method:    "initialized",
+		ReqParams: "{}",
+	})
+	ls.Call(t, &langserver.CallRequest{
+		Method: "textDocument/didOpen",
+		ReqParams: fmt.Sprintf(`{
+		"textDocument": {
+			"version": 0,
+			"languageId": "terraform",
+			"text": "resource \"aws_instance\" \"foo\" {\n  ami = \"ami-123\"\n}\n",
+			"uri": "%s/main.tf"
+		}
+	}`, tmpDir.URI)})
+	waitForAllJobs(t, ss)
+
+	ls.CallAndExpectResponse(t, &langserver.CallRequest{
+		Method: "textDocument/codeAction",
+		ReqParams: fmt.Sprintf(`{
+			"textDocument": { "uri": "%s/main.tf" },
+			"range": {
+				"start": { "line": 1, "character": 8 },
+				"end": { "line": 1, "character": 17 }
+			},
+			"context": { "diagnostics": [], "only": ["refactor.extract"] }
+		}`, tmpDir.URI)}, fmt.Sprintf(`{
+			"jsonrpc": "2.0",
+			"id": 3,
+			"result": [
+				{
+					"title": "Extract to local value",
+					"kind": "refactor.extract",
+					"edit": {
+						"changes": {
+							"%s/main.tf": [
+								{
+									"range": {
+										"start": { "line": 0, "character": 0 },
+										"end": { "line": 0, "character": 0 }
+									},
+									"newText": "locals {\n  ami = \"ami-123\"\n}\n\n"
+								},
+								{
+									"range": {
+										"start": { "line": 1, "character": 8 },
+										"end": { "line": 1, "character": 17 }
+									},
+									"newText": "local.ami"
+								}
+							]
+						}
+					}
+				}
+			]
+		}`, tmpDir.URI))
+}
+
+func TestLangServer_codeAction_countToForEach(t *testing.T) {
+	tmpDir := TempDir(t)
+
+	ss, err := state.NewStateStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+	wc := walker.NewWalkerCollector()
+
+	ls := langserver.NewLangServerMock(t, NewMockSession(&MockSessionInput{
+		TerraformCalls: &exec.TerraformMockCalls{
+			PerWorkDir: map[string][]*mock.Call{
+				tmpDir.Path(): validTfMockCalls(),
+			},
+		},
+		StateStore:      ss,
+		WalkerCollector: wc,
+	}))
+	stop := ls.Start(t)
+	defer stop()
+
+	ls.Call(t, &langserver.CallRequest{
+		Method: "initialize",
+		ReqParams: fmt.Sprintf(`{
+	    "capabilities": {},
+	    "rootUri": %q,
+	    "processId": 12345
+	}`, tmpDir.URI)})
+	waitForWalkerPath(t, ss, wc, tmpDir)
+	ls.Notify(t, &langserver.CallRequest{
+		Method:    "initialized",
+		ReqParams: "{}",
+	})
+	ls.Call(t, &langserver.CallRequest{
+		Method: "textDocument/didOpen",
+		ReqParams: fmt.Sprintf(`{
+		"textDocument": {
+			"version": 0,
+			"languageId": "terraform",
+			"text": "resource \"aws_instance\" \"foo\" {\n  count = length(var.names)\n  name = var.names[count.index]\n}\n",
+			"uri": "%s/main.tf"
+		}
+	}`, tmpDir.URI)})
+	waitForAllJobs(t, ss)
+
+	ls.CallAndExpectResponse(t, &langserver.CallRequest{
+		Method: "textDocument/codeAction",
+		ReqParams: fmt.Sprintf(`{
+			"textDocument": { "uri": "%s/main.tf" },
+			"range": {
+				"start": { "line": 1, "character": 3 },
+				"end": { "line": 1, "character": 3 }
+			},
+			"context": { "diagnostics": [], "only": ["refactor.rewrite"] }
+		}`, tmpDir.URI)}, fmt.Sprintf(`{
+			"jsonrpc": "2.0",
+			"id": 3,
+			"result": [
+				{
+					"title": "Convert count to for_each",
+					"kind": "refactor.rewrite",
+					"edit": {
+						"changes": {
+							"%s/main.tf": [
+								{
+									"range": {
+										"start": { "line": 1, "character": 2 },
+										"end": { "line": 1, "character": 27 }
+									},
+									"newText": "for_each = toset(var.names)"
+								},
+								{
+									"range": {
+										"start": { "line": 2, "character": 9 },
+										"end": { "line": 2, "character": 31 }
+									},
+									"newText": "each.value"
+								}
+							]
+						}
+					}
+				}
+			]
+		}`, tmpDir.URI))
+}
+
+func TestLangServer_codeAction_countToForEach_unsafe(t *testing.T) {
+	tmpDir := TempDir(t)
+
+	ss, err := state.NewStateStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+	wc := walker.NewWalkerCollector()
+
+	ls := langserver.NewLangServerMock(t, NewMockSession(&MockSessionInput{
+		TerraformCalls: &exec.TerraformMockCalls{
+			PerWorkDir: map[string][]*mock.Call{
+				tmpDir.Path(): validTfMockCalls(),
+			},
+		},
+		StateStore:      ss,
+		WalkerCollector: wc,
+	}))
+	stop := ls.Start(t)
+	defer stop()
+
+	ls.Call(t, &langserver.CallRequest{
+		Method: "initialize",
+		ReqParams: fmt.Sprintf(`{
+	    "capabilities": {},
+	    "rootUri": %q,
+	    "processId": 12345
+	}`, tmpDir.URI)})
+	waitForWalkerPath(t, ss, wc, tmpDir)
+	ls.Notify(t, &langserver.CallRequest{
+		Method:    "initialized",
+		ReqParams: "{}",
+	})
+	ls.Call(t, &langserver.CallRequest{
+		Method: "textDocument/didOpen",
+		ReqParams: fmt.Sprintf(`{
+		"textDocument": {
+			"version": 0,
+			"languageId": "terraform",
+			"text": "resource \"aws_instance\" \"foo\" {\n  count = length(var.names)\n  name = \"${var.names[count.index]}-${count.index}\"\n}\n",
+			"uri": "%s/main.tf"
+		}
+	}`, tmpDir.URI)})
+	waitForAllJobs(t, ss)
+
+	ls.CallAndExpectResponse(t, &langserver.CallRequest{
+		Method: "textDocument/codeAction",
+		ReqParams: fmt.Sprintf(`{
+			"textDocument": { "uri": "%s/main.tf" },
+			"range": {
+				"start": { "line": 1, "character": 3 },
+				"end": { "line": 1, "character": 3 }
+			},
+			"context": { "diagnostics": [], "only": ["refactor.rewrite"] }
+		}`, tmpDir.URI)}, `{
+			"jsonrpc": "2.0",
+			"id": 3,
+			"result": null
+		}`)
+}
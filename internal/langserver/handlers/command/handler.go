@@ -6,10 +6,12 @@ package command
 import (
 	"log"
 
+	"github.com/hashicorp/terraform-ls/internal/indexer"
 	"github.com/hashicorp/terraform-ls/internal/state"
 )
 
 type CmdHandler struct {
 	StateStore *state.StateStore
 	Logger     *log.Logger
+	Indexer    *indexer.Indexer
 }
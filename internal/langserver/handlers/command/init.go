@@ -13,18 +13,40 @@ import (
 	"github.com/hashicorp/terraform-ls/internal/langserver/errors"
 	"github.com/hashicorp/terraform-ls/internal/langserver/progress"
 	"github.com/hashicorp/terraform-ls/internal/state"
+	"github.com/hashicorp/terraform-ls/internal/terraform/exec"
 	"github.com/hashicorp/terraform-ls/internal/terraform/module"
 	"github.com/hashicorp/terraform-ls/internal/uri"
 )
 
 func (h *CmdHandler) TerraformInitHandler(ctx context.Context, args cmd.CommandArgs) (interface{}, error) {
+	tfExec, _, err := h.initExecutor(ctx, args)
+	if err != nil {
+		return nil, err
+	}
+
+	progress.Begin(ctx, "Initializing")
+	defer func() {
+		progress.End(ctx, "Finished")
+	}()
+
+	err = runInit(ctx, tfExec)
+	if err != nil {
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// initExecutor returns Terraform executor for the module
+// passed in arguments, adding the module to the store if necessary.
+func (h *CmdHandler) initExecutor(ctx context.Context, args cmd.CommandArgs) (exec.TerraformExecutor, document.DirHandle, error) {
 	dirUri, ok := args.GetString("uri")
 	if !ok || dirUri == "" {
-		return nil, fmt.Errorf("%w: expected module uri argument to be set", jrpc2.InvalidParams.Err())
+		return nil, document.DirHandle{}, fmt.Errorf("%w: expected module uri argument to be set", jrpc2.InvalidParams.Err())
 	}
 
 	if !uri.IsURIValid(dirUri) {
-		return nil, fmt.Errorf("URI %q is not valid", dirUri)
+		return nil, document.DirHandle{}, fmt.Errorf("URI %q is not valid", dirUri)
 	}
 
 	dirHandle := document.DirHandleFromURI(dirUri)
@@ -34,32 +56,28 @@ func (h *CmdHandler) TerraformInitHandler(ctx context.Context, args cmd.CommandA
 		if state.IsModuleNotFound(err) {
 			err = h.StateStore.Modules.Add(dirHandle.Path())
 			if err != nil {
-				return nil, err
+				return nil, dirHandle, err
 			}
 			mod, err = h.StateStore.Modules.ModuleByPath(dirHandle.Path())
 			if err != nil {
-				return nil, err
+				return nil, dirHandle, err
 			}
 		} else {
-			return nil, err
+			return nil, dirHandle, err
 		}
 	}
 
 	tfExec, err := module.TerraformExecutorForModule(ctx, mod.Path)
 	if err != nil {
-		return nil, errors.EnrichTfExecError(err)
+		return nil, dirHandle, errors.EnrichTfExecError(err)
 	}
 
-	progress.Begin(ctx, "Initializing")
-	defer func() {
-		progress.End(ctx, "Finished")
-	}()
+	return tfExec, dirHandle, nil
+}
 
+// runInit runs terraform init, reporting progress
+// within an already started progress.
+func runInit(ctx context.Context, tfExec exec.TerraformExecutor) error {
 	progress.Report(ctx, "Running terraform init ...")
-	err = tfExec.Init(ctx)
-	if err != nil {
-		return nil, err
-	}
-
-	return nil, nil
+	return tfExec.Init(ctx)
 }
@@ -0,0 +1,123 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package command
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/go-multierror"
+	lsctx "github.com/hashicorp/terraform-ls/internal/context"
+	"github.com/hashicorp/terraform-ls/internal/job"
+	"github.com/hashicorp/terraform-ls/internal/langserver/cmd"
+	"github.com/hashicorp/terraform-ls/internal/langserver/progress"
+	"github.com/hashicorp/terraform-ls/internal/state"
+)
+
+// ModuleInitHandler runs terraform init in the given module
+// and re-indexes the module manifest, installed provider versions
+// and provider schemas once init finishes.
+//
+// Both init and indexing are reported within a single progress.
+func (h *CmdHandler) ModuleInitHandler(ctx context.Context, args cmd.CommandArgs) (interface{}, error) {
+	tfExec, dirHandle, err := h.initExecutor(ctx, args)
+	if err != nil {
+		return nil, err
+	}
+
+	progress.Begin(ctx, "Initializing")
+	defer func() {
+		progress.End(ctx, "Finished")
+	}()
+
+	err = runInit(ctx, tfExec)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make(job.IDs, 0)
+	var errs *multierror.Error
+
+	progress.Report(ctx, "Parsing module manifest ...")
+	manifestIds, err := h.Indexer.ModuleManifestChanged(ctx, dirHandle)
+	if err != nil {
+		errs = multierror.Append(errs, err)
+	}
+	ids = append(ids, manifestIds...)
+
+	progress.Report(ctx, "Obtaining provider versions and schemas ...")
+	providerIds, err := h.Indexer.PluginLockChanged(ctx, dirHandle)
+	if err != nil {
+		errs = multierror.Append(errs, err)
+	}
+	ids = append(ids, providerIds...)
+
+	stopReporting := reportSchemaLoadProgress(ctx, h.StateStore.ProviderSchemas, dirHandle.Path())
+	err = h.StateStore.JobStore.WaitForJobs(ctx, ids...)
+	stopReporting()
+	if err != nil {
+		errs = multierror.Append(errs, err)
+	}
+
+	return nil, errs.ErrorOrNil()
+}
+
+// reportSchemaLoadProgress reports any changes in the state of loading
+// provider schemas for the module at the given path via $/progress
+// until the returned function is called.
+func reportSchemaLoadProgress(ctx context.Context, schemaStore *state.ProviderSchemaStore, modPath string) func() {
+	if _, ok := lsctx.ProgressToken(ctx); !ok {
+		return func() {}
+	}
+
+	reported := make(map[string]state.SchemaLoadState, 0)
+	loads, watchCh, err := schemaStore.SchemaLoads()
+	if err != nil {
+		return func() {}
+	}
+	for _, load := range loads {
+		if load.ModulePath != modPath {
+			continue
+		}
+		reported[load.Address.String()] = load.State
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-watchCh:
+			}
+
+			loads, watchCh, err = schemaStore.SchemaLoads()
+			if err != nil {
+				return
+			}
+			for _, load := range loads {
+				if load.ModulePath != modPath {
+					continue
+				}
+				addr := load.Address.String()
+				if s, ok := reported[addr]; ok && s == load.State {
+					continue
+				}
+				reported[addr] = load.State
+
+				stateName := strings.TrimPrefix(load.State.String(), "SchemaLoadState")
+				progress.Report(ctx, fmt.Sprintf("%s schema: %s", load.Address.ForDisplay(),
+					strings.ToLower(stateName)))
+			}
+		}
+	}()
+
+	return func() {
+		cancel()
+		<-done
+	}
+}
@@ -45,5 +45,11 @@ func (svc *service) TextDocumentComplete(ctx context.Context, params lsp.Complet
 	svc.logger.Printf("Looking for candidates at %q -> %#v", doc.Filename, pos)
 	candidates, err := d.CompletionAtPos(ctx, doc.Filename, pos)
 	svc.logger.Printf("received candidates: %#v", candidates)
-	return ilsp.ToCompletionList(candidates, cc.TextDocument), err
+	list = ilsp.ToCompletionList(candidates, cc.TextDocument)
+
+	if supportsLazyDocumentation(cc.TextDocument.Completion) {
+		svc.completionDocs.Defer(list.Items)
+	}
+
+	return list, err
 }
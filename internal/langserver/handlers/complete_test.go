@@ -21,6 +21,7 @@ import (
 	tfjson "github.com/hashicorp/terraform-json"
 	"github.com/hashicorp/terraform-ls/internal/langserver"
 	"github.com/hashicorp/terraform-ls/internal/langserver/session"
+	lsp "github.com/hashicorp/terraform-ls/internal/protocol"
 	"github.com/hashicorp/terraform-ls/internal/state"
 	"github.com/hashicorp/terraform-ls/internal/terraform/exec"
 	"github.com/hashicorp/terraform-ls/internal/walker"
@@ -929,6 +930,98 @@ var testModuleSchemaOutput = `{
 	}
 }`
 
+func TestModuleCompletion_terraformBlock(t *testing.T) {
+	tmpDir := TempDir(t)
+	InitPluginCache(t, tmpDir.Path())
+
+	cfg := "terraform {\n  required_version = \">= 1.5.0\"\n\n}\n"
+	err := os.WriteFile(filepath.Join(tmpDir.Path(), "main.tf"), []byte(cfg), 0o755)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ss, err := state.NewStateStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+	wc := walker.NewWalkerCollector()
+
+	ls := langserver.NewLangServerMock(t, NewMockSession(&MockSessionInput{
+		TerraformCalls: &exec.TerraformMockCalls{
+			PerWorkDir: map[string][]*mock.Call{
+				tmpDir.Path(): validTfMockCalls(),
+			},
+		},
+		StateStore:      ss,
+		WalkerCollector: wc,
+	}))
+	stop := ls.Start(t)
+	defer stop()
+
+	ls.Call(t, &langserver.CallRequest{
+		Method: "initialize",
+		ReqParams: fmt.Sprintf(`{
+		"capabilities": {},
+		"rootUri": %q,
+		"processId": 12345
+	}`, tmpDir.URI)})
+	waitForWalkerPath(t, ss, wc, tmpDir)
+	ls.Notify(t, &langserver.CallRequest{
+		Method:    "initialized",
+		ReqParams: "{}",
+	})
+	ls.Call(t, &langserver.CallRequest{
+		Method: "textDocument/didOpen",
+		ReqParams: fmt.Sprintf(`{
+		"textDocument": {
+			"version": 0,
+			"languageId": "terraform",
+			"text": %q,
+			"uri": "%s/main.tf"
+		}
+	}`, cfg, tmpDir.URI)})
+	waitForAllJobs(t, ss)
+
+	rsp := ls.Call(t, &langserver.CallRequest{
+		Method: "textDocument/completion",
+		ReqParams: fmt.Sprintf(`{
+			"textDocument": {
+				"uri": "%s/main.tf"
+			},
+			"position": {
+				"character": 0,
+				"line": 2
+			}
+		}`, tmpDir.URI)})
+
+	var list lsp.CompletionList
+	err = json.Unmarshal(rsp.Result, &list)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	labels := make(map[string]bool, len(list.Items))
+	for _, item := range list.Items {
+		labels[item.Label] = true
+	}
+	// required_version is already declared, so it is not offered
+	expectedLabels := []string{
+		"backend",
+		"cloud",
+		"experiments",
+		"provider_meta",
+		"required_providers",
+	}
+	for _, label := range expectedLabels {
+		if !labels[label] {
+			t.Fatalf("expected %q to be completed, given: %#v", label, labels)
+		}
+	}
+	if labels["required_version"] {
+		t.Fatalf("not expected declared %q to be completed", "required_version")
+	}
+}
+
 func TestVarsCompletion_withValidData(t *testing.T) {
 	tmpDir := TempDir(t)
 	InitPluginCache(t, tmpDir.Path())
@@ -1052,6 +1145,164 @@ func TestVarsCompletion_withValidData(t *testing.T) {
 		}`)
 }
 
+func TestVarsCompletion_lazyDocumentation(t *testing.T) {
+	tmpDir := TempDir(t)
+	InitPluginCache(t, tmpDir.Path())
+
+	var testSchema tfjson.ProviderSchemas
+	err := json.Unmarshal([]byte(testModuleSchemaOutput), &testSchema)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ss, err := state.NewStateStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+	wc := walker.NewWalkerCollector()
+
+	ls := langserver.NewLangServerMock(t, NewMockSession(&MockSessionInput{
+		TerraformCalls: &exec.TerraformMockCalls{
+			PerWorkDir: map[string][]*mock.Call{
+				tmpDir.Path(): {
+					{
+						Method:        "Version",
+						Repeatability: 1,
+						Arguments: []interface{}{
+							mock.AnythingOfType(""),
+						},
+						ReturnArguments: []interface{}{
+							version.Must(version.NewVersion("0.12.0")),
+							nil,
+							nil,
+						},
+					},
+					{
+						Method:        "GetExecPath",
+						Repeatability: 1,
+						ReturnArguments: []interface{}{
+							"",
+						},
+					},
+					{
+						Method:        "ProviderSchemas",
+						Repeatability: 1,
+						Arguments: []interface{}{
+							mock.AnythingOfType(""),
+						},
+						ReturnArguments: []interface{}{
+							&testSchema,
+							nil,
+						},
+					},
+				},
+			},
+		},
+		StateStore:      ss,
+		WalkerCollector: wc,
+	}))
+	stop := ls.Start(t)
+	defer stop()
+
+	ls.Call(t, &langserver.CallRequest{
+		Method: "initialize",
+		ReqParams: fmt.Sprintf(`{
+		"capabilities": {
+			"textDocument": {
+				"completion": {
+					"completionItem": {
+						"resolveSupport": {
+							"properties": ["documentation"]
+						}
+					}
+				}
+			}
+		},
+		"rootUri": %q,
+		"processId": 12345
+	}`, tmpDir.URI)})
+	waitForWalkerPath(t, ss, wc, tmpDir)
+	ls.Notify(t, &langserver.CallRequest{
+		Method:    "initialized",
+		ReqParams: "{}",
+	})
+	ls.Call(t, &langserver.CallRequest{
+		Method: "textDocument/didOpen",
+		ReqParams: fmt.Sprintf(`{
+		"textDocument": {
+			"version": 0,
+			"languageId": "terraform",
+			"text": "variable \"test\" {\n type=string\n description=\"Test variable\"\n}\n",
+			"uri": "%s/variables.tf"
+		}
+	}`, tmpDir.URI)})
+	ls.Call(t, &langserver.CallRequest{
+		Method: "textDocument/didOpen",
+		ReqParams: fmt.Sprintf(`{
+		"textDocument": {
+			"version": 0,
+			"languageId": "terraform-vars",
+			"uri": "%s/terraform.tfvars"
+		}
+	}`, tmpDir.URI)})
+	waitForAllJobs(t, ss)
+
+	ls.CallAndExpectResponse(t, &langserver.CallRequest{
+		Method: "textDocument/completion",
+		ReqParams: fmt.Sprintf(`{
+			"textDocument": {
+				"uri": "%s/terraform.tfvars"
+			},
+			"position": {
+				"character": 0,
+				"line": 0
+			}
+		}`, tmpDir.URI)}, `{
+			"jsonrpc": "2.0",
+			"id": 4,
+			"result": {
+				"isIncomplete": false,
+				"items": [
+					{
+						"label": "test",
+						"kind": 10,
+						"detail": "required, string",
+						"insertTextFormat":1,
+						"textEdit": {
+							"range": {"start":{"line":0,"character":0}, "end":{"line":0,"character":0}},
+							"newText":"test"
+						},
+						"data": {
+							"documentation_id": "1-0"
+						}
+					}
+				]
+			}
+		}`)
+
+	ls.CallAndExpectResponse(t, &langserver.CallRequest{
+		Method: "completionItem/resolve",
+		ReqParams: `{
+			"label": "test",
+			"kind": 10,
+			"data": {
+				"documentation_id": "1-0"
+			}
+		}`,
+	}, `{
+			"jsonrpc": "2.0",
+			"id": 5,
+			"result": {
+				"label": "test",
+				"kind": 10,
+				"documentation": "Test variable",
+				"data": {
+					"documentation_id": "1-0"
+				}
+			}
+		}`)
+}
+
 func TestCompletion_moduleWithValidData(t *testing.T) {
 	tmpDir := TempDir(t)
 
@@ -0,0 +1,66 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package handlers
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/hashicorp/hcl-lang/lang"
+	lsp "github.com/hashicorp/terraform-ls/internal/protocol"
+)
+
+// completionDocs holds documentation of items from the latest
+// completion request, such that it can be attached lazily
+// on completionItem/resolve instead of being sent upfront.
+type completionDocs struct {
+	mu      sync.Mutex
+	request uint64
+	docs    map[string]string
+}
+
+// Defer moves documentation out of the given items into the cache,
+// replacing documentation cached from any previous request.
+func (cd *completionDocs) Defer(items []lsp.CompletionItem) {
+	cd.mu.Lock()
+	defer cd.mu.Unlock()
+
+	cd.request++
+	cd.docs = make(map[string]string, 0)
+
+	for i, item := range items {
+		if item.Documentation == "" {
+			continue
+		}
+
+		id := fmt.Sprintf("%d-%d", cd.request, i)
+		cd.docs[id] = item.Documentation
+
+		hook, _ := item.Data.(*lang.ResolveHook)
+		items[i].Documentation = ""
+		items[i].Data = lsp.CompletionItemData{
+			ResolveHook:     hook,
+			DocumentationID: id,
+		}
+	}
+}
+
+// Get returns documentation for the given ID
+// if it belongs to the latest completion request.
+func (cd *completionDocs) Get(id string) (string, bool) {
+	cd.mu.Lock()
+	defer cd.mu.Unlock()
+
+	doc, ok := cd.docs[id]
+	return doc, ok
+}
+
+func supportsLazyDocumentation(caps lsp.CompletionClientCapabilities) bool {
+	for _, property := range caps.CompletionItem.ResolveSupport.Properties {
+		if property == "documentation" {
+			return true
+		}
+	}
+	return false
+}
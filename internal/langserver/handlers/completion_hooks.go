@@ -15,6 +15,7 @@ func (s *service) AppendCompletionHooks(decoderContext decoder.DecoderContext) {
 		ModStore:       s.modStore,
 		RegistryClient: s.registryClient,
 		Logger:         s.logger,
+		DataDir:        s.dataDir,
 	}
 
 	credentials, ok := algolia.CredentialsFromContext(s.srvCtx)
@@ -18,12 +18,22 @@ func (svc *service) CompletionItemResolve(ctx context.Context, params lsp.Comple
 		return params, err
 	}
 
-	if params.ResolveHook == nil {
+	if params.Data == nil {
+		return params, nil
+	}
+
+	if params.Data.DocumentationID != "" {
+		if doc, ok := svc.completionDocs.Get(params.Data.DocumentationID); ok {
+			params.Documentation = doc
+		}
+	}
+
+	if params.Data.ResolveHook == nil {
 		return params, nil
 	}
 
 	unresolvedCandidate := decoder.UnresolvedCandidate{
-		ResolveHook: params.ResolveHook,
+		ResolveHook: params.Data.ResolveHook,
 	}
 
 	resolvedCandidate, err := svc.decoder.ResolveCandidate(ctx, unresolvedCandidate)
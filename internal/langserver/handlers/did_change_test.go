@@ -167,3 +167,97 @@ module "app" {
 		t.Fatalf("unexpected text: %s", diff)
 	}
 }
+
+func TestLangServer_didChange_utf16PositionEncodingNegotiated(t *testing.T) {
+	tmpDir := TempDir(t)
+
+	ss, err := state.NewStateStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+	wc := walker.NewWalkerCollector()
+
+	ls := langserver.NewLangServerMock(t, NewMockSession(&MockSessionInput{
+		TerraformCalls: &exec.TerraformMockCalls{
+			PerWorkDir: map[string][]*mock.Call{
+				tmpDir.Path(): validTfMockCalls(),
+			},
+		},
+		StateStore:      ss,
+		WalkerCollector: wc,
+	}))
+	stop := ls.Start(t)
+	defer stop()
+
+	ls.Call(t, &langserver.CallRequest{
+		Method: "initialize",
+		ReqParams: fmt.Sprintf(`{
+	    "capabilities": {
+	        "general": {
+	            "positionEncodings": ["utf-8", "utf-16"]
+	        }
+	    },
+	    "rootUri": %q,
+	    "processId": 12345
+	}`, tmpDir.URI)})
+	waitForWalkerPath(t, ss, wc, tmpDir)
+	ls.Notify(t, &langserver.CallRequest{
+		Method:    "initialized",
+		ReqParams: "{}",
+	})
+
+	// UTF-16 is negotiated even if the client prefers UTF-8,
+	// so 😀 occupies 2 columns rather than 4
+	originalText := `variable "emoji" {
+  default = "😀x"
+}
+`
+	ls.Call(t, &langserver.CallRequest{
+		Method: "textDocument/didOpen",
+		ReqParams: fmt.Sprintf(`{
+    "textDocument": {
+        "languageId": "terraform",
+        "version": 0,
+        "uri": "%s/main.tf",
+        "text": %q
+    }
+}`, tmpDir.URI, originalText)})
+	waitForAllJobs(t, ss)
+
+	ls.Call(t, &langserver.CallRequest{
+		Method: "textDocument/didChange",
+		ReqParams: fmt.Sprintf(`{
+    "textDocument": {
+        "version": 1,
+        "uri": "%s/main.tf"
+    },
+    "contentChanges": [
+        {
+            "text": "y",
+            "range": {
+                "start": {
+                    "line": 1,
+                    "character": 15
+                },
+                "end": {
+                    "line": 1,
+                    "character": 16
+                }
+            }
+        }
+    ]
+}`, tmpDir.URI)})
+
+	dh := document.HandleFromPath(filepath.Join(tmpDir.Path(), "main.tf"))
+	doc, err := ss.DocumentStore.GetDocument(dh)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectedText := `variable "emoji" {
+  default = "😀y"
+}
+`
+	if diff := cmp.Diff(expectedText, string(doc.Text)); diff != "" {
+		t.Fatalf("unexpected text: %s", diff)
+	}
+}
@@ -29,7 +29,7 @@ func (svc *service) DidChangeWatchedFiles(ctx context.Context, params lsp.DidCha
 		// This is necessary because clients may not send delete notifications
 		// for individual nested files when the parent directory is deleted.
 		// VS Code / vscode-languageclient behaves this way.
-		if modUri, ok := datadir.ModuleUriFromDataDir(rawURI); ok {
+		if modUri, ok := datadir.ModuleUriFromDataDir(svc.dataDir, rawURI); ok {
 			modHandle := document.DirHandleFromURI(modUri)
 			if change.Type == protocol.Deleted {
 				// This is unlikely to happen unless the user manually removed files
@@ -42,7 +42,7 @@ func (svc *service) DidChangeWatchedFiles(ctx context.Context, params lsp.DidCha
 			continue
 		}
 
-		if modUri, ok := datadir.ModuleUriFromPluginLockFile(rawURI); ok {
+		if modUri, ok := datadir.ModuleUriFromPluginLockFile(svc.dataDir, rawURI); ok {
 			if change.Type == protocol.Deleted {
 				// This is unlikely to happen unless the user manually removed files
 				// See https://github.com/hashicorp/terraform/issues/30005
@@ -67,7 +67,7 @@ func (svc *service) DidChangeWatchedFiles(ctx context.Context, params lsp.DidCha
 			continue
 		}
 
-		if modUri, ok := datadir.ModuleUriFromModuleLockFile(rawURI); ok {
+		if modUri, ok := datadir.ModuleUriFromModuleLockFile(svc.dataDir, rawURI); ok {
 			modHandle := document.DirHandleFromURI(modUri)
 			if change.Type == protocol.Deleted {
 				// This is unlikely to happen unless the user manually removed files
@@ -135,14 +135,16 @@ func (svc *service) DidChangeWatchedFiles(ctx context.Context, params lsp.DidCha
 
 			// if the parent directory exists, we just need to
 			// reparse the module after a file was deleted from it
+			// but we defer that in case the file is being renamed
+			// and a creation of the new file follows shortly
 			dirHandle := document.DirHandleFromPath(parentDir)
-			jobIds, err := svc.indexer.DocumentChanged(ctx, dirHandle)
-			if err != nil {
-				svc.logger.Printf("error parsing module (%q deleted): %s", rawURI, err)
-				continue
-			}
-
-			ids = append(ids, jobIds...)
+			reparseCtx := context.WithoutCancel(ctx)
+			svc.pendingDeletions.Defer(parentDir, renameWindow, func() {
+				_, err := svc.indexer.DocumentChanged(reparseCtx, dirHandle)
+				if err != nil {
+					svc.logger.Printf("error parsing module (%q deleted): %s", rawURI, err)
+				}
+			})
 		}
 
 		if change.Type == protocol.Changed {
@@ -205,6 +207,11 @@ func (svc *service) DidChangeWatchedFiles(ctx context.Context, params lsp.DidCha
 					continue
 				}
 			} else {
+				if svc.pendingDeletions.Cancel(ph.DirHandle.Path()) {
+					// reparsing below also reflects the preceding deletion
+					svc.logger.Printf("treating %q as renamed file", rawURI)
+				}
+
 				jobIds, err := svc.indexer.DocumentChanged(ctx, ph.DirHandle)
 				if err != nil {
 					svc.logger.Printf("error parsing module (%q created): %s", rawURI, err)
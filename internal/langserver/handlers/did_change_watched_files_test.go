@@ -9,6 +9,7 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/hashicorp/go-version"
@@ -18,6 +19,7 @@ import (
 	"github.com/hashicorp/hc-install/src"
 	tfjson "github.com/hashicorp/terraform-json"
 	"github.com/hashicorp/terraform-ls/internal/document"
+	"github.com/hashicorp/terraform-ls/internal/job"
 	"github.com/hashicorp/terraform-ls/internal/langserver"
 	"github.com/hashicorp/terraform-ls/internal/state"
 	"github.com/hashicorp/terraform-ls/internal/terraform/exec"
@@ -370,6 +372,7 @@ func TestLangServer_DidChangeWatchedFiles_delete_file(t *testing.T) {
         }
     ]
 }`, TempDir(t).URI)})
+	waitForDeferredReparse(t, ss)
 
 	// Verify main.tf was deleted
 	mod, err = ss.Modules.ModuleByPath(tmpDir.Path())
@@ -383,6 +386,124 @@ func TestLangServer_DidChangeWatchedFiles_delete_file(t *testing.T) {
 	}
 }
 
+func TestLangServer_DidChangeWatchedFiles_rename_file(t *testing.T) {
+	tmpDir := TempDir(t)
+
+	InitPluginCache(t, tmpDir.Path())
+
+	mainSrc := `variable "main" {}
+`
+	err := os.WriteFile(filepath.Join(tmpDir.Path(), "main.tf"), []byte(mainSrc), 0o755)
+	if err != nil {
+		t.Fatal(err)
+	}
+	originalSrc := `variable "original" {
+  default = "foo"
+}
+`
+	err = os.WriteFile(filepath.Join(tmpDir.Path(), "original.tf"), []byte(originalSrc), 0o755)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ss, err := state.NewStateStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+	wc := walker.NewWalkerCollector()
+
+	ls := langserver.NewLangServerMock(t, NewMockSession(&MockSessionInput{
+		TerraformCalls: &exec.TerraformMockCalls{
+			PerWorkDir: map[string][]*mock.Call{
+				tmpDir.Path(): validTfMockCalls(),
+			},
+		},
+		StateStore:      ss,
+		WalkerCollector: wc,
+	}))
+	stop := ls.Start(t)
+	defer stop()
+
+	ls.Call(t, &langserver.CallRequest{
+		Method: "initialize",
+		ReqParams: fmt.Sprintf(`{
+	    "capabilities": {},
+	    "rootUri": %q,
+	    "processId": 12345
+	}`, tmpDir.URI)})
+	waitForWalkerPath(t, ss, wc, tmpDir)
+	ls.Notify(t, &langserver.CallRequest{
+		Method:    "initialized",
+		ReqParams: "{}",
+	})
+
+	// Rename original.tf on disk
+	err = os.Rename(filepath.Join(tmpDir.Path(), "original.tf"), filepath.Join(tmpDir.Path(), "renamed.tf"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Clients report a rename as a deletion followed by a creation
+	ls.Call(t, &langserver.CallRequest{
+		Method: "workspace/didChangeWatchedFiles",
+		ReqParams: fmt.Sprintf(`{
+    "changes": [
+        {
+            "uri": "%s/original.tf",
+            "type": 3
+        },
+        {
+            "uri": "%s/renamed.tf",
+            "type": 1
+        }
+    ]
+}`, tmpDir.URI, tmpDir.URI)})
+
+	// Verify the rename is reflected straight away
+	mod, err := ss.Modules.ModuleByPath(tmpDir.Path())
+	if err != nil {
+		t.Fatal(err)
+	}
+	parsedFiles := mod.ParsedModuleFiles.AsMap()
+	if _, ok := parsedFiles["original.tf"]; ok {
+		t.Fatalf("not expected file to be parsed: %q", "original.tf")
+	}
+	parsedFile, ok := parsedFiles["renamed.tf"]
+	if !ok {
+		t.Fatalf("file not parsed: %q", "renamed.tf")
+	}
+	if diff := cmp.Diff(originalSrc, string(parsedFile.Bytes)); diff != "" {
+		t.Fatalf("bytes mismatch for %q: %s", "renamed.tf", diff)
+	}
+
+	// Verify the deletion did not cause the module to be reparsed again
+	jobsBefore, err := ss.JobStore.ListAllJobs()
+	if err != nil {
+		t.Fatal(err)
+	}
+	waitForDeferredReparse(t, ss)
+	jobsAfter, err := ss.JobStore.ListAllJobs()
+	if err != nil {
+		t.Fatal(err)
+	}
+	knownJobs := make(map[job.ID]bool, len(jobsBefore))
+	for _, id := range jobsBefore {
+		knownJobs[id] = true
+	}
+	for _, id := range jobsAfter {
+		if !knownJobs[id] {
+			t.Fatalf("unexpected job enqueued after rename: %s", id)
+		}
+	}
+}
+
+// waitForDeferredReparse waits until reparsing of a module, which is deferred
+// after a file deletion to detect renames, is dispatched and finished.
+func waitForDeferredReparse(t *testing.T, ss *state.StateStore) {
+	time.Sleep(2 * renameWindow)
+	waitForAllJobs(t, ss)
+}
+
 func TestLangServer_DidChangeWatchedFiles_change_dir(t *testing.T) {
 	tmpDir := TempDir(t)
 
@@ -83,5 +83,17 @@ func (svc *service) removeIndexedModule(ctx context.Context, modURI string) {
 	if len(callers) == 0 {
 		err = svc.modStore.Remove(modHandle.Path())
 		svc.logger.Printf("failed to remove module: %s", err)
+
+		err = svc.stateStore.ProviderSchemas.RemoveSchemaLoadStates(modHandle.Path())
+		if err != nil {
+			svc.logger.Printf("failed to remove schema load states of module: %s", err)
+		}
+
+		if svc.schemaCache != nil {
+			svc.schemaCache.Remove(modHandle.Path())
+		}
+		if svc.hoverCache != nil {
+			svc.hoverCache.Remove(modHandle.Path())
+		}
 	}
 }
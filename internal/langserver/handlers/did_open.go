@@ -9,13 +9,32 @@ import (
 
 	"github.com/creachadair/jrpc2"
 	"github.com/hashicorp/terraform-ls/internal/document"
+	"github.com/hashicorp/terraform-ls/internal/job"
 	lsp "github.com/hashicorp/terraform-ls/internal/protocol"
 	"github.com/hashicorp/terraform-ls/internal/state"
+	"github.com/hashicorp/terraform-ls/internal/terraform/datadir"
 	"github.com/hashicorp/terraform-ls/internal/uri"
 )
 
 func (svc *service) TextDocumentDidOpen(ctx context.Context, params lsp.DidOpenTextDocumentParams) error {
-	docURI := string(params.TextDocument.URI)
+	modHandle, err := svc.openDocument(ctx, params.TextDocument)
+	if err != nil {
+		return err
+	}
+
+	jobIds, err := svc.indexOpenedModule(ctx, modHandle)
+	if err != nil {
+		return err
+	}
+
+	return svc.stateStore.JobStore.WaitForJobs(ctx, jobIds...)
+}
+
+// openDocument opens the given document and makes sure its module
+// is tracked, without indexing the module, so that indexing can be
+// scheduled once for multiple documents of the same module.
+func (svc *service) openDocument(ctx context.Context, item lsp.TextDocumentItem) (document.DirHandle, error) {
+	docURI := string(item.URI)
 
 	// URIs are always checked during initialize request, but
 	// we still allow single-file mode, therefore invalid URIs
@@ -26,15 +45,15 @@ func (svc *service) TextDocumentDidOpen(ctx context.Context, params lsp.DidOpenT
 			Message: fmt.Sprintf("Ignoring workspace folder (unsupport or invalid URI) %s."+
 				" This is most likely bug, please report it.", docURI),
 		})
-		return fmt.Errorf("invalid URI: %s", docURI)
+		return document.DirHandle{}, fmt.Errorf("invalid URI: %s", docURI)
 	}
 
 	dh := document.HandleFromURI(docURI)
 
-	err := svc.stateStore.DocumentStore.OpenDocument(dh, params.TextDocument.LanguageID,
-		int(params.TextDocument.Version), []byte(params.TextDocument.Text))
+	err := svc.stateStore.DocumentStore.OpenDocument(dh, item.LanguageID,
+		int(item.Version), []byte(item.Text))
 	if err != nil {
-		return err
+		return document.DirHandle{}, err
 	}
 
 	mod, err := svc.modStore.ModuleByPath(dh.Dir.Path())
@@ -42,34 +61,75 @@ func (svc *service) TextDocumentDidOpen(ctx context.Context, params lsp.DidOpenT
 		if state.IsModuleNotFound(err) {
 			err = svc.modStore.Add(dh.Dir.Path())
 			if err != nil {
-				return err
+				return document.DirHandle{}, err
 			}
 			mod, err = svc.modStore.ModuleByPath(dh.Dir.Path())
 			if err != nil {
-				return err
+				return document.DirHandle{}, err
 			}
 		} else {
-			return err
+			return document.DirHandle{}, err
 		}
 	}
 
 	svc.logger.Printf("opened module: %s", mod.Path)
 
+	if datadir.IsInstalledModulePath(svc.dataDir, mod.Path) {
+		svc.notifyInstalledModuleOpened(ctx, mod.Path)
+	}
+
+	return document.DirHandleFromPath(mod.Path), nil
+}
+
+// indexOpenedModule schedules indexing of the module
+// with (newly) open documents and returns IDs of the scheduled jobs.
+func (svc *service) indexOpenedModule(ctx context.Context, modHandle document.DirHandle) (job.IDs, error) {
 	// We reparse because the file being opened may not match
 	// (originally parsed) content on the disk
 	// TODO: Do this only if we can verify the file differs?
-	modHandle := document.DirHandleFromPath(mod.Path)
 	jobIds, err := svc.indexer.DocumentOpened(ctx, modHandle)
 	if err != nil {
-		return err
+		return jobIds, err
 	}
 
 	if svc.singleFileMode {
-		err = svc.stateStore.WalkerPaths.EnqueueDir(ctx, modHandle)
+		walkHandle := modHandle
+		if rootPath, isInstalled := datadir.InstalledModuleRootPath(svc.dataDir, modHandle.Path()); isInstalled {
+			// Installed modules are indexed as part of the root module
+			// along with its module manifest and provider lock file
+			walkHandle = document.DirHandleFromPath(rootPath)
+		}
+		err = svc.stateStore.WalkerPaths.EnqueueDir(ctx, walkHandle)
 		if err != nil {
-			return err
+			return jobIds, err
 		}
 	}
 
-	return svc.stateStore.JobStore.WaitForJobs(ctx, jobIds...)
+	return jobIds, nil
+}
+
+// notifyInstalledModuleOpened informs the user that the opened document
+// belongs to a module installed by Terraform, and where it was installed from,
+// since any changes are discarded when modules are reinstalled.
+func (svc *service) notifyInstalledModuleOpened(ctx context.Context, modPath string) {
+	msg := fmt.Sprintf("%s is a module installed by Terraform, "+
+		"changes will be overwritten when modules are reinstalled.", modPath)
+
+	mc, ok, err := svc.modStore.InstalledModuleCall(modPath)
+	if err != nil {
+		svc.logger.Printf("failed to find installed module call for %q: %s", modPath, err)
+	}
+	if ok && mc.SourceAddr != nil {
+		source := mc.SourceAddr.ForDisplay()
+		if mc.Version != nil {
+			source = fmt.Sprintf("%s (%s)", source, mc.Version)
+		}
+		msg = fmt.Sprintf("%s is module %q installed by Terraform from %s, "+
+			"changes will be overwritten when modules are reinstalled.", modPath, mc.LocalName, source)
+	}
+
+	jrpc2.ServerFromContext(ctx).Notify(ctx, "window/showMessage", &lsp.ShowMessageParams{
+		Type:    lsp.Info,
+		Message: msg,
+	})
 }
@@ -5,6 +5,7 @@ package handlers
 
 import (
 	"fmt"
+	"os"
 	"path/filepath"
 	"testing"
 
@@ -103,3 +104,108 @@ func TestLangServer_didOpenLanguageIdStored(t *testing.T) {
 		t.Fatalf("unexpected version: %s", diff)
 	}
 }
+
+func TestLangServer_didOpenInstalledModule(t *testing.T) {
+	rootPath := t.TempDir()
+	rootHandle := document.DirHandleFromPath(rootPath)
+	modPath := filepath.Join(rootPath, ".terraform", "modules", "vpc")
+	modHandle := document.DirHandleFromPath(modPath)
+
+	err := os.MkdirAll(modPath, 0o755)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rootCfg := `module "vpc" {
+  source  = "terraform-aws-modules/vpc/aws"
+  version = "5.0.0"
+}
+`
+	err = os.WriteFile(filepath.Join(rootPath, "main.tf"), []byte(rootCfg), 0o755)
+	if err != nil {
+		t.Fatal(err)
+	}
+	manifest := `{"Modules":[
+  {"Key":"","Source":"","Dir":"."},
+  {"Key":"vpc","Source":"registry.terraform.io/terraform-aws-modules/vpc/aws","Version":"5.0.0","Dir":".terraform/modules/vpc"}
+]}`
+	err = os.WriteFile(filepath.Join(rootPath, ".terraform", "modules", "modules.json"), []byte(manifest), 0o755)
+	if err != nil {
+		t.Fatal(err)
+	}
+	modCfg := `variable "name" {
+}
+`
+	err = os.WriteFile(filepath.Join(modPath, "main.tf"), []byte(modCfg), 0o755)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ss, err := state.NewStateStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+	wc := walker.NewWalkerCollector()
+
+	ls := langserver.NewLangServerMock(t, NewMockSession(&MockSessionInput{
+		TerraformCalls: &exec.TerraformMockCalls{
+			PerWorkDir: map[string][]*mock.Call{
+				rootPath: validTfMockCalls(),
+				modPath:  validTfMockCalls(),
+			},
+		},
+		StateStore:      ss,
+		WalkerCollector: wc,
+	}))
+	stop := ls.Start(t)
+	defer stop()
+
+	ls.Call(t, &langserver.CallRequest{
+		Method: "initialize",
+		ReqParams: fmt.Sprintf(`{
+		"capabilities": {},
+		"rootUri": %q,
+		"processId": 12345
+	}`, rootHandle.URI)})
+	waitForWalkerPath(t, ss, wc, rootHandle)
+	ls.Notify(t, &langserver.CallRequest{
+		Method:    "initialized",
+		ReqParams: "{}",
+	})
+	ls.Call(t, &langserver.CallRequest{
+		Method: "textDocument/didOpen",
+		ReqParams: fmt.Sprintf(`{
+		"textDocument": {
+			"version": 0,
+			"languageId": "terraform",
+			"text": %q,
+			"uri": "%s/main.tf"
+		}
+	}`, modCfg, modHandle.URI)})
+	waitForAllJobs(t, ss)
+
+	mod, err := ss.Modules.ModuleByPath(modPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := mod.Meta.Variables["name"]; !ok {
+		t.Fatalf("expected installed module to be indexed, variables: %#v", mod.Meta.Variables)
+	}
+
+	mc, ok, err := ss.Modules.InstalledModuleCall(modPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !ok {
+		t.Fatal("expected installed module call to be found")
+	}
+	if mc.LocalName != "vpc" {
+		t.Fatalf("unexpected module call name: %q", mc.LocalName)
+	}
+	expectedSource := "registry.terraform.io/terraform-aws-modules/vpc/aws"
+	if mc.SourceAddr.String() != expectedSource {
+		t.Fatalf("unexpected source: %q, expected: %q", mc.SourceAddr.String(), expectedSource)
+	}
+	if mc.Version.String() != "5.0.0" {
+		t.Fatalf("unexpected version: %q", mc.Version)
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/hashicorp/terraform-ls/internal/langserver/handlers/command"
 	ilsp "github.com/hashicorp/terraform-ls/internal/lsp"
 	lsp "github.com/hashicorp/terraform-ls/internal/protocol"
+	"github.com/hashicorp/terraform-ls/internal/terraform/ast"
 )
 
 func (svc *service) TextDocumentDidSave(ctx context.Context, params lsp.DidSaveTextDocumentParams) error {
@@ -21,6 +22,13 @@ func (svc *service) TextDocumentDidSave(ctx context.Context, params lsp.DidSaveT
 	if !expFeatures.ValidateOnSave {
 		return nil
 	}
+	validationOptions, err := lsctx.ValidationOptions(ctx)
+	if err != nil {
+		return err
+	}
+	if !validationOptions.IsSourceEnabled(ast.TerraformValidateSource) {
+		return nil
+	}
 
 	dh := ilsp.HandleFromDocumentURI(params.TextDocument.URI)
 
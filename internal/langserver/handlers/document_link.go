@@ -8,6 +8,7 @@ import (
 
 	ilsp "github.com/hashicorp/terraform-ls/internal/lsp"
 	lsp "github.com/hashicorp/terraform-ls/internal/protocol"
+	"github.com/hashicorp/terraform-ls/internal/terraform/ast"
 )
 
 func (svc *service) TextDocumentLink(ctx context.Context, params lsp.DocumentLinkParams) ([]lsp.DocumentLink, error) {
@@ -36,5 +37,12 @@ func (svc *service) TextDocumentLink(ctx context.Context, params lsp.DocumentLin
 		return nil, err
 	}
 
+	mod, err := svc.modStore.ModuleByPath(doc.Dir.Path())
+	if err == nil {
+		if file, ok := mod.ParsedModuleFiles[ast.ModFilename(doc.Filename)]; ok {
+			links = append(links, providerSourceLinks(ctx, file)...)
+		}
+	}
+
 	return ilsp.Links(links, cc.TextDocument.DocumentLink), nil
 }
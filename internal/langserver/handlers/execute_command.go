@@ -18,10 +18,12 @@ func cmdHandlers(svc *service) cmd.Handlers {
 	cmdHandler := &command.CmdHandler{
 		StateStore: svc.stateStore,
 		Logger:     svc.logger,
+		Indexer:    svc.indexer,
 	}
 	return cmd.Handlers{
 		cmd.Name("rootmodules"):        removedHandler("use module.callers instead"),
 		cmd.Name("module.callers"):     cmdHandler.ModuleCallersHandler,
+		cmd.Name("module.init"):        cmdHandler.ModuleInitHandler,
 		cmd.Name("terraform.init"):     cmdHandler.TerraformInitHandler,
 		cmd.Name("terraform.validate"): cmdHandler.TerraformValidateHandler,
 		cmd.Name("module.calls"):       cmdHandler.ModuleCallsHandler,
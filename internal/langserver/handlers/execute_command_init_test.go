@@ -4,17 +4,23 @@
 package handlers
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/creachadair/jrpc2"
+	"github.com/google/go-cmp/cmp"
 	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/terraform-exec/tfexec"
 	"github.com/hashicorp/terraform-ls/internal/langserver"
 	"github.com/hashicorp/terraform-ls/internal/langserver/cmd"
 	"github.com/hashicorp/terraform-ls/internal/state"
 	"github.com/hashicorp/terraform-ls/internal/terraform/exec"
 	"github.com/hashicorp/terraform-ls/internal/walker"
+	tfaddr "github.com/hashicorp/terraform-registry-address"
 	"github.com/stretchr/testify/mock"
 )
 
@@ -247,3 +253,112 @@ func TestLangServer_workspaceExecuteCommand_init_error(t *testing.T) {
 		"arguments": ["uri=%s"]
 	}`, cmd.Name("terraform.init"), testFileURI)}, jrpc2.SystemError.Err())
 }
+
+func TestLangServer_workspaceExecuteCommand_moduleInit(t *testing.T) {
+	tmpDir := TempDir(t)
+	testFileURI := fmt.Sprintf("%s/main.tf", tmpDir.URI)
+
+	tfMockCalls := []*mock.Call{
+		{
+			Method:        "Version",
+			Repeatability: 1,
+			Arguments: []interface{}{
+				mock.AnythingOfType(""),
+			},
+			ReturnArguments: []interface{}{
+				version.Must(version.NewVersion("0.12.0")),
+				nil,
+				nil,
+			},
+		},
+		{
+			Method:        "GetExecPath",
+			Repeatability: 1,
+			ReturnArguments: []interface{}{
+				"",
+			},
+		},
+		{
+			Method:        "Init",
+			Repeatability: 1,
+			Arguments: []interface{}{
+				mock.AnythingOfType(""),
+			},
+			ReturnArguments: []interface{}{
+				func(ctx context.Context, opts ...tfexec.InitOption) error {
+					// simulate init installing a provider
+					lockFile := `provider "registry.terraform.io/hashicorp/aws" {
+  version = "4.23.0"
+}
+`
+					return os.WriteFile(filepath.Join(tmpDir.Path(), ".terraform.lock.hcl"),
+						[]byte(lockFile), 0o644)
+				},
+			},
+		},
+	}
+
+	ss, err := state.NewStateStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+	wc := walker.NewWalkerCollector()
+
+	ls := langserver.NewLangServerMock(t, NewMockSession(&MockSessionInput{
+		TerraformCalls: &exec.TerraformMockCalls{
+			PerWorkDir: map[string][]*mock.Call{
+				tmpDir.Path(): tfMockCalls,
+			},
+		},
+		StateStore:      ss,
+		WalkerCollector: wc,
+	}))
+	stop := ls.Start(t)
+	defer stop()
+
+	ls.Call(t, &langserver.CallRequest{
+		Method: "initialize",
+		ReqParams: fmt.Sprintf(`{
+	    "capabilities": {},
+	    "rootUri": %q,
+		"processId": 12345
+	}`, tmpDir.URI)})
+	waitForWalkerPath(t, ss, wc, tmpDir)
+	ls.Notify(t, &langserver.CallRequest{
+		Method:    "initialized",
+		ReqParams: "{}",
+	})
+	ls.Call(t, &langserver.CallRequest{
+		Method: "textDocument/didOpen",
+		ReqParams: fmt.Sprintf(`{
+		"textDocument": {
+			"version": 0,
+			"languageId": "terraform",
+			"text": "variable \"name\" {}",
+			"uri": %q
+		}
+	}`, testFileURI)})
+	waitForAllJobs(t, ss)
+
+	ls.CallAndExpectResponse(t, &langserver.CallRequest{
+		Method: "workspace/executeCommand",
+		ReqParams: fmt.Sprintf(`{
+		"command": %q,
+		"arguments": ["uri=%s"]
+	}`, cmd.Name("module.init"), tmpDir.URI)}, `{
+		"jsonrpc": "2.0",
+		"id": 3,
+		"result": null
+	}`)
+
+	mod, err := ss.Modules.ModuleByPath(tmpDir.Path())
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectedProviders := state.InstalledProviders{
+		tfaddr.MustParseProviderSource("hashicorp/aws"): version.Must(version.NewVersion("4.23.0")),
+	}
+	if diff := cmp.Diff(expectedProviders, mod.InstalledProviders); diff != "" {
+		t.Fatalf("unexpected installed providers after init: %s", diff)
+	}
+}
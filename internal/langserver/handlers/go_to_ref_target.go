@@ -52,10 +52,21 @@ func (svc *service) goToReferenceTarget(ctx context.Context, params lsp.TextDocu
 		return nil, err
 	}
 
+	svc.waitForReferences(ctx, doc.Dir.Path())
+
 	path := lang.Path{
 		Path:       doc.Dir.Path(),
 		LanguageID: doc.LanguageID,
 	}
 
-	return svc.decoder.ReferenceTargetsForOriginAtPos(path, doc.Filename, pos)
+	targets, err := svc.decoder.ReferenceTargetsForOriginAtPos(path, doc.Filename, pos)
+	if err != nil {
+		return nil, err
+	}
+
+	if doc.LanguageID == ilsp.Terraform.String() {
+		targets = append(targets, svc.moduleOutputTargets(doc.Dir.Path(), doc.Filename, pos)...)
+	}
+
+	return targets, nil
 }
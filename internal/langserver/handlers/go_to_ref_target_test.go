@@ -10,10 +10,12 @@ import (
 	"path/filepath"
 	"testing"
 
+	"github.com/google/go-cmp/cmp"
 	"github.com/hashicorp/go-version"
 	tfjson "github.com/hashicorp/terraform-json"
 	"github.com/hashicorp/terraform-ls/internal/document"
 	"github.com/hashicorp/terraform-ls/internal/langserver"
+	lsp "github.com/hashicorp/terraform-ls/internal/protocol"
 	"github.com/hashicorp/terraform-ls/internal/state"
 	"github.com/hashicorp/terraform-ls/internal/terraform/exec"
 	"github.com/hashicorp/terraform-ls/internal/walker"
@@ -517,6 +519,110 @@ func TestDefinition_moduleInputToVariable(t *testing.T) {
 		}`, modHandle.URI))
 }
 
+func TestDefinition_nestedModuleOutputs(t *testing.T) {
+	modPath, err := filepath.Abs(filepath.Join("testdata", "nested-module-outputs"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	modHandle := document.DirHandleFromPath(modPath)
+
+	ss, err := state.NewStateStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+	wc := walker.NewWalkerCollector()
+
+	ls := langserver.NewLangServerMock(t, NewMockSession(&MockSessionInput{
+		TerraformCalls: &exec.TerraformMockCalls{
+			AnyWorkDir: validTfMockCalls(),
+		},
+		StateStore:      ss,
+		WalkerCollector: wc,
+	}))
+	stop := ls.Start(t)
+	defer stop()
+
+	ls.Call(t, &langserver.CallRequest{
+		Method: "initialize",
+		ReqParams: fmt.Sprintf(`{
+			"capabilities": {},
+			"rootUri": %q,
+			"processId": 12345
+	}`, modHandle.URI)})
+	waitForWalkerPath(t, ss, wc, modHandle)
+	ls.Notify(t, &langserver.CallRequest{
+		Method:    "initialized",
+		ReqParams: "{}",
+	})
+
+	content, err := ioutil.ReadFile(filepath.Join(modPath, "main.tf"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ls.Call(t, &langserver.CallRequest{
+		Method: "textDocument/didOpen",
+		ReqParams: fmt.Sprintf(`{
+		"textDocument": {
+			"version": 0,
+			"languageId": "terraform",
+			"text": %q,
+			"uri": "%s/main.tf"
+		}
+	}`, string(content), modHandle.URI)})
+	waitForAllJobs(t, ss)
+
+	rsp := ls.Call(t, &langserver.CallRequest{
+		Method: "textDocument/definition",
+		ReqParams: fmt.Sprintf(`{
+			"textDocument": {
+				"uri": "%s/main.tf"
+			},
+			"position": {
+				"line": 5,
+				"character": 20
+			}
+		}`, modHandle.URI)})
+
+	var locations []lsp.Location
+	err = json.Unmarshal(rsp.Result, &locations)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// module.a.from_b re-exports module.b.from_c,
+	// which in turn re-exports module.c.value
+	expectedLocations := []lsp.Location{
+		{
+			URI: lsp.DocumentURI(modHandle.URI + "/a/main.tf"),
+			Range: lsp.Range{
+				Start: lsp.Position{Line: 4, Character: 0},
+				End:   lsp.Position{Line: 6, Character: 1},
+			},
+		},
+		{
+			URI: lsp.DocumentURI(modHandle.URI + "/a/b/main.tf"),
+			Range: lsp.Range{
+				Start: lsp.Position{Line: 4, Character: 0},
+				End:   lsp.Position{Line: 6, Character: 1},
+			},
+		},
+		{
+			URI: lsp.DocumentURI(modHandle.URI + "/a/b/c/main.tf"),
+			Range: lsp.Range{
+				Start: lsp.Position{Line: 0, Character: 0},
+				End:   lsp.Position{Line: 2, Character: 1},
+			},
+		},
+	}
+	if len(locations) < len(expectedLocations) {
+		t.Fatalf("expected at least %d locations, given: %#v", len(expectedLocations), locations)
+	}
+	chain := locations[len(locations)-len(expectedLocations):]
+	if diff := cmp.Diff(expectedLocations, chain); diff != "" {
+		t.Fatalf("unexpected locations: %s", diff)
+	}
+}
+
 func TestDeclaration_basic(t *testing.T) {
 	tmpDir := TempDir(t)
 
@@ -51,11 +51,14 @@ func initializeResponse(t *testing.T, commandPrefix string) string {
 				"referencesProvider": true,
 				"documentSymbolProvider": true,
 				"codeActionProvider": {
-					"codeActionKinds": ["source.formatAll.terraform"]
+					"codeActionKinds": ["quickfix", "refactor.extract", "refactor.rewrite", "source.formatAll.terraform"]
 				},
 				"codeLensProvider": {},
 				"documentLinkProvider": {},
 				"workspaceSymbolProvider": true,
+				"callHierarchyProvider": true,
+				"renameProvider": true,
+				"linkedEditingRangeProvider": true,
 				"documentFormattingProvider": true,
 				"executeCommandProvider": {
 					"commands": %s,
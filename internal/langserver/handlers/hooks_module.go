@@ -6,12 +6,19 @@ package handlers
 import (
 	"context"
 	"fmt"
+	"sort"
+	"sync"
 
 	"github.com/hashicorp/terraform-ls/internal/langserver/diagnostics"
 	"github.com/hashicorp/terraform-ls/internal/langserver/notifier"
 	"github.com/hashicorp/terraform-ls/internal/langserver/session"
+	lsp "github.com/hashicorp/terraform-ls/internal/protocol"
+	"github.com/hashicorp/terraform-ls/internal/settings"
 	"github.com/hashicorp/terraform-ls/internal/state"
 	"github.com/hashicorp/terraform-ls/internal/telemetry"
+	"github.com/hashicorp/terraform-ls/internal/terraform/ast"
+	op "github.com/hashicorp/terraform-ls/internal/terraform/module/operation"
+	tfaddr "github.com/hashicorp/terraform-registry-address"
 	"github.com/hashicorp/terraform-schema/backend"
 )
 
@@ -38,7 +45,7 @@ func sendModuleTelemetry(store *state.StateStore, telemetrySender telemetry.Send
 func moduleTelemetryData(mod *state.Module, ch state.ModuleChanges, store *state.StateStore) (map[string]interface{}, bool) {
 	properties := make(map[string]interface{})
 	hasChanged := ch.CoreRequirements || ch.Backend || ch.ProviderRequirements ||
-		ch.TerraformVersion || ch.InstalledProviders
+		ch.TerraformVersion || ch.InstalledProviders || ch.Metrics
 
 	if !hasChanged {
 		return properties, false
@@ -129,6 +136,16 @@ func moduleTelemetryData(mod *state.Module, ch state.ModuleChanges, store *state
 		properties["installedProviders"] = installedProviders
 	}
 
+	if mod.MetricsState == op.OpStateLoaded {
+		// anonymize exact counts by only reporting buckets
+		properties["metrics.resources"] = countBucket(mod.Metrics.Resources)
+		properties["metrics.dataSources"] = countBucket(mod.Metrics.DataSources)
+		properties["metrics.variables"] = countBucket(mod.Metrics.Variables)
+		properties["metrics.outputs"] = countBucket(mod.Metrics.Outputs)
+		properties["metrics.moduleCalls"] = countBucket(mod.Metrics.ModuleCalls)
+		properties["metrics.providers"] = countBucket(mod.Metrics.Providers)
+	}
+
 	if !hasChanged {
 		return nil, false
 	}
@@ -142,7 +159,24 @@ func moduleTelemetryData(mod *state.Module, ch state.ModuleChanges, store *state
 	return properties, true
 }
 
-func updateDiagnostics(dNotifier *diagnostics.Notifier) notifier.Hook {
+// countBucket returns a range which the given count falls into
+func countBucket(count int) string {
+	switch {
+	case count == 0:
+		return "0"
+	case count < 10:
+		return "1-9"
+	case count < 50:
+		return "10-49"
+	case count < 100:
+		return "50-99"
+	case count < 500:
+		return "100-499"
+	}
+	return "500+"
+}
+
+func updateDiagnostics(dNotifier *diagnostics.Notifier, validationOptions settings.ValidationOptions) notifier.Hook {
 	return func(ctx context.Context, changes state.ModuleChanges) error {
 		if changes.Diagnostics {
 			mod, err := notifier.ModuleFromContext(ctx)
@@ -154,9 +188,21 @@ func updateDiagnostics(dNotifier *diagnostics.Notifier) notifier.Hook {
 			diags.EmptyRootDiagnostic()
 
 			for source, dm := range mod.ModuleDiagnostics {
+				if !validationOptions.IsSourceEnabled(source) {
+					continue
+				}
 				diags.Append(source, dm.AutoloadedOnly().AsMap())
 			}
 			for source, dm := range mod.VarsDiagnostics {
+				if !validationOptions.IsSourceEnabled(source) {
+					continue
+				}
+				if source == ast.OrphanedVariablesSource {
+					// files without a module are reported whether
+					// or not Terraform would load them automatically
+					diags.Append(source, dm.AsMap())
+					continue
+				}
 				diags.Append(source, dm.AutoloadedOnly().AsMap())
 			}
 
@@ -228,3 +274,103 @@ func refreshSemanticTokens(clientRequester session.ClientCaller) notifier.Hook {
 		return nil
 	}
 }
+
+// notifiedSchemaFailures tracks providers for which the user
+// was already told that their schema could not be loaded,
+// to avoid repeating the same message for every affected module.
+type notifiedSchemaFailures struct {
+	mu        sync.Mutex
+	providers map[tfaddr.Provider]bool
+}
+
+// Add records the given provider and reports whether
+// it was not recorded before.
+func (nsf *notifiedSchemaFailures) Add(pAddr tfaddr.Provider) bool {
+	nsf.mu.Lock()
+	defer nsf.mu.Unlock()
+
+	if nsf.providers == nil {
+		nsf.providers = make(map[tfaddr.Provider]bool)
+	}
+	if nsf.providers[pAddr] {
+		return false
+	}
+	nsf.providers[pAddr] = true
+	return true
+}
+
+// providerModulesReader looks up modules using a given provider
+type providerModulesReader interface {
+	ModulePathsByProvider(addr tfaddr.Provider) ([]string, error)
+}
+
+func notifySchemaLoadFailures(clientNotifier session.ClientNotifier, schemaStore *state.ProviderSchemaStore,
+	modReader providerModulesReader, notified *notifiedSchemaFailures) notifier.Hook {
+	return func(ctx context.Context, changes state.ModuleChanges) error {
+		if !changes.ProviderSchemaErr {
+			return nil
+		}
+
+		mod, err := notifier.ModuleFromContext(ctx)
+		if err != nil {
+			return err
+		}
+
+		schemaErr := mod.PreloadEmbeddedSchemaErr
+		if schemaErr == nil {
+			schemaErr = mod.ProviderSchemaErr
+		}
+		if schemaErr == nil {
+			return nil
+		}
+
+		// Schemas may still be available from another source
+		// (e.g. embedded schemas when CLI failed to obtain them)
+		// so we only report providers which are left without any.
+		missing, err := schemaStore.MissingSchemas(mod.Meta.ProviderRequirements)
+		if err != nil {
+			return err
+		}
+		sort.SliceStable(missing, func(i, j int) bool {
+			return missing[i].String() < missing[j].String()
+		})
+
+		for _, pAddr := range missing {
+			if !notified.Add(pAddr) {
+				continue
+			}
+
+			impact := ""
+			modPaths, err := modReader.ModulePathsByProvider(pAddr)
+			if err != nil {
+				return err
+			}
+			if len(modPaths) > 1 {
+				impact = fmt.Sprintf(" in %d modules", len(modPaths))
+			}
+
+			// Errors of the module may span multiple providers,
+			// so we prefer the one recorded for this provider.
+			pErr := schemaErr
+			load, err := schemaStore.SchemaLoad(mod.Path, pAddr)
+			if err != nil {
+				return err
+			}
+			if load != nil && load.Err != nil {
+				pErr = load.Err
+			}
+
+			err = clientNotifier.Notify(ctx, "window/showMessage", &lsp.ShowMessageParams{
+				Type: lsp.Warning,
+				Message: fmt.Sprintf("Unable to load schema for provider %s: %s. "+
+					"Completion, hover and validation for the provider may be limited%s.",
+					pAddr.ForDisplay(), pErr, impact),
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+}
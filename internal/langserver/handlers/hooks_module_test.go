@@ -0,0 +1,279 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/terraform-ls/internal/document"
+	"github.com/hashicorp/terraform-ls/internal/langserver/diagnostics"
+	"github.com/hashicorp/terraform-ls/internal/langserver/notifier"
+	lsp "github.com/hashicorp/terraform-ls/internal/protocol"
+	"github.com/hashicorp/terraform-ls/internal/settings"
+	"github.com/hashicorp/terraform-ls/internal/state"
+	"github.com/hashicorp/terraform-ls/internal/terraform/ast"
+	"github.com/hashicorp/terraform-ls/internal/uri"
+	tfaddr "github.com/hashicorp/terraform-registry-address"
+	tfmod "github.com/hashicorp/terraform-schema/module"
+)
+
+func TestNotifySchemaLoadFailures_oncePerProvider(t *testing.T) {
+	ss, err := state.NewStateStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	awsAddr := tfaddr.MustParseProviderSource("hashicorp/aws")
+	googleAddr := tfaddr.MustParseProviderSource("hashicorp/google")
+
+	firstPath, secondPath := t.TempDir(), t.TempDir()
+	mods := map[string]*state.Module{
+		firstPath: {
+			Path: firstPath,
+			Meta: state.ModuleMetadata{
+				ProviderRequirements: tfmod.ProviderRequirements{
+					awsAddr:    version.MustConstraints(version.NewConstraint(">= 1.0")),
+					googleAddr: version.MustConstraints(version.NewConstraint(">= 1.0")),
+				},
+			},
+			ProviderSchemaErr: errors.New("aws: failed to decode schema; google: failed to obtain schema"),
+		},
+		secondPath: {
+			Path: secondPath,
+			Meta: state.ModuleMetadata{
+				ProviderRequirements: tfmod.ProviderRequirements{
+					awsAddr: version.MustConstraints(version.NewConstraint(">= 1.0")),
+				},
+			},
+			PreloadEmbeddedSchemaErr: errors.New("failed to decode schema"),
+		},
+	}
+	err = ss.ProviderSchemas.SetSchemaLoadState(firstPath, awsAddr, state.SchemaLoadStateFailed,
+		errors.New("failed to decode schema"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = ss.ProviderSchemas.SetSchemaLoadState(firstPath, googleAddr, state.SchemaLoadStateFailed,
+		errors.New("failed to obtain schema"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	schemaErrChange := state.ModuleChanges{ProviderSchemaErr: true}
+	modStore := newMockChangeStore(mods, []state.ModuleChangeBatch{
+		{DirHandle: document.DirHandleFromPath(firstPath), Changes: schemaErrChange},
+		{DirHandle: document.DirHandleFromPath(secondPath), Changes: schemaErrChange},
+		// repeated failure of the same module
+		{DirHandle: document.DirHandleFromPath(firstPath), Changes: schemaErrChange},
+		// unrelated change
+		{DirHandle: document.DirHandleFromPath(secondPath), Changes: state.ModuleChanges{Diagnostics: true}},
+	})
+
+	clientNotifier := &mockClientNotifier{}
+	n := notifier.NewNotifier(modStore, []notifier.Hook{
+		notifySchemaLoadFailures(clientNotifier, ss.ProviderSchemas, modStore, &notifiedSchemaFailures{}),
+	})
+
+	ctx, cancelFunc := context.WithCancel(context.Background())
+	t.Cleanup(cancelFunc)
+	n.Start(ctx)
+	<-modStore.drained
+
+	messages := clientNotifier.Messages()
+	if len(messages) != 2 {
+		t.Fatalf("expected 2 messages, given %d: %#v", len(messages), messages)
+	}
+	for i, pAddr := range []tfaddr.Provider{awsAddr, googleAddr} {
+		if messages[i].Type != lsp.Warning {
+			t.Fatalf("expected warning, given: %#v", messages[i])
+		}
+		if !strings.Contains(messages[i].Message, pAddr.ForDisplay()) {
+			t.Fatalf("expected message %d to mention %s, given: %q",
+				i, pAddr.ForDisplay(), messages[i].Message)
+		}
+	}
+	// only the error of the given provider is reported
+	if strings.Contains(messages[0].Message, "obtain") {
+		t.Fatalf("expected message not to mention error of other providers, given: %q", messages[0].Message)
+	}
+	if !strings.Contains(messages[1].Message, "failed to obtain schema") {
+		t.Fatalf("expected message to mention error of the provider, given: %q", messages[1].Message)
+	}
+	if strings.Contains(messages[1].Message, "decode") {
+		t.Fatalf("expected message not to mention error of other providers, given: %q", messages[1].Message)
+	}
+	// aws is required by both modules, google only by the first one
+	if !strings.Contains(messages[0].Message, "in 2 modules") {
+		t.Fatalf("expected message to mention number of affected modules, given: %q", messages[0].Message)
+	}
+	if strings.Contains(messages[1].Message, "modules") {
+		t.Fatalf("expected message not to mention number of affected modules, given: %q", messages[1].Message)
+	}
+}
+
+func TestUpdateDiagnostics_orphanedVarsFileNotAutoloaded(t *testing.T) {
+	modPath := t.TempDir()
+	diag := &hcl.Diagnostic{
+		Severity: hcl.DiagWarning,
+		Summary:  "test",
+		Subject: &hcl.Range{
+			Filename: "prod.tfvars",
+			Start:    hcl.InitialPos,
+			End:      hcl.Pos{Line: 1, Column: 5, Byte: 4},
+		},
+	}
+	mods := map[string]*state.Module{
+		modPath: {
+			Path: modPath,
+			VarsDiagnostics: ast.SourceVarsDiags{
+				ast.OrphanedVariablesSource: ast.VarsDiags{
+					"prod.tfvars": hcl.Diagnostics{diag},
+				},
+				ast.HCLParsingSource: ast.VarsDiags{
+					"other.tfvars": hcl.Diagnostics{diag},
+				},
+			},
+		},
+	}
+	modStore := newMockChangeStore(mods, []state.ModuleChangeBatch{
+		{
+			DirHandle: document.DirHandleFromPath(modPath),
+			Changes:   state.ModuleChanges{Diagnostics: true},
+		},
+	})
+
+	diagsNotifier := &mockDiagnosticsNotifier{
+		published: make(chan lsp.PublishDiagnosticsParams, 10),
+	}
+	dNotifier := diagnostics.NewNotifier(diagsNotifier, log.New(io.Discard, "", 0), 0)
+	n := notifier.NewNotifier(modStore, []notifier.Hook{
+		updateDiagnostics(dNotifier, settings.ValidationOptions{}),
+	})
+
+	ctx, cancelFunc := context.WithCancel(context.Background())
+	t.Cleanup(cancelFunc)
+	n.Start(ctx)
+	<-modStore.drained
+
+	prodURI := lsp.DocumentURI(uri.FromPath(filepath.Join(modPath, "prod.tfvars")))
+	otherURI := lsp.DocumentURI(uri.FromPath(filepath.Join(modPath, "other.tfvars")))
+	timeout := time.After(5 * time.Second)
+	for {
+		select {
+		case params := <-diagsNotifier.published:
+			if params.URI == otherURI {
+				t.Fatalf("unexpected diagnostics published for non-autoloaded file: %#v", params)
+			}
+			if params.URI != prodURI {
+				continue
+			}
+			if len(params.Diagnostics) != 1 {
+				t.Fatalf("expected 1 diagnostic for %s, given: %#v", prodURI, params.Diagnostics)
+			}
+			if params.Diagnostics[0].Source != ast.OrphanedVariablesSource.String() {
+				t.Fatalf("expected diagnostic from %s, given: %#v",
+					ast.OrphanedVariablesSource, params.Diagnostics[0])
+			}
+			return
+		case <-timeout:
+			t.Fatalf("timed out waiting for diagnostics of %s", prodURI)
+		}
+	}
+}
+
+type mockDiagnosticsNotifier struct {
+	published chan lsp.PublishDiagnosticsParams
+}
+
+func (mdn *mockDiagnosticsNotifier) Notify(ctx context.Context, method string, params interface{}) error {
+	if method != "textDocument/publishDiagnostics" {
+		return fmt.Errorf("unexpected method: %q", method)
+	}
+	mdn.published <- params.(lsp.PublishDiagnosticsParams)
+	return nil
+}
+
+type mockChangeStore struct {
+	mods    map[string]*state.Module
+	batches chan state.ModuleChangeBatch
+	drained chan struct{}
+	once    sync.Once
+}
+
+func newMockChangeStore(mods map[string]*state.Module, batches []state.ModuleChangeBatch) *mockChangeStore {
+	ch := make(chan state.ModuleChangeBatch, len(batches))
+	for _, batch := range batches {
+		ch <- batch
+	}
+	return &mockChangeStore{
+		mods:    mods,
+		batches: ch,
+		drained: make(chan struct{}),
+	}
+}
+
+func (mcs *mockChangeStore) AwaitNextChangeBatch(ctx context.Context) (state.ModuleChangeBatch, error) {
+	select {
+	case batch := <-mcs.batches:
+		return batch, nil
+	default:
+	}
+
+	// hooks for all batches have been called by now
+	mcs.once.Do(func() { close(mcs.drained) })
+	<-ctx.Done()
+	return state.ModuleChangeBatch{}, ctx.Err()
+}
+
+func (mcs *mockChangeStore) ModulePathsByProvider(addr tfaddr.Provider) ([]string, error) {
+	paths := make([]string, 0)
+	for path, mod := range mcs.mods {
+		if _, ok := mod.Meta.ProviderRequirements[addr]; ok {
+			paths = append(paths, path)
+		}
+	}
+	return paths, nil
+}
+
+func (mcs *mockChangeStore) ModuleByPath(path string) (*state.Module, error) {
+	mod, ok := mcs.mods[path]
+	if !ok {
+		return nil, fmt.Errorf("unexpected path: %q", path)
+	}
+	return mod, nil
+}
+
+type mockClientNotifier struct {
+	mu       sync.Mutex
+	messages []lsp.ShowMessageParams
+}
+
+func (mcn *mockClientNotifier) Notify(ctx context.Context, method string, params interface{}) error {
+	mcn.mu.Lock()
+	defer mcn.mu.Unlock()
+
+	if method != "window/showMessage" {
+		return fmt.Errorf("unexpected method: %q", method)
+	}
+	mcn.messages = append(mcn.messages, *params.(*lsp.ShowMessageParams))
+	return nil
+}
+
+func (mcn *mockClientNotifier) Messages() []lsp.ShowMessageParams {
+	mcn.mu.Lock()
+	defer mcn.mu.Unlock()
+
+	return mcn.messages
+}
@@ -6,6 +6,10 @@ package handlers
 import (
 	"context"
 
+	"github.com/hashicorp/hcl-lang/decoder"
+	"github.com/hashicorp/hcl-lang/lang"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/terraform-ls/internal/document"
 	ilsp "github.com/hashicorp/terraform-ls/internal/lsp"
 	lsp "github.com/hashicorp/terraform-ls/internal/protocol"
 )
@@ -32,8 +36,12 @@ func (svc *service) TextDocumentHover(ctx context.Context, params lsp.TextDocume
 		return nil, err
 	}
 
+	if hoverData, ok := svc.registryModuleHover(ctx, doc, pos); ok {
+		return ilsp.HoverData(hoverData, cc.TextDocument), nil
+	}
+
 	svc.logger.Printf("Looking for hover data at %q -> %#v", doc.Filename, pos)
-	hoverData, err := d.HoverAtPos(ctx, doc.Filename, pos)
+	hoverData, err := svc.hoverAtPos(ctx, d, doc, pos)
 	svc.logger.Printf("received hover data: %#v", hoverData)
 	if err != nil {
 		return nil, err
@@ -41,3 +49,18 @@ func (svc *service) TextDocumentHover(ctx context.Context, params lsp.TextDocume
 
 	return ilsp.HoverData(hoverData, cc.TextDocument), nil
 }
+
+// hoverAtPos reuses cached hover content of attribute names
+// within Terraform modules, where available.
+func (svc *service) hoverAtPos(ctx context.Context, d *decoder.PathDecoder, doc *document.Document, pos hcl.Pos) (*lang.HoverData, error) {
+	if svc.hoverCache == nil || doc.LanguageID != ilsp.Terraform.String() {
+		return d.HoverAtPos(ctx, doc.Filename, pos)
+	}
+
+	mod, err := svc.modStore.ModuleByPath(doc.Dir.Path())
+	if err != nil {
+		return d.HoverAtPos(ctx, doc.Filename, pos)
+	}
+
+	return svc.hoverCache.HoverAtPos(ctx, d, mod, doc.Filename, pos)
+}
@@ -0,0 +1,115 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/hcl-lang/lang"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/terraform-ls/internal/document"
+	"github.com/hashicorp/terraform-ls/internal/job"
+	ilsp "github.com/hashicorp/terraform-ls/internal/lsp"
+	"github.com/hashicorp/terraform-ls/internal/state"
+	"github.com/hashicorp/terraform-ls/internal/terraform/ast"
+	op "github.com/hashicorp/terraform-ls/internal/terraform/module/operation"
+	tfaddr "github.com/hashicorp/terraform-registry-address"
+)
+
+// registryModuleHover returns hover data describing the registry module
+// whose source address is at the given position, based on data cached
+// from the registry. If the data is not cached yet, it is requested
+// and hover falls back to the decoder until the data becomes available.
+func (svc *service) registryModuleHover(ctx context.Context, doc *document.Document, pos hcl.Pos) (*lang.HoverData, bool) {
+	if doc.LanguageID != ilsp.Terraform.String() {
+		return nil, false
+	}
+
+	mod, err := svc.modStore.ModuleByPath(doc.Dir.Path())
+	if err != nil {
+		return nil, false
+	}
+	f, ok := mod.ParsedModuleFiles[ast.ModFilename(doc.Filename)]
+	if !ok {
+		return nil, false
+	}
+	body, ok := f.Body.(*hclsyntax.Body)
+	if !ok {
+		return nil, false
+	}
+
+	for _, block := range body.Blocks {
+		if block.Type != "module" || len(block.Labels) != 1 {
+			continue
+		}
+		sourceAttr, ok := block.Body.Attributes["source"]
+		if !ok || !sourceAttr.Expr.Range().ContainsPos(pos) {
+			continue
+		}
+
+		mc, ok := mod.Meta.ModuleCalls[block.Labels[0]]
+		if !ok {
+			return nil, false
+		}
+		sourceAddr, ok := mc.SourceAddr.(tfaddr.Module)
+		if !ok {
+			// not a registry module
+			return nil, false
+		}
+
+		modData, err := svc.stateStore.RegistryModules.ModuleData(sourceAddr, mc.Version)
+		if err != nil {
+			svc.fetchRegistryModuleData(ctx, doc.Dir, sourceAddr)
+			return nil, false
+		}
+
+		return &lang.HoverData{
+			Content: lang.Markdown(registryModuleHoverContent(sourceAddr, mc.Version, modData)),
+			Range:   sourceAttr.Expr.Range(),
+		}, true
+	}
+
+	return nil, false
+}
+
+// fetchRegistryModuleData requests data of registry modules called
+// from the given module, unless a request is already in flight,
+// such that repeated hovers do not flood the job queue.
+func (svc *service) fetchRegistryModuleData(ctx context.Context, modHandle document.DirHandle, sourceAddr tfaddr.Module) {
+	pending, err := svc.stateStore.JobStore.HasPendingJobs(modHandle,
+		op.OpTypeGetModuleDataFromRegistry.String())
+	if err != nil {
+		svc.logger.Printf("failed to check pending registry data requests of %q: %s", sourceAddr, err)
+		return
+	}
+	if pending {
+		return
+	}
+
+	_, err = svc.indexer.FetchRegistryModuleData(ctx, modHandle, job.IDs{})
+	if err != nil {
+		svc.logger.Printf("failed to request registry data of %q: %s", sourceAddr, err)
+	}
+}
+
+func registryModuleHoverContent(addr tfaddr.Module, cons version.Constraints, modData *state.RegistryModuleData) string {
+	var content strings.Builder
+	fmt.Fprintf(&content, "**%s** (registry module)", addr.ForDisplay())
+
+	if modData.Description != "" {
+		fmt.Fprintf(&content, "\n\n%s", modData.Description)
+	}
+
+	if len(cons) > 0 {
+		fmt.Fprintf(&content, "\n\nLatest version matching `%s`: `%s`", cons, modData.Version)
+	} else {
+		fmt.Fprintf(&content, "\n\nLatest version: `%s`", modData.Version)
+	}
+
+	return content.String()
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/hashicorp/terraform-ls/internal/state"
 	"github.com/hashicorp/terraform-ls/internal/terraform/exec"
 	"github.com/hashicorp/terraform-ls/internal/walker"
+	tfaddr "github.com/hashicorp/terraform-registry-address"
 	"github.com/stretchr/testify/mock"
 )
 
@@ -263,3 +264,90 @@ func TestVarsHover_withValidData(t *testing.T) {
 			}
 		}`)
 }
+
+func TestHover_registryModuleSource(t *testing.T) {
+	tmpDir := TempDir(t)
+
+	ss, err := state.NewStateStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+	wc := walker.NewWalkerCollector()
+
+	sourceAddr, err := tfaddr.ParseModuleSource("hashicorp/consul/aws")
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = ss.RegistryModules.Cache(sourceAddr, version.Must(version.NewVersion("0.11.0")),
+		"Deploys a Consul cluster on AWS", nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ls := langserver.NewLangServerMock(t, NewMockSession(&MockSessionInput{
+		TerraformCalls: &exec.TerraformMockCalls{
+			PerWorkDir: map[string][]*mock.Call{
+				tmpDir.Path(): validTfMockCalls(),
+			},
+		},
+		StateStore:      ss,
+		WalkerCollector: wc,
+	}))
+	stop := ls.Start(t)
+	defer stop()
+
+	ls.Call(t, &langserver.CallRequest{
+		Method: "initialize",
+		ReqParams: fmt.Sprintf(`{
+		"capabilities": {
+			"textDocument": {
+				"hover": {
+					"contentFormat": ["markdown"]
+				}
+			}
+		},
+		"rootUri": %q,
+		"processId": 12345
+	}`, tmpDir.URI)})
+	waitForWalkerPath(t, ss, wc, tmpDir)
+	ls.Notify(t, &langserver.CallRequest{
+		Method:    "initialized",
+		ReqParams: "{}",
+	})
+	ls.Call(t, &langserver.CallRequest{
+		Method: "textDocument/didOpen",
+		ReqParams: fmt.Sprintf(`{
+		"textDocument": {
+			"version": 0,
+			"languageId": "terraform",
+			"text": "module \"consul\" {\n  source = \"hashicorp/consul/aws\"\n}\n",
+			"uri": "%s/main.tf"
+		}
+	}`, tmpDir.URI)})
+	waitForAllJobs(t, ss)
+
+	ls.CallAndExpectResponse(t, &langserver.CallRequest{
+		Method: "textDocument/hover",
+		ReqParams: fmt.Sprintf(`{
+			"textDocument": {
+				"uri": "%s/main.tf"
+			},
+			"position": {
+				"character": 16,
+				"line": 1
+			}
+		}`, tmpDir.URI)}, `{
+			"jsonrpc": "2.0",
+			"id": 3,
+			"result": {
+				"contents": {
+					"kind": "markdown",
+					"value": "**hashicorp/consul/aws** (registry module)\n\nDeploys a Consul cluster on AWS\n\nLatest version: `+"`0.11.0`"+`"
+				},
+				"range": {
+					"start": { "line":1, "character":11 },
+					"end": { "line":1, "character":33 }
+				}
+			}
+		}`)
+}
@@ -16,6 +16,7 @@ import (
 	lsp "github.com/hashicorp/terraform-ls/internal/protocol"
 	"github.com/hashicorp/terraform-ls/internal/settings"
 	"github.com/hashicorp/terraform-ls/internal/uri"
+	"github.com/hashicorp/terraform-ls/internal/walker"
 	"github.com/mitchellh/go-homedir"
 )
 
@@ -81,6 +82,10 @@ func (svc *service) Initialize(ctx context.Context, params lsp.InitializeParams)
 		return serverCaps, err
 	}
 
+	if posEncoding, ok := ilsp.NegotiatePositionEncoding(clientCaps); ok {
+		serverCaps.Capabilities.PositionEncoding = posEncoding
+	}
+
 	stCaps := clientCaps.TextDocument.SemanticTokens
 	caps := ilsp.SemanticTokensClientCapabilities{
 		SemanticTokensClientCapabilities: clientCaps.TextDocument.SemanticTokens,
@@ -108,7 +113,13 @@ func (svc *service) Initialize(ctx context.Context, params lsp.InitializeParams)
 	// set experimental feature flags
 	lsctx.SetExperimentalFeatures(ctx, out.Options.ExperimentalFeatures)
 	// set validation options for jobs
-	lsctx.SetValidationOptions(ctx, out.Options.Validation)
+	validationOptions := out.Options.Validation
+	if params.RootURI != "" && uri.IsURIValid(string(params.RootURI)) {
+		root := document.DirHandleFromURI(string(params.RootURI))
+		validationOptions.EnhancedValidationPaths = resolveEnhancedValidationPaths(ctx,
+			root.Path(), validationOptions.EnhancedValidationPaths)
+	}
+	lsctx.SetValidationOptions(ctx, validationOptions)
 
 	if len(out.UnusedKeys) > 0 {
 		jrpc2.ServerFromContext(ctx).Notify(ctx, "window/showMessage", &lsp.ShowMessageParams{
@@ -126,6 +137,8 @@ func (svc *service) Initialize(ctx context.Context, params lsp.InitializeParams)
 		})
 	}
 
+	svc.debugMode = cfgOpts.Debug
+
 	if params.RootURI == "" {
 		svc.singleFileMode = true
 		properties["root_uri"] = "file"
@@ -204,9 +217,12 @@ func getTelemetryProperties(out *settings.DecodedOptions) map[string]interface{}
 		"options.terraform.path":                          false,
 		"options.terraform.timeout":                       "",
 		"options.terraform.logFilePath":                   false,
+		"options.terraform.dataDir":                       false,
 		"options.validation.earlyValidation":              false,
-		"root_uri":                                        "dir",
-		"lsVersion":                                       "",
+		"options.validation.enhancedValidationPaths":      false,
+		"options.validation.interpolationOnlyHints":       false,
+		"root_uri":  "dir",
+		"lsVersion": "",
 	}
 
 	properties["options.rootModulePaths"] = len(out.Options.XLegacyModulePaths) > 0
@@ -217,10 +233,16 @@ func getTelemetryProperties(out *settings.DecodedOptions) map[string]interface{}
 	properties["options.experimentalFeatures.prefillRequiredFields"] = out.Options.ExperimentalFeatures.PrefillRequiredFields
 	properties["options.experimentalFeatures.validateOnSave"] = out.Options.ExperimentalFeatures.ValidateOnSave
 	properties["options.ignoreSingleFileWarning"] = out.Options.IgnoreSingleFileWarning
+	properties["options.debug"] = out.Options.Debug
 	properties["options.terraform.path"] = len(out.Options.Terraform.Path) > 0
 	properties["options.terraform.timeout"] = out.Options.Terraform.Timeout
 	properties["options.terraform.logFilePath"] = len(out.Options.Terraform.LogFilePath) > 0
+	properties["options.terraform.dataDir"] = len(out.Options.Terraform.DataDir) > 0
 	properties["options.validation.earlyValidation"] = out.Options.Validation.EnableEnhancedValidation
+	properties["options.validation.enhancedValidationPaths"] = len(out.Options.Validation.EnhancedValidationPaths) > 0
+	properties["options.validation.interpolationOnlyHints"] = out.Options.Validation.EnableInterpolationOnlyHints
+	properties["options.validation.reportOrphanedVarsFiles"] = out.Options.Validation.ReportOrphanedVarsFiles
+	properties["options.validation.strictBackendValidation"] = out.Options.Validation.StrictBackendValidation
 
 	return properties
 }
@@ -248,6 +270,9 @@ func initializeResult(ctx context.Context) lsp.InitializeResult {
 			DocumentFormattingProvider: true,
 			DocumentSymbolProvider:     true,
 			WorkspaceSymbolProvider:    true,
+			CallHierarchyProvider:      true,
+			RenameProvider:             true,
+			LinkedEditingRangeProvider: true,
 			Workspace: lsp.Workspace6Gn{
 				WorkspaceFolders: lsp.WorkspaceFolders5Gn{
 					Supported:           true,
@@ -344,14 +369,41 @@ func (svc *service) setupWalker(ctx context.Context, params lsp.InitializeParams
 		}
 	}
 
+	terraformIgnore, err := walker.LoadTerraformIgnore(root.Path())
+	if err != nil {
+		jrpc2.ServerFromContext(ctx).Notify(ctx, "window/showMessage", &lsp.ShowMessageParams{
+			Type:    lsp.Warning,
+			Message: fmt.Sprintf("Ignoring .terraformignore: %s", err),
+		})
+	}
+
 	svc.closedDirWalker.SetIgnoredDirectoryNames(options.Indexing.IgnoreDirectoryNames)
 	svc.closedDirWalker.SetIgnoredPaths(ignoredPaths)
+	svc.closedDirWalker.SetTerraformIgnore(terraformIgnore)
 	svc.openDirWalker.SetIgnoredDirectoryNames(options.Indexing.IgnoreDirectoryNames)
 	svc.openDirWalker.SetIgnoredPaths(ignoredPaths)
+	svc.openDirWalker.SetTerraformIgnore(terraformIgnore)
 
 	return nil
 }
 
+func resolveEnhancedValidationPaths(ctx context.Context, rootDir string, rawPaths []string) []string {
+	var paths []string
+	for _, rawPath := range rawPaths {
+		path, err := resolvePath(rootDir, rawPath)
+		if err != nil {
+			jrpc2.ServerFromContext(ctx).Notify(ctx, "window/showMessage", &lsp.ShowMessageParams{
+				Type: lsp.Warning,
+				Message: fmt.Sprintf("Unable to limit enhanced validation to path (unsupported or invalid URI): %s: %s",
+					rawPath, err),
+			})
+			continue
+		}
+		paths = append(paths, path)
+	}
+	return paths
+}
+
 func resolvePath(rootDir, rawPath string) (string, error) {
 	path, err := homedir.Expand(rawPath)
 	if err != nil {
@@ -4,7 +4,9 @@
 package handlers
 
 import (
+	"context"
 	"fmt"
+	"os"
 	"path/filepath"
 	"testing"
 
@@ -55,6 +57,60 @@ func TestInitialize_twice(t *testing.T) {
 	}`, tmpDir.URI)}, jrpc2.SystemError.Err())
 }
 
+func TestInitialize_skipsUnchangedDirsOnRewalkWithinSession(t *testing.T) {
+	tmpDir := TempDir(t)
+	err := os.WriteFile(filepath.Join(tmpDir.Path(), "main.tf"), []byte("variable \"foo\" {}\n"), 0o755)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ss, err := state.NewStateStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+	wc := walker.NewWalkerCollector()
+
+	ls := langserver.NewLangServerMock(t, NewMockSession(&MockSessionInput{
+		TerraformCalls: &exec.TerraformMockCalls{
+			PerWorkDir: map[string][]*mock.Call{
+				tmpDir.Path(): validTfMockCalls(),
+			},
+		},
+		StateStore:      ss,
+		WalkerCollector: wc,
+	}))
+	stop := ls.Start(t)
+	defer stop()
+
+	ls.Call(t, &langserver.CallRequest{
+		Method: "initialize",
+		ReqParams: fmt.Sprintf(`{
+		"capabilities": {},
+		"rootUri": %q,
+		"processId": 12345
+	}`, tmpDir.URI)})
+	waitForWalkerPath(t, ss, wc, tmpDir)
+
+	walkedIds := len(wc.JobIds())
+	if walkedIds == 0 {
+		t.Fatal("expected jobs to be enqueued on first walk")
+	}
+
+	// Fingerprints are only kept in memory, so skipping
+	// applies to directories walked again within the same session,
+	// e.g. when the workspace folder is added again.
+	err = ss.WalkerPaths.EnqueueDir(context.Background(), tmpDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	waitForWalkerPath(t, ss, wc, tmpDir)
+
+	if ids := wc.JobIds(); len(ids) != walkedIds {
+		t.Fatalf("expected no jobs to be enqueued for unchanged directory, %d given: %s",
+			len(ids)-walkedIds, ids[walkedIds:])
+	}
+}
+
 func TestInitialize_withIncompatibleTerraformVersion(t *testing.T) {
 	tmpDir := TempDir(t)
 
@@ -34,7 +34,7 @@ func (svc *service) setupWatchedFiles(ctx context.Context, caps lsp.DidChangeWat
 		return err
 	}
 
-	watchPatterns := datadir.PathGlobPatternsForWatching()
+	watchPatterns := datadir.PathGlobPatternsForWatching(svc.dataDir)
 	watchers := make([]lsp.FileSystemWatcher, len(watchPatterns))
 	for i, wp := range watchPatterns {
 		watchers[i] = lsp.FileSystemWatcher{
@@ -0,0 +1,63 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package handlers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/creachadair/jrpc2"
+	"github.com/hashicorp/terraform-ls/internal/document"
+	"github.com/hashicorp/terraform-ls/internal/uri"
+)
+
+const jobsVersion = 0
+
+type jobsParams struct {
+	URI string `json:"uri"`
+}
+
+type jobsResponse struct {
+	FormatVersion int       `json:"v"`
+	Jobs          []jobInfo `json:"jobs"`
+}
+
+type jobInfo struct {
+	ID        string   `json:"id"`
+	Type      string   `json:"type"`
+	State     string   `json:"state"`
+	DependsOn []string `json:"depends_on"`
+}
+
+// Jobs returns jobs scheduled for the given directory,
+// e.g. to help operators find out why a module is not indexed yet.
+func (svc *service) Jobs(ctx context.Context, params jobsParams) (jobsResponse, error) {
+	response := jobsResponse{
+		FormatVersion: jobsVersion,
+		Jobs:          make([]jobInfo, 0),
+	}
+
+	if params.URI == "" {
+		return response, fmt.Errorf("%w: expected uri to be set", jrpc2.InvalidParams.Err())
+	}
+	if !uri.IsURIValid(params.URI) {
+		return response, fmt.Errorf("%w: URI %q is not valid", jrpc2.InvalidParams.Err(), params.URI)
+	}
+
+	jobs, err := svc.stateStore.JobStore.JobsForDir(document.DirHandleFromURI(params.URI))
+	if err != nil {
+		return response, err
+	}
+
+	for _, j := range jobs {
+		response.Jobs = append(response.Jobs, jobInfo{
+			ID:        j.ID.String(),
+			Type:      j.Type,
+			State:     j.State.String(),
+			DependsOn: j.DependsOn.StringSlice(),
+		})
+	}
+
+	return response, nil
+}
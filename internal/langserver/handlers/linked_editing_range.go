@@ -0,0 +1,169 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package handlers
+
+import (
+	"context"
+	"sort"
+
+	"github.com/hashicorp/hcl-lang/lang"
+	"github.com/hashicorp/hcl-lang/reference"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	ilsp "github.com/hashicorp/terraform-ls/internal/lsp"
+	lsp "github.com/hashicorp/terraform-ls/internal/protocol"
+	"github.com/hashicorp/terraform-ls/internal/state"
+	"github.com/hashicorp/terraform-ls/internal/terraform/ast"
+)
+
+// linkedBlockTypes maps block types whose name label can be edited
+// together with its references to the root step of such references
+var linkedBlockTypes = map[string]string{
+	"variable": "var",
+	"module":   "module",
+}
+
+// LinkedEditingRange returns the range of the name of the variable
+// or module call at the given position, along with ranges of all
+// references to it within the same file, so that they can be edited together.
+func (svc *service) LinkedEditingRange(ctx context.Context, params lsp.LinkedEditingRangeParams) (*lsp.LinkedEditingRanges, error) {
+	dh := ilsp.HandleFromDocumentURI(params.TextDocument.URI)
+	doc, err := svc.stateStore.DocumentStore.GetDocument(dh)
+	if err != nil {
+		return nil, err
+	}
+	if doc.LanguageID != ilsp.Terraform.String() {
+		return nil, nil
+	}
+
+	pos, err := ilsp.HCLPositionFromLspPosition(params.Position, doc)
+	if err != nil {
+		return nil, err
+	}
+
+	mod, err := svc.modStore.ModuleByPath(doc.Dir.Path())
+	if err != nil {
+		return nil, err
+	}
+
+	root, name, ok := linkedSymbolAtPos(mod, doc.Filename, pos)
+	if !ok {
+		return nil, nil
+	}
+
+	// Editing references without the declaration would break them,
+	// so the name has to be declared in the same file
+	declRng, ok := declaredNameRange(mod, doc.Filename, root, name)
+	if !ok {
+		return nil, nil
+	}
+
+	ranges := []lsp.Range{ilsp.HCLRangeToLSP(declRng)}
+	for _, origin := range mod.RefOrigins {
+		if origin.OriginRange().Filename != doc.Filename {
+			continue
+		}
+		localOrigin, ok := origin.(reference.LocalOrigin)
+		if !ok || !originTargetsName(localOrigin, root, name) {
+			continue
+		}
+		rng, ok := refNameRangeInOrigin(mod, origin, root, name)
+		if !ok {
+			continue
+		}
+		ranges = append(ranges, ilsp.HCLRangeToLSP(rng))
+	}
+	sort.SliceStable(ranges, func(i, j int) bool {
+		if ranges[i].Start.Line != ranges[j].Start.Line {
+			return ranges[i].Start.Line < ranges[j].Start.Line
+		}
+		return ranges[i].Start.Character < ranges[j].Start.Character
+	})
+
+	return &lsp.LinkedEditingRanges{
+		Ranges: ranges,
+	}, nil
+}
+
+// linkedSymbolAtPos returns the root step and name of the symbol
+// whose name label, or a reference to which, is located at the given position
+func linkedSymbolAtPos(mod *state.Module, filename string, pos hcl.Pos) (string, string, bool) {
+	file, ok := mod.ParsedModuleFiles[ast.ModFilename(filename)]
+	if !ok {
+		return "", "", false
+	}
+	if body, ok := file.Body.(*hclsyntax.Body); ok {
+		for _, block := range body.Blocks {
+			root, ok := linkedBlockTypes[block.Type]
+			if !ok || len(block.Labels) != 1 {
+				continue
+			}
+			if block.LabelRanges[0].ContainsPos(pos) {
+				return root, block.Labels[0], true
+			}
+		}
+	}
+
+	for _, origin := range mod.RefOrigins {
+		localOrigin, ok := origin.(reference.LocalOrigin)
+		if !ok || localOrigin.Range.Filename != filename || !localOrigin.Range.ContainsPos(pos) {
+			continue
+		}
+		for _, root := range linkedBlockTypes {
+			if len(localOrigin.Addr) < 2 || localOrigin.Addr[0].String() != root {
+				continue
+			}
+			if step, ok := localOrigin.Addr[1].(lang.AttrStep); ok {
+				return root, step.Name, true
+			}
+		}
+	}
+
+	return "", "", false
+}
+
+// declaredNameRange returns the range of the name label (excluding quotes)
+// of the block declaring the given symbol within the given file
+func declaredNameRange(mod *state.Module, filename, root, name string) (hcl.Range, bool) {
+	file, ok := mod.ParsedModuleFiles[ast.ModFilename(filename)]
+	if !ok {
+		return hcl.Range{}, false
+	}
+	body, ok := file.Body.(*hclsyntax.Body)
+	if !ok {
+		return hcl.Range{}, false
+	}
+	for _, block := range body.Blocks {
+		if linkedBlockTypes[block.Type] != root || len(block.Labels) != 1 || block.Labels[0] != name {
+			continue
+		}
+		rng := block.LabelRanges[0]
+		if rng.End.Byte-rng.Start.Byte != len(name)+2 {
+			// unquoted labels are not supported
+			return hcl.Range{}, false
+		}
+		return hcl.Range{
+			Filename: rng.Filename,
+			Start: hcl.Pos{
+				Line:   rng.Start.Line,
+				Column: rng.Start.Column + 1,
+				Byte:   rng.Start.Byte + 1,
+			},
+			End: hcl.Pos{
+				Line:   rng.End.Line,
+				Column: rng.End.Column - 1,
+				Byte:   rng.End.Byte - 1,
+			},
+		}, true
+	}
+	return hcl.Range{}, false
+}
+
+func originTargetsName(origin reference.LocalOrigin, root, name string) bool {
+	if len(origin.Addr) < 2 || origin.Addr[0].String() != root {
+		return false
+	}
+	step, ok := origin.Addr[1].(lang.AttrStep)
+	return ok && step.Name == name
+}
@@ -0,0 +1,79 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package handlers
+
+import (
+	"context"
+	"sort"
+
+	"github.com/hashicorp/terraform-ls/internal/state"
+)
+
+const loadErrorsVersion = 0
+
+type loadErrorsResponse struct {
+	FormatVersion int         `json:"v"`
+	LoadErrors    []loadError `json:"load_errors"`
+}
+
+type loadError struct {
+	Path  string `json:"path"`
+	Field string `json:"field"`
+	Error string `json:"error"`
+}
+
+// LoadErrors returns errors recorded while loading any of the known modules,
+// e.g. to help operators find out what failed to be indexed.
+func (svc *service) LoadErrors(ctx context.Context, _ interface{}) (loadErrorsResponse, error) {
+	response := loadErrorsResponse{
+		FormatVersion: loadErrorsVersion,
+		LoadErrors:    make([]loadError, 0),
+	}
+
+	mods, err := svc.modStore.List()
+	if err != nil {
+		return response, err
+	}
+
+	for _, mod := range mods {
+		for field, err := range moduleLoadErrors(mod) {
+			if err == nil {
+				continue
+			}
+			response.LoadErrors = append(response.LoadErrors, loadError{
+				Path:  mod.Path,
+				Field: field,
+				Error: err.Error(),
+			})
+		}
+	}
+
+	sort.SliceStable(response.LoadErrors, func(i, j int) bool {
+		if response.LoadErrors[i].Path != response.LoadErrors[j].Path {
+			return response.LoadErrors[i].Path < response.LoadErrors[j].Path
+		}
+		return response.LoadErrors[i].Field < response.LoadErrors[j].Field
+	})
+
+	return response, nil
+}
+
+// moduleLoadErrors returns errors recorded while loading the given module,
+// keyed by name of the field they are stored in.
+func moduleLoadErrors(mod *state.Module) map[string]error {
+	return map[string]error{
+		"ModManifestErr":           mod.ModManifestErr,
+		"TerraformVersionErr":      mod.TerraformVersionErr,
+		"InstalledProvidersErr":    mod.InstalledProvidersErr,
+		"ProviderSchemaErr":        mod.ProviderSchemaErr,
+		"PreloadEmbeddedSchemaErr": mod.PreloadEmbeddedSchemaErr,
+		"RefTargetsErr":            mod.RefTargetsErr,
+		"RefOriginsErr":            mod.RefOriginsErr,
+		"VarsRefOriginsErr":        mod.VarsRefOriginsErr,
+		"ModuleParsingErr":         mod.ModuleParsingErr,
+		"VarsParsingErr":           mod.VarsParsingErr,
+		"MetaErr":                  mod.MetaErr,
+		"MetricsErr":               mod.MetricsErr,
+	}
+}
@@ -0,0 +1,64 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package handlers
+
+import (
+	"context"
+	"sort"
+
+	op "github.com/hashicorp/terraform-ls/internal/terraform/module/operation"
+)
+
+const moduleMetricsVersion = 0
+
+type moduleMetricsResponse struct {
+	FormatVersion int             `json:"v"`
+	Modules       []moduleMetrics `json:"modules"`
+}
+
+type moduleMetrics struct {
+	Path        string `json:"path"`
+	Resources   int    `json:"resources"`
+	DataSources int    `json:"data_sources"`
+	Variables   int    `json:"variables"`
+	Outputs     int    `json:"outputs"`
+	ModuleCalls int    `json:"module_calls"`
+	Providers   int    `json:"providers"`
+}
+
+// ModuleMetrics returns counts of notable blocks for each indexed module,
+// e.g. to help understand the size of configuration when diagnosing
+// performance issues.
+func (svc *service) ModuleMetrics(ctx context.Context, _ interface{}) (moduleMetricsResponse, error) {
+	response := moduleMetricsResponse{
+		FormatVersion: moduleMetricsVersion,
+		Modules:       make([]moduleMetrics, 0),
+	}
+
+	mods, err := svc.modStore.List()
+	if err != nil {
+		return response, err
+	}
+
+	for _, mod := range mods {
+		if mod.MetricsState != op.OpStateLoaded {
+			continue
+		}
+		response.Modules = append(response.Modules, moduleMetrics{
+			Path:        mod.Path,
+			Resources:   mod.Metrics.Resources,
+			DataSources: mod.Metrics.DataSources,
+			Variables:   mod.Metrics.Variables,
+			Outputs:     mod.Metrics.Outputs,
+			ModuleCalls: mod.Metrics.ModuleCalls,
+			Providers:   mod.Metrics.Providers,
+		})
+	}
+
+	sort.SliceStable(response.Modules, func(i, j int) bool {
+		return response.Modules[i].Path < response.Modules[j].Path
+	})
+
+	return response, nil
+}
@@ -0,0 +1,155 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package handlers
+
+import (
+	"path/filepath"
+
+	"github.com/hashicorp/hcl-lang/decoder"
+	"github.com/hashicorp/hcl-lang/lang"
+	"github.com/hashicorp/hcl-lang/reference"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	ilsp "github.com/hashicorp/terraform-ls/internal/lsp"
+	tfmod "github.com/hashicorp/terraform-schema/module"
+)
+
+// moduleOutputTargets resolves references to outputs of called modules
+// (e.g. module.foo.bar) at the given position to declarations of those
+// outputs. Where an output re-exports an output of a nested module
+// (e.g. value = module.baz.bar), the chain is followed further,
+// up to MaxModuleNesting levels.
+func (svc *service) moduleOutputTargets(modPath, filename string, pos hcl.Pos) decoder.ReferenceTargets {
+	targets := make(decoder.ReferenceTargets, 0)
+
+	mod, err := svc.modStore.ModuleByPath(modPath)
+	if err != nil {
+		return targets
+	}
+
+	origins, ok := mod.RefOrigins.AtPos(filename, pos)
+	if !ok {
+		return targets
+	}
+
+	for _, origin := range origins {
+		localOrigin, ok := origin.(reference.LocalOrigin)
+		if !ok {
+			continue
+		}
+		targets = append(targets, svc.followModuleOutput(modPath, localOrigin.Address(), origin.OriginRange(), 0)...)
+	}
+
+	return targets
+}
+
+func (svc *service) followModuleOutput(modPath string, addr lang.Address, originRng hcl.Range, level int) decoder.ReferenceTargets {
+	if level >= svc.modStore.MaxModuleNesting {
+		return decoder.ReferenceTargets{}
+	}
+
+	callName, outputName, ok := moduleOutputAddress(addr)
+	if !ok {
+		return decoder.ReferenceTargets{}
+	}
+
+	childPath, ok := svc.calledModulePath(modPath, callName)
+	if !ok {
+		return decoder.ReferenceTargets{}
+	}
+
+	child, err := svc.modStore.ModuleByPath(childPath)
+	if err != nil {
+		return decoder.ReferenceTargets{}
+	}
+
+	for _, f := range child.ParsedModuleFiles {
+		body, ok := f.Body.(*hclsyntax.Body)
+		if !ok {
+			continue
+		}
+		for _, block := range body.Blocks {
+			if block.Type != "output" || len(block.Labels) != 1 || block.Labels[0] != outputName {
+				continue
+			}
+
+			defRng := block.DefRange()
+			targets := decoder.ReferenceTargets{
+				{
+					OriginRange: originRng,
+					Path: lang.Path{
+						Path:       childPath,
+						LanguageID: ilsp.Terraform.String(),
+					},
+					Range:       block.Range(),
+					DefRangePtr: &defRng,
+				},
+			}
+
+			valueAttr, ok := block.Body.Attributes["value"]
+			if !ok {
+				return targets
+			}
+			traversal, diags := hcl.AbsTraversalForExpr(valueAttr.Expr)
+			if diags.HasErrors() {
+				return targets
+			}
+			valueAddr, err := lang.TraversalToAddress(traversal)
+			if err != nil {
+				return targets
+			}
+
+			return append(targets, svc.followModuleOutput(childPath, valueAddr, originRng, level+1)...)
+		}
+	}
+
+	return decoder.ReferenceTargets{}
+}
+
+// calledModulePath returns the path of a module called from the module
+// at the given path, as long as it is local or installed.
+func (svc *service) calledModulePath(modPath, callName string) (string, bool) {
+	calls, err := svc.modStore.ModuleCalls(modPath)
+	if err != nil {
+		return "", false
+	}
+
+	if declared, ok := calls.Declared[callName]; ok {
+		if localAddr, ok := declared.SourceAddr.(tfmod.LocalSourceAddr); ok {
+			return filepath.Join(modPath, localAddr.String()), true
+		}
+	}
+	if installed, ok := calls.Installed[callName]; ok {
+		return installed.Path, true
+	}
+
+	return "", false
+}
+
+// moduleOutputAddress returns the module call name and output name
+// of an address referencing a module output, e.g. module.foo.bar[0].
+func moduleOutputAddress(addr lang.Address) (string, string, bool) {
+	if len(addr) < 3 || addr[0].String() != "module" {
+		return "", "", false
+	}
+	callStep, ok := addr[1].(lang.AttrStep)
+	if !ok {
+		return "", "", false
+	}
+
+	// output may follow an instance key of module with count/for_each
+	outputIdx := 2
+	if _, ok := addr[outputIdx].(lang.IndexStep); ok {
+		outputIdx++
+	}
+	if len(addr) <= outputIdx {
+		return "", "", false
+	}
+	outputStep, ok := addr[outputIdx].(lang.AttrStep)
+	if !ok {
+		return "", "", false
+	}
+
+	return callStep.Name, outputStep.Name, true
+}
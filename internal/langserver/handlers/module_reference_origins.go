@@ -0,0 +1,115 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/creachadair/jrpc2"
+	"github.com/hashicorp/hcl-lang/decoder"
+	"github.com/hashicorp/hcl-lang/lang"
+	"github.com/hashicorp/hcl-lang/reference"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/terraform-ls/internal/document"
+	ilsp "github.com/hashicorp/terraform-ls/internal/lsp"
+	lsp "github.com/hashicorp/terraform-ls/internal/protocol"
+	"github.com/hashicorp/terraform-ls/internal/uri"
+)
+
+const moduleReferenceOriginsVersion = 0
+
+type moduleReferenceOriginsParams struct {
+	URI     string `json:"uri"`
+	Address string `json:"address"`
+}
+
+type moduleReferenceOriginsResponse struct {
+	FormatVersion int            `json:"v"`
+	Locations     []lsp.Location `json:"locations"`
+}
+
+// ModuleReferenceOrigins returns locations of all references within
+// the module directory (including variable files) to the given address,
+// such as var.foo, or any nested address, such as var.foo.bar.
+func (svc *service) ModuleReferenceOrigins(ctx context.Context, params moduleReferenceOriginsParams) (moduleReferenceOriginsResponse, error) {
+	response := moduleReferenceOriginsResponse{
+		FormatVersion: moduleReferenceOriginsVersion,
+		Locations:     make([]lsp.Location, 0),
+	}
+
+	if !uri.IsURIValid(params.URI) {
+		return response, fmt.Errorf("%w: URI %q is not valid", jrpc2.InvalidParams.Err(), params.URI)
+	}
+	traversal, diags := hclsyntax.ParseTraversalAbs([]byte(params.Address), "", hcl.InitialPos)
+	if diags.HasErrors() {
+		return response, fmt.Errorf("%w: address %q is not valid: %s", jrpc2.InvalidParams.Err(), params.Address, diags)
+	}
+	targetAddr, err := lang.TraversalToAddress(traversal)
+	if err != nil {
+		return response, fmt.Errorf("%w: address %q is not valid: %s", jrpc2.InvalidParams.Err(), params.Address, err)
+	}
+
+	modPath := document.DirHandleFromURI(params.URI).Path()
+	mod, err := svc.modStore.ModuleByPath(modPath)
+	if err != nil {
+		return response, err
+	}
+
+	origins := make(decoder.ReferenceOrigins, 0)
+	origins = append(origins, originsTargetingAddress(mod.RefOrigins, lang.Path{
+		Path:       modPath,
+		LanguageID: ilsp.Terraform.String(),
+	}, targetAddr)...)
+	origins = append(origins, originsTargetingAddress(mod.VarsRefOrigins, lang.Path{
+		Path:       modPath,
+		LanguageID: ilsp.Tfvars.String(),
+	}, targetAddr)...)
+
+	sort.SliceStable(origins, func(i, j int) bool {
+		if origins[i].Range.Filename != origins[j].Range.Filename {
+			return origins[i].Range.Filename < origins[j].Range.Filename
+		}
+		return origins[i].Range.Start.Byte < origins[j].Range.Start.Byte
+	})
+	response.Locations = ilsp.RefOriginsToLocations(origins)
+
+	return response, nil
+}
+
+// originsTargetingAddress returns origins which refer to the given address
+// within the module at the given path, or to any address nested in it.
+func originsTargetingAddress(origins reference.Origins, path lang.Path, targetAddr lang.Address) decoder.ReferenceOrigins {
+	matching := make(decoder.ReferenceOrigins, 0)
+
+	for _, origin := range origins {
+		var addr lang.Address
+		switch o := origin.(type) {
+		case reference.LocalOrigin:
+			addr = o.Addr
+		case reference.PathOrigin:
+			// origins targeting other modules, such as module inputs
+			// are not relevant here
+			if o.TargetPath.Path != path.Path {
+				continue
+			}
+			addr = o.TargetAddr
+		default:
+			continue
+		}
+
+		if len(addr) < len(targetAddr) || !addr.FirstSteps(uint(len(targetAddr))).Equals(targetAddr) {
+			continue
+		}
+
+		matching = append(matching, decoder.ReferenceOrigin{
+			Path:  path,
+			Range: origin.OriginRange(),
+		})
+	}
+
+	return matching
+}
@@ -0,0 +1,175 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/creachadair/jrpc2"
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-ls/internal/document"
+	"github.com/hashicorp/terraform-ls/internal/langserver"
+	"github.com/hashicorp/terraform-ls/internal/state"
+	"github.com/hashicorp/terraform-ls/internal/terraform/exec"
+	"github.com/hashicorp/terraform-ls/internal/walker"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestLangServer_moduleReferenceOrigins(t *testing.T) {
+	modPath := t.TempDir()
+	modHandle := document.DirHandleFromPath(modPath)
+
+	cfg := `variable "foo" {
+  default = "bar"
+}
+
+variable "unused" {
+}
+
+locals {
+  first  = var.foo
+  second = "${var.foo}-suffix"
+}
+
+output "foo" {
+  value = var.foo
+}
+`
+	err := os.WriteFile(filepath.Join(modPath, "main.tf"), []byte(cfg), 0o755)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = os.WriteFile(filepath.Join(modPath, "terraform.tfvars"),
+		[]byte("foo = \"baz\"\n"), 0o755)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ss, err := state.NewStateStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+	wc := walker.NewWalkerCollector()
+
+	ls := langserver.NewLangServerMock(t, NewMockSession(&MockSessionInput{
+		TerraformCalls: &exec.TerraformMockCalls{
+			PerWorkDir: map[string][]*mock.Call{
+				modPath: validTfMockCalls(),
+			},
+		},
+		StateStore:      ss,
+		WalkerCollector: wc,
+	}))
+	stop := ls.Start(t)
+	defer stop()
+
+	ls.Call(t, &langserver.CallRequest{
+		Method: "initialize",
+		ReqParams: fmt.Sprintf(`{
+		"capabilities": {},
+		"rootUri": %q,
+		"processId": 12345
+	}`, modHandle.URI)})
+	waitForWalkerPath(t, ss, wc, modHandle)
+	ls.Notify(t, &langserver.CallRequest{
+		Method:    "initialized",
+		ReqParams: "{}",
+	})
+	waitForAllJobs(t, ss)
+
+	testCases := []struct {
+		address           string
+		expectedLocations []string
+	}{
+		{
+			"var.foo",
+			[]string{
+				fmt.Sprintf("%s/main.tf:8", modHandle.URI),
+				fmt.Sprintf("%s/main.tf:9", modHandle.URI),
+				fmt.Sprintf("%s/main.tf:13", modHandle.URI),
+				fmt.Sprintf("%s/terraform.tfvars:0", modHandle.URI),
+			},
+		},
+		{
+			"local.first",
+			[]string{},
+		},
+		{
+			"var.unused",
+			[]string{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.address, func(t *testing.T) {
+			rsp := ls.Call(t, &langserver.CallRequest{
+				Method: "terraform-ls/module/referenceOrigins",
+				ReqParams: fmt.Sprintf(`{
+				"uri": %q,
+				"address": %q
+			}`, modHandle.URI, tc.address)})
+
+			var response moduleReferenceOriginsResponse
+			err := json.Unmarshal(rsp.Result, &response)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			locations := make([]string, 0)
+			for _, location := range response.Locations {
+				locations = append(locations, fmt.Sprintf("%s:%d", location.URI, location.Range.Start.Line))
+			}
+			if diff := cmp.Diff(tc.expectedLocations, locations); diff != "" {
+				t.Fatalf("unexpected locations: %s", diff)
+			}
+		})
+	}
+}
+
+func TestLangServer_moduleReferenceOrigins_invalidAddress(t *testing.T) {
+	tmpDir := TempDir(t)
+	InitPluginCache(t, tmpDir.Path())
+
+	ss, err := state.NewStateStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+	wc := walker.NewWalkerCollector()
+
+	ls := langserver.NewLangServerMock(t, NewMockSession(&MockSessionInput{
+		TerraformCalls: &exec.TerraformMockCalls{
+			PerWorkDir: map[string][]*mock.Call{
+				tmpDir.Path(): validTfMockCalls(),
+			},
+		},
+		StateStore:      ss,
+		WalkerCollector: wc,
+	}))
+	stop := ls.Start(t)
+	defer stop()
+
+	ls.Call(t, &langserver.CallRequest{
+		Method: "initialize",
+		ReqParams: fmt.Sprintf(`{
+		"capabilities": {},
+		"rootUri": %q,
+		"processId": 12345
+	}`, tmpDir.URI)})
+	waitForWalkerPath(t, ss, wc, tmpDir)
+	ls.Notify(t, &langserver.CallRequest{
+		Method:    "initialized",
+		ReqParams: "{}",
+	})
+
+	ls.CallAndExpectError(t, &langserver.CallRequest{
+		Method: "terraform-ls/module/referenceOrigins",
+		ReqParams: fmt.Sprintf(`{
+		"uri": %q,
+		"address": "var."
+	}`, tmpDir.URI)}, jrpc2.InvalidParams.Err())
+}
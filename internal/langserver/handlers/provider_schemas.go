@@ -0,0 +1,50 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package handlers
+
+import (
+	"context"
+)
+
+const providerSchemasVersion = 0
+
+type providerSchemasResponse struct {
+	FormatVersion int                  `json:"v"`
+	Providers     []providerSchemaLoad `json:"providers"`
+}
+
+type providerSchemaLoad struct {
+	Address    string `json:"address"`
+	ModulePath string `json:"module_path"`
+	State      string `json:"state"`
+	Error      string `json:"error,omitempty"`
+}
+
+// ProviderSchemas returns the state of loading schema of each provider
+// for each module, e.g. to help find out why completion of resources is not available yet.
+func (svc *service) ProviderSchemas(ctx context.Context, _ interface{}) (providerSchemasResponse, error) {
+	response := providerSchemasResponse{
+		FormatVersion: providerSchemasVersion,
+		Providers:     make([]providerSchemaLoad, 0),
+	}
+
+	loads, _, err := svc.stateStore.ProviderSchemas.SchemaLoads()
+	if err != nil {
+		return response, err
+	}
+
+	for _, load := range loads {
+		pLoad := providerSchemaLoad{
+			Address:    load.Address.String(),
+			ModulePath: load.ModulePath,
+			State:      load.State.String(),
+		}
+		if load.Err != nil {
+			pLoad.Error = load.Err.Error()
+		}
+		response.Providers = append(response.Providers, pLoad)
+	}
+
+	return response, nil
+}
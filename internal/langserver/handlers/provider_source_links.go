@@ -0,0 +1,105 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/hashicorp/hcl-lang/lang"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/terraform-ls/internal/utm"
+	tfaddr "github.com/hashicorp/terraform-registry-address"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// providerSourceLinks returns links to registry documentation
+// for provider source addresses declared within required_providers.
+func providerSourceLinks(ctx context.Context, file *hcl.File) []lang.Link {
+	links := make([]lang.Link, 0)
+
+	body, ok := file.Body.(*hclsyntax.Body)
+	if !ok {
+		return links
+	}
+
+	for _, block := range body.Blocks {
+		if block.Type != "terraform" {
+			continue
+		}
+		for _, nestedBlock := range block.Body.Blocks {
+			if nestedBlock.Type != "required_providers" {
+				continue
+			}
+			for _, attr := range nestedBlock.Body.Attributes {
+				objExpr, ok := attr.Expr.(*hclsyntax.ObjectConsExpr)
+				if !ok {
+					continue
+				}
+				for _, item := range objExpr.Items {
+					if hcl.ExprAsKeyword(item.KeyExpr) != "source" {
+						continue
+					}
+
+					val, diags := item.ValueExpr.Value(nil)
+					if diags.HasErrors() || !val.IsWhollyKnown() || val.IsNull() || val.Type() != cty.String {
+						continue
+					}
+					addr, err := tfaddr.ParseProviderSource(val.AsString())
+					if err != nil {
+						continue
+					}
+
+					docsURL, ok := providerDocsURL(ctx, addr)
+					if !ok {
+						continue
+					}
+
+					links = append(links, lang.Link{
+						URI:     docsURL,
+						Tooltip: fmt.Sprintf("%s Documentation", addr.ForDisplay()),
+						Range:   item.ValueExpr.Range(),
+					})
+				}
+			}
+		}
+	}
+
+	return links
+}
+
+// providerDocsURL returns URL of the documentation of the given provider
+// in the registry it comes from.
+func providerDocsURL(ctx context.Context, addr tfaddr.Provider) (string, bool) {
+	if addr.IsLegacy() || addr.IsBuiltIn() {
+		return "", false
+	}
+
+	switch addr.Hostname {
+	case tfaddr.DefaultProviderRegistryHost:
+		u := url.URL{
+			Scheme: "https",
+			Host:   addr.Hostname.String(),
+			Path:   fmt.Sprintf("/providers/%s/%s/latest", addr.Namespace, addr.Type),
+		}
+		q := u.Query()
+		q.Set("utm_source", utm.UtmSource)
+		if medium := utm.UtmMedium(ctx); medium != "" {
+			q.Set("utm_medium", medium)
+		}
+		q.Set("utm_content", "textDocument/documentLink")
+		u.RawQuery = q.Encode()
+
+		return u.String(), true
+	case "app.terraform.io":
+		// Private registry of HCP Terraform lives under the organization
+		return fmt.Sprintf("https://%s/app/%s/registry/providers/private/%s/%s/latest",
+			addr.Hostname, addr.Namespace, addr.Namespace, addr.Type), true
+	}
+
+	return fmt.Sprintf("https://%s/providers/%s/%s/latest",
+		addr.Hostname, addr.Namespace, addr.Type), true
+}
@@ -0,0 +1,83 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package handlers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/hcl-lang/lang"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+)
+
+func TestProviderSourceLinks(t *testing.T) {
+	cfg := `terraform {
+  required_providers {
+    aws = {
+      source  = "hashicorp/aws"
+      version = "~> 5.0"
+    }
+    private = {
+      source = "example.com/acme/private"
+    }
+    hcp = {
+      source = "app.terraform.io/acme/internal"
+    }
+    tf = {
+      source = "terraform.io/builtin/terraform"
+    }
+  }
+}
+`
+	f, diags := hclsyntax.ParseConfig([]byte(cfg), "main.tf", hcl.InitialPos)
+	if len(diags) > 0 {
+		t.Fatal(diags)
+	}
+
+	links := providerSourceLinks(context.Background(), f)
+
+	expectedLinks := []lang.Link{
+		{
+			URI:     "https://registry.terraform.io/providers/hashicorp/aws/latest?utm_content=textDocument%2FdocumentLink&utm_source=terraform-ls",
+			Tooltip: "hashicorp/aws Documentation",
+			Range: hcl.Range{
+				Filename: "main.tf",
+				Start:    hcl.Pos{Line: 4, Column: 17, Byte: 63},
+				End:      hcl.Pos{Line: 4, Column: 32, Byte: 78},
+			},
+		},
+		{
+			URI:     "https://example.com/providers/acme/private/latest",
+			Tooltip: "example.com/acme/private Documentation",
+			Range: hcl.Range{
+				Filename: "main.tf",
+				Start:    hcl.Pos{Line: 8, Column: 16, Byte: 141},
+				End:      hcl.Pos{Line: 8, Column: 42, Byte: 167},
+			},
+		},
+		{
+			URI:     "https://app.terraform.io/app/acme/registry/providers/private/acme/internal/latest",
+			Tooltip: "app.terraform.io/acme/internal Documentation",
+			Range: hcl.Range{
+				Filename: "main.tf",
+				Start:    hcl.Pos{Line: 11, Column: 16, Byte: 201},
+				End:      hcl.Pos{Line: 11, Column: 48, Byte: 233},
+			},
+		},
+	}
+
+	// attributes are kept in a map, so the order of links is not stable
+	sortLinks := cmp.Transformer("sortLinks", func(in []lang.Link) map[string]lang.Link {
+		out := make(map[string]lang.Link, len(in))
+		for _, link := range in {
+			out[link.URI] = link
+		}
+		return out
+	})
+	if diff := cmp.Diff(expectedLinks, links, sortLinks); diff != "" {
+		t.Fatalf("unexpected links: %s", diff)
+	}
+}
@@ -5,10 +5,14 @@ package handlers
 
 import (
 	"context"
+	"time"
 
 	"github.com/hashicorp/hcl-lang/lang"
+	"github.com/hashicorp/terraform-ls/internal/document"
 	ilsp "github.com/hashicorp/terraform-ls/internal/lsp"
 	lsp "github.com/hashicorp/terraform-ls/internal/protocol"
+	"github.com/hashicorp/terraform-ls/internal/state"
+	op "github.com/hashicorp/terraform-ls/internal/terraform/module/operation"
 )
 
 func (svc *service) References(ctx context.Context, params lsp.ReferenceParams) ([]lsp.Location, error) {
@@ -25,6 +29,8 @@ func (svc *service) References(ctx context.Context, params lsp.ReferenceParams)
 		return list, err
 	}
 
+	svc.waitForReferences(ctx, doc.Dir.Path())
+
 	path := lang.Path{
 		Path:       doc.Dir.Path(),
 		LanguageID: doc.LanguageID,
@@ -34,3 +40,33 @@ func (svc *service) References(ctx context.Context, params lsp.ReferenceParams)
 
 	return ilsp.RefOriginsToLocations(origins), nil
 }
+
+// referencesWaitTimeout represents how long requests wait
+// for references of a freshly opened module to be collected
+const referencesWaitTimeout = 500 * time.Millisecond
+
+// waitForReferences briefly blocks until reference targets and origins
+// of the module at the given path are collected, such that requests
+// sent right after opening a document do not race the indexing.
+// Requests proceed with whatever is collected once the timeout elapses,
+// or right away if no jobs collecting references are queued or running.
+func (svc *service) waitForReferences(ctx context.Context, modPath string) {
+	pending, err := svc.stateStore.JobStore.HasPendingJobs(document.DirHandleFromPath(modPath),
+		op.OpTypeDecodeReferenceTargets.String(),
+		op.OpTypeDecodeReferenceOrigins.String())
+	if err != nil {
+		svc.logger.Printf("failed to check pending reference jobs of %q: %s", modPath, err)
+		return
+	}
+	if !pending {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, referencesWaitTimeout)
+	defer cancel()
+
+	err = svc.modStore.WaitForReferences(ctx, modPath)
+	if err != nil && ctx.Err() == nil && !state.IsModuleNotFound(err) {
+		svc.logger.Printf("failed to wait for references of %q: %s", modPath, err)
+	}
+}
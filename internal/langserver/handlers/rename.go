@@ -0,0 +1,169 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"path/filepath"
+	"sort"
+
+	"github.com/creachadair/jrpc2"
+	"github.com/hashicorp/hcl-lang/lang"
+	"github.com/hashicorp/hcl-lang/reference"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	ilsp "github.com/hashicorp/terraform-ls/internal/lsp"
+	lsp "github.com/hashicorp/terraform-ls/internal/protocol"
+	"github.com/hashicorp/terraform-ls/internal/state"
+	"github.com/hashicorp/terraform-ls/internal/terraform/ast"
+	"github.com/hashicorp/terraform-ls/internal/uri"
+)
+
+// Rename renames the module call at the given position, i.e. either
+// the name label of a module block, or a reference such as module.name,
+// along with all references to it within the module.
+//
+// Other symbols are not supported yet, for which no edits are returned.
+func (svc *service) Rename(ctx context.Context, params lsp.RenameParams) (*lsp.WorkspaceEdit, error) {
+	if !hclsyntax.ValidIdentifier(params.NewName) {
+		return nil, fmt.Errorf("%w: %q is not a valid module name", jrpc2.InvalidParams.Err(), params.NewName)
+	}
+
+	dh := ilsp.HandleFromDocumentURI(params.TextDocument.URI)
+	doc, err := svc.stateStore.DocumentStore.GetDocument(dh)
+	if err != nil {
+		return nil, err
+	}
+	if doc.LanguageID != ilsp.Terraform.String() {
+		return nil, nil
+	}
+
+	pos, err := ilsp.HCLPositionFromLspPosition(params.Position, doc)
+	if err != nil {
+		return nil, err
+	}
+
+	modPath := doc.Dir.Path()
+	mod, err := svc.modStore.ModuleByPath(modPath)
+	if err != nil {
+		return nil, err
+	}
+
+	name, ok := moduleCallNameAtPos(mod, doc.Filename, pos)
+	if !ok {
+		return nil, nil
+	}
+	if _, exists := mod.Meta.ModuleCalls[params.NewName]; exists && params.NewName != name {
+		return nil, fmt.Errorf("%w: module %q is already declared", jrpc2.InvalidParams.Err(), params.NewName)
+	}
+
+	refs, ok, err := svc.modStore.ModuleCallReferences(modPath, name)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, nil
+	}
+
+	changes := make(map[lsp.DocumentURI][]lsp.TextEdit, 0)
+	addEdit := func(rng hcl.Range, newText string) {
+		docURI := lsp.DocumentURI(uri.FromPath(filepath.Join(modPath, rng.Filename)))
+		changes[docURI] = append(changes[docURI], lsp.TextEdit{
+			Range:   ilsp.HCLRangeToLSP(rng),
+			NewText: newText,
+		})
+	}
+
+	addEdit(refs.NameRange, fmt.Sprintf("%q", params.NewName))
+	for _, origin := range refs.Origins {
+		rng, ok := moduleCallNameRangeInOrigin(mod, origin, name)
+		if !ok {
+			continue
+		}
+		addEdit(rng, params.NewName)
+	}
+	for _, edits := range changes {
+		sort.SliceStable(edits, func(i, j int) bool {
+			if edits[i].Range.Start.Line != edits[j].Range.Start.Line {
+				return edits[i].Range.Start.Line < edits[j].Range.Start.Line
+			}
+			return edits[i].Range.Start.Character < edits[j].Range.Start.Character
+		})
+	}
+
+	return &lsp.WorkspaceEdit{
+		Changes: changes,
+	}, nil
+}
+
+// moduleCallNameAtPos returns the name of the module call whose name label,
+// or a reference to which, is located at the given position
+func moduleCallNameAtPos(mod *state.Module, filename string, pos hcl.Pos) (string, bool) {
+	file, ok := mod.ParsedModuleFiles[ast.ModFilename(filename)]
+	if !ok {
+		return "", false
+	}
+	if body, ok := file.Body.(*hclsyntax.Body); ok {
+		for _, block := range body.Blocks {
+			if block.Type == "module" && len(block.Labels) == 1 &&
+				block.LabelRanges[0].ContainsPos(pos) {
+				return block.Labels[0], true
+			}
+		}
+	}
+
+	for _, origin := range mod.RefOrigins {
+		localOrigin, ok := origin.(reference.LocalOrigin)
+		if !ok || localOrigin.Range.Filename != filename || !localOrigin.Range.ContainsPos(pos) {
+			continue
+		}
+		if len(localOrigin.Addr) < 2 || localOrigin.Addr[0].String() != "module" {
+			continue
+		}
+		if step, ok := localOrigin.Addr[1].(lang.AttrStep); ok {
+			return step.Name, true
+		}
+	}
+
+	return "", false
+}
+
+// moduleCallNameRangeInOrigin returns the range of the module call name
+// within the given origin, e.g. foo in module.foo.bar
+func moduleCallNameRangeInOrigin(mod *state.Module, origin reference.Origin, name string) (hcl.Range, bool) {
+	return refNameRangeInOrigin(mod, origin, "module", name)
+}
+
+// refNameRangeInOrigin returns the range of the name following
+// the given root step within the origin, e.g. foo in var.foo
+func refNameRangeInOrigin(mod *state.Module, origin reference.Origin, root, name string) (hcl.Range, bool) {
+	rng := origin.OriginRange()
+	file, ok := mod.ParsedModuleFiles[ast.ModFilename(rng.Filename)]
+	if !ok || rng.End.Byte > len(file.Bytes) {
+		return hcl.Range{}, false
+	}
+
+	prefix := []byte(root + ".")
+	src := rng.SliceBytes(file.Bytes)
+	if !bytes.HasPrefix(src, append(prefix, name...)) {
+		return hcl.Range{}, false
+	}
+
+	start := hcl.Pos{
+		Line:   rng.Start.Line,
+		Column: rng.Start.Column + len(prefix),
+		Byte:   rng.Start.Byte + len(prefix),
+	}
+	return hcl.Range{
+		Filename: rng.Filename,
+		Start:    start,
+		End: hcl.Pos{
+			Line:   start.Line,
+			Column: start.Column + len(name),
+			Byte:   start.Byte + len(name),
+		},
+	}, true
+}
@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"io/ioutil"
 	"log"
+	"os"
 	"time"
 
 	"github.com/creachadair/jrpc2"
@@ -31,6 +32,7 @@ import (
 	"github.com/hashicorp/terraform-ls/internal/settings"
 	"github.com/hashicorp/terraform-ls/internal/state"
 	"github.com/hashicorp/terraform-ls/internal/telemetry"
+	"github.com/hashicorp/terraform-ls/internal/terraform/datadir"
 	"github.com/hashicorp/terraform-ls/internal/terraform/discovery"
 	"github.com/hashicorp/terraform-ls/internal/terraform/exec"
 	"github.com/hashicorp/terraform-ls/internal/walker"
@@ -65,17 +67,25 @@ type service struct {
 	tfExecOpts       *exec.ExecutorOpts
 	telemetry        telemetry.Sender
 	decoder          *decoder.Decoder
+	schemaCache      *idecoder.ModuleSchemaCache
+	hoverCache       *idecoder.HoverCache
 	stateStore       *state.StateStore
 	server           session.Server
 	diagsNotifier    *diagnostics.Notifier
 	notifier         *notifier.Notifier
 	indexer          *indexer.Indexer
 	registryClient   registry.Client
+	dataDir          datadir.Dir
+
+	completionDocs completionDocs
+
+	pendingDeletions pendingDeletions
 
 	walkerCollector    *walker.WalkerCollector
 	additionalHandlers map[string]rpch.Func
 
 	singleFileMode bool
+	debugMode      bool
 }
 
 var discardLogs = log.New(ioutil.Discard, "", 0)
@@ -261,6 +271,7 @@ func (svc *service) Assigner() (jrpc2.Assigner, error) {
 			ctx = ilsp.WithClientCapabilities(ctx, cc)
 			ctx = exec.WithExecutorOpts(ctx, svc.tfExecOpts)
 			ctx = exec.WithExecutorFactory(ctx, svc.tfExecFactory)
+			ctx = lsctx.WithValidationOptions(ctx, &validationOptions)
 
 			return handle(ctx, req, svc.TextDocumentCodeAction)
 		},
@@ -313,6 +324,7 @@ func (svc *service) Assigner() (jrpc2.Assigner, error) {
 
 			ctx = lsctx.WithDiagnosticsNotifier(ctx, svc.diagsNotifier)
 			ctx = lsctx.WithExperimentalFeatures(ctx, &expFeatures)
+			ctx = lsctx.WithValidationOptions(ctx, &validationOptions)
 			ctx = exec.WithExecutorOpts(ctx, svc.tfExecOpts)
 			ctx = exec.WithExecutorFactory(ctx, svc.tfExecFactory)
 
@@ -343,6 +355,46 @@ func (svc *service) Assigner() (jrpc2.Assigner, error) {
 
 			return handle(ctx, req, svc.References)
 		},
+		"textDocument/rename": func(ctx context.Context, req *jrpc2.Request) (interface{}, error) {
+			err := session.CheckInitializationIsConfirmed()
+			if err != nil {
+				return nil, err
+			}
+
+			return handle(ctx, req, svc.Rename)
+		},
+		"textDocument/linkedEditingRange": func(ctx context.Context, req *jrpc2.Request) (interface{}, error) {
+			err := session.CheckInitializationIsConfirmed()
+			if err != nil {
+				return nil, err
+			}
+
+			return handle(ctx, req, svc.LinkedEditingRange)
+		},
+		"textDocument/prepareCallHierarchy": func(ctx context.Context, req *jrpc2.Request) (interface{}, error) {
+			err := session.CheckInitializationIsConfirmed()
+			if err != nil {
+				return nil, err
+			}
+
+			return handle(ctx, req, svc.PrepareCallHierarchy)
+		},
+		"callHierarchy/incomingCalls": func(ctx context.Context, req *jrpc2.Request) (interface{}, error) {
+			err := session.CheckInitializationIsConfirmed()
+			if err != nil {
+				return nil, err
+			}
+
+			return handle(ctx, req, svc.IncomingCalls)
+		},
+		"callHierarchy/outgoingCalls": func(ctx context.Context, req *jrpc2.Request) (interface{}, error) {
+			err := session.CheckInitializationIsConfirmed()
+			if err != nil {
+				return nil, err
+			}
+
+			return handle(ctx, req, svc.OutgoingCalls)
+		},
 		"workspace/executeCommand": func(ctx context.Context, req *jrpc2.Request) (interface{}, error) {
 			err := session.CheckInitializationIsConfirmed()
 			if err != nil {
@@ -368,6 +420,88 @@ func (svc *service) Assigner() (jrpc2.Assigner, error) {
 
 			return handle(ctx, req, svc.WorkspaceSymbol)
 		},
+		"terraform-ls/diagnostics/loadErrors": func(ctx context.Context, req *jrpc2.Request) (interface{}, error) {
+			err := session.CheckInitializationIsConfirmed()
+			if err != nil {
+				return nil, err
+			}
+
+			return handle(ctx, req, svc.LoadErrors)
+		},
+		"terraform-ls/diagnostics/moduleMetrics": func(ctx context.Context, req *jrpc2.Request) (interface{}, error) {
+			err := session.CheckInitializationIsConfirmed()
+			if err != nil {
+				return nil, err
+			}
+
+			return handle(ctx, req, svc.ModuleMetrics)
+		},
+		"terraform-ls/diagnostics/jobs": func(ctx context.Context, req *jrpc2.Request) (interface{}, error) {
+			err := session.CheckInitializationIsConfirmed()
+			if err != nil {
+				return nil, err
+			}
+
+			return handle(ctx, req, svc.Jobs)
+		},
+		"terraform-ls/diagnostics/providerSchemas": func(ctx context.Context, req *jrpc2.Request) (interface{}, error) {
+			err := session.CheckInitializationIsConfirmed()
+			if err != nil {
+				return nil, err
+			}
+
+			return handle(ctx, req, svc.ProviderSchemas)
+		},
+		"terraform-ls/module/variableValue": func(ctx context.Context, req *jrpc2.Request) (interface{}, error) {
+			err := session.CheckInitializationIsConfirmed()
+			if err != nil {
+				return nil, err
+			}
+
+			return handle(ctx, req, svc.VariableValue)
+		},
+		"terraform-ls/module/referenceOrigins": func(ctx context.Context, req *jrpc2.Request) (interface{}, error) {
+			err := session.CheckInitializationIsConfirmed()
+			if err != nil {
+				return nil, err
+			}
+
+			return handle(ctx, req, svc.ModuleReferenceOrigins)
+		},
+		"terraform-ls/workspace/index": func(ctx context.Context, req *jrpc2.Request) (interface{}, error) {
+			err := session.CheckInitializationIsConfirmed()
+			if err != nil {
+				return nil, err
+			}
+
+			return handle(ctx, req, svc.WorkspaceIndex)
+		},
+		"terraform-ls/workspace/openDocuments": func(ctx context.Context, req *jrpc2.Request) (interface{}, error) {
+			err := session.CheckInitializationIsConfirmed()
+			if err != nil {
+				return nil, err
+			}
+
+			ctx = lsctx.WithValidationOptions(ctx, &validationOptions)
+
+			return handle(ctx, req, svc.WorkspaceOpenDocuments)
+		},
+		"terraform-ls/debug/stateDump": func(ctx context.Context, req *jrpc2.Request) (interface{}, error) {
+			err := session.CheckInitializationIsConfirmed()
+			if err != nil {
+				return nil, err
+			}
+
+			return handle(ctx, req, svc.StateDump)
+		},
+		"terraform-ls/debug/activeSchema": func(ctx context.Context, req *jrpc2.Request) (interface{}, error) {
+			err := session.CheckInitializationIsConfirmed()
+			if err != nil {
+				return nil, err
+			}
+
+			return handle(ctx, req, svc.ActiveSchema)
+		},
 		"shutdown": func(ctx context.Context, req *jrpc2.Request) (interface{}, error) {
 			err := session.Shutdown(req)
 			if err != nil {
@@ -431,7 +565,9 @@ func (svc *service) configureSessionDependencies(ctx context.Context, cfgOpts *s
 	}
 
 	// The following is set via CLI flags, hence available in the server context
-	execOpts := &exec.ExecutorOpts{}
+	execOpts := &exec.ExecutorOpts{
+		Logger: svc.logger,
+	}
 	if len(cfgOpts.Terraform.Path) > 0 {
 		execOpts.ExecPath = cfgOpts.Terraform.Path
 	} else {
@@ -454,10 +590,38 @@ func (svc *service) configureSessionDependencies(ctx context.Context, cfgOpts *s
 		execOpts.Timeout = d
 	}
 
-	svc.diagsNotifier = diagnostics.NewNotifier(svc.server, svc.logger)
+	var diagsInterval time.Duration
+	if len(cfgOpts.Validation.PublishInterval) > 0 {
+		d, err := time.ParseDuration(cfgOpts.Validation.PublishInterval)
+		if err != nil {
+			return fmt.Errorf("Failed to parse validation.publishInterval LSP config option: %s", err)
+		}
+		diagsInterval = d
+	}
+
+	svc.diagsNotifier = diagnostics.NewNotifier(svc.server, svc.logger, diagsInterval)
 
 	svc.tfExecOpts = execOpts
 
+	dataDirPath := os.Getenv(datadir.DataDirEnvVar)
+	if cfgOpts.Terraform.DataDir != "" {
+		dataDirPath = cfgOpts.Terraform.DataDir
+	}
+	dataDir, err := datadir.NewDir(dataDirPath)
+	if err != nil {
+		jrpc2.ServerFromContext(ctx).Notify(ctx, "window/showMessage", &lsp.ShowMessageParams{
+			Type: lsp.Warning,
+			Message: fmt.Sprintf("Ignoring data directory %q, using %q instead: %s",
+				dataDirPath, datadir.DataDirName, err),
+		})
+	}
+	svc.dataDir = dataDir
+	if cfgOpts.Terraform.DataDir != "" && err == nil {
+		// Terraform itself only reads TF_DATA_DIR,
+		// so the setting needs to be passed on
+		execOpts.DataDir = dataDir.String()
+	}
+
 	svc.sessCtx = exec.WithExecutorOpts(svc.sessCtx, execOpts)
 	svc.sessCtx = exec.WithExecutorFactory(svc.sessCtx, svc.tfExecFactory)
 
@@ -470,10 +634,13 @@ func (svc *service) configureSessionDependencies(ctx context.Context, cfgOpts *s
 	}
 
 	svc.stateStore.SetLogger(svc.logger)
+	svc.diagsNotifier.SetLineColumnReader(svc.stateStore.DocumentStore)
+	svc.diagsNotifier.SetMaxDiagnosticsPerFile(cfgOpts.Validation.MaxDiagnosticsPerFile)
 
 	moduleHooks := []notifier.Hook{
-		updateDiagnostics(svc.diagsNotifier),
+		updateDiagnostics(svc.diagsNotifier, cfgOpts.Validation),
 		sendModuleTelemetry(svc.stateStore, svc.telemetry),
+		notifySchemaLoadFailures(svc.server, svc.stateStore.ProviderSchemas, svc.stateStore.Modules, &notifiedSchemaFailures{}),
 	}
 
 	svc.lowPrioIndexer = scheduler.NewScheduler(svc.stateStore.JobStore, 1, job.LowPriority)
@@ -522,10 +689,14 @@ func (svc *service) configureSessionDependencies(ctx context.Context, cfgOpts *s
 	svc.indexer = indexer.NewIndexer(svc.fs, svc.modStore, svc.schemaStore, svc.stateStore.RegistryModules,
 		svc.stateStore.JobStore, svc.tfExecFactory, svc.registryClient)
 	svc.indexer.SetLogger(svc.logger)
+	svc.indexer.SetDataDir(svc.dataDir)
 
+	svc.schemaCache = idecoder.NewModuleSchemaCache()
+	svc.hoverCache = idecoder.NewHoverCache(svc.schemaCache)
 	svc.decoder = decoder.NewDecoder(&idecoder.PathReader{
 		ModuleReader: svc.modStore,
 		SchemaReader: svc.schemaStore,
+		SchemaCache:  svc.schemaCache,
 	})
 	decoderContext := idecoder.DecoderContext(ctx)
 	svc.AppendCompletionHooks(decoderContext)
@@ -535,11 +706,13 @@ func (svc *service) configureSessionDependencies(ctx context.Context, cfgOpts *s
 	svc.closedDirWalker = walker.NewWalker(svc.fs, closedPa, svc.modStore, svc.indexer.WalkedModule)
 	svc.closedDirWalker.Collector = svc.walkerCollector
 	svc.closedDirWalker.SetLogger(svc.logger)
+	svc.closedDirWalker.SetDataDir(svc.dataDir)
 
 	opendPa := state.NewPathAwaiter(svc.stateStore.WalkerPaths, true)
 	svc.openDirWalker = walker.NewWalker(svc.fs, opendPa, svc.modStore, svc.indexer.WalkedModule)
 	svc.closedDirWalker.Collector = svc.walkerCollector
 	svc.openDirWalker.SetLogger(svc.logger)
+	svc.openDirWalker.SetDataDir(svc.dataDir)
 
 	return nil
 }
@@ -564,6 +737,12 @@ func (svc *service) Finish(_ jrpc2.Assigner, status jrpc2.ServerStatus) {
 }
 
 func (svc *service) shutdown() {
+	svc.pendingDeletions.Stop()
+
+	if svc.stateStore != nil {
+		svc.dequeueJobsForOpenDocuments()
+	}
+
 	if svc.closedDirWalker != nil {
 		svc.logger.Printf("stopping closedDirWalker for session ...")
 		svc.closedDirWalker.Stop()
@@ -583,6 +762,31 @@ func (svc *service) shutdown() {
 	}
 }
 
+// dequeueJobsForOpenDocuments removes any queued jobs
+// for directories of documents which are still open,
+// so that no more per-document work is dispatched
+// while the session is shutting down.
+func (svc *service) dequeueJobsForOpenDocuments() {
+	handles, err := svc.stateStore.DocumentStore.ListOpenDocuments()
+	if err != nil {
+		svc.logger.Printf("failed to list open documents: %s", err)
+		return
+	}
+
+	dirs := make(map[document.DirHandle]bool, 0)
+	for _, dh := range handles {
+		dirs[dh.Dir] = true
+	}
+
+	for dir := range dirs {
+		err := svc.stateStore.JobStore.DequeueJobsForDir(dir)
+		if err != nil {
+			svc.logger.Printf("failed to dequeue jobs for %q: %s", dir.URI, err)
+		}
+	}
+	svc.logger.Printf("dequeued jobs for %d open documents", len(handles))
+}
+
 // convertMap is a helper function allowing us to omit the jrpc2.Func
 // signature from the method definitions
 func convertMap(m map[string]rpch.Func) rpch.Map {
@@ -0,0 +1,150 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/creachadair/jrpc2"
+	"github.com/hashicorp/terraform-ls/internal/document"
+	op "github.com/hashicorp/terraform-ls/internal/terraform/module/operation"
+)
+
+const stateDumpVersion = 0
+
+type stateDumpResponse struct {
+	FormatVersion int                `json:"v"`
+	Modules       []moduleStateDump  `json:"modules"`
+	Documents     []documentDump     `json:"documents"`
+	Jobs          []dirJobsStateDump `json:"jobs"`
+}
+
+type moduleStateDump struct {
+	Path             string            `json:"path"`
+	States           map[string]string `json:"states"`
+	Errors           map[string]string `json:"errors"`
+	DiagnosticCounts map[string]int    `json:"diagnostic_counts"`
+}
+
+type documentDump struct {
+	URI        string `json:"uri"`
+	LanguageID string `json:"language_id"`
+	Version    int    `json:"version"`
+}
+
+type dirJobsStateDump struct {
+	Path string    `json:"path"`
+	Jobs []jobInfo `json:"jobs"`
+}
+
+// StateDump returns the in-memory state of all modules, open documents
+// and jobs, excluding any file contents, e.g. to be attached to bug reports.
+// It is only available when the debug option is enabled.
+func (svc *service) StateDump(ctx context.Context, _ interface{}) (stateDumpResponse, error) {
+	response := stateDumpResponse{
+		FormatVersion: stateDumpVersion,
+		Modules:       make([]moduleStateDump, 0),
+		Documents:     make([]documentDump, 0),
+		Jobs:          make([]dirJobsStateDump, 0),
+	}
+
+	if !svc.debugMode {
+		return response, fmt.Errorf("%w: debug requests require the debug option to be enabled",
+			jrpc2.MethodNotFound.Err())
+	}
+
+	mods, err := svc.modStore.List()
+	if err != nil {
+		return response, err
+	}
+	sort.SliceStable(mods, func(i, j int) bool {
+		return mods[i].Path < mods[j].Path
+	})
+
+	for _, mod := range mods {
+		states := map[string]op.OpState{
+			"ModManifestState":           mod.ModManifestState,
+			"TerraformVersionState":      mod.TerraformVersionState,
+			"InstalledProvidersState":    mod.InstalledProvidersState,
+			"ProviderSchemaState":        mod.ProviderSchemaState,
+			"PreloadEmbeddedSchemaState": mod.PreloadEmbeddedSchemaState,
+			"RefTargetsState":            mod.RefTargetsState,
+			"RefOriginsState":            mod.RefOriginsState,
+			"VarsRefOriginsState":        mod.VarsRefOriginsState,
+			"MetaState":                  mod.MetaState,
+			"MetricsState":               mod.MetricsState,
+		}
+		for source, state := range mod.ModuleDiagnosticsState {
+			states["ModuleDiagnosticsState."+source.Name()] = state
+		}
+		for source, state := range mod.VarsDiagnosticsState {
+			states["VarsDiagnosticsState."+source.Name()] = state
+		}
+
+		diagCounts, err := svc.modStore.DiagnosticCounts(mod.Path)
+		if err != nil {
+			return response, err
+		}
+
+		modDump := moduleStateDump{
+			Path:             mod.Path,
+			States:           make(map[string]string, len(states)),
+			Errors:           make(map[string]string, 0),
+			DiagnosticCounts: make(map[string]int, len(diagCounts)),
+		}
+		for field, state := range states {
+			modDump.States[field] = state.String()
+		}
+		for field, err := range moduleLoadErrors(mod) {
+			if err != nil {
+				modDump.Errors[field] = err.Error()
+			}
+		}
+		for source, count := range diagCounts {
+			modDump.DiagnosticCounts[source.Name()] = count
+		}
+		response.Modules = append(response.Modules, modDump)
+
+		jobs, err := svc.stateStore.JobStore.JobsForDir(document.DirHandleFromPath(mod.Path))
+		if err != nil {
+			return response, err
+		}
+		dirJobs := dirJobsStateDump{
+			Path: mod.Path,
+			Jobs: make([]jobInfo, 0, len(jobs)),
+		}
+		for _, j := range jobs {
+			dirJobs.Jobs = append(dirJobs.Jobs, jobInfo{
+				ID:        j.ID.String(),
+				Type:      j.Type,
+				State:     j.State.String(),
+				DependsOn: j.DependsOn.StringSlice(),
+			})
+		}
+		response.Jobs = append(response.Jobs, dirJobs)
+	}
+
+	handles, err := svc.stateStore.DocumentStore.ListOpenDocuments()
+	if err != nil {
+		return response, err
+	}
+	for _, dh := range handles {
+		doc, err := svc.stateStore.DocumentStore.GetDocument(dh)
+		if err != nil {
+			return response, err
+		}
+		response.Documents = append(response.Documents, documentDump{
+			URI:        dh.FullURI(),
+			LanguageID: doc.LanguageID,
+			Version:    doc.Version,
+		})
+	}
+	sort.SliceStable(response.Documents, func(i, j int) bool {
+		return response.Documents[i].URI < response.Documents[j].URI
+	})
+
+	return response, nil
+}
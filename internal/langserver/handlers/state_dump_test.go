@@ -0,0 +1,118 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/terraform-ls/internal/document"
+	"github.com/hashicorp/terraform-ls/internal/langserver"
+	"github.com/hashicorp/terraform-ls/internal/state"
+	"github.com/hashicorp/terraform-ls/internal/terraform/ast"
+	"github.com/hashicorp/terraform-ls/internal/terraform/exec"
+	"github.com/hashicorp/terraform-ls/internal/walker"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestLangServer_stateDump(t *testing.T) {
+	rootDir := document.DirHandleFromPath(t.TempDir())
+	modDir := t.TempDir()
+
+	ss, err := state.NewStateStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = ss.Modules.Add(modDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = ss.Modules.UpdateParsedModuleFiles(modDir, nil, errors.New("invalid configuration"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = ss.Modules.FinishPreloadEmbeddedSchema(modDir, errors.New("schema not found"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = ss.Modules.UpdateModuleDiagnostics(modDir, ast.HCLParsingSource, ast.ModDiags{
+		"main.tf": hcl.Diagnostics{
+			{Severity: hcl.DiagError, Summary: "Argument or block definition required"},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	wc := walker.NewWalkerCollector()
+
+	ls := langserver.NewLangServerMock(t, NewMockSession(&MockSessionInput{
+		TerraformCalls: &exec.TerraformMockCalls{
+			PerWorkDir: map[string][]*mock.Call{
+				rootDir.Path(): validTfMockCalls(),
+			},
+		},
+		StateStore:      ss,
+		WalkerCollector: wc,
+	}))
+	stop := ls.Start(t)
+	defer stop()
+
+	ls.Call(t, &langserver.CallRequest{
+		Method: "initialize",
+		ReqParams: fmt.Sprintf(`{
+		"capabilities": {},
+		"rootUri": %q,
+		"processId": 12345,
+		"initializationOptions": {
+			"debug": true
+		}
+	}`, rootDir.URI)})
+	waitForWalkerPath(t, ss, wc, rootDir)
+	ls.Notify(t, &langserver.CallRequest{
+		Method:    "initialized",
+		ReqParams: "{}",
+	})
+
+	rsp := ls.Call(t, &langserver.CallRequest{
+		Method:    "terraform-ls/debug/stateDump",
+		ReqParams: "{}",
+	})
+
+	var dump stateDumpResponse
+	err = json.Unmarshal(rsp.Result, &dump)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(dump.Modules) != 1 {
+		t.Fatalf("expected 1 module, %d given: %#v", len(dump.Modules), dump.Modules)
+	}
+	mod := dump.Modules[0]
+	if mod.Path != modDir {
+		t.Fatalf("expected module path %q, %q given", modDir, mod.Path)
+	}
+	expectedStates := map[string]string{
+		"MetaState":                         "OpStateUnknown",
+		"ModuleDiagnosticsState.HCLParsing": "OpStateLoaded",
+	}
+	for field, expectedState := range expectedStates {
+		if state := mod.States[field]; state != expectedState {
+			t.Fatalf("expected %s to be %q, %q given", field, expectedState, state)
+		}
+	}
+	if parsingErr := mod.Errors["ModuleParsingErr"]; parsingErr != "invalid configuration" {
+		t.Fatalf("unexpected ModuleParsingErr: %q", parsingErr)
+	}
+	if preloadErr := mod.Errors["PreloadEmbeddedSchemaErr"]; preloadErr != "schema not found" {
+		t.Fatalf("unexpected PreloadEmbeddedSchemaErr: %q", preloadErr)
+	}
+	if count := mod.DiagnosticCounts["HCLParsing"]; count != 1 {
+		t.Fatalf("expected 1 HCLParsing diagnostic, %d given", count)
+	}
+}
@@ -34,5 +34,5 @@ func (svc *service) TextDocumentSymbol(ctx context.Context, params lsp.DocumentS
 		return symbols, err
 	}
 
-	return ilsp.DocumentSymbols(sbs, cc.TextDocument.DocumentSymbol), nil
+	return ilsp.DocumentSymbols(sbs, cc.TextDocument.DocumentSymbol, doc.LineColumn()), nil
 }
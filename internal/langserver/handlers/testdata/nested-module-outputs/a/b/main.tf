@@ -0,0 +1,7 @@
+module "c" {
+  source = "./c"
+}
+
+output "from_c" {
+  value = module.c.value
+}
@@ -0,0 +1,7 @@
+module "a" {
+  source = "./a"
+}
+
+output "greeting" {
+  value = module.a.from_b
+}
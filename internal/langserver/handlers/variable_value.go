@@ -0,0 +1,170 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	ilsp "github.com/hashicorp/terraform-ls/internal/lsp"
+	lsp "github.com/hashicorp/terraform-ls/internal/protocol"
+	"github.com/hashicorp/terraform-ls/internal/terraform/ast"
+	"github.com/zclconf/go-cty/cty"
+	ctyjson "github.com/zclconf/go-cty/cty/json"
+)
+
+const variableValueVersion = 0
+
+const (
+	variableValueSourceTfvars  = "tfvars"
+	variableValueSourceDefault = "default"
+)
+
+type variableValueResponse struct {
+	FormatVersion int    `json:"v"`
+	Name          string `json:"name"`
+	// Source is either "tfvars" or "default",
+	// or empty if the variable has no known value.
+	Source    string          `json:"source,omitempty"`
+	Filename  string          `json:"filename,omitempty"`
+	Sensitive bool            `json:"sensitive"`
+	Value     json.RawMessage `json:"value,omitempty"`
+}
+
+// VariableValue returns the effective value of the variable declared
+// at the given position, as Terraform would resolve it from autoloaded
+// variable files and the declared default value, i.e. without
+// considering any -var or -var-file flags, or environment variables.
+func (svc *service) VariableValue(ctx context.Context, params lsp.TextDocumentPositionParams) (*variableValueResponse, error) {
+	dh := ilsp.HandleFromDocumentURI(params.TextDocument.URI)
+	doc, err := svc.stateStore.DocumentStore.GetDocument(dh)
+	if err != nil {
+		return nil, err
+	}
+
+	pos, err := ilsp.HCLPositionFromLspPosition(params.Position, doc)
+	if err != nil {
+		return nil, err
+	}
+
+	mod, err := svc.modStore.ModuleByPath(doc.Dir.Path())
+	if err != nil {
+		return nil, err
+	}
+
+	name, ok := variableNameAtPos(mod.ParsedModuleFiles, doc.Filename, pos)
+	if !ok {
+		return nil, nil
+	}
+
+	response := &variableValueResponse{
+		FormatVersion: variableValueVersion,
+		Name:          name,
+	}
+
+	variable, declared := mod.Meta.Variables[name]
+	if declared {
+		response.Sensitive = variable.IsSensitive
+	}
+
+	var val cty.Value
+	if filename, tfvarsVal, ok := autoloadedVariableValue(mod.ParsedVarsFiles, name); ok {
+		response.Source = variableValueSourceTfvars
+		response.Filename = filename.String()
+		val = tfvarsVal
+	} else if declared && variable.DefaultValue.Type() != cty.NilType {
+		response.Source = variableValueSourceDefault
+		val = variable.DefaultValue
+	} else {
+		return response, nil
+	}
+
+	// avoid leaking sensitive values to the client
+	if response.Sensitive {
+		return response, nil
+	}
+
+	response.Value, err = ctyjson.Marshal(val, val.Type())
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+func variableNameAtPos(files ast.ModFiles, filename string, pos hcl.Pos) (string, bool) {
+	f, ok := files[ast.ModFilename(filename)]
+	if !ok {
+		return "", false
+	}
+	body, ok := f.Body.(*hclsyntax.Body)
+	if !ok {
+		return "", false
+	}
+
+	for _, block := range body.Blocks {
+		if block.Type == "variable" && len(block.Labels) == 1 && block.Range().ContainsPos(pos) {
+			return block.Labels[0], true
+		}
+	}
+
+	return "", false
+}
+
+// autoloadedVariableValue returns value of the named variable from
+// autoloaded variable files, following the precedence Terraform uses,
+// where terraform.tfvars is loaded first, followed by terraform.tfvars.json
+// and *.auto.tfvars(.json) files in lexical order, each overriding
+// values from files loaded before it.
+func autoloadedVariableValue(files ast.VarsFiles, name string) (ast.VarsFilename, cty.Value, bool) {
+	filenames := make([]ast.VarsFilename, 0)
+	for filename := range files {
+		if filename.IsAutoloaded() {
+			filenames = append(filenames, filename)
+		}
+	}
+	sort.SliceStable(filenames, func(i, j int) bool {
+		iRank, jRank := varsFileRank(filenames[i]), varsFileRank(filenames[j])
+		if iRank != jRank {
+			return iRank < jRank
+		}
+		return filenames[i] < filenames[j]
+	})
+
+	var (
+		valueFilename ast.VarsFilename
+		value         cty.Value
+		found         bool
+	)
+	for _, filename := range filenames {
+		attrs, diags := files[filename].Body.JustAttributes()
+		if diags.HasErrors() {
+			continue
+		}
+		attr, ok := attrs[name]
+		if !ok {
+			continue
+		}
+		val, diags := attr.Expr.Value(nil)
+		if diags.HasErrors() {
+			continue
+		}
+		valueFilename, value, found = filename, val, true
+	}
+
+	return valueFilename, value, found
+}
+
+func varsFileRank(filename ast.VarsFilename) int {
+	switch filename {
+	case "terraform.tfvars":
+		return 0
+	case "terraform.tfvars.json":
+		return 1
+	}
+	return 2
+}
@@ -0,0 +1,146 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-ls/internal/document"
+	"github.com/hashicorp/terraform-ls/internal/langserver"
+	"github.com/hashicorp/terraform-ls/internal/state"
+	"github.com/hashicorp/terraform-ls/internal/terraform/exec"
+	"github.com/hashicorp/terraform-ls/internal/walker"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestLangServer_variableValue(t *testing.T) {
+	modPath := t.TempDir()
+	modHandle := document.DirHandleFromPath(modPath)
+
+	cfg := `variable "instance_type" {
+  default = "t2.micro"
+}
+
+variable "region" {
+  default = "eu-west-1"
+}
+
+variable "name" {
+}
+`
+	err := os.WriteFile(filepath.Join(modPath, "variables.tf"), []byte(cfg), 0o755)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = os.WriteFile(filepath.Join(modPath, "terraform.tfvars"),
+		[]byte("instance_type = \"t3.large\"\n"), 0o755)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ss, err := state.NewStateStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+	wc := walker.NewWalkerCollector()
+
+	ls := langserver.NewLangServerMock(t, NewMockSession(&MockSessionInput{
+		TerraformCalls: &exec.TerraformMockCalls{
+			PerWorkDir: map[string][]*mock.Call{
+				modPath: validTfMockCalls(),
+			},
+		},
+		StateStore:      ss,
+		WalkerCollector: wc,
+	}))
+	stop := ls.Start(t)
+	defer stop()
+
+	ls.Call(t, &langserver.CallRequest{
+		Method: "initialize",
+		ReqParams: fmt.Sprintf(`{
+		"capabilities": {},
+		"rootUri": %q,
+		"processId": 12345
+	}`, modHandle.URI)})
+	waitForWalkerPath(t, ss, wc, modHandle)
+	ls.Notify(t, &langserver.CallRequest{
+		Method:    "initialized",
+		ReqParams: "{}",
+	})
+	ls.Call(t, &langserver.CallRequest{
+		Method: "textDocument/didOpen",
+		ReqParams: fmt.Sprintf(`{
+		"textDocument": {
+			"version": 0,
+			"languageId": "terraform",
+			"text": %q,
+			"uri": "%s/variables.tf"
+		}
+	}`, cfg, modHandle.URI)})
+	waitForAllJobs(t, ss)
+
+	testCases := []struct {
+		line             int
+		expectedResponse *variableValueResponse
+	}{
+		{
+			line: 1,
+			expectedResponse: &variableValueResponse{
+				Name:     "instance_type",
+				Source:   "tfvars",
+				Filename: "terraform.tfvars",
+				Value:    json.RawMessage(`"t3.large"`),
+			},
+		},
+		{
+			line: 5,
+			expectedResponse: &variableValueResponse{
+				Name:   "region",
+				Source: "default",
+				Value:  json.RawMessage(`"eu-west-1"`),
+			},
+		},
+		{
+			line: 8,
+			expectedResponse: &variableValueResponse{
+				Name: "name",
+			},
+		},
+		{
+			line:             3,
+			expectedResponse: nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(fmt.Sprintf("line %d", tc.line), func(t *testing.T) {
+			rsp := ls.Call(t, &langserver.CallRequest{
+				Method: "terraform-ls/module/variableValue",
+				ReqParams: fmt.Sprintf(`{
+				"textDocument": {
+					"uri": "%s/variables.tf"
+				},
+				"position": {
+					"line": %d,
+					"character": 0
+				}
+			}`, modHandle.URI, tc.line)})
+
+			var response *variableValueResponse
+			err := json.Unmarshal(rsp.Result, &response)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tc.expectedResponse, response); diff != "" {
+				t.Fatalf("unexpected response: %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,79 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package handlers
+
+import (
+	"sync"
+	"time"
+)
+
+// renameWindow represents how long reparsing of a module is deferred
+// after one of its files was deleted. Clients report renames
+// as a deletion followed by a creation, so a file created
+// in the same directory within the window is treated as a rename,
+// which requires the module to be reparsed only once.
+const renameWindow = 200 * time.Millisecond
+
+// pendingDeletions tracks deferred reparsing of directories
+// in which files were recently deleted.
+type pendingDeletions struct {
+	mu      sync.Mutex
+	pending map[string]*time.Timer
+}
+
+// Defer schedules reparseFunc to be called once the window elapses,
+// unless cancelled in the meantime. Any reparsing already pending
+// for the same directory is rescheduled.
+func (pd *pendingDeletions) Defer(dirPath string, window time.Duration, reparseFunc func()) {
+	pd.mu.Lock()
+	defer pd.mu.Unlock()
+
+	if pd.pending == nil {
+		pd.pending = make(map[string]*time.Timer)
+	}
+	if timer, ok := pd.pending[dirPath]; ok {
+		timer.Stop()
+	}
+
+	var timer *time.Timer
+	timer = time.AfterFunc(window, func() {
+		pd.mu.Lock()
+		if pd.pending[dirPath] != timer {
+			// rescheduled or cancelled while firing
+			pd.mu.Unlock()
+			return
+		}
+		delete(pd.pending, dirPath)
+		pd.mu.Unlock()
+
+		reparseFunc()
+	})
+	pd.pending[dirPath] = timer
+}
+
+// Cancel cancels reparsing pending for the given directory
+// and reports whether there was any.
+func (pd *pendingDeletions) Cancel(dirPath string) bool {
+	pd.mu.Lock()
+	defer pd.mu.Unlock()
+
+	timer, ok := pd.pending[dirPath]
+	if !ok {
+		return false
+	}
+	timer.Stop()
+	delete(pd.pending, dirPath)
+	return true
+}
+
+// Stop cancels reparsing pending for all directories.
+func (pd *pendingDeletions) Stop() {
+	pd.mu.Lock()
+	defer pd.mu.Unlock()
+
+	for dirPath, timer := range pd.pending {
+		timer.Stop()
+		delete(pd.pending, dirPath)
+	}
+}
@@ -0,0 +1,71 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/creachadair/jrpc2"
+	"github.com/hashicorp/terraform-ls/internal/document"
+	"github.com/hashicorp/terraform-ls/internal/uri"
+)
+
+const workspaceIndexVersion = 0
+
+type workspaceIndexParams struct {
+	URI string `json:"uri"`
+}
+
+type workspaceIndexResponse struct {
+	FormatVersion int      `json:"v"`
+	Modules       []string `json:"modules"`
+}
+
+// WorkspaceIndex enqueues the given directory for walking and returns
+// once the walk finishes, along with paths of all modules discovered
+// within it, e.g. for clients which do not send rootUri on initialize
+// but still want a particular directory to be indexed.
+func (svc *service) WorkspaceIndex(ctx context.Context, params workspaceIndexParams) (workspaceIndexResponse, error) {
+	response := workspaceIndexResponse{
+		FormatVersion: workspaceIndexVersion,
+		Modules:       make([]string, 0),
+	}
+
+	if params.URI == "" {
+		return response, fmt.Errorf("%w: expected uri to be set", jrpc2.InvalidParams.Err())
+	}
+	if !uri.IsURIValid(params.URI) {
+		return response, fmt.Errorf("%w: URI %q is not valid", jrpc2.InvalidParams.Err(), params.URI)
+	}
+
+	dir := document.DirHandleFromURI(params.URI)
+
+	err := svc.stateStore.WalkerPaths.EnqueueDir(ctx, dir)
+	if err != nil {
+		return response, err
+	}
+	err = svc.stateStore.WalkerPaths.WaitForDirs(ctx, []document.DirHandle{dir})
+	if err != nil {
+		return response, err
+	}
+
+	mods, err := svc.modStore.List()
+	if err != nil {
+		return response, err
+	}
+
+	dirPath := dir.Path()
+	for _, mod := range mods {
+		if mod.Path == dirPath || strings.HasPrefix(mod.Path, dirPath+string(filepath.Separator)) {
+			response.Modules = append(response.Modules, mod.Path)
+		}
+	}
+	sort.Strings(response.Modules)
+
+	return response, nil
+}
@@ -0,0 +1,107 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/creachadair/jrpc2"
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-ls/internal/document"
+	"github.com/hashicorp/terraform-ls/internal/langserver"
+	"github.com/hashicorp/terraform-ls/internal/state"
+	"github.com/hashicorp/terraform-ls/internal/terraform/exec"
+	"github.com/hashicorp/terraform-ls/internal/walker"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestLangServer_workspaceIndex(t *testing.T) {
+	rootModPath, err := filepath.Abs(filepath.Join("testdata", "single-submodule"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	submodPath := filepath.Join(rootModPath, "application")
+	rootModHandle := document.DirHandleFromPath(rootModPath)
+
+	ss, err := state.NewStateStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+	wc := walker.NewWalkerCollector()
+
+	ls := langserver.NewLangServerMock(t, NewMockSession(&MockSessionInput{
+		TerraformCalls: &exec.TerraformMockCalls{
+			PerWorkDir: map[string][]*mock.Call{
+				rootModPath: validTfMockCalls(),
+				submodPath:  validTfMockCalls(),
+			},
+		},
+		StateStore:      ss,
+		WalkerCollector: wc,
+	}))
+	stop := ls.Start(t)
+	defer stop()
+
+	// no rootUri, i.e. nothing gets walked until requested
+	ls.Call(t, &langserver.CallRequest{
+		Method: "initialize",
+		ReqParams: `{
+		"capabilities": {},
+		"initializationOptions": {
+			"ignoreSingleFileWarning": true
+		},
+		"processId": 12345
+	}`})
+	ls.Notify(t, &langserver.CallRequest{
+		Method:    "initialized",
+		ReqParams: "{}",
+	})
+
+	rsp := ls.Call(t, &langserver.CallRequest{
+		Method:    "terraform-ls/workspace/index",
+		ReqParams: fmt.Sprintf(`{"uri": %q}`, rootModHandle.URI),
+	})
+
+	var response workspaceIndexResponse
+	err = json.Unmarshal(rsp.Result, &response)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectedResponse := workspaceIndexResponse{
+		Modules: []string{rootModPath, submodPath},
+	}
+	if diff := cmp.Diff(expectedResponse, response); diff != "" {
+		t.Fatalf("unexpected response: %s", diff)
+	}
+
+	waitForWalkerPath(t, ss, wc, rootModHandle)
+}
+
+func TestLangServer_workspaceIndex_invalidURI(t *testing.T) {
+	ls := langserver.NewLangServerMock(t, NewMockSession(&MockSessionInput{}))
+	stop := ls.Start(t)
+	defer stop()
+
+	ls.Call(t, &langserver.CallRequest{
+		Method: "initialize",
+		ReqParams: `{
+		"capabilities": {},
+		"initializationOptions": {
+			"ignoreSingleFileWarning": true
+		},
+		"processId": 12345
+	}`})
+	ls.Notify(t, &langserver.CallRequest{
+		Method:    "initialized",
+		ReqParams: "{}",
+	})
+
+	ls.CallAndExpectError(t, &langserver.CallRequest{
+		Method:    "terraform-ls/workspace/index",
+		ReqParams: `{"uri": "meh"}`,
+	}, jrpc2.InvalidParams.Err())
+}
@@ -0,0 +1,67 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package handlers
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-ls/internal/document"
+	"github.com/hashicorp/terraform-ls/internal/job"
+	lsp "github.com/hashicorp/terraform-ls/internal/protocol"
+)
+
+const workspaceOpenDocumentsVersion = 0
+
+type workspaceOpenDocumentsParams struct {
+	TextDocuments []lsp.TextDocumentItem `json:"textDocuments"`
+}
+
+type workspaceOpenDocumentsResponse struct {
+	FormatVersion int `json:"v"`
+	// Modules represents paths of modules indexed as a result
+	// of opening the documents, each of them indexed once.
+	Modules []string `json:"modules"`
+}
+
+// WorkspaceOpenDocuments opens the given documents, as if each of them
+// was opened via textDocument/didOpen, e.g. when the client restores
+// a previous session. Modules are indexed once for all of their documents
+// and the response is sent once indexing of all modules finishes.
+func (svc *service) WorkspaceOpenDocuments(ctx context.Context, params workspaceOpenDocumentsParams) (workspaceOpenDocumentsResponse, error) {
+	response := workspaceOpenDocumentsResponse{
+		FormatVersion: workspaceOpenDocumentsVersion,
+		Modules:       make([]string, 0),
+	}
+
+	modHandles := make([]document.DirHandle, 0)
+	seen := make(map[document.DirHandle]bool)
+	for _, item := range params.TextDocuments {
+		modHandle, err := svc.openDocument(ctx, item)
+		if err != nil {
+			return response, err
+		}
+		if seen[modHandle] {
+			continue
+		}
+		seen[modHandle] = true
+		modHandles = append(modHandles, modHandle)
+	}
+
+	jobIds := make(job.IDs, 0)
+	for _, modHandle := range modHandles {
+		ids, err := svc.indexOpenedModule(ctx, modHandle)
+		if err != nil {
+			return response, err
+		}
+		jobIds = append(jobIds, ids...)
+		response.Modules = append(response.Modules, modHandle.Path())
+	}
+
+	err := svc.stateStore.JobStore.WaitForJobs(ctx, jobIds...)
+	if err != nil {
+		return response, err
+	}
+
+	return response, nil
+}
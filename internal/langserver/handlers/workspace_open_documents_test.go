@@ -0,0 +1,119 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-ls/internal/document"
+	"github.com/hashicorp/terraform-ls/internal/langserver"
+	"github.com/hashicorp/terraform-ls/internal/state"
+	"github.com/hashicorp/terraform-ls/internal/terraform/exec"
+	"github.com/hashicorp/terraform-ls/internal/walker"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestLangServer_workspaceOpenDocuments(t *testing.T) {
+	rootPath := t.TempDir()
+	rootHandle := document.DirHandleFromPath(rootPath)
+
+	modPaths := []string{
+		filepath.Join(rootPath, "network"),
+		filepath.Join(rootPath, "compute"),
+	}
+	items := make([]string, 0)
+	docHandles := make([]document.Handle, 0)
+	for _, modPath := range modPaths {
+		err := os.MkdirAll(modPath, 0o755)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for i := 0; i < 5; i++ {
+			filename := fmt.Sprintf("file-%d.tf", i)
+			text := fmt.Sprintf("variable \"var_%d\" {}\n", i)
+			err := os.WriteFile(filepath.Join(modPath, filename), []byte(text), 0o755)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			dh := document.HandleFromPath(filepath.Join(modPath, filename))
+			docHandles = append(docHandles, dh)
+			items = append(items, fmt.Sprintf(`{
+				"version": 0,
+				"languageId": "terraform",
+				"text": %q,
+				"uri": %q
+			}`, text, dh.FullURI()))
+		}
+	}
+
+	ss, err := state.NewStateStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+	wc := walker.NewWalkerCollector()
+
+	ls := langserver.NewLangServerMock(t, NewMockSession(&MockSessionInput{
+		TerraformCalls: &exec.TerraformMockCalls{
+			PerWorkDir: map[string][]*mock.Call{
+				rootPath:    validTfMockCalls(),
+				modPaths[0]: validTfMockCalls(),
+				modPaths[1]: validTfMockCalls(),
+			},
+		},
+		StateStore:      ss,
+		WalkerCollector: wc,
+	}))
+	stop := ls.Start(t)
+	defer stop()
+
+	ls.Call(t, &langserver.CallRequest{
+		Method: "initialize",
+		ReqParams: fmt.Sprintf(`{
+		"capabilities": {},
+		"rootUri": %q,
+		"processId": 12345
+	}`, rootHandle.URI)})
+	waitForWalkerPath(t, ss, wc, rootHandle)
+	ls.Notify(t, &langserver.CallRequest{
+		Method:    "initialized",
+		ReqParams: "{}",
+	})
+
+	rsp := ls.Call(t, &langserver.CallRequest{
+		Method: "terraform-ls/workspace/openDocuments",
+		ReqParams: fmt.Sprintf(`{
+		"textDocuments": [%s]
+	}`, strings.Join(items, ","))})
+
+	var response workspaceOpenDocumentsResponse
+	err = json.Unmarshal(rsp.Result, &response)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// each module is expected to be indexed once for all its documents
+	expectedResponse := workspaceOpenDocumentsResponse{
+		Modules: modPaths,
+	}
+	if diff := cmp.Diff(expectedResponse, response); diff != "" {
+		t.Fatalf("unexpected response: %s", diff)
+	}
+
+	for _, dh := range docHandles {
+		isOpen, err := ss.DocumentStore.IsDocumentOpen(dh)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !isOpen {
+			t.Fatalf("expected %q to be open", dh.FullURI())
+		}
+	}
+}
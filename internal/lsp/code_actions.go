@@ -5,6 +5,7 @@ package lsp
 
 import (
 	"sort"
+	"strings"
 
 	lsp "github.com/hashicorp/terraform-ls/internal/protocol"
 )
@@ -33,8 +34,16 @@ var (
 	// We do not register this for terraform to allow fine grained selection of actions.
 	// A user should be able to set `source.formatAll` to true, and source.formatAll.terraform to false to allow all
 	// files to be formatted, but not terraform files (or vice versa).
+	//
+	// `quickfix`: Quick fixes address a particular problem (typically a diagnostic)
+	// and are shown in the normal lightbulb menu.
+	//
+	// `refactor.*`: Refactoring actions are shown in the refactor context menu.
 	SupportedCodeActions = CodeActions{
 		SourceFormatAllTerraform: true,
+		lsp.QuickFix:             true,
+		lsp.RefactorExtract:      true,
+		lsp.RefactorRewrite:      true,
 	}
 )
 
@@ -50,12 +59,20 @@ func (c CodeActions) AsSlice() []lsp.CodeActionKind {
 	return s
 }
 
+// Only returns the code actions matching any of the requested kinds.
+// Kinds are hierarchical, i.e. requesting `refactor` also
+// matches `refactor.extract`.
 func (ca CodeActions) Only(only []lsp.CodeActionKind) CodeActions {
 	wanted := make(CodeActions, 0)
 
-	for _, kind := range only {
-		if v, ok := ca[kind]; ok {
-			wanted[kind] = v
+	for _, requested := range only {
+		if requested == "" {
+			continue
+		}
+		for kind, v := range ca {
+			if kind == requested || strings.HasPrefix(string(kind), string(requested)+".") {
+				wanted[kind] = v
+			}
 		}
 	}
 
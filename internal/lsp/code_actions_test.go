@@ -0,0 +1,57 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package lsp
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	lsp "github.com/hashicorp/terraform-ls/internal/protocol"
+)
+
+func TestCodeActions_Only(t *testing.T) {
+	testCases := []struct {
+		only     []lsp.CodeActionKind
+		expected CodeActions
+	}{
+		{
+			[]lsp.CodeActionKind{""},
+			CodeActions{},
+		},
+		{
+			[]lsp.CodeActionKind{"source.fixAll"},
+			CodeActions{},
+		},
+		{
+			[]lsp.CodeActionKind{"source.formatAll.terraform"},
+			CodeActions{SourceFormatAllTerraform: true},
+		},
+		{
+			[]lsp.CodeActionKind{"source"},
+			CodeActions{SourceFormatAllTerraform: true},
+		},
+		{
+			[]lsp.CodeActionKind{"refactor", "quickfix"},
+			CodeActions{
+				lsp.QuickFix:        true,
+				lsp.RefactorExtract: true,
+				lsp.RefactorRewrite: true,
+			},
+		},
+		{
+			[]lsp.CodeActionKind{"refactor.extract.function"},
+			CodeActions{},
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%d", i), func(t *testing.T) {
+			wanted := SupportedCodeActions.Only(tc.only)
+			if diff := cmp.Diff(tc.expected, wanted); diff != "" {
+				t.Fatalf("unexpected code actions: %s", diff)
+			}
+		})
+	}
+}
@@ -5,9 +5,18 @@ package lsp
 
 import (
 	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/terraform-ls/internal/document"
 	lsp "github.com/hashicorp/terraform-ls/internal/protocol"
 )
 
+// HintDiagnostic can be attached to a diagnostic as Extra
+// to have it reported with hint severity, which HCL lacks.
+type HintDiagnostic struct{}
+
+// InformationDiagnostic can be attached to a diagnostic as Extra
+// to have it reported with information severity, which HCL lacks.
+type InformationDiagnostic struct{}
+
 func HCLSeverityToLSP(severity hcl.DiagnosticSeverity) lsp.DiagnosticSeverity {
 	var sev lsp.DiagnosticSeverity
 	switch severity {
@@ -22,6 +31,18 @@ func HCLSeverityToLSP(severity hcl.DiagnosticSeverity) lsp.DiagnosticSeverity {
 }
 
 func HCLDiagsToLSP(hclDiags hcl.Diagnostics, source string) []lsp.Diagnostic {
+	return hclDiagsToLSP(hclDiags, source, HCLRangeToLSP)
+}
+
+// HCLDiagsToLSPInDocument converts diagnostics of the given document
+// with columns counted in UTF-16 code units, as expected by LSP clients.
+func HCLDiagsToLSPInDocument(hclDiags hcl.Diagnostics, source string, lc document.LineColumn) []lsp.Diagnostic {
+	return hclDiagsToLSP(hclDiags, source, func(rng hcl.Range) lsp.Range {
+		return HCLRangeToLSPInDocument(rng, lc)
+	})
+}
+
+func hclDiagsToLSP(hclDiags hcl.Diagnostics, source string, convertRange func(hcl.Range) lsp.Range) []lsp.Diagnostic {
 	diags := []lsp.Diagnostic{}
 
 	for _, hclDiag := range hclDiags {
@@ -31,11 +52,18 @@ func HCLDiagsToLSP(hclDiags hcl.Diagnostics, source string) []lsp.Diagnostic {
 		}
 		var rnge lsp.Range
 		if hclDiag.Subject != nil {
-			rnge = HCLRangeToLSP(*hclDiag.Subject)
+			rnge = convertRange(*hclDiag.Subject)
+		}
+		severity := HCLSeverityToLSP(hclDiag.Severity)
+		switch hclDiag.Extra.(type) {
+		case HintDiagnostic:
+			severity = lsp.SeverityHint
+		case InformationDiagnostic:
+			severity = lsp.SeverityInformation
 		}
 		diags = append(diags, lsp.Diagnostic{
 			Range:    rnge,
-			Severity: HCLSeverityToLSP(hclDiag.Severity),
+			Severity: severity,
 			Source:   source,
 			Message:  msg,
 		})
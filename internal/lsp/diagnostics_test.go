@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	"github.com/hashicorp/hcl/v2"
+	lsp "github.com/hashicorp/terraform-ls/internal/protocol"
 )
 
 func TestHCLDiagsToLSP_NeverReturnsNil(t *testing.T) {
@@ -29,3 +30,24 @@ func TestHCLDiagsToLSP_NeverReturnsNil(t *testing.T) {
 		t.Fatal("diags should not be nil")
 	}
 }
+
+func TestHCLDiagsToLSP_hint(t *testing.T) {
+	diags := HCLDiagsToLSP(hcl.Diagnostics{
+		{
+			Severity: hcl.DiagWarning,
+			Summary:  "warning",
+		},
+		{
+			Severity: hcl.DiagWarning,
+			Summary:  "hint",
+			Extra:    HintDiagnostic{},
+		},
+	}, "source")
+
+	if diags[0].Severity != lsp.SeverityWarning {
+		t.Fatalf("expected warning severity, given: %v", diags[0].Severity)
+	}
+	if diags[1].Severity != lsp.SeverityHint {
+		t.Fatalf("expected hint severity, given: %v", diags[1].Severity)
+	}
+}
@@ -10,7 +10,7 @@ import (
 )
 
 func HCLPositionFromLspPosition(pos lsp.Position, doc *document.Document) (hcl.Pos, error) {
-	byteOffset, err := document.ByteOffsetForPos(doc.Lines, lspPosToDocumentPos(pos))
+	byteOffset, err := doc.LineColumn().ByteOffset(lspPosToDocumentPos(pos))
 	if err != nil {
 		return hcl.Pos{}, err
 	}
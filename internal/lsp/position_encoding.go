@@ -0,0 +1,23 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package lsp
+
+import (
+	lsp "github.com/hashicorp/terraform-ls/internal/protocol"
+)
+
+// NegotiatePositionEncoding picks the position encoding to be used
+// for the session. It returns false if the client did not declare
+// any encodings, in which case UTF-16 is implied and need not be announced.
+//
+// Only UTF-16 is supported, regardless of the client's preference,
+// as all positions are converted to and from UTF-16 based columns.
+func NegotiatePositionEncoding(caps lsp.ClientCapabilities) (lsp.PositionEncodingKind, bool) {
+	if caps.General == nil || len(caps.General.PositionEncodings) == 0 {
+		return "", false
+	}
+
+	// UTF-16 must always be supported by clients
+	return lsp.UTF16, true
+}
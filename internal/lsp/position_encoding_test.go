@@ -0,0 +1,43 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package lsp
+
+import (
+	"fmt"
+	"testing"
+
+	lsp "github.com/hashicorp/terraform-ls/internal/protocol"
+)
+
+func TestNegotiatePositionEncoding(t *testing.T) {
+	testCases := []struct {
+		clientEncodings  []lsp.PositionEncodingKind
+		expectedEncoding lsp.PositionEncodingKind
+		expectedOk       bool
+	}{
+		{nil, "", false},
+		{[]lsp.PositionEncodingKind{}, "", false},
+		{[]lsp.PositionEncodingKind{lsp.UTF8}, lsp.UTF16, true},
+		{[]lsp.PositionEncodingKind{lsp.UTF32, lsp.UTF16}, lsp.UTF16, true},
+		{[]lsp.PositionEncodingKind{"utf-7", lsp.UTF8}, lsp.UTF16, true},
+		{[]lsp.PositionEncodingKind{"utf-7"}, lsp.UTF16, true},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%d", i), func(t *testing.T) {
+			caps := lsp.ClientCapabilities{
+				General: &lsp.GeneralClientCapabilities{
+					PositionEncodings: tc.clientEncodings,
+				},
+			}
+			enc, ok := NegotiatePositionEncoding(caps)
+			if ok != tc.expectedOk {
+				t.Fatalf("expected ok: %t, given: %t", tc.expectedOk, ok)
+			}
+			if enc != tc.expectedEncoding {
+				t.Fatalf("expected encoding %q, given %q", tc.expectedEncoding, enc)
+			}
+		})
+	}
+}
@@ -50,6 +50,13 @@ func HCLRangeToLSP(rng hcl.Range) lsp.Range {
 	}
 }
 
+// HCLRangeToLSPInDocument converts the given range within a document
+// with columns counted in UTF-16 code units, as expected by LSP clients.
+func HCLRangeToLSPInDocument(rng hcl.Range, lc document.LineColumn) lsp.Range {
+	docRng := lc.RangeForHCLRange(rng)
+	return documentRangeToLSP(&docRng)
+}
+
 func HCLPosToLSP(pos hcl.Pos) lsp.Position {
 	return lsp.Position{
 		Line:      uint32(pos.Line - 1),
@@ -8,6 +8,7 @@ import (
 
 	"github.com/hashicorp/hcl-lang/decoder"
 	"github.com/hashicorp/hcl-lang/lang"
+	"github.com/hashicorp/terraform-ls/internal/document"
 	lsp "github.com/hashicorp/terraform-ls/internal/protocol"
 	"github.com/hashicorp/terraform-ls/internal/uri"
 	"github.com/zclconf/go-cty/cty"
@@ -64,11 +65,11 @@ func WorkspaceSymbols(sbs []decoder.Symbol, caps *lsp.WorkspaceSymbolClientCapab
 	return symbols
 }
 
-func DocumentSymbols(sbs []decoder.Symbol, caps lsp.DocumentSymbolClientCapabilities) []lsp.DocumentSymbol {
+func DocumentSymbols(sbs []decoder.Symbol, caps lsp.DocumentSymbolClientCapabilities, lc document.LineColumn) []lsp.DocumentSymbol {
 	symbols := make([]lsp.DocumentSymbol, 0)
 
 	for _, s := range sbs {
-		symbol, ok := documentSymbol(s, caps)
+		symbol, ok := documentSymbol(s, caps, lc)
 		if !ok {
 			// skip symbol not supported by client
 			continue
@@ -78,7 +79,7 @@ func DocumentSymbols(sbs []decoder.Symbol, caps lsp.DocumentSymbolClientCapabili
 	return symbols
 }
 
-func documentSymbol(symbol decoder.Symbol, caps lsp.DocumentSymbolClientCapabilities) (lsp.DocumentSymbol, bool) {
+func documentSymbol(symbol decoder.Symbol, caps lsp.DocumentSymbolClientCapabilities, lc document.LineColumn) (lsp.DocumentSymbol, bool) {
 	supportedSymbols := defaultSymbols
 	if caps.SymbolKind != nil {
 		supportedSymbols = caps.SymbolKind.ValueSet
@@ -92,11 +93,11 @@ func documentSymbol(symbol decoder.Symbol, caps lsp.DocumentSymbolClientCapabili
 	ds := lsp.DocumentSymbol{
 		Name:           symbol.Name(),
 		Kind:           kind,
-		Range:          HCLRangeToLSP(symbol.Range()),
-		SelectionRange: HCLRangeToLSP(symbol.Range()),
+		Range:          HCLRangeToLSPInDocument(symbol.Range(), lc),
+		SelectionRange: HCLRangeToLSPInDocument(symbol.Range(), lc),
 	}
 	if caps.HierarchicalDocumentSymbolSupport {
-		ds.Children = DocumentSymbols(symbol.NestedSymbols(), caps)
+		ds.Children = DocumentSymbols(symbol.NestedSymbols(), caps, lc)
 	}
 	return ds, true
 }
@@ -4,9 +4,8 @@
 package lsp
 
 import (
-	"bytes"
-
 	"github.com/hashicorp/hcl-lang/lang"
+	"github.com/hashicorp/terraform-ls/internal/document"
 	"github.com/hashicorp/terraform-ls/internal/lsp/semtok"
 	lsp "github.com/hashicorp/terraform-ls/internal/protocol"
 	"github.com/hashicorp/terraform-ls/internal/source"
@@ -20,10 +19,14 @@ type TokenEncoder struct {
 	// lastEncodedTokenIdx tracks index of the last encoded token
 	// so we can account for any skipped tokens in calculating diff
 	lastEncodedTokenIdx int
+
+	// lineColumn converts token positions to UTF-16 based columns
+	lineColumn document.LineColumn
 }
 
 func (te *TokenEncoder) Encode() []uint32 {
 	data := make([]uint32, 0)
+	te.lineColumn = document.NewLineColumn(te.Lines)
 
 	for i := range te.Tokens {
 		data = append(data, te.encodeTokenOfIndex(i)...)
@@ -53,6 +56,8 @@ func (te *TokenEncoder) encodeTokenOfIndex(i int) []uint32 {
 	// For now we just safely assume client does *not* support it.
 
 	tokenLineDelta := token.Range.End.Line - token.Range.Start.Line
+	startPos := te.lineColumn.PosForHCLPos(token.Range.Start)
+	endPos := te.lineColumn.PosForHCLPos(token.Range.End)
 
 	previousLine := 0
 	previousStartChar := 0
@@ -60,14 +65,14 @@ func (te *TokenEncoder) encodeTokenOfIndex(i int) []uint32 {
 		previousLine = te.Tokens[te.lastEncodedTokenIdx].Range.End.Line - 1
 		currentLine := te.Tokens[i].Range.End.Line - 1
 		if currentLine == previousLine {
-			previousStartChar = te.Tokens[te.lastEncodedTokenIdx].Range.Start.Column - 1
+			previousStartChar = te.lineColumn.PosForHCLPos(te.Tokens[te.lastEncodedTokenIdx].Range.Start).Column
 		}
 	}
 
 	if tokenLineDelta == 0 || false /* te.clientCaps.MultilineTokenSupport */ {
 		deltaLine := token.Range.Start.Line - 1 - previousLine
-		tokenLength := token.Range.End.Byte - token.Range.Start.Byte
-		deltaStartChar := token.Range.Start.Column - 1 - previousStartChar
+		tokenLength := endPos.Column - startPos.Column
+		deltaStartChar := startPos.Column - previousStartChar
 
 		data = append(data, []uint32{
 			uint32(deltaLine),
@@ -83,14 +88,13 @@ func (te *TokenEncoder) encodeTokenOfIndex(i int) []uint32 {
 
 			deltaStartChar := 0
 			if tokenLine == token.Range.Start.Line-1 {
-				deltaStartChar = token.Range.Start.Column - 1 - previousStartChar
+				deltaStartChar = startPos.Column - previousStartChar
 			}
 
-			lineBytes := bytes.TrimRight(te.Lines[tokenLine].Bytes, "\n\r")
-			length := len(lineBytes)
+			length := te.lineColumn.LineLength(tokenLine)
 
 			if tokenLine == token.Range.End.Line-1 {
-				length = token.Range.End.Column - 1
+				length = endPos.Column
 			}
 
 			data = append(data, []uint32{
@@ -175,6 +175,57 @@ func TestTokenEncoder_multiLineTokens(t *testing.T) {
 	}
 }
 
+func TestTokenEncoder_multibyteCharacters(t *testing.T) {
+	// 日 and 本 are 3 bytes each in UTF-8 but a single UTF-16 unit
+	bytes := []byte(`myblock "日本" "x" {
+}
+`)
+	te := &TokenEncoder{
+		Lines: source.MakeSourceLines("test.tf", bytes),
+		Tokens: []lang.SemanticToken{
+			{
+				Type: lang.TokenBlockType,
+				Range: hcl.Range{
+					Filename: "test.tf",
+					Start:    hcl.Pos{Line: 1, Column: 1, Byte: 0},
+					End:      hcl.Pos{Line: 1, Column: 8, Byte: 7},
+				},
+			},
+			{
+				Type: lang.TokenBlockLabel,
+				Range: hcl.Range{
+					Filename: "test.tf",
+					Start:    hcl.Pos{Line: 1, Column: 9, Byte: 8},
+					End:      hcl.Pos{Line: 1, Column: 13, Byte: 16},
+				},
+			},
+			{
+				Type: lang.TokenBlockLabel,
+				Range: hcl.Range{
+					Filename: "test.tf",
+					Start:    hcl.Pos{Line: 1, Column: 14, Byte: 17},
+					End:      hcl.Pos{Line: 1, Column: 17, Byte: 20},
+				},
+			},
+		},
+		ClientCaps: protocol.SemanticTokensClientCapabilities{
+			TokenTypes:     serverTokenTypes.AsStrings(),
+			TokenModifiers: serverTokenModifiers.AsStrings(),
+		},
+	}
+	data := te.Encode()
+	expectedData := []uint32{
+		0, 0, 7, 10, 0,
+		0, 8, 4, 11, 0,
+		0, 5, 3, 11, 0,
+	}
+
+	if diff := cmp.Diff(expectedData, data); diff != "" {
+		t.Fatalf("unexpected encoded data.\nexpected: %#v\ngiven:    %#v",
+			expectedData, data)
+	}
+}
+
 func TestTokenEncoder_deltaStartCharBug(t *testing.T) {
 	bytes := []byte(`resource "aws_iam_role_policy" "firehose_s3_access" {
 }
@@ -8,5 +8,15 @@ import "github.com/hashicorp/hcl-lang/lang"
 type CompletionItemWithResolveHook struct {
 	CompletionItem
 
-	ResolveHook *lang.ResolveHook `json:"data,omitempty"`
+	Data *CompletionItemData `json:"data,omitempty"`
+}
+
+// CompletionItemData represents data attached to a completion item
+// which is used to resolve the item via completionItem/resolve
+type CompletionItemData struct {
+	*lang.ResolveHook
+
+	// DocumentationID refers to documentation which was
+	// left out of the item, so it can be resolved lazily
+	DocumentationID string `json:"documentation_id,omitempty"`
 }
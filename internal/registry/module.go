@@ -23,6 +23,7 @@ import (
 
 type ModuleResponse struct {
 	Version     string     `json:"version"`
+	Description string     `json:"description"`
 	PublishedAt time.Time  `json:"published_at"`
 	Root        ModuleRoot `json:"root"`
 }
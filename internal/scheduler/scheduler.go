@@ -8,6 +8,7 @@ import (
 	"errors"
 	"io/ioutil"
 	"log"
+	"runtime/debug"
 
 	"github.com/hashicorp/terraform-ls/internal/job"
 	"go.opentelemetry.io/otel"
@@ -65,6 +66,11 @@ func (s *Scheduler) Stop() {
 
 func (s *Scheduler) eval(ctx context.Context) {
 	for {
+		// avoid dispatching any more jobs once stopped
+		if ctx.Err() != nil {
+			return
+		}
+
 		ctx, id, nextJob, err := s.jobStorage.AwaitNextJob(ctx, s.priority)
 		if err != nil {
 			if errors.Is(err, context.Canceled) {
@@ -92,7 +98,7 @@ func (s *Scheduler) eval(ctx context.Context) {
 				Value: attribute.StringValue(nextJob.Dir.URI),
 			}))
 
-		jobErr := nextJob.Func(ctx)
+		jobErr := s.runJob(ctx, id, nextJob)
 
 		if jobErr != nil {
 			if errors.Is(jobErr, job.StateNotChangedErr{Dir: nextJob.Dir}) {
@@ -110,7 +116,7 @@ func (s *Scheduler) eval(ctx context.Context) {
 
 		deferredJobIds := make(job.IDs, 0)
 		if nextJob.Defer != nil {
-			deferredJobIds, err = nextJob.Defer(ctx, jobErr)
+			deferredJobIds, err = s.runDefer(ctx, id, nextJob, jobErr)
 			if err != nil {
 				s.logger.Printf("deferred job failed: %s", err)
 			}
@@ -129,3 +135,42 @@ func (s *Scheduler) eval(ctx context.Context) {
 		}
 	}
 }
+
+// runJob executes the given job and recovers from any panic within it,
+// which is then logged and returned as the job error, such that
+// the eval loop keeps running and records the failure like any other.
+func (s *Scheduler) runJob(ctx context.Context, id job.ID, j job.Job) (jobErr error) {
+	defer func() {
+		if r := recover(); r != nil {
+			s.logger.Printf("recovered from panic in job %q (%s) for %q: %v\n%s",
+				id, j.Type, j.Dir.URI, r, debug.Stack())
+			jobErr = job.PanicErr{
+				Dir:   j.Dir,
+				Type:  j.Type,
+				Value: r,
+			}
+		}
+	}()
+
+	return j.Func(ctx)
+}
+
+// runDefer executes the deferred function of the given job
+// and recovers from any panic within it, which is then logged
+// and returned as an error, with no deferred jobs scheduled.
+func (s *Scheduler) runDefer(ctx context.Context, id job.ID, j job.Job, jobErr error) (ids job.IDs, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			s.logger.Printf("recovered from panic in deferred func of job %q (%s) for %q: %v\n%s",
+				id, j.Type, j.Dir.URI, r, debug.Stack())
+			ids = job.IDs{}
+			err = job.PanicErr{
+				Dir:   j.Dir,
+				Type:  j.Type,
+				Value: r,
+			}
+		}
+	}()
+
+	return j.Defer(ctx, jobErr)
+}
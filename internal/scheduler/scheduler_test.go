@@ -5,6 +5,7 @@ package scheduler
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"log"
@@ -20,6 +21,7 @@ import (
 	"github.com/hashicorp/terraform-ls/internal/document"
 	"github.com/hashicorp/terraform-ls/internal/job"
 	"github.com/hashicorp/terraform-ls/internal/state"
+	op "github.com/hashicorp/terraform-ls/internal/terraform/module/operation"
 )
 
 func TestScheduler_withIgnoreExistingState(t *testing.T) {
@@ -391,6 +393,96 @@ func TestScheduler_defer(t *testing.T) {
 	}
 }
 
+func TestScheduler_recoversFromPanic(t *testing.T) {
+	ss, err := state.NewStateStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ss.SetLogger(testLogger())
+
+	tmpDir := t.TempDir()
+
+	ctx := context.Background()
+	ctx = lsctx.WithDocumentContext(ctx, lsctx.Document{})
+
+	// a single eval loop has to survive the panic
+	// for the subsequent job to be executed
+	s := NewScheduler(ss.JobStore, 1, job.LowPriority)
+	s.SetLogger(testLogger())
+	s.Start(ctx)
+	t.Cleanup(func() {
+		s.Stop()
+	})
+
+	modPath := filepath.Join(tmpDir, "first")
+	err = ss.Modules.Add(modPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var recordedErr error
+	panickingJobId, err := ss.JobStore.EnqueueJob(ctx, job.Job{
+		Func: func(c context.Context) error {
+			err := ss.Modules.SetMetaState(modPath, op.OpStateLoading)
+			if err != nil {
+				return err
+			}
+			panic("unexpected schema")
+		},
+		Dir:  document.DirHandleFromPath(modPath),
+		Type: op.OpTypeLoadModuleMetadata.String(),
+		Defer: func(ctx context.Context, jobErr error) (job.IDs, error) {
+			recordedErr = jobErr
+			return job.IDs{}, nil
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var jobsExecuted int64 = 0
+	nextJobId, err := ss.JobStore.EnqueueJob(ctx, job.Job{
+		Func: func(c context.Context) error {
+			atomic.AddInt64(&jobsExecuted, 1)
+			return nil
+		},
+		Dir:       document.DirHandleFromPath(filepath.Join(tmpDir, "second")),
+		Type:      "test-type",
+		DependsOn: job.IDs{panickingJobId},
+		Defer: func(ctx context.Context, jobErr error) (job.IDs, error) {
+			panic("unexpected deferred job")
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = ss.JobStore.WaitForJobs(ctx, panickingJobId, nextJobId)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if jobsExecuted != 1 {
+		t.Fatalf("expected job after panic to execute once, given: %d", jobsExecuted)
+	}
+
+	var panicErr job.PanicErr
+	if !errors.As(recordedErr, &panicErr) {
+		t.Fatalf("expected panic error to be recorded, given: %#v", recordedErr)
+	}
+	if panicErr.Value != "unexpected schema" {
+		t.Fatalf("unexpected panic value: %#v", panicErr.Value)
+	}
+	// the operation must not remain loading, so that it can be retried
+	mod, err := ss.Modules.ModuleByPath(modPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mod.MetaState != op.OpStateUnknown {
+		t.Fatalf("expected metadata state to be reset, given: %s", mod.MetaState)
+	}
+}
+
 func TestScheduler_dependsOn(t *testing.T) {
 	ss, err := state.NewStateStore()
 	if err != nil {
@@ -9,6 +9,7 @@ import (
 	"path/filepath"
 	"strings"
 
+	"github.com/hashicorp/terraform-ls/internal/terraform/ast"
 	"github.com/hashicorp/terraform-ls/internal/terraform/datadir"
 	"github.com/mcuadros/go-defaults"
 	"github.com/mitchellh/mapstructure"
@@ -21,6 +22,76 @@ type ExperimentalFeatures struct {
 
 type ValidationOptions struct {
 	EnableEnhancedValidation bool `mapstructure:"enableEnhancedValidation" default:"true"`
+
+	// EnhancedValidationPaths limits enhanced validation to directories
+	// matching any of the given path globs, or nested within them.
+	// Enhanced validation applies to all directories if empty.
+	EnhancedValidationPaths []string `mapstructure:"enhancedValidationPaths"`
+
+	// StrictBackendValidation reports required backend attributes
+	// as missing, instead of assuming they are supplied via
+	// -backend-config (partial configuration) at init time.
+	StrictBackendValidation bool `mapstructure:"strictBackendValidation"`
+
+	// PublishInterval limits how often diagnostics are published
+	// for a single document (e.g. "500ms"). Diagnostics are published
+	// as soon as they are available if empty.
+	PublishInterval string `mapstructure:"publishInterval"`
+
+	// EnableInterpolationOnlyHints enables hints for interpolation-only
+	// expressions (e.g. "${var.foo}") which can be replaced by bare references
+	EnableInterpolationOnlyHints bool `mapstructure:"enableInterpolationOnlyHints"`
+
+	// ReportOrphanedVarsFiles enables informational diagnostics for
+	// variable files (*.tfvars) in directories without any module files,
+	// which therefore have no variable declarations to validate against
+	ReportOrphanedVarsFiles bool `mapstructure:"reportOrphanedVarsFiles"`
+
+	// MaxDiagnosticsPerFile limits the number of diagnostics published
+	// for a single file, such that a badly broken file does not overwhelm
+	// the client with cascading errors. Zero means no limit.
+	MaxDiagnosticsPerFile int `mapstructure:"maxDiagnosticsPerFile" default:"100"`
+
+	// DisabledSources lists names of diagnostic sources (e.g. "ReferenceValidation")
+	// for which validation is skipped and diagnostics are not published.
+	DisabledSources []string `mapstructure:"disabledSources"`
+}
+
+// IsSourceEnabled reports whether diagnostics from the given source
+// should be produced and published.
+func (vo ValidationOptions) IsSourceEnabled(source ast.DiagnosticSource) bool {
+	for _, name := range vo.DisabledSources {
+		if name == source.Name() {
+			return false
+		}
+	}
+	return true
+}
+
+// IsEnhancedValidationEnabled reports whether enhanced validation
+// should be performed for the directory at the given path.
+func (vo ValidationOptions) IsEnhancedValidationEnabled(dirPath string) bool {
+	if !vo.EnableEnhancedValidation {
+		return false
+	}
+	if len(vo.EnhancedValidationPaths) == 0 {
+		return true
+	}
+
+	path := filepath.Clean(dirPath)
+	for {
+		for _, pattern := range vo.EnhancedValidationPaths {
+			if matched, _ := filepath.Match(pattern, path); matched {
+				return true
+			}
+		}
+
+		parent := filepath.Dir(path)
+		if parent == path {
+			return false
+		}
+		path = parent
+	}
 }
 
 type Indexing struct {
@@ -32,6 +103,11 @@ type Terraform struct {
 	Path        string `mapstructure:"path"`
 	Timeout     string `mapstructure:"timeout"`
 	LogFilePath string `mapstructure:"logFilePath"`
+
+	// DataDir represents the path of the data directory, either relative
+	// to each module or absolute, if relocated via TF_DATA_DIR, taking
+	// precedence over TF_DATA_DIR in the server's own environment.
+	DataDir string `mapstructure:"dataDir"`
 }
 
 type Options struct {
@@ -45,6 +121,10 @@ type Options struct {
 
 	IgnoreSingleFileWarning bool `mapstructure:"ignoreSingleFileWarning"`
 
+	// Debug enables terraform-ls/debug/* requests, such as
+	// terraform-ls/debug/stateDump, to aid bug reports.
+	Debug bool `mapstructure:"debug"`
+
 	Terraform Terraform `mapstructure:"terraform"`
 
 	XLegacyModulePaths              []string `mapstructure:"rootModulePaths"`
@@ -82,9 +162,41 @@ func (o *Options) Validate() error {
 		}
 	}
 
+	for _, name := range o.Validation.DisabledSources {
+		source, ok := ast.DiagnosticSourceFromName(name)
+		if !ok {
+			return fmt.Errorf("unknown diagnostic source %q, expected one of: %s",
+				name, disableableSourceNames())
+		}
+		if source == ast.HCLParsingSource {
+			return fmt.Errorf("cannot disable diagnostic source %q", name)
+		}
+	}
+
+	if o.Validation.MaxDiagnosticsPerFile < 0 {
+		return fmt.Errorf("expected non-negative maximum number of diagnostics per file, got %d",
+			o.Validation.MaxDiagnosticsPerFile)
+	}
+
+	for _, pattern := range o.Validation.EnhancedValidationPaths {
+		if _, err := filepath.Match(pattern, ""); err != nil {
+			return fmt.Errorf("invalid enhanced validation path %q: %s", pattern, err)
+		}
+	}
+
 	return nil
 }
 
+// disableableSourceNames returns a human-readable list
+// of diagnostic sources which can be disabled.
+func disableableSourceNames() string {
+	names := ast.DiagnosticSourceNames(ast.HCLParsingSource)
+	if len(names) < 2 {
+		return strings.Join(names, "")
+	}
+	return strings.Join(names[:len(names)-1], ", ") + " or " + names[len(names)-1]
+}
+
 type DecodedOptions struct {
 	Options    *Options
 	UnusedKeys []string
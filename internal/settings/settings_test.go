@@ -9,6 +9,7 @@ import (
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-ls/internal/terraform/ast"
 	"github.com/hashicorp/terraform-ls/internal/terraform/datadir"
 )
 
@@ -107,3 +108,174 @@ func TestValidate_relativePath(t *testing.T) {
 		t.Fatal("expected decoding of relative path to result in error")
 	}
 }
+
+func TestValidate_EnhancedValidationPaths_error(t *testing.T) {
+	out, err := DecodeOptions(map[string]interface{}{
+		"validation": map[string]interface{}{
+			"enhancedValidationPaths": []string{"modules/["},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result := out.Options.Validate()
+	if result == nil {
+		t.Fatal("expected invalid glob to result in error")
+	}
+}
+
+func TestValidationOptions_IsEnhancedValidationEnabled(t *testing.T) {
+	testCases := []struct {
+		name     string
+		options  ValidationOptions
+		dirPath  string
+		expected bool
+	}{
+		{
+			"disabled",
+			ValidationOptions{
+				EnableEnhancedValidation: false,
+			},
+			filepath.Join("/", "root", "modules", "foo"),
+			false,
+		},
+		{
+			"no allowlist",
+			ValidationOptions{
+				EnableEnhancedValidation: true,
+			},
+			filepath.Join("/", "root", "modules", "foo"),
+			true,
+		},
+		{
+			"matching glob",
+			ValidationOptions{
+				EnableEnhancedValidation: true,
+				EnhancedValidationPaths:  []string{filepath.Join("/", "root", "modules", "*")},
+			},
+			filepath.Join("/", "root", "modules", "foo"),
+			true,
+		},
+		{
+			"nested in matching glob",
+			ValidationOptions{
+				EnableEnhancedValidation: true,
+				EnhancedValidationPaths:  []string{filepath.Join("/", "root", "modules", "*")},
+			},
+			filepath.Join("/", "root", "modules", "foo", "bar"),
+			true,
+		},
+		{
+			"outside of allowlist",
+			ValidationOptions{
+				EnableEnhancedValidation: true,
+				EnhancedValidationPaths:  []string{filepath.Join("/", "root", "modules", "*")},
+			},
+			filepath.Join("/", "root", "envs", "prod"),
+			false,
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%d-%s", i, tc.name), func(t *testing.T) {
+			enabled := tc.options.IsEnhancedValidationEnabled(tc.dirPath)
+			if enabled != tc.expected {
+				t.Fatalf("expected: %t, got: %t", tc.expected, enabled)
+			}
+		})
+	}
+}
+
+func TestValidate_DisabledSources_error(t *testing.T) {
+	testCases := []string{
+		"HCLParsing",
+		"UnknownValidation",
+	}
+	for _, name := range testCases {
+		t.Run(name, func(t *testing.T) {
+			out, err := DecodeOptions(map[string]interface{}{
+				"validation": map[string]interface{}{
+					"disabledSources": []string{name},
+				},
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			result := out.Options.Validate()
+			if result == nil {
+				t.Fatalf("expected %q to result in error", name)
+			}
+		})
+	}
+}
+
+func TestValidate_DisabledSources_errorMessage(t *testing.T) {
+	out, err := DecodeOptions(map[string]interface{}{
+		"validation": map[string]interface{}{
+			"disabledSources": []string{"UnknownValidation"},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = out.Options.Validate()
+	if err == nil {
+		t.Fatal("expected unknown source to result in error")
+	}
+	expectedErr := `unknown diagnostic source "UnknownValidation", expected one of: ` +
+		"SchemaValidation, ReferenceValidation, TerraformValidate or OrphanedVariables"
+	if err.Error() != expectedErr {
+		t.Fatalf("expected error %q, given: %q", expectedErr, err.Error())
+	}
+}
+
+func TestValidationOptions_IsSourceEnabled(t *testing.T) {
+	out, err := DecodeOptions(map[string]interface{}{
+		"validation": map[string]interface{}{
+			"disabledSources": []string{"ReferenceValidation"},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := out.Options.Validate(); err != nil {
+		t.Fatal(err)
+	}
+
+	opts := out.Options.Validation
+	if opts.IsSourceEnabled(ast.ReferenceValidationSource) {
+		t.Fatal("expected ReferenceValidation to be disabled")
+	}
+	if !opts.IsSourceEnabled(ast.SchemaValidationSource) {
+		t.Fatal("expected SchemaValidation to be enabled")
+	}
+}
+
+func TestDecodeOptions_maxDiagnosticsPerFileDefault(t *testing.T) {
+	out, err := DecodeOptions(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if max := out.Options.Validation.MaxDiagnosticsPerFile; max != 100 {
+		t.Fatalf("expected default of 100 diagnostics per file, got %d", max)
+	}
+}
+
+func TestValidate_MaxDiagnosticsPerFile_error(t *testing.T) {
+	out, err := DecodeOptions(map[string]interface{}{
+		"validation": map[string]interface{}{
+			"maxDiagnosticsPerFile": -1,
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result := out.Options.Validate()
+	if result == nil {
+		t.Fatal("expected negative maximum to result in error")
+	}
+}
@@ -5,6 +5,7 @@ package state
 
 import (
 	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/hashicorp/go-version"
 	"github.com/hashicorp/hcl/v2"
 	"github.com/hashicorp/hcl/v2/hclsyntax"
@@ -22,5 +23,7 @@ var cmpOpts = cmp.Options{
 		return (x.Body == y.Body &&
 			cmp.Equal(x.Bytes, y.Bytes))
 	}),
+	// revisions depend on the order in which schemas were stored
+	cmpopts.IgnoreFields(ProviderSchema{}, "Revision"),
 	ctydebug.CmpOptions,
 }
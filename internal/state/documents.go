@@ -105,6 +105,16 @@ func (s *DocumentStore) GetDocument(dh document.Handle) (*document.Document, err
 	return getDocument(txn, dh)
 }
 
+// LineColumn returns a converter between byte offsets and LSP positions
+// for the given document, as stored at the time of the call.
+func (s *DocumentStore) LineColumn(dh document.Handle) (document.LineColumn, error) {
+	doc, err := s.GetDocument(dh)
+	if err != nil {
+		return document.LineColumn{}, err
+	}
+	return doc.LineColumn(), nil
+}
+
 func getDocument(txn *memdb.Txn, dh document.Handle) (*document.Document, error) {
 	obj, err := txn.First(documentsTableName, "id", dh.Dir, dh.Filename)
 	if err != nil {
@@ -174,6 +184,27 @@ func (s *DocumentStore) ListDocumentsInDir(dirHandle document.DirHandle) ([]*doc
 	return docs, nil
 }
 
+// ListOpenDocuments returns handles of all documents
+// which are currently open in the client.
+func (s *DocumentStore) ListOpenDocuments() ([]document.Handle, error) {
+	txn := s.db.Txn(false)
+	it, err := txn.Get(s.tableName, "id")
+	if err != nil {
+		return nil, err
+	}
+
+	handles := make([]document.Handle, 0)
+	for item := it.Next(); item != nil; item = it.Next() {
+		doc := item.(*document.Document)
+		handles = append(handles, document.Handle{
+			Dir:      doc.Dir,
+			Filename: doc.Filename,
+		})
+	}
+
+	return handles, nil
+}
+
 func (s *DocumentStore) IsDocumentOpen(dh document.Handle) (bool, error) {
 	txn := s.db.Txn(false)
 
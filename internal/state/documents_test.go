@@ -232,6 +232,35 @@ func TestDocumentStore_ListDocumentsInDir_parentDir(t *testing.T) {
 	}
 }
 
+func TestDocumentStore_ListOpenDocuments(t *testing.T) {
+	s, err := NewStateStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	handles := []document.Handle{
+		document.HandleFromURI("file:///dir/test1.tf"),
+		document.HandleFromURI("file:///dir/test2.tf"),
+		document.HandleFromURI("file:///dir/sub/test3.tfvars"),
+		document.HandleFromURI("file:///other/test4.tf"),
+	}
+	for _, dh := range handles {
+		err = s.DocumentStore.OpenDocument(dh, "terraform", 0, []byte("foobar"))
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	openHandles, err := s.DocumentStore.ListOpenDocuments()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if diff := cmp.Diff(handles, openHandles); diff != "" {
+		t.Fatalf("unexpected handles: %s", diff)
+	}
+}
+
 func TestDocumentStore_IsDocumentOpen(t *testing.T) {
 	s, err := NewStateStore()
 	if err != nil {
@@ -8,6 +8,7 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -30,6 +31,11 @@ type JobStore struct {
 	nextJobHighPrioMu *sync.Mutex
 	nextJobLowPrioMu  *sync.Mutex
 
+	// focusedDir represents the directory of the document
+	// which the user most recently interacted with
+	focusedDir   document.DirHandle
+	focusedDirMu *sync.RWMutex
+
 	lastJobId uint64
 }
 
@@ -73,6 +79,15 @@ func (sj *ScheduledJob) Copy() *ScheduledJob {
 	}
 }
 
+// JobInfo represents a summary of a scheduled job
+// for the purpose of diagnostics
+type JobInfo struct {
+	ID        job.ID
+	Type      string
+	State     State
+	DependsOn job.IDs
+}
+
 //go:generate go run golang.org/x/tools/cmd/stringer -type=State -output=jobs_state_string.go
 type State uint
 
@@ -101,6 +116,11 @@ func (js *JobStore) EnqueueJob(ctx context.Context, newJob job.Job) (job.ID, err
 	newJob.DependsOn = dependsOn
 	dirOpen := isDirOpen(txn, newJob.Dir)
 
+	docCtx := lsctx.DocumentContext(ctx)
+	if docCtx.URI != "" && strings.HasPrefix(docCtx.Method, "textDocument/") {
+		js.setFocusedDir(document.HandleFromURI(docCtx.URI).Dir)
+	}
+
 	_, jobSpan := otel.Tracer(tracerName).Start(ctx, "job",
 		trace.WithAttributes(attribute.KeyValue{
 			Key:   attribute.Key("JobID"),
@@ -129,7 +149,7 @@ func (js *JobStore) EnqueueJob(ctx context.Context, newJob job.Job) (job.ID, err
 		State:           StateQueued,
 		EnqueueTime:     time.Now(),
 		TraceSpan:       jobSpan,
-		DocumentContext: lsctx.DocumentContext(ctx),
+		DocumentContext: docCtx,
 	}
 
 	err := txn.Insert(js.tableName, sJob)
@@ -284,23 +304,32 @@ func (js *JobStore) awaitNextJob(ctx context.Context, priority job.JobPriority)
 	var sJob *ScheduledJob
 	for {
 		txn := js.db.Txn(false)
-		wCh, obj, err := txn.FirstWatch(js.tableName, "priority_dependecies_state", priority, 0, StateQueued)
+		focusedJob, err := js.focusedJob(txn, priority)
 		if err != nil {
 			return ctx, "", job.Job{}, err
 		}
 
-		if obj == nil {
-			select {
-			case <-wCh:
-			case <-ctx.Done():
-				return ctx, "", job.Job{}, ctx.Err()
+		if focusedJob != nil {
+			sJob = focusedJob
+		} else {
+			wCh, obj, err := txn.FirstWatch(js.tableName, "priority_dependecies_state", priority, 0, StateQueued)
+			if err != nil {
+				return ctx, "", job.Job{}, err
 			}
 
-			js.logger.Printf("retrying on obj is nil")
-			continue
-		}
+			if obj == nil {
+				select {
+				case <-wCh:
+				case <-ctx.Done():
+					return ctx, "", job.Job{}, ctx.Err()
+				}
 
-		sJob = obj.(*ScheduledJob)
+				js.logger.Printf("retrying on obj is nil")
+				continue
+			}
+
+			sJob = obj.(*ScheduledJob)
+		}
 
 		err = js.markJobAsRunning(sJob)
 		if err != nil {
@@ -348,6 +377,60 @@ func (js *JobStore) awaitNextJob(ctx context.Context, priority job.JobPriority)
 	return ctx, sJob.ID, sJob.Job, nil
 }
 
+func (js *JobStore) setFocusedDir(dirHandle document.DirHandle) {
+	js.focusedDirMu.Lock()
+	defer js.focusedDirMu.Unlock()
+	js.focusedDir = dirHandle
+}
+
+// FocusedDir returns the directory of the document
+// which the user most recently interacted with, if any.
+func (js *JobStore) FocusedDir() (document.DirHandle, bool) {
+	js.focusedDirMu.RLock()
+	defer js.focusedDirMu.RUnlock()
+	return js.focusedDir, js.focusedDir.URI != ""
+}
+
+// focusedJob returns a queued job of the given priority with
+// no pending dependencies, which prefers to be dispatched first
+// when its directory is focused, or nil if there is no such job.
+func (js *JobStore) focusedJob(txn *memdb.Txn, priority job.JobPriority) (*ScheduledJob, error) {
+	dir, ok := js.FocusedDir()
+	if !ok {
+		return nil, nil
+	}
+
+	it, err := txn.Get(js.tableName, "dir_state", dir, StateQueued)
+	if err != nil {
+		return nil, err
+	}
+	for obj := it.Next(); obj != nil; obj = it.Next() {
+		sJob := obj.(*ScheduledJob)
+		if !sJob.PreferWhenFocused || len(sJob.DependsOn) > 0 {
+			continue
+		}
+		if sJob.impliedPriority() != priority {
+			continue
+		}
+		return sJob, nil
+	}
+
+	return nil, nil
+}
+
+// impliedPriority mirrors the priority used for indexing
+// in JobPriorityIndex, where explicit priority takes precedence
+// over the one implied from whether the directory is open.
+func (sj *ScheduledJob) impliedPriority() job.JobPriority {
+	if sj.Priority != 0 {
+		return sj.Priority
+	}
+	if sj.IsDirOpen {
+		return job.HighPriority
+	}
+	return job.LowPriority
+}
+
 func isDirOpen(txn *memdb.Txn, dirHandle document.DirHandle) bool {
 	docObj, err := txn.First(documentsTableName, "dir", dirHandle)
 	if err != nil {
@@ -461,6 +544,15 @@ func (js *JobStore) FinishJob(id job.ID, jobErr error, deferredJobIds ...job.ID)
 	js.logger.Printf("JOBS: Finishing job %q: %q for %q (err = %s, deferredJobs: %q)",
 		sj.ID, sj.Type, sj.Dir, jobErr, deferredJobIds)
 
+	var panicErr job.PanicErr
+	if errors.As(jobErr, &panicErr) {
+		// A panicking job never got to finish its operation
+		err = resetLoadingOpState(txn, sj.Dir.Path(), sj.Type)
+		if err != nil {
+			return err
+		}
+	}
+
 	err = js.removeJobFromDependsOn(txn, id)
 	if err != nil {
 		return err
@@ -605,6 +697,50 @@ func (js *JobStore) ListQueuedJobs() (job.IDs, error) {
 	return jobIDs, nil
 }
 
+// JobsForDir returns all jobs scheduled for the given directory
+// which are queued, running or done (but not yet cleaned up).
+func (js *JobStore) JobsForDir(dir document.DirHandle) ([]JobInfo, error) {
+	txn := js.db.Txn(false)
+
+	jobs := make([]JobInfo, 0)
+	for _, state := range []State{StateQueued, StateRunning, StateDone} {
+		it, err := txn.Get(js.tableName, "dir_state", dir, state)
+		if err != nil {
+			return nil, err
+		}
+
+		for obj := it.Next(); obj != nil; obj = it.Next() {
+			sj := obj.(*ScheduledJob)
+			jobs = append(jobs, JobInfo{
+				ID:        sj.ID,
+				Type:      sj.Type,
+				State:     sj.State,
+				DependsOn: sj.DependsOn.Copy(),
+			})
+		}
+	}
+
+	return jobs, nil
+}
+
+// HasPendingJobs reports whether any job of the given types
+// is queued or running for the given directory.
+func (js *JobStore) HasPendingJobs(dir document.DirHandle, jobTypes ...string) (bool, error) {
+	for _, jobType := range jobTypes {
+		for _, state := range []State{StateQueued, StateRunning} {
+			_, ok, err := js.jobExists(job.Job{Dir: dir, Type: jobType}, state)
+			if err != nil {
+				return false, err
+			}
+			if ok {
+				return true, nil
+			}
+		}
+	}
+
+	return false, nil
+}
+
 func (js *JobStore) ListAllJobs() (job.IDs, error) {
 	txn := js.db.Txn(false)
 
@@ -259,6 +259,140 @@ func TestJobStore_DequeueJobsForDir(t *testing.T) {
 	}
 }
 
+func TestJobStore_JobsForDir(t *testing.T) {
+	ss, err := NewStateStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	ctx = lsctx.WithDocumentContext(ctx, lsctx.Document{})
+	firstDir := document.DirHandleFromPath("/test-1")
+	id1, err := ss.JobStore.EnqueueJob(ctx, job.Job{
+		Func: func(ctx context.Context) error {
+			return nil
+		},
+		Dir:  firstDir,
+		Type: "test-type-1",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	id2, err := ss.JobStore.EnqueueJob(ctx, job.Job{
+		Func: func(ctx context.Context) error {
+			return nil
+		},
+		Dir:       firstDir,
+		Type:      "test-type-2",
+		DependsOn: job.IDs{id1},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// directory with the same prefix should not be matched
+	_, err = ss.JobStore.EnqueueJob(ctx, job.Job{
+		Func: func(ctx context.Context) error {
+			return nil
+		},
+		Dir:  document.DirHandleFromPath("/test-10"),
+		Type: "test-type-1",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = ss.JobStore.EnqueueJob(ctx, job.Job{
+		Func: func(ctx context.Context) error {
+			return nil
+		},
+		Dir:  document.DirHandleFromPath("/test-2"),
+		Type: "test-type-1",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	jobs, err := ss.JobStore.JobsForDir(firstDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedJobs := []JobInfo{
+		{
+			ID:        id1,
+			Type:      "test-type-1",
+			State:     StateQueued,
+			DependsOn: job.IDs{},
+		},
+		{
+			ID:        id2,
+			Type:      "test-type-2",
+			State:     StateQueued,
+			DependsOn: job.IDs{id1},
+		},
+	}
+	if diff := cmp.Diff(expectedJobs, jobs); diff != "" {
+		t.Fatalf("unexpected jobs: %s", diff)
+	}
+}
+
+func TestJobStore_HasPendingJobs(t *testing.T) {
+	ss, err := NewStateStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	ctx = lsctx.WithDocumentContext(ctx, lsctx.Document{})
+	dir := document.DirHandleFromPath("/test-1")
+	id, err := ss.JobStore.EnqueueJob(ctx, job.Job{
+		Func: func(ctx context.Context) error {
+			return nil
+		},
+		Dir:  dir,
+		Type: "test-type-1",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pending, err := ss.JobStore.HasPendingJobs(dir, "test-type-1", "test-type-2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !pending {
+		t.Fatal("expected queued job to be pending")
+	}
+
+	pending, err = ss.JobStore.HasPendingJobs(dir, "test-type-2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pending {
+		t.Fatal("expected no pending job of a different type")
+	}
+
+	pending, err = ss.JobStore.HasPendingJobs(document.DirHandleFromPath("/test-2"), "test-type-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pending {
+		t.Fatal("expected no pending job in a different directory")
+	}
+
+	err = ss.JobStore.FinishJob(id, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pending, err = ss.JobStore.HasPendingJobs(dir, "test-type-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pending {
+		t.Fatal("expected finished job not to be pending")
+	}
+}
+
 func TestJobStore_AwaitNextJob_closedOnly(t *testing.T) {
 	ss, err := NewStateStore()
 	if err != nil {
@@ -566,6 +700,79 @@ func TestJobStore_AwaitNextJob_lowPriority(t *testing.T) {
 	}
 }
 
+func TestJobStore_AwaitNextJob_focusedDir(t *testing.T) {
+	ss, err := NewStateStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	ctx = lsctx.WithDocumentContext(ctx, lsctx.Document{})
+	firstDir := document.DirHandleFromPath("/test-1")
+	secondDir := document.DirHandleFromPath("/test-2")
+
+	id1, err := ss.JobStore.EnqueueJob(ctx, job.Job{
+		Func: func(ctx context.Context) error {
+			return nil
+		},
+		Dir:      secondDir,
+		Type:     "test-type",
+		Priority: job.LowPriority,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	id2, err := ss.JobStore.EnqueueJob(ctx, job.Job{
+		Func: func(ctx context.Context) error {
+			return nil
+		},
+		Dir:               firstDir,
+		Type:              "test-schema",
+		Priority:          job.LowPriority,
+		PreferWhenFocused: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	docCtx := lsctx.WithDocumentContext(ctx, lsctx.Document{
+		Method: "textDocument/didOpen",
+		URI:    document.HandleFromPath("/test-2/main.tf").FullURI(),
+	})
+	id3, err := ss.JobStore.EnqueueJob(docCtx, job.Job{
+		Func: func(ctx context.Context) error {
+			return nil
+		},
+		Dir:               secondDir,
+		Type:              "test-schema",
+		Priority:          job.LowPriority,
+		PreferWhenFocused: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	focusedDir, ok := ss.JobStore.FocusedDir()
+	if !ok {
+		t.Fatal("expected focused dir to be set")
+	}
+	if focusedDir != secondDir {
+		t.Fatalf("expected focused dir %q, given: %q", secondDir, focusedDir)
+	}
+
+	expectedIds := job.IDs{id3, id1, id2}
+	for _, expectedId := range expectedIds {
+		_, nextId, _, err := ss.JobStore.AwaitNextJob(ctx, job.LowPriority)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if nextId != expectedId {
+			t.Fatalf("expected next job ID %q, given: %q", expectedId, nextId)
+		}
+	}
+}
+
 func TestJobStore_WaitForJobs(t *testing.T) {
 	ss, err := NewStateStore()
 	if err != nil {
@@ -4,18 +4,24 @@
 package state
 
 import (
+	"context"
 	"fmt"
 	"path/filepath"
+	"sort"
+	"strings"
 
 	"github.com/hashicorp/go-memdb"
 	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/hcl-lang/lang"
 	"github.com/hashicorp/hcl-lang/reference"
 	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
 	tfaddr "github.com/hashicorp/terraform-registry-address"
 	"github.com/hashicorp/terraform-schema/backend"
 	tfmod "github.com/hashicorp/terraform-schema/module"
 	"github.com/hashicorp/terraform-schema/registry"
 
+	"github.com/hashicorp/terraform-ls/internal/pathcmp"
 	"github.com/hashicorp/terraform-ls/internal/terraform/ast"
 	"github.com/hashicorp/terraform-ls/internal/terraform/datadir"
 	op "github.com/hashicorp/terraform-ls/internal/terraform/module/operation"
@@ -37,11 +43,17 @@ func (mm ModuleMetadata) Copy() ModuleMetadata {
 	newMm := ModuleMetadata{
 		// version.Constraints is practically immutable once parsed
 		CoreRequirements: mm.CoreRequirements,
-		Filenames:        mm.Filenames,
+	}
+
+	if mm.Filenames != nil {
+		newMm.Filenames = make([]string, len(mm.Filenames))
+		copy(newMm.Filenames, mm.Filenames)
 	}
 
 	if mm.Cloud != nil {
-		newMm.Cloud = mm.Cloud
+		newMm.Cloud = &backend.Cloud{
+			Hostname: mm.Cloud.Hostname,
+		}
 	}
 
 	if mm.Backend != nil {
@@ -90,6 +102,17 @@ func (mm ModuleMetadata) Copy() ModuleMetadata {
 	return newMm
 }
 
+// ModuleMetrics represents counts of notable blocks within a module,
+// which help to understand the size of a configuration.
+type ModuleMetrics struct {
+	Resources   int
+	DataSources int
+	Variables   int
+	Outputs     int
+	ModuleCalls int
+	Providers   int
+}
+
 type Module struct {
 	Path string
 
@@ -108,6 +131,7 @@ type Module struct {
 	ProviderSchemaErr   error
 	ProviderSchemaState op.OpState
 
+	PreloadEmbeddedSchemaErr   error
 	PreloadEmbeddedSchemaState op.OpState
 
 	RefTargets      reference.Targets
@@ -131,6 +155,15 @@ type Module struct {
 	MetaErr   error
 	MetaState op.OpState
 
+	Metrics      ModuleMetrics
+	MetricsErr   error
+	MetricsState op.OpState
+
+	// WalkedDirFingerprint represents modification times of files
+	// in the module directory at the time it was last walked
+	// within the current session.
+	WalkedDirFingerprint string
+
 	ModuleDiagnostics      ast.SourceModDiags
 	ModuleDiagnosticsState ast.DiagnosticSourceState
 	VarsDiagnostics        ast.SourceVarsDiags
@@ -156,6 +189,7 @@ func (m *Module) Copy() *Module {
 		ProviderSchemaErr:   m.ProviderSchemaErr,
 		ProviderSchemaState: m.ProviderSchemaState,
 
+		PreloadEmbeddedSchemaErr:   m.PreloadEmbeddedSchemaErr,
 		PreloadEmbeddedSchemaState: m.PreloadEmbeddedSchemaState,
 
 		InstalledProvidersErr:   m.InstalledProvidersErr,
@@ -180,6 +214,12 @@ func (m *Module) Copy() *Module {
 		MetaErr:   m.MetaErr,
 		MetaState: m.MetaState,
 
+		Metrics:      m.Metrics,
+		MetricsErr:   m.MetricsErr,
+		MetricsState: m.MetricsState,
+
+		WalkedDirFingerprint: m.WalkedDirFingerprint,
+
 		ModuleDiagnosticsState: m.ModuleDiagnosticsState.Copy(),
 		VarsDiagnosticsState:   m.VarsDiagnosticsState.Copy(),
 	}
@@ -208,31 +248,8 @@ func (m *Module) Copy() *Module {
 		}
 	}
 
-	if m.ModuleDiagnostics != nil {
-		newMod.ModuleDiagnostics = make(ast.SourceModDiags, len(m.ModuleDiagnostics))
-
-		for source, modDiags := range m.ModuleDiagnostics {
-			newMod.ModuleDiagnostics[source] = make(ast.ModDiags, len(modDiags))
-
-			for name, diags := range modDiags {
-				newMod.ModuleDiagnostics[source][name] = make(hcl.Diagnostics, len(diags))
-				copy(newMod.ModuleDiagnostics[source][name], diags)
-			}
-		}
-	}
-
-	if m.VarsDiagnostics != nil {
-		newMod.VarsDiagnostics = make(ast.SourceVarsDiags, len(m.VarsDiagnostics))
-
-		for source, varsDiags := range m.VarsDiagnostics {
-			newMod.VarsDiagnostics[source] = make(ast.VarsDiags, len(varsDiags))
-
-			for name, diags := range varsDiags {
-				newMod.VarsDiagnostics[source][name] = make(hcl.Diagnostics, len(diags))
-				copy(newMod.VarsDiagnostics[source][name], diags)
-			}
-		}
-	}
+	newMod.ModuleDiagnostics = m.ModuleDiagnostics.Copy()
+	newMod.VarsDiagnostics = m.VarsDiagnostics.Copy()
 
 	return newMod
 }
@@ -258,6 +275,7 @@ func newModule(modPath string) *Module {
 			ast.SchemaValidationSource:    op.OpStateUnknown,
 			ast.ReferenceValidationSource: op.OpStateUnknown,
 			ast.TerraformValidateSource:   op.OpStateUnknown,
+			ast.OrphanedVariablesSource:   op.OpStateUnknown,
 		},
 	}
 }
@@ -363,6 +381,91 @@ func (s *ModuleStore) ModuleByPath(path string) (*Module, error) {
 	return mod, nil
 }
 
+// SchemaInputsWatch returns channels which get closed on the next change
+// of any module, registry module or provider schema, i.e. of any input
+// which module schemas are assembled from.
+func (s *ModuleStore) SchemaInputsWatch() ([]<-chan struct{}, error) {
+	txn := s.db.Txn(false)
+
+	tables := []string{s.tableName, registryModuleTableName, providerSchemaTableName}
+	watchChs := make([]<-chan struct{}, 0, len(tables))
+	for _, table := range tables {
+		it, err := txn.Get(table, "id")
+		if err != nil {
+			return nil, err
+		}
+		watchChs = append(watchChs, it.WatchCh())
+	}
+
+	return watchChs, nil
+}
+
+// IsInitialized reports whether the module at the given path
+// is a root module initialized via terraform init, based on
+// the parsed module manifest or installed provider versions.
+func (s *ModuleStore) IsInitialized(path string) bool {
+	mod, err := s.ModuleByPath(path)
+	if err != nil {
+		return false
+	}
+
+	if mod.ModManifest != nil {
+		return true
+	}
+
+	// Versions may not be known for all installed providers
+	// (e.g. in 0.12-style data directory) so we only rely
+	// on the lock file or plugin directory being parsed.
+	return mod.InstalledProvidersState == op.OpStateLoaded &&
+		mod.InstalledProvidersErr == nil
+}
+
+// ReferencesReady reports whether both reference targets and origins
+// of the module at the given path have been collected.
+//
+// The returned channel is closed whenever the module changes,
+// which allows callers to await readiness, see WaitForReferences.
+func (s *ModuleStore) ReferencesReady(path string) (bool, <-chan struct{}, error) {
+	txn := s.db.Txn(false)
+
+	wCh, obj, err := txn.FirstWatch(s.tableName, "id", path)
+	if err != nil {
+		return false, nil, err
+	}
+	if obj == nil {
+		return false, nil, &ModuleNotFoundError{
+			Source: path,
+		}
+	}
+
+	mod := obj.(*Module)
+	ready := mod.RefTargetsState == op.OpStateLoaded &&
+		mod.RefOriginsState == op.OpStateLoaded
+
+	return ready, wCh, nil
+}
+
+// WaitForReferences blocks until both reference targets and origins
+// of the module at the given path have been collected, or until
+// the context is cancelled.
+func (s *ModuleStore) WaitForReferences(ctx context.Context, path string) error {
+	for {
+		ready, wCh, err := s.ReferencesReady(path)
+		if err != nil {
+			return err
+		}
+		if ready {
+			return nil
+		}
+
+		select {
+		case <-wCh:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
 func (s *ModuleStore) AddIfNotExists(path string) error {
 	txn := s.db.Txn(true)
 	defer txn.Abort()
@@ -404,7 +507,7 @@ func (s *ModuleStore) ModuleCalls(modPath string) (tfmod.ModuleCalls, error) {
 				LocalName:  record.Key,
 				SourceAddr: record.SourceAddr,
 				Version:    record.Version,
-				Path:       filepath.Join(modPath, record.Dir),
+				Path:       record.Path(modPath),
 			}
 		}
 	}
@@ -422,6 +525,169 @@ func (s *ModuleStore) ModuleCalls(modPath string) (tfmod.ModuleCalls, error) {
 	return modCalls, err
 }
 
+// ModuleCallReferences represents a module call declared in a module
+// along with reference origins within the same module which refer to it,
+// such as module.name or module.name.output.
+type ModuleCallReferences struct {
+	Call tfmod.DeclaredModuleCall
+
+	// NameRange represents the range of the name label
+	// of the module block, including quotes
+	NameRange hcl.Range
+
+	Origins reference.Origins
+}
+
+// ModuleCallReferences returns the module call of the given name declared
+// in the module at the given path, along with origins referring to it,
+// which together represent all places to edit when renaming the call.
+func (s *ModuleStore) ModuleCallReferences(modPath, name string) (ModuleCallReferences, bool, error) {
+	mod, err := s.ModuleByPath(modPath)
+	if err != nil {
+		return ModuleCallReferences{}, false, err
+	}
+
+	mc, ok := mod.Meta.ModuleCalls[name]
+	if !ok || mc.RangePtr == nil {
+		return ModuleCallReferences{}, false, nil
+	}
+
+	file, ok := mod.ParsedModuleFiles[ast.ModFilename(mc.RangePtr.Filename)]
+	if !ok {
+		return ModuleCallReferences{}, false, nil
+	}
+	nameRange, ok := moduleCallNameRange(file, name)
+	if !ok {
+		return ModuleCallReferences{}, false, nil
+	}
+
+	callAddr := lang.Address{
+		lang.RootStep{Name: "module"},
+		lang.AttrStep{Name: name},
+	}
+	origins := make(reference.Origins, 0)
+	for _, origin := range mod.RefOrigins {
+		localOrigin, ok := origin.(reference.LocalOrigin)
+		if !ok || len(localOrigin.Addr) < len(callAddr) {
+			continue
+		}
+		if localOrigin.Addr.FirstSteps(uint(len(callAddr))).Equals(callAddr) {
+			origins = append(origins, localOrigin)
+		}
+	}
+
+	return ModuleCallReferences{
+		Call:      mc.Copy(),
+		NameRange: nameRange,
+		Origins:   origins,
+	}, true, nil
+}
+
+func moduleCallNameRange(file *hcl.File, name string) (hcl.Range, bool) {
+	body, ok := file.Body.(*hclsyntax.Body)
+	if !ok {
+		return hcl.Range{}, false
+	}
+	for _, block := range body.Blocks {
+		if block.Type == "module" && len(block.Labels) == 1 && block.Labels[0] == name {
+			return block.LabelRanges[0], true
+		}
+	}
+	return hcl.Range{}, false
+}
+
+// ProviderForType returns the address of the provider which the given
+// resource or data source type (e.g. aws_instance) belongs to, where
+// blockType is either resource or data, as declared in the module
+// at the given path.
+//
+// The provider is looked up by the local name implied by the type
+// (e.g. aws), or otherwise among all providers referenced in the module
+// whose schema declares the type, which is the case when the provider
+// is required under a different local name (e.g. amazon).
+func (s *ModuleStore) ProviderForType(modPath, blockType, typeName string, schemaReader SchemaReader) (tfaddr.Provider, bool, error) {
+	mod, err := s.ModuleByPath(modPath)
+	if err != nil {
+		return tfaddr.Provider{}, false, err
+	}
+
+	impliedName := strings.SplitN(typeName, "_", 2)[0]
+	if pAddr, ok := mod.Meta.ProviderReferences[tfmod.ProviderRef{LocalName: impliedName}]; ok {
+		return pAddr, true, nil
+	}
+
+	providers := make([]tfaddr.Provider, 0)
+	seen := make(map[tfaddr.Provider]bool)
+	for _, pAddr := range mod.Meta.ProviderReferences {
+		if seen[pAddr] {
+			continue
+		}
+		seen[pAddr] = true
+		providers = append(providers, pAddr)
+	}
+	sort.SliceStable(providers, func(i, j int) bool {
+		return providers[i].LessThan(providers[j])
+	})
+
+	for _, pAddr := range providers {
+		pSchema, err := schemaReader.ProviderSchema(modPath, pAddr, mod.Meta.ProviderRequirements[pAddr])
+		if err != nil || pSchema == nil {
+			// schema is not available (yet)
+			continue
+		}
+
+		var declared bool
+		switch blockType {
+		case "resource":
+			_, declared = pSchema.Resources[typeName]
+		case "data":
+			_, declared = pSchema.DataSources[typeName]
+		}
+		if declared {
+			return pAddr, true, nil
+		}
+	}
+
+	return tfaddr.Provider{}, false, nil
+}
+
+// InstalledModuleCall returns the call which the module at the given path
+// was installed for, as recorded in the module manifest of a known root.
+//
+// Manifests of all roots are searched, since the root cannot be told
+// from the path when the data directory is shared by all modules.
+func (s *ModuleStore) InstalledModuleCall(modPath string) (tfmod.InstalledModuleCall, bool, error) {
+	txn := s.db.Txn(false)
+	it, err := txn.Get(s.tableName, "id")
+	if err != nil {
+		return tfmod.InstalledModuleCall{}, false, err
+	}
+
+	for item := it.Next(); item != nil; item = it.Next() {
+		rootMod := item.(*Module)
+		if rootMod.ModManifest == nil {
+			continue
+		}
+
+		for _, record := range rootMod.ModManifest.Records {
+			if record.IsRoot() {
+				continue
+			}
+			recordPath := record.Path(rootMod.Path)
+			if pathcmp.PathEquals(recordPath, modPath) {
+				return tfmod.InstalledModuleCall{
+					LocalName:  record.Key,
+					SourceAddr: record.SourceAddr,
+					Version:    record.Version,
+					Path:       recordPath,
+				}, true, nil
+			}
+		}
+	}
+
+	return tfmod.InstalledModuleCall{}, false, nil
+}
+
 func (s *ModuleStore) ProviderRequirementsForModule(modPath string) (tfmod.ProviderRequirements, error) {
 	return s.providerRequirementsForModule(modPath, 0)
 }
@@ -483,7 +749,7 @@ func (s *ModuleStore) providerRequirementsForModule(modPath string, level int) (
 				continue
 			}
 
-			fullPath := filepath.Join(modPath, record.Dir)
+			fullPath := record.Path(modPath)
 			pr, err := s.providerRequirementsForModule(fullPath, level)
 			if err != nil {
 				continue
@@ -549,10 +815,11 @@ func (s *ModuleStore) RegistryModuleMeta(addr tfaddr.Module, cons version.Constr
 		}
 
 		if cons.Check(mod.Version) {
+			modData := mod.Copy()
 			return &registry.ModuleData{
-				Version: mod.Version,
-				Inputs:  mod.Inputs,
-				Outputs: mod.Outputs,
+				Version: modData.Version,
+				Inputs:  modData.Inputs,
+				Outputs: modData.Outputs,
 			}, nil
 		}
 	}
@@ -584,6 +851,71 @@ func moduleCopyByPath(txn *memdb.Txn, path string) (*Module, error) {
 	return mod.Copy(), nil
 }
 
+// resetLoadingOpState resets state of the operation represented by
+// the given job type back to unknown if it is still loading, such that
+// the operation can be attempted again (e.g. after its job panicked).
+func resetLoadingOpState(txn *memdb.Txn, path string, jobType string) error {
+	mod, err := moduleCopyByPath(txn, path)
+	if err != nil {
+		if IsModuleNotFound(err) {
+			return nil
+		}
+		return err
+	}
+
+	var state *op.OpState
+	switch jobType {
+	case op.OpTypeGetTerraformVersion.String():
+		state = &mod.TerraformVersionState
+	case op.OpTypeObtainSchema.String():
+		state = &mod.ProviderSchemaState
+	case op.OpTypeParseModuleManifest.String():
+		state = &mod.ModManifestState
+	case op.OpTypeLoadModuleMetadata.String():
+		state = &mod.MetaState
+	case op.OpTypeDecodeReferenceTargets.String():
+		state = &mod.RefTargetsState
+	case op.OpTypeDecodeReferenceOrigins.String():
+		state = &mod.RefOriginsState
+	case op.OpTypeDecodeVarsReferences.String():
+		state = &mod.VarsRefOriginsState
+	case op.OpTypeParseProviderVersions.String():
+		state = &mod.InstalledProvidersState
+	case op.OpTypePreloadEmbeddedSchema.String():
+		state = &mod.PreloadEmbeddedSchemaState
+	case op.OpTypeComputeModuleMetrics.String():
+		state = &mod.MetricsState
+	case op.OpTypeSchemaModuleValidation.String():
+		return resetLoadingDiagnosticsState(txn, mod, mod.ModuleDiagnosticsState, ast.SchemaValidationSource)
+	case op.OpTypeReferenceValidation.String():
+		return resetLoadingDiagnosticsState(txn, mod, mod.ModuleDiagnosticsState, ast.ReferenceValidationSource)
+	case op.OpTypeTerraformValidate.String():
+		return resetLoadingDiagnosticsState(txn, mod, mod.ModuleDiagnosticsState, ast.TerraformValidateSource)
+	case op.OpTypeSchemaVarsValidation.String():
+		return resetLoadingDiagnosticsState(txn, mod, mod.VarsDiagnosticsState, ast.SchemaValidationSource)
+	case op.OpTypeOrphanedVarsValidation.String():
+		return resetLoadingDiagnosticsState(txn, mod, mod.VarsDiagnosticsState, ast.OrphanedVariablesSource)
+	default:
+		return nil
+	}
+
+	if *state != op.OpStateLoading {
+		return nil
+	}
+	*state = op.OpStateUnknown
+
+	return txn.Insert(moduleTableName, mod)
+}
+
+func resetLoadingDiagnosticsState(txn *memdb.Txn, mod *Module, states ast.DiagnosticSourceState, source ast.DiagnosticSource) error {
+	if states[source] != op.OpStateLoading {
+		return nil
+	}
+	states[source] = op.OpStateUnknown
+
+	return txn.Insert(moduleTableName, mod)
+}
+
 func (s *ModuleStore) UpdateInstalledProviders(path string, pvs map[tfaddr.Provider]*version.Version, pvErr error) error {
 	txn := s.db.Txn(true)
 	txn.Defer(func() {
@@ -610,7 +942,7 @@ func (s *ModuleStore) UpdateInstalledProviders(path string, pvs map[tfaddr.Provi
 		return err
 	}
 
-	err = updateProviderVersions(txn, path, pvs)
+	err = updateProviderVersions(txn, s.schemaRevisions, path, pvs)
 	if err != nil {
 		return err
 	}
@@ -656,6 +988,40 @@ func (s *ModuleStore) List() ([]*Module, error) {
 	return modules, nil
 }
 
+// ModulePathsByProvider returns sorted paths of all modules which require
+// or reference the given provider, e.g. to tell which modules are affected
+// by a missing schema of that provider.
+func (s *ModuleStore) ModulePathsByProvider(addr tfaddr.Provider) ([]string, error) {
+	mods, err := s.List()
+	if err != nil {
+		return nil, err
+	}
+
+	paths := make([]string, 0)
+	for _, mod := range mods {
+		if moduleUsesProvider(mod, addr) {
+			paths = append(paths, mod.Path)
+		}
+	}
+	sort.Strings(paths)
+
+	return paths, nil
+}
+
+func moduleUsesProvider(mod *Module, addr tfaddr.Provider) bool {
+	for pAddr := range mod.Meta.ProviderRequirements {
+		if pAddr.Equals(addr) {
+			return true
+		}
+	}
+	for _, pAddr := range mod.Meta.ProviderReferences {
+		if pAddr.Equals(addr) {
+			return true
+		}
+	}
+	return false
+}
+
 func (s *ModuleStore) SetModManifestState(path string, state op.OpState) error {
 	txn := s.db.Txn(true)
 	defer txn.Abort()
@@ -767,6 +1133,35 @@ func (s *ModuleStore) SetPreloadEmbeddedSchemaState(path string, state op.OpStat
 	return nil
 }
 
+func (s *ModuleStore) FinishPreloadEmbeddedSchema(path string, pErr error) error {
+	txn := s.db.Txn(true)
+	txn.Defer(func() {
+		s.SetPreloadEmbeddedSchemaState(path, op.OpStateLoaded)
+	})
+	defer txn.Abort()
+
+	oldMod, err := moduleByPath(txn, path)
+	if err != nil {
+		return err
+	}
+
+	mod := oldMod.Copy()
+	mod.PreloadEmbeddedSchemaErr = pErr
+
+	err = txn.Insert(s.tableName, mod)
+	if err != nil {
+		return err
+	}
+
+	err = s.queueModuleChange(txn, oldMod, mod)
+	if err != nil {
+		return err
+	}
+
+	txn.Commit()
+	return nil
+}
+
 func (s *ModuleStore) FinishProviderSchemaLoading(path string, psErr error) error {
 	txn := s.db.Txn(true)
 	txn.Defer(func() {
@@ -822,7 +1217,7 @@ func (s *ModuleStore) UpdateTerraformAndProviderVersions(modPath string, tfVer *
 		return err
 	}
 
-	err = updateProviderVersions(txn, modPath, pv)
+	err = updateProviderVersions(txn, s.schemaRevisions, modPath, pv)
 	if err != nil {
 		return err
 	}
@@ -934,6 +1329,79 @@ func (s *ModuleStore) UpdateMetadata(path string, meta *tfmod.Meta, mErr error)
 	return nil
 }
 
+func (s *ModuleStore) SetMetricsState(path string, state op.OpState) error {
+	txn := s.db.Txn(true)
+	defer txn.Abort()
+
+	mod, err := moduleCopyByPath(txn, path)
+	if err != nil {
+		return err
+	}
+
+	mod.MetricsState = state
+	err = txn.Insert(s.tableName, mod)
+	if err != nil {
+		return err
+	}
+
+	txn.Commit()
+	return nil
+}
+
+func (s *ModuleStore) UpdateMetrics(path string, metrics ModuleMetrics, mErr error) error {
+	txn := s.db.Txn(true)
+	txn.Defer(func() {
+		s.SetMetricsState(path, op.OpStateLoaded)
+	})
+	defer txn.Abort()
+
+	oldMod, err := moduleByPath(txn, path)
+	if err != nil {
+		return err
+	}
+
+	mod := oldMod.Copy()
+	mod.Metrics = metrics
+	mod.MetricsErr = mErr
+
+	err = txn.Insert(s.tableName, mod)
+	if err != nil {
+		return err
+	}
+
+	err = s.queueModuleChange(txn, oldMod, mod)
+	if err != nil {
+		return err
+	}
+
+	txn.Commit()
+	return nil
+}
+
+// UpdateWalkedDirFingerprint records the fingerprint of the module
+// directory as of the last walk, so that re-walking can be skipped
+// while the directory remains unchanged.
+func (s *ModuleStore) UpdateWalkedDirFingerprint(path string, fingerprint string) error {
+	txn := s.db.Txn(true)
+	defer txn.Abort()
+
+	oldMod, err := moduleByPath(txn, path)
+	if err != nil {
+		return err
+	}
+
+	mod := oldMod.Copy()
+	mod.WalkedDirFingerprint = fingerprint
+
+	err = txn.Insert(s.tableName, mod)
+	if err != nil {
+		return err
+	}
+
+	txn.Commit()
+	return nil
+}
+
 func (s *ModuleStore) UpdateModuleDiagnostics(path string, source ast.DiagnosticSource, diags ast.ModDiags) error {
 	txn := s.db.Txn(true)
 	txn.Defer(func() {
@@ -966,6 +1434,28 @@ func (s *ModuleStore) UpdateModuleDiagnostics(path string, source ast.Diagnostic
 	return nil
 }
 
+// DiagnosticCounts returns the number of diagnostics from each source
+// across module and variable files of the module at the given path,
+// without copying the module or any of its diagnostics.
+func (s *ModuleStore) DiagnosticCounts(path string) (map[ast.DiagnosticSource]int, error) {
+	txn := s.db.Txn(false)
+
+	mod, err := moduleByPath(txn, path)
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make(map[ast.DiagnosticSource]int, len(mod.ModuleDiagnostics))
+	for source, diags := range mod.ModuleDiagnostics {
+		counts[source] += diags.Count()
+	}
+	for source, diags := range mod.VarsDiagnostics {
+		counts[source] += diags.Count()
+	}
+
+	return counts, nil
+}
+
 func (s *ModuleStore) SetModuleDiagnosticsState(path string, source ast.DiagnosticSource, state op.OpState) error {
 	txn := s.db.Txn(true)
 	defer txn.Abort()
@@ -41,6 +41,10 @@ type ModuleChanges struct {
 	Diagnostics          bool
 	ReferenceOrigins     bool
 	ReferenceTargets     bool
+	Metrics              bool
+	// ProviderSchemaErr indicates whether an error from obtaining
+	// or preloading provider schemas has changed
+	ProviderSchemaErr bool
 }
 
 const maxTimespan = 1 * time.Second
@@ -138,6 +142,10 @@ func (s *ModuleStore) queueModuleChange(txn *memdb.Txn, oldMod, newMod *Module)
 		if !oldMod.InstalledProviders.Equals(newMod.InstalledProviders) {
 			cb.Changes.InstalledProviders = true
 		}
+		if errorChanged(oldMod.ProviderSchemaErr, newMod.ProviderSchemaErr) ||
+			errorChanged(oldMod.PreloadEmbeddedSchemaErr, newMod.PreloadEmbeddedSchemaErr) {
+			cb.Changes.ProviderSchemaErr = true
+		}
 	}
 
 	oldDiags, newDiags := 0, 0
@@ -170,6 +178,17 @@ func (s *ModuleStore) queueModuleChange(txn *memdb.Txn, oldMod, newMod *Module)
 		cb.Changes.ReferenceTargets = true
 	}
 
+	var oldMetrics, newMetrics ModuleMetrics
+	if oldMod != nil {
+		oldMetrics = oldMod.Metrics
+	}
+	if newMod != nil {
+		newMetrics = newMod.Metrics
+	}
+	if oldMetrics != newMetrics {
+		cb.Changes.Metrics = true
+	}
+
 	// update change batch
 	_, err = txn.DeleteAll(moduleChangesTableName, "id", modHandle)
 	if err != nil {
@@ -178,6 +197,13 @@ func (s *ModuleStore) queueModuleChange(txn *memdb.Txn, oldMod, newMod *Module)
 	return txn.Insert(moduleChangesTableName, cb)
 }
 
+func errorChanged(oldErr, newErr error) bool {
+	if oldErr == nil || newErr == nil {
+		return oldErr != newErr
+	}
+	return oldErr.Error() != newErr.Error()
+}
+
 func updateModuleChangeDirOpenMark(txn *memdb.Txn, dirHandle document.DirHandle, isDirOpen bool) error {
 	it, err := txn.Get(moduleChangesTableName, "id", dirHandle)
 	if err != nil {
@@ -5,6 +5,7 @@ package state
 
 import (
 	"context"
+	"errors"
 	"testing"
 	"time"
 
@@ -179,6 +180,53 @@ func TestModuleChanges_AwaitNextChangeBatch_multipleChanges(t *testing.T) {
 	}
 }
 
+func TestModuleChanges_AwaitNextChangeBatch_providerSchemaErr(t *testing.T) {
+	ss, err := NewStateStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ss.Modules.TimeProvider = testTimeProvider
+
+	modPath := t.TempDir()
+
+	err = ss.Modules.Add(modPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = ss.Modules.FinishPreloadEmbeddedSchema(modPath, errors.New("failed to decode schema"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancelFunc := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancelFunc()
+	batch, err := ss.Modules.AwaitNextChangeBatch(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectedBatch := ModuleChangeBatch{
+		DirHandle:       document.DirHandleFromPath(modPath),
+		FirstChangeTime: testTimeProvider(),
+		IsDirOpen:       false,
+		Changes: ModuleChanges{
+			ProviderSchemaErr: true,
+		},
+	}
+	if diff := cmp.Diff(expectedBatch, batch); diff != "" {
+		t.Fatalf("unexpected change batch: %s", diff)
+	}
+
+	mod, err := ss.Modules.ModuleByPath(modPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mod.PreloadEmbeddedSchemaErr == nil {
+		t.Fatal("expected preload error to be recorded")
+	}
+}
+
 func TestModuleChanges_AwaitNextChangeBatch_removal(t *testing.T) {
 	ss, err := NewStateStore()
 	if err != nil {
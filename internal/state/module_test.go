@@ -4,14 +4,18 @@
 package state
 
 import (
+	"context"
 	"errors"
+	"fmt"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/hashicorp/go-version"
 	"github.com/hashicorp/hcl-lang/lang"
 	"github.com/hashicorp/hcl-lang/reference"
+	"github.com/hashicorp/hcl-lang/schema"
 	"github.com/hashicorp/hcl/v2"
 	"github.com/hashicorp/hcl/v2/hclparse"
 	"github.com/hashicorp/hcl/v2/hclsyntax"
@@ -21,6 +25,7 @@ import (
 	"github.com/hashicorp/terraform-ls/internal/terraform/module/operation"
 	tfaddr "github.com/hashicorp/terraform-registry-address"
 	tfmod "github.com/hashicorp/terraform-schema/module"
+	tfschema "github.com/hashicorp/terraform-schema/schema"
 	"github.com/zclconf/go-cty/cty"
 )
 
@@ -88,6 +93,7 @@ func TestModuleStore_ModuleByPath(t *testing.T) {
 			ast.SchemaValidationSource:    operation.OpStateUnknown,
 			ast.ReferenceValidationSource: operation.OpStateUnknown,
 			ast.TerraformValidateSource:   operation.OpStateUnknown,
+			ast.OrphanedVariablesSource:   operation.OpStateUnknown,
 		},
 	}
 	if diff := cmp.Diff(expectedModule, mod); diff != "" {
@@ -207,6 +213,7 @@ func TestModuleStore_CallersOfModule(t *testing.T) {
 				ast.SchemaValidationSource:    operation.OpStateUnknown,
 				ast.ReferenceValidationSource: operation.OpStateUnknown,
 				ast.TerraformValidateSource:   operation.OpStateUnknown,
+				ast.OrphanedVariablesSource:   operation.OpStateUnknown,
 			},
 		},
 		{
@@ -224,6 +231,7 @@ func TestModuleStore_CallersOfModule(t *testing.T) {
 				ast.SchemaValidationSource:    operation.OpStateUnknown,
 				ast.ReferenceValidationSource: operation.OpStateUnknown,
 				ast.TerraformValidateSource:   operation.OpStateUnknown,
+				ast.OrphanedVariablesSource:   operation.OpStateUnknown,
 			},
 		},
 	}
@@ -272,6 +280,7 @@ func TestModuleStore_List(t *testing.T) {
 				ast.SchemaValidationSource:    operation.OpStateUnknown,
 				ast.ReferenceValidationSource: operation.OpStateUnknown,
 				ast.TerraformValidateSource:   operation.OpStateUnknown,
+				ast.OrphanedVariablesSource:   operation.OpStateUnknown,
 			},
 		},
 		{
@@ -287,6 +296,7 @@ func TestModuleStore_List(t *testing.T) {
 				ast.SchemaValidationSource:    operation.OpStateUnknown,
 				ast.ReferenceValidationSource: operation.OpStateUnknown,
 				ast.TerraformValidateSource:   operation.OpStateUnknown,
+				ast.OrphanedVariablesSource:   operation.OpStateUnknown,
 			},
 		},
 		{
@@ -302,6 +312,7 @@ func TestModuleStore_List(t *testing.T) {
 				ast.SchemaValidationSource:    operation.OpStateUnknown,
 				ast.ReferenceValidationSource: operation.OpStateUnknown,
 				ast.TerraformValidateSource:   operation.OpStateUnknown,
+				ast.OrphanedVariablesSource:   operation.OpStateUnknown,
 			},
 		},
 	}
@@ -311,6 +322,88 @@ func TestModuleStore_List(t *testing.T) {
 	}
 }
 
+func TestModuleStore_ProviderForType(t *testing.T) {
+	s, err := NewStateStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tmpDir := t.TempDir()
+
+	awsAddr := NewDefaultProvider("aws")
+	googleAddr := NewDefaultProvider("google")
+	metadata := &tfmod.Meta{
+		Path: tmpDir,
+		ProviderRequirements: map[tfaddr.Provider]version.Constraints{
+			awsAddr:    testConstraint(t, ">= 1.0.0"),
+			googleAddr: testConstraint(t, ">= 1.0.0"),
+		},
+		ProviderReferences: map[tfmod.ProviderRef]tfaddr.Provider{
+			// aws provider required under a remapped local name
+			{LocalName: "amazon"}:                awsAddr,
+			{LocalName: "amazon", Alias: "west"}: awsAddr,
+			{LocalName: "google"}:                googleAddr,
+		},
+	}
+
+	err = s.Modules.Add(tmpDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = s.Modules.UpdateMetadata(tmpDir, metadata, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = s.ProviderSchemas.AddPreloadedSchema(awsAddr, testVersion(t, "1.0.0"), &tfschema.ProviderSchema{
+		Resources: map[string]*schema.BodySchema{
+			"aws_instance": {},
+		},
+		DataSources: map[string]*schema.BodySchema{
+			"aws_ami": {},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = s.ProviderSchemas.AddPreloadedSchema(googleAddr, testVersion(t, "1.0.0"), &tfschema.ProviderSchema{
+		Resources: map[string]*schema.BodySchema{
+			"google_compute_instance": {},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		blockType     string
+		typeName      string
+		expectedAddr  tfaddr.Provider
+		expectedFound bool
+	}{
+		{"resource", "google_compute_instance", googleAddr, true},
+		{"resource", "aws_instance", awsAddr, true},
+		{"data", "aws_ami", awsAddr, true},
+		{"data", "aws_instance", tfaddr.Provider{}, false},
+		{"resource", "azurerm_virtual_machine", tfaddr.Provider{}, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(fmt.Sprintf("%s.%s", tc.blockType, tc.typeName), func(t *testing.T) {
+			pAddr, ok, err := s.Modules.ProviderForType(tmpDir, tc.blockType, tc.typeName, s.ProviderSchemas)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if ok != tc.expectedFound {
+				t.Fatalf("expected found: %t, given: %t", tc.expectedFound, ok)
+			}
+			if !pAddr.Equals(tc.expectedAddr) {
+				t.Fatalf("expected provider %s, given: %s", tc.expectedAddr, pAddr)
+			}
+		})
+	}
+}
+
 func TestModuleStore_UpdateMetadata(t *testing.T) {
 	s, err := NewStateStore()
 	if err != nil {
@@ -372,6 +465,7 @@ func TestModuleStore_UpdateMetadata(t *testing.T) {
 			ast.SchemaValidationSource:    operation.OpStateUnknown,
 			ast.ReferenceValidationSource: operation.OpStateUnknown,
 			ast.TerraformValidateSource:   operation.OpStateUnknown,
+			ast.OrphanedVariablesSource:   operation.OpStateUnknown,
 		},
 	}
 
@@ -420,6 +514,7 @@ func TestModuleStore_UpdateTerraformAndProviderVersions(t *testing.T) {
 			ast.SchemaValidationSource:    operation.OpStateUnknown,
 			ast.ReferenceValidationSource: operation.OpStateUnknown,
 			ast.TerraformValidateSource:   operation.OpStateUnknown,
+			ast.OrphanedVariablesSource:   operation.OpStateUnknown,
 		},
 	}
 	if diff := cmp.Diff(expectedModule, mod, cmpOpts); diff != "" {
@@ -427,6 +522,170 @@ func TestModuleStore_UpdateTerraformAndProviderVersions(t *testing.T) {
 	}
 }
 
+func TestModuleStore_IsInitialized(t *testing.T) {
+	s, err := NewStateStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	uninitializedPath := t.TempDir()
+	manifestPath := t.TempDir()
+	lockFilePath := t.TempDir()
+	brokenLockFilePath := t.TempDir()
+	for _, path := range []string{uninitializedPath, manifestPath, lockFilePath, brokenLockFilePath} {
+		err = s.Modules.Add(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	err = s.Modules.UpdateModManifest(manifestPath, datadir.NewModuleManifest(manifestPath, []datadir.ModuleRecord{}), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = s.Modules.UpdateInstalledProviders(lockFilePath, map[tfaddr.Provider]*version.Version{
+		tfaddr.MustParseProviderSource("hashicorp/aws"): testVersion(t, "5.0.0"),
+	}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = s.Modules.UpdateInstalledProviders(brokenLockFilePath, nil, customErr{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		path                string
+		expectedInitialized bool
+	}{
+		{uninitializedPath, false},
+		{manifestPath, true},
+		{lockFilePath, true},
+		{brokenLockFilePath, false},
+		{filepath.Join(uninitializedPath, "unknown"), false},
+	}
+	for i, tc := range testCases {
+		initialized := s.Modules.IsInitialized(tc.path)
+		if initialized != tc.expectedInitialized {
+			t.Fatalf("%d: expected initialized: %t, given: %t", i, tc.expectedInitialized, initialized)
+		}
+	}
+}
+
+func TestModuleStore_ModulePathsByProvider(t *testing.T) {
+	s, err := NewStateStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	awsAddr := NewDefaultProvider("aws")
+	googleAddr := NewDefaultProvider("google")
+
+	awsModPath, otherModPath := t.TempDir(), t.TempDir()
+	metas := map[string]*tfmod.Meta{
+		awsModPath: {
+			Path: awsModPath,
+			ProviderRequirements: tfmod.ProviderRequirements{
+				awsAddr: version.MustConstraints(version.NewConstraint(">= 5.0")),
+			},
+		},
+		otherModPath: {
+			Path: otherModPath,
+			ProviderRequirements: tfmod.ProviderRequirements{
+				googleAddr: version.MustConstraints(version.NewConstraint(">= 4.0")),
+			},
+		},
+	}
+	for modPath, meta := range metas {
+		err = s.Modules.Add(modPath)
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = s.Modules.UpdateMetadata(modPath, meta, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	paths, err := s.Modules.ModulePathsByProvider(awsAddr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff([]string{awsModPath}, paths); diff != "" {
+		t.Fatalf("unexpected paths: %s", diff)
+	}
+
+	paths, err = s.Modules.ModulePathsByProvider(NewDefaultProvider("azurerm"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff([]string{}, paths); diff != "" {
+		t.Fatalf("unexpected paths: %s", diff)
+	}
+}
+
+func TestModuleStore_ReferencesReady(t *testing.T) {
+	s, err := NewStateStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	modPath := t.TempDir()
+	err = s.Modules.Add(modPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ready, _, err := s.Modules.ReferencesReady(modPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ready {
+		t.Fatal("expected references not to be ready before collection")
+	}
+
+	waitErrCh := make(chan error, 1)
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		waitErrCh <- s.Modules.WaitForReferences(ctx, modPath)
+	}()
+
+	err = s.Modules.UpdateReferenceTargets(modPath, reference.Targets{}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ready, _, err = s.Modules.ReferencesReady(modPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ready {
+		t.Fatal("expected references not to be ready before origins are collected")
+	}
+
+	err = s.Modules.UpdateReferenceOrigins(modPath, reference.Origins{}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ready, _, err = s.Modules.ReferencesReady(modPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !ready {
+		t.Fatal("expected references to be ready after collection")
+	}
+
+	err = <-waitErrCh
+	if err != nil {
+		t.Fatalf("expected wait to finish once references are ready, got: %s", err)
+	}
+
+	_, _, err = s.Modules.ReferencesReady(filepath.Join(modPath, "unknown"))
+	if !IsModuleNotFound(err) {
+		t.Fatalf("expected module not found error, got: %#v", err)
+	}
+}
+
 func TestModuleStore_UpdateParsedModuleFiles(t *testing.T) {
 	s, err := NewStateStore()
 	if err != nil {
@@ -570,6 +829,63 @@ provider "blah" {
 	}
 }
 
+func TestModuleStore_DiagnosticCounts(t *testing.T) {
+	s, err := NewStateStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tmpDir := t.TempDir()
+	err = s.Modules.Add(tmpDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = s.Modules.UpdateModuleDiagnostics(tmpDir, ast.HCLParsingSource, ast.ModDiags{
+		"main.tf": hcl.Diagnostics{
+			{Severity: hcl.DiagError, Summary: "First"},
+			{Severity: hcl.DiagError, Summary: "Second"},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = s.Modules.UpdateModuleDiagnostics(tmpDir, ast.SchemaValidationSource, ast.ModDiags{
+		"main.tf": hcl.Diagnostics{
+			{Severity: hcl.DiagWarning, Summary: "Third"},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = s.Modules.UpdateVarsDiagnostics(tmpDir, ast.HCLParsingSource, ast.VarsDiags{
+		"terraform.tfvars": hcl.Diagnostics{
+			{Severity: hcl.DiagError, Summary: "Fourth"},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	counts, err := s.Modules.DiagnosticCounts(tmpDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedCounts := map[ast.DiagnosticSource]int{
+		ast.HCLParsingSource:       3,
+		ast.SchemaValidationSource: 1,
+	}
+	if diff := cmp.Diff(expectedCounts, counts); diff != "" {
+		t.Fatalf("unexpected counts: %s", diff)
+	}
+
+	_, err = s.Modules.DiagnosticCounts(filepath.Join(tmpDir, "missing"))
+	if err == nil {
+		t.Fatal("expected error for unknown module")
+	}
+}
+
 func TestModuleStore_UpdateVarsDiagnostics(t *testing.T) {
 	s, err := NewStateStore()
 	if err != nil {
@@ -893,3 +1209,57 @@ func testVersion(t testOrBench, v string) *version.Version {
 	}
 	return ver
 }
+
+func TestModule_Copy_isolation(t *testing.T) {
+	mod := newModule(t.TempDir())
+	mod.InstalledProviders = InstalledProviders{
+		tfaddr.MustParseProviderSource("hashicorp/aws"): version.Must(version.NewVersion("5.0.0")),
+	}
+	mod.Meta = ModuleMetadata{
+		Filenames: []string{"main.tf", "variables.tf"},
+		Variables: map[string]tfmod.Variable{
+			"name": {Type: cty.String},
+		},
+	}
+	mod.ModuleDiagnostics = ast.SourceModDiags{
+		ast.HCLParsingSource: ast.ModDiags{
+			"main.tf": hcl.Diagnostics{
+				{Severity: hcl.DiagError, Summary: "Test error"},
+			},
+		},
+	}
+	mod.VarsDiagnostics = ast.SourceVarsDiags{
+		ast.HCLParsingSource: ast.VarsDiags{
+			"terraform.tfvars": hcl.Diagnostics{
+				{Severity: hcl.DiagError, Summary: "Test error"},
+			},
+		},
+	}
+
+	copied := mod.Copy()
+	copied.InstalledProviders[tfaddr.MustParseProviderSource("hashicorp/google")] = version.Must(version.NewVersion("4.0.0"))
+	copied.Meta.Filenames[0] = "modified.tf"
+	copied.Meta.Variables["other"] = tfmod.Variable{}
+	copied.ModuleDiagnostics[ast.HCLParsingSource]["main.tf"][0] = &hcl.Diagnostic{Summary: "Modified"}
+	copied.ModuleDiagnosticsState[ast.HCLParsingSource] = operation.OpStateLoaded
+	copied.VarsDiagnostics[ast.HCLParsingSource]["terraform.tfvars"][0] = &hcl.Diagnostic{Summary: "Modified"}
+
+	if len(mod.InstalledProviders) != 1 {
+		t.Fatalf("original installed providers changed: %#v", mod.InstalledProviders)
+	}
+	if mod.Meta.Filenames[0] != "main.tf" {
+		t.Fatalf("original filenames changed: %#v", mod.Meta.Filenames)
+	}
+	if len(mod.Meta.Variables) != 1 {
+		t.Fatalf("original variables changed: %#v", mod.Meta.Variables)
+	}
+	if mod.ModuleDiagnostics[ast.HCLParsingSource]["main.tf"][0].Summary != "Test error" {
+		t.Fatalf("original module diagnostics changed: %#v", mod.ModuleDiagnostics)
+	}
+	if mod.ModuleDiagnosticsState[ast.HCLParsingSource] != operation.OpStateUnknown {
+		t.Fatalf("original module diagnostics state changed: %#v", mod.ModuleDiagnosticsState)
+	}
+	if mod.VarsDiagnostics[ast.HCLParsingSource]["terraform.tfvars"][0].Summary != "Test error" {
+		t.Fatalf("original vars diagnostics changed: %#v", mod.VarsDiagnostics)
+	}
+}
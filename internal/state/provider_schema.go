@@ -6,6 +6,7 @@ package state
 import (
 	"fmt"
 	"sort"
+	"sync/atomic"
 
 	"github.com/hashicorp/go-memdb"
 	"github.com/hashicorp/go-version"
@@ -19,6 +20,20 @@ type ProviderSchema struct {
 	Source  SchemaSource
 
 	Schema *tfschema.ProviderSchema
+
+	// Revision changes whenever the schema is replaced,
+	// which allows telling schemas apart without comparing them.
+	Revision uint64
+}
+
+// schemaRevisions assigns revisions to provider schemas
+// of a single state store.
+type schemaRevisions struct {
+	last uint64
+}
+
+func (r *schemaRevisions) next() uint64 {
+	return atomic.AddUint64(&r.last, 1)
 }
 
 func (ps *ProviderSchema) Copy() *ProviderSchema {
@@ -27,10 +42,11 @@ func (ps *ProviderSchema) Copy() *ProviderSchema {
 	}
 
 	return &ProviderSchema{
-		Address: ps.Address,
-		Version: ps.Version, // version.Version is immutable by design
-		Source:  ps.Source,
-		Schema:  ps.Schema.Copy(),
+		Address:  ps.Address,
+		Version:  ps.Version, // version.Version is immutable by design
+		Source:   ps.Source,
+		Schema:   ps.Schema.Copy(),
+		Revision: ps.Revision,
 	}
 }
 
@@ -46,7 +62,7 @@ func (psi *ProviderSchemaIterator) Next() *ProviderSchema {
 	return item.(*ProviderSchema)
 }
 
-func updateProviderVersions(txn *memdb.Txn, modPath string, pv map[tfaddr.Provider]*version.Version) error {
+func updateProviderVersions(txn *memdb.Txn, revisions *schemaRevisions, modPath string, pv map[tfaddr.Provider]*version.Version) error {
 	for pAddr, pVer := range pv {
 		// first check for existing record to avoid duplicates
 		src := LocalSchemaSource{
@@ -62,16 +78,16 @@ func updateProviderVersions(txn *memdb.Txn, modPath string, pv map[tfaddr.Provid
 			continue
 		}
 
-		// add version if schema is already present and version unknown
-		obj, err = txn.First(providerSchemaTableName, "id_prefix", pAddr, src, nil)
+		// add version if schema is already present and version unknown,
+		// schemas of other (previously installed) versions are kept
+		// until the schema of the new version is obtained
+		versionedPs, err := unversionedProviderSchema(txn, pAddr, src)
 		if err != nil {
 			return fmt.Errorf("unable to find provider schema without version: %w", err)
 		}
-		if obj != nil {
+		if versionedPs != nil {
 			// TODO: Implement txn.Update?
 			// See https://github.com/hashicorp/go-memdb/pull/49
-			versionedPs := obj.(*ProviderSchema)
-
 			if versionedPs.Schema != nil {
 				_, err = txn.DeleteAll(providerSchemaTableName, "id_prefix", pAddr, src)
 				if err != nil {
@@ -81,6 +97,7 @@ func updateProviderVersions(txn *memdb.Txn, modPath string, pv map[tfaddr.Provid
 				psCopy := versionedPs.Copy()
 				psCopy.Version = pVer
 				psCopy.Schema.SetProviderVersion(psCopy.Address, pVer)
+				psCopy.Revision = revisions.next()
 
 				err = txn.Insert(providerSchemaTableName, psCopy)
 				if err != nil {
@@ -105,6 +122,20 @@ func updateProviderVersions(txn *memdb.Txn, modPath string, pv map[tfaddr.Provid
 	return nil
 }
 
+func unversionedProviderSchema(txn *memdb.Txn, addr tfaddr.Provider, src SchemaSource) (*ProviderSchema, error) {
+	it, err := txn.Get(providerSchemaTableName, "id_prefix", addr, src)
+	if err != nil {
+		return nil, err
+	}
+	for item := it.Next(); item != nil; item = it.Next() {
+		ps := item.(*ProviderSchema)
+		if ps.Version == nil {
+			return ps, nil
+		}
+	}
+	return nil, nil
+}
+
 func (s *ProviderSchemaStore) AddLocalSchema(modPath string, addr tfaddr.Provider, schema *tfschema.ProviderSchema) error {
 	s.logger.Printf("PSS: adding local schema (%s, %s): %p", modPath, addr, schema)
 	txn := s.db.Txn(true)
@@ -114,11 +145,22 @@ func (s *ProviderSchemaStore) AddLocalSchema(modPath string, addr tfaddr.Provide
 		ModulePath: modPath,
 	}
 
-	// check for existing entries
-	obj, err := txn.First(s.tableName, "id_prefix", addr, src)
+	// check for existing entries, preferring one without schema,
+	// such as a newly installed provider version
+	it, err := txn.Get(s.tableName, "id_prefix", addr, src)
 	if err != nil {
 		return err
 	}
+	var obj interface{}
+	for item := it.Next(); item != nil; item = it.Next() {
+		if obj == nil {
+			obj = item
+		}
+		if existingEntry, ok := item.(*ProviderSchema); ok && existingEntry.Schema == nil {
+			obj = item
+			break
+		}
+	}
 	ps := &ProviderSchema{
 		Address: addr,
 		Source:  src,
@@ -152,6 +194,7 @@ func (s *ProviderSchemaStore) AddLocalSchema(modPath string, addr tfaddr.Provide
 	}
 
 	ps.Schema = schemaCopy
+	ps.Revision = s.revisions.next()
 
 	err = txn.Insert(s.tableName, ps)
 	if err != nil {
@@ -162,6 +205,21 @@ func (s *ProviderSchemaStore) AddLocalSchema(modPath string, addr tfaddr.Provide
 	return nil
 }
 
+// LocalSchemaExists returns true if schema of the given provider version
+// was already obtained for the module at the given path.
+func (s *ProviderSchemaStore) LocalSchemaExists(modPath string, addr tfaddr.Provider, pv *version.Version) (bool, error) {
+	txn := s.db.Txn(false)
+
+	obj, err := txn.First(s.tableName, "id", addr, LocalSchemaSource{ModulePath: modPath}, pv)
+	if err != nil {
+		return false, err
+	}
+	if obj == nil {
+		return false, nil
+	}
+	return obj.(*ProviderSchema).Schema != nil, nil
+}
+
 func (s *ProviderSchemaStore) AddPreloadedSchema(addr tfaddr.Provider, pv *version.Version, schema *tfschema.ProviderSchema) error {
 	txn := s.db.Txn(true)
 	defer txn.Abort()
@@ -185,6 +243,7 @@ func (s *ProviderSchemaStore) AddPreloadedSchema(addr tfaddr.Provider, pv *versi
 	schemaCopy := schema.Copy()
 
 	ps.Schema = schemaCopy
+	ps.Revision = s.revisions.next()
 
 	err = txn.Insert(s.tableName, ps)
 	if err != nil {
@@ -303,8 +362,36 @@ func providerAddrEquals(a, b tfaddr.Provider) bool {
 }
 
 func (s *ProviderSchemaStore) ProviderSchema(modPath string, addr tfaddr.Provider, vc version.Constraints) (*tfschema.ProviderSchema, error) {
-	txn := s.db.Txn(false)
+	ps, err := s.selectProviderSchema(s.db.Txn(false), modPath, addr, vc)
+	if err != nil {
+		return nil, err
+	}
+	return ps.Schema, nil
+}
+
+// SelectedProviderSchema returns the entry (including version and source)
+// of the schema which is used for the given provider in the given module,
+// i.e. the same one [ProviderSchemaStore.ProviderSchema] returns.
+func (s *ProviderSchemaStore) SelectedProviderSchema(modPath string, addr tfaddr.Provider, vc version.Constraints) (*ProviderSchema, error) {
+	ps, err := s.selectProviderSchema(s.db.Txn(false), modPath, addr, vc)
+	if err != nil {
+		return nil, err
+	}
+	return ps.Copy(), nil
+}
+
+// ProviderSchemaRevision returns the revision of the schema which is used
+// for the given provider in the given module, i.e. the same one
+// [ProviderSchemaStore.ProviderSchema] returns, without copying the schema.
+func (s *ProviderSchemaStore) ProviderSchemaRevision(modPath string, addr tfaddr.Provider, vc version.Constraints) (uint64, error) {
+	ps, err := s.selectProviderSchema(s.db.Txn(false), modPath, addr, vc)
+	if err != nil {
+		return 0, err
+	}
+	return ps.Revision, nil
+}
 
+func (s *ProviderSchemaStore) selectProviderSchema(txn *memdb.Txn, modPath string, addr tfaddr.Provider, vc version.Constraints) (*ProviderSchema, error) {
 	it, err := txn.Get(s.tableName, "id_prefix", addr)
 	if err != nil {
 		return nil, err
@@ -325,12 +412,12 @@ func (s *ProviderSchemaStore) ProviderSchema(modPath string, addr tfaddr.Provide
 
 	if len(schemas) == 0 && addr.Equals(NewDefaultProvider("terraform")) {
 		// assume that hashicorp/terraform is just the builtin provider
-		return s.ProviderSchema(modPath, NewBuiltInProvider("terraform"), vc)
+		return s.selectProviderSchema(txn, modPath, NewBuiltInProvider("terraform"), vc)
 	}
 
 	if len(schemas) == 0 && addr.IsLegacy() {
 		if addr.Type == "terraform" {
-			return s.ProviderSchema(modPath, NewBuiltInProvider("terraform"), vc)
+			return s.selectProviderSchema(txn, modPath, NewBuiltInProvider("terraform"), vc)
 		}
 
 		// Schema may be missing e.g. because Terraform 0.12
@@ -347,7 +434,7 @@ func (s *ProviderSchemaStore) ProviderSchema(modPath string, addr tfaddr.Provide
 		if obj != nil {
 			ps := obj.(*ProviderSchema)
 			if ps.Schema != nil {
-				return ps.Schema, nil
+				return ps, nil
 			}
 		}
 
@@ -379,7 +466,7 @@ func (s *ProviderSchemaStore) ProviderSchema(modPath string, addr tfaddr.Provide
 
 	sort.Stable(ss)
 
-	return ss.schemas[0].Schema, nil
+	return ss.schemas[0], nil
 }
 
 type ModuleLookupFunc func(string) (*Module, error)
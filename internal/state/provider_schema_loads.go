@@ -0,0 +1,119 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package state
+
+import (
+	"sort"
+
+	tfaddr "github.com/hashicorp/terraform-registry-address"
+)
+
+// SchemaLoad represents the state of loading schema of a single provider
+// for a module, either from schemas embedded in the server
+// or via Terraform CLI.
+type SchemaLoad struct {
+	ModulePath string
+	Address    tfaddr.Provider
+	State      SchemaLoadState
+	Err        error
+}
+
+//go:generate go run golang.org/x/tools/cmd/stringer -type=SchemaLoadState -output=schema_load_state_string.go
+type SchemaLoadState uint
+
+const (
+	SchemaLoadStateUnknown SchemaLoadState = iota
+	SchemaLoadStateLoading
+	SchemaLoadStateLoaded
+	SchemaLoadStateFailed
+)
+
+// SetSchemaLoadState records the state of loading schema
+// of the given provider for the module at the given path.
+// The record is removed if the state is [SchemaLoadStateUnknown].
+//
+// States are tracked per module, so that loading schemas
+// for one module does not override the outcome for another.
+func (s *ProviderSchemaStore) SetSchemaLoadState(modPath string, addr tfaddr.Provider, state SchemaLoadState, loadErr error) error {
+	txn := s.db.Txn(true)
+	defer txn.Abort()
+
+	_, err := txn.DeleteAll(schemaLoadsTableName, "id", modPath, addr)
+	if err != nil {
+		return err
+	}
+
+	if state != SchemaLoadStateUnknown {
+		err = txn.Insert(schemaLoadsTableName, &SchemaLoad{
+			ModulePath: modPath,
+			Address:    addr,
+			State:      state,
+			Err:        loadErr,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	txn.Commit()
+	return nil
+}
+
+// RemoveSchemaLoadStates removes states of loading schemas
+// recorded for the module at the given path.
+func (s *ProviderSchemaStore) RemoveSchemaLoadStates(modPath string) error {
+	txn := s.db.Txn(true)
+	defer txn.Abort()
+
+	_, err := txn.DeleteAll(schemaLoadsTableName, "module_path", modPath)
+	if err != nil {
+		return err
+	}
+
+	txn.Commit()
+	return nil
+}
+
+// SchemaLoad returns the state of loading schema of the given
+// provider for the module at the given path, or nil if unknown.
+func (s *ProviderSchemaStore) SchemaLoad(modPath string, addr tfaddr.Provider) (*SchemaLoad, error) {
+	txn := s.db.Txn(false)
+
+	obj, err := txn.First(schemaLoadsTableName, "id", modPath, addr)
+	if err != nil {
+		return nil, err
+	}
+	if obj == nil {
+		return nil, nil
+	}
+
+	load := *obj.(*SchemaLoad)
+	return &load, nil
+}
+
+// SchemaLoads returns states of loading schemas of all providers
+// sorted by module path and address, along with a channel
+// which is closed once any of the states change.
+func (s *ProviderSchemaStore) SchemaLoads() ([]SchemaLoad, <-chan struct{}, error) {
+	txn := s.db.Txn(false)
+
+	it, err := txn.Get(schemaLoadsTableName, "id")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	loads := make([]SchemaLoad, 0)
+	for item := it.Next(); item != nil; item = it.Next() {
+		loads = append(loads, *item.(*SchemaLoad))
+	}
+
+	sort.SliceStable(loads, func(i, j int) bool {
+		if loads[i].ModulePath != loads[j].ModulePath {
+			return loads[i].ModulePath < loads[j].ModulePath
+		}
+		return loads[i].Address.String() < loads[j].Address.String()
+	})
+
+	return loads, it.WatchCh(), nil
+}
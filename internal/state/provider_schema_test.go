@@ -348,72 +348,72 @@ func TestStateStore_ProviderSchema_localHasPriority(t *testing.T) {
 
 	schemas := []*ProviderSchema{
 		{
-			tfaddr.Provider{
+			Address: tfaddr.Provider{
 				Hostname:  tfaddr.DefaultProviderRegistryHost,
 				Namespace: "hashicorp",
 				Type:      "blah",
 			},
-			testVersion(t, "0.9.0"),
-			PreloadedSchemaSource{},
-			&tfschema.ProviderSchema{
+			Version: testVersion(t, "0.9.0"),
+			Source:  PreloadedSchemaSource{},
+			Schema: &tfschema.ProviderSchema{
 				Provider: &schema.BodySchema{
 					Description: lang.PlainText("preload: hashicorp/blah 0.9.0"),
 				},
 			},
 		},
 		{
-			tfaddr.Provider{
+			Address: tfaddr.Provider{
 				Hostname:  tfaddr.DefaultProviderRegistryHost,
 				Namespace: "hashicorp",
 				Type:      "aws",
 			},
-			testVersion(t, "0.9.0"),
-			PreloadedSchemaSource{},
-			&tfschema.ProviderSchema{
+			Version: testVersion(t, "0.9.0"),
+			Source:  PreloadedSchemaSource{},
+			Schema: &tfschema.ProviderSchema{
 				Provider: &schema.BodySchema{
 					Description: lang.PlainText("preload: hashicorp/aws 0.9.0"),
 				},
 			},
 		},
 		{
-			tfaddr.Provider{
+			Address: tfaddr.Provider{
 				Hostname:  tfaddr.DefaultProviderRegistryHost,
 				Namespace: "hashicorp",
 				Type:      "aws",
 			},
-			testVersion(t, "1.0.0"),
-			LocalSchemaSource{
+			Version: testVersion(t, "1.0.0"),
+			Source: LocalSchemaSource{
 				ModulePath: modPath,
 			},
-			&tfschema.ProviderSchema{
+			Schema: &tfschema.ProviderSchema{
 				Provider: &schema.BodySchema{
 					Description: lang.PlainText("local: hashicorp/aws 1.0.0"),
 				},
 			},
 		},
 		{
-			tfaddr.Provider{
+			Address: tfaddr.Provider{
 				Hostname:  tfaddr.DefaultProviderRegistryHost,
 				Namespace: "hashicorp",
 				Type:      "aws",
 			},
-			testVersion(t, "1.0.0"),
-			PreloadedSchemaSource{},
-			&tfschema.ProviderSchema{
+			Version: testVersion(t, "1.0.0"),
+			Source:  PreloadedSchemaSource{},
+			Schema: &tfschema.ProviderSchema{
 				Provider: &schema.BodySchema{
 					Description: lang.PlainText("preload: hashicorp/aws 1.0.0"),
 				},
 			},
 		},
 		{
-			tfaddr.Provider{
+			Address: tfaddr.Provider{
 				Hostname:  tfaddr.DefaultProviderRegistryHost,
 				Namespace: "hashicorp",
 				Type:      "aws",
 			},
-			testVersion(t, "1.3.0"),
-			PreloadedSchemaSource{},
-			&tfschema.ProviderSchema{
+			Version: testVersion(t, "1.3.0"),
+			Source:  PreloadedSchemaSource{},
+			Schema: &tfschema.ProviderSchema{
 				Provider: &schema.BodySchema{
 					Description: lang.PlainText("preload: hashicorp/aws 1.3.0"),
 				},
@@ -457,20 +457,20 @@ func TestStateStore_ProviderSchema_legacyAddress_exactMatch(t *testing.T) {
 
 	schemas := []*ProviderSchema{
 		{
-			NewLegacyProvider("aws"),
-			testVersion(t, "2.0.0"),
-			LocalSchemaSource{ModulePath: modPath},
-			&tfschema.ProviderSchema{
+			Address: NewLegacyProvider("aws"),
+			Version: testVersion(t, "2.0.0"),
+			Source:  LocalSchemaSource{ModulePath: modPath},
+			Schema: &tfschema.ProviderSchema{
 				Provider: &schema.BodySchema{
 					Description: lang.PlainText("local: -/aws 2.0.0"),
 				},
 			},
 		},
 		{
-			NewDefaultProvider("aws"),
-			testVersion(t, "2.5.0"),
-			PreloadedSchemaSource{},
-			&tfschema.ProviderSchema{
+			Address: NewDefaultProvider("aws"),
+			Version: testVersion(t, "2.5.0"),
+			Source:  PreloadedSchemaSource{},
+			Schema: &tfschema.ProviderSchema{
 				Provider: &schema.BodySchema{
 					Description: lang.PlainText("preload: hashicorp/aws 2.5.0"),
 				},
@@ -525,20 +525,20 @@ func TestStateStore_ProviderSchema_legacyAddress_looseMatch(t *testing.T) {
 
 	schemas := []*ProviderSchema{
 		{
-			NewDefaultProvider("aws"),
-			testVersion(t, "2.5.0"),
-			PreloadedSchemaSource{},
-			&tfschema.ProviderSchema{
+			Address: NewDefaultProvider("aws"),
+			Version: testVersion(t, "2.5.0"),
+			Source:  PreloadedSchemaSource{},
+			Schema: &tfschema.ProviderSchema{
 				Provider: &schema.BodySchema{
 					Description: lang.PlainText("preload: hashicorp/aws 2.5.0"),
 				},
 			},
 		},
 		{
-			tfaddr.NewProvider(tfaddr.DefaultProviderRegistryHost, "grafana", "grafana"),
-			testVersion(t, "1.0.0"),
-			PreloadedSchemaSource{},
-			&tfschema.ProviderSchema{
+			Address: tfaddr.NewProvider(tfaddr.DefaultProviderRegistryHost, "grafana", "grafana"),
+			Version: testVersion(t, "1.0.0"),
+			Source:  PreloadedSchemaSource{},
+			Schema: &tfschema.ProviderSchema{
 				Provider: &schema.BodySchema{
 					Description: lang.PlainText("preload: grafana/grafana 1.0.0"),
 				},
@@ -582,10 +582,10 @@ func TestStateStore_ProviderSchema_terraformProvider(t *testing.T) {
 
 	schemas := []*ProviderSchema{
 		{
-			NewBuiltInProvider("terraform"),
-			testVersion(t, "1.0.0"),
-			PreloadedSchemaSource{},
-			&tfschema.ProviderSchema{
+			Address: NewBuiltInProvider("terraform"),
+			Version: testVersion(t, "1.0.0"),
+			Source:  PreloadedSchemaSource{},
+			Schema: &tfschema.ProviderSchema{
 				Provider: &schema.BodySchema{
 					Description: lang.PlainText("preload: builtin/terraform 1.0.0"),
 				},
@@ -632,6 +632,31 @@ func TestStateStore_ProviderSchema_terraformProvider(t *testing.T) {
 	}
 }
 
+func TestStateStore_ProviderSchemaRevision_perStore(t *testing.T) {
+	addr := NewDefaultProvider("aws")
+	cons := testConstraint(t, "0.9.0")
+
+	for i := 0; i < 2; i++ {
+		s, err := NewStateStore()
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = s.ProviderSchemas.AddPreloadedSchema(addr, testVersion(t, "0.9.0"), &tfschema.ProviderSchema{})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		revision, err := s.ProviderSchemas.ProviderSchemaRevision("/test", addr, cons)
+		if err != nil {
+			t.Fatal(err)
+		}
+		// revisions of one store are not affected by schemas of other stores
+		if revision != 1 {
+			t.Fatalf("store %d: expected revision 1, given: %d", i, revision)
+		}
+	}
+}
+
 func TestStateStore_ListSchemas(t *testing.T) {
 	s, err := NewStateStore()
 	if err != nil {
@@ -656,58 +681,58 @@ func TestStateStore_ListSchemas(t *testing.T) {
 
 	localSchemas := []*ProviderSchema{
 		{
-			tfaddr.Provider{
+			Address: tfaddr.Provider{
 				Hostname:  tfaddr.DefaultProviderRegistryHost,
 				Namespace: "hashicorp",
 				Type:      "blah",
 			},
-			testVersion(t, "1.0.0"),
-			LocalSchemaSource{
+			Version: testVersion(t, "1.0.0"),
+			Source: LocalSchemaSource{
 				ModulePath: modPathA,
 			},
-			&tfschema.ProviderSchema{
+			Schema: &tfschema.ProviderSchema{
 				Provider: schema.NewBodySchema(),
 			},
 		},
 		{
-			tfaddr.Provider{
+			Address: tfaddr.Provider{
 				Hostname:  tfaddr.DefaultProviderRegistryHost,
 				Namespace: "hashicorp",
 				Type:      "aws-local",
 			},
-			testVersion(t, "0.9.0"),
-			LocalSchemaSource{
+			Version: testVersion(t, "0.9.0"),
+			Source: LocalSchemaSource{
 				ModulePath: modPathA,
 			},
-			&tfschema.ProviderSchema{
+			Schema: &tfschema.ProviderSchema{
 				Provider: schema.NewBodySchema(),
 			},
 		},
 		{
-			tfaddr.Provider{
+			Address: tfaddr.Provider{
 				Hostname:  tfaddr.DefaultProviderRegistryHost,
 				Namespace: "hashicorp",
 				Type:      "aws-local",
 			},
-			testVersion(t, "1.0.0"),
-			LocalSchemaSource{
+			Version: testVersion(t, "1.0.0"),
+			Source: LocalSchemaSource{
 				ModulePath: modPathB,
 			},
-			&tfschema.ProviderSchema{
+			Schema: &tfschema.ProviderSchema{
 				Provider: schema.NewBodySchema(),
 			},
 		},
 		{
-			tfaddr.Provider{
+			Address: tfaddr.Provider{
 				Hostname:  tfaddr.DefaultProviderRegistryHost,
 				Namespace: "hashicorp",
 				Type:      "aws-local",
 			},
-			testVersion(t, "1.3.0"),
-			LocalSchemaSource{
+			Version: testVersion(t, "1.3.0"),
+			Source: LocalSchemaSource{
 				ModulePath: modPathC,
 			},
-			&tfschema.ProviderSchema{
+			Schema: &tfschema.ProviderSchema{
 				Provider: schema.NewBodySchema(),
 			},
 		},
@@ -725,16 +750,16 @@ func TestStateStore_ListSchemas(t *testing.T) {
 
 	expectedSchemas := []*ProviderSchema{
 		{
-			tfaddr.Provider{
+			Address: tfaddr.Provider{
 				Hostname:  tfaddr.DefaultProviderRegistryHost,
 				Namespace: "hashicorp",
 				Type:      "aws-local",
 			},
-			testVersion(t, "0.9.0"),
-			LocalSchemaSource{
+			Version: testVersion(t, "0.9.0"),
+			Source: LocalSchemaSource{
 				ModulePath: modPathA,
 			},
-			&tfschema.ProviderSchema{
+			Schema: &tfschema.ProviderSchema{
 				Provider: &schema.BodySchema{
 					Detail:   "hashicorp/aws-local 0.9.0",
 					HoverURL: "https://registry.terraform.io/providers/hashicorp/aws-local/0.9.0/docs",
@@ -748,16 +773,16 @@ func TestStateStore_ListSchemas(t *testing.T) {
 			},
 		},
 		{
-			tfaddr.Provider{
+			Address: tfaddr.Provider{
 				Hostname:  tfaddr.DefaultProviderRegistryHost,
 				Namespace: "hashicorp",
 				Type:      "aws-local",
 			},
-			testVersion(t, "1.0.0"),
-			LocalSchemaSource{
+			Version: testVersion(t, "1.0.0"),
+			Source: LocalSchemaSource{
 				ModulePath: modPathB,
 			},
-			&tfschema.ProviderSchema{
+			Schema: &tfschema.ProviderSchema{
 				Provider: &schema.BodySchema{
 					Detail:   "hashicorp/aws-local 1.0.0",
 					HoverURL: "https://registry.terraform.io/providers/hashicorp/aws-local/1.0.0/docs",
@@ -771,16 +796,16 @@ func TestStateStore_ListSchemas(t *testing.T) {
 			},
 		},
 		{
-			tfaddr.Provider{
+			Address: tfaddr.Provider{
 				Hostname:  tfaddr.DefaultProviderRegistryHost,
 				Namespace: "hashicorp",
 				Type:      "aws-local",
 			},
-			testVersion(t, "1.3.0"),
-			LocalSchemaSource{
+			Version: testVersion(t, "1.3.0"),
+			Source: LocalSchemaSource{
 				ModulePath: modPathC,
 			},
-			&tfschema.ProviderSchema{
+			Schema: &tfschema.ProviderSchema{
 				Provider: &schema.BodySchema{
 					Detail:   "hashicorp/aws-local 1.3.0",
 					HoverURL: "https://registry.terraform.io/providers/hashicorp/aws-local/1.3.0/docs",
@@ -794,16 +819,16 @@ func TestStateStore_ListSchemas(t *testing.T) {
 			},
 		},
 		{
-			tfaddr.Provider{
+			Address: tfaddr.Provider{
 				Hostname:  tfaddr.DefaultProviderRegistryHost,
 				Namespace: "hashicorp",
 				Type:      "blah",
 			},
-			testVersion(t, "1.0.0"),
-			LocalSchemaSource{
+			Version: testVersion(t, "1.0.0"),
+			Source: LocalSchemaSource{
 				ModulePath: modPathA,
 			},
-			&tfschema.ProviderSchema{
+			Schema: &tfschema.ProviderSchema{
 				Provider: &schema.BodySchema{
 					Detail:   "hashicorp/blah 1.0.0",
 					HoverURL: "https://registry.terraform.io/providers/hashicorp/blah/1.0.0/docs",
@@ -935,28 +960,28 @@ func BenchmarkProviderSchema(b *testing.B) {
 
 	schemas := []*ProviderSchema{
 		{
-			tfaddr.Provider{
+			Address: tfaddr.Provider{
 				Hostname:  tfaddr.DefaultProviderRegistryHost,
 				Namespace: "hashicorp",
 				Type:      "blah",
 			},
-			testVersion(b, "0.9.0"),
-			PreloadedSchemaSource{},
-			&tfschema.ProviderSchema{
+			Version: testVersion(b, "0.9.0"),
+			Source:  PreloadedSchemaSource{},
+			Schema: &tfschema.ProviderSchema{
 				Provider: &schema.BodySchema{
 					Description: lang.PlainText("preload: hashicorp/blah 0.9.0"),
 				},
 			},
 		},
 		{
-			tfaddr.Provider{
+			Address: tfaddr.Provider{
 				Hostname:  tfaddr.DefaultProviderRegistryHost,
 				Namespace: "hashicorp",
 				Type:      "aws",
 			},
-			testVersion(b, "0.9.0"),
-			PreloadedSchemaSource{},
-			&tfschema.ProviderSchema{
+			Version: testVersion(b, "0.9.0"),
+			Source:  PreloadedSchemaSource{},
+			Schema: &tfschema.ProviderSchema{
 				Provider: &schema.BodySchema{
 					Description: lang.PlainText("preload: hashicorp/aws 0.9.0"),
 				},
@@ -1018,3 +1043,49 @@ func addAnySchema(t testOrBench, ss *ProviderSchemaStore, ms *ModuleStore, ps *P
 		}
 	}
 }
+
+func TestStateStore_SetSchemaLoadState_perModule(t *testing.T) {
+	s, err := NewStateStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	addr := tfaddr.MustParseProviderSource("hashicorp/aws")
+	firstPath := filepath.Join("first", "module")
+	secondPath := filepath.Join("second", "module")
+
+	err = s.ProviderSchemas.SetSchemaLoadState(firstPath, addr, SchemaLoadStateLoaded, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = s.ProviderSchemas.SetSchemaLoadState(secondPath, addr, SchemaLoadStateFailed, errors.New("boom"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	loads, _, err := s.ProviderSchemas.SchemaLoads()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(loads) != 2 {
+		t.Fatalf("expected 2 schema loads, given: %#v", loads)
+	}
+	if loads[0].ModulePath != firstPath || loads[0].State != SchemaLoadStateLoaded {
+		t.Fatalf("expected schema to remain loaded for %q, given: %#v", firstPath, loads[0])
+	}
+	if loads[1].ModulePath != secondPath || loads[1].State != SchemaLoadStateFailed {
+		t.Fatalf("expected schema to fail loading for %q, given: %#v", secondPath, loads[1])
+	}
+
+	err = s.ProviderSchemas.RemoveSchemaLoadStates(secondPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	loads, _, err = s.ProviderSchemas.SchemaLoads()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(loads) != 1 || loads[0].ModulePath != firstPath {
+		t.Fatalf("expected only schema load of %q to remain, given: %#v", firstPath, loads)
+	}
+}
@@ -12,11 +12,37 @@ import (
 )
 
 type RegistryModuleData struct {
-	Source  tfaddr.Module
-	Version *version.Version
-	Error   bool
-	Inputs  []registry.Input
-	Outputs []registry.Output
+	Source      tfaddr.Module
+	Version     *version.Version
+	Description string
+	Error       bool
+	Inputs      []registry.Input
+	Outputs     []registry.Output
+}
+
+func (rmd *RegistryModuleData) Copy() *RegistryModuleData {
+	if rmd == nil {
+		return nil
+	}
+
+	newRmd := &RegistryModuleData{
+		Source: rmd.Source,
+		// version.Version is practically immutable once parsed
+		Version:     rmd.Version,
+		Description: rmd.Description,
+		Error:       rmd.Error,
+	}
+
+	if rmd.Inputs != nil {
+		newRmd.Inputs = make([]registry.Input, len(rmd.Inputs))
+		copy(newRmd.Inputs, rmd.Inputs)
+	}
+	if rmd.Outputs != nil {
+		newRmd.Outputs = make([]registry.Output, len(rmd.Outputs))
+		copy(newRmd.Outputs, rmd.Outputs)
+	}
+
+	return newRmd
 }
 
 func (s *RegistryModuleStore) Exists(sourceAddr tfaddr.Module, constraint version.Constraints) (bool, error) {
@@ -44,8 +70,33 @@ func (s *RegistryModuleStore) Exists(sourceAddr tfaddr.Module, constraint versio
 	return false, nil
 }
 
+// ModuleData returns cached data of the registry module
+// of a version matching the given constraints.
+func (s *RegistryModuleStore) ModuleData(sourceAddr tfaddr.Module, constraint version.Constraints) (*RegistryModuleData, error) {
+	txn := s.db.Txn(false)
+
+	iter, err := txn.Get(s.tableName, "source_addr", sourceAddr)
+	if err != nil {
+		return nil, err
+	}
+
+	for obj := iter.Next(); obj != nil; obj = iter.Next() {
+		p := obj.(*RegistryModuleData)
+		if p.Error {
+			continue
+		}
+		if constraint.Check(p.Version) {
+			return p.Copy(), nil
+		}
+	}
+
+	return nil, &ModuleNotFoundError{
+		Source: sourceAddr.String(),
+	}
+}
+
 func (s *RegistryModuleStore) Cache(sourceAddr tfaddr.Module, modVer *version.Version,
-	inputs []registry.Input, outputs []registry.Output) error {
+	description string, inputs []registry.Input, outputs []registry.Output) error {
 
 	txn := s.db.Txn(true)
 	defer txn.Abort()
@@ -61,10 +112,11 @@ func (s *RegistryModuleStore) Cache(sourceAddr tfaddr.Module, modVer *version.Ve
 	}
 
 	modData := &RegistryModuleData{
-		Source:  sourceAddr,
-		Version: modVer,
-		Inputs:  inputs,
-		Outputs: outputs,
+		Source:      sourceAddr,
+		Version:     modVer,
+		Description: description,
+		Inputs:      inputs,
+		Outputs:     outputs,
 	}
 
 	err = txn.Insert(s.tableName, modData)
@@ -54,7 +54,7 @@ func TestStateStore_cache_metadata(t *testing.T) {
 	}
 
 	// store a dummy data
-	err = s.RegistryModules.Cache(source, v, inputs, outputs)
+	err = s.RegistryModules.Cache(source, v, "", inputs, outputs)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -98,7 +98,7 @@ func TestModule_DeclaredModuleMeta(t *testing.T) {
 	}
 
 	// store some dummy data
-	err = ss.RegistryModules.Cache(source, v, inputs, outputs)
+	err = ss.RegistryModules.Cache(source, v, "", inputs, outputs)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -155,3 +155,39 @@ func TestStateStore_cache_error(t *testing.T) {
 		t.Fatal("should exist")
 	}
 }
+
+func TestRegistryModuleStore_ModuleData_isolation(t *testing.T) {
+	s, err := NewStateStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	source, err := tfaddr.ParseModuleSource("hashicorp/consul/aws")
+	if err != nil {
+		t.Fatal(err)
+	}
+	v := version.Must(version.NewVersion("0.11.0"))
+	inputs := []registry.Input{
+		{Name: "cluster_name", Type: cty.String},
+	}
+	err = s.RegistryModules.Cache(source, v, "", inputs, []registry.Output{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cons := version.MustConstraints(version.NewConstraint("0.11.0"))
+	data, err := s.RegistryModules.ModuleData(source, cons)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data.Inputs[0].Name = "modified"
+	data.Description = "modified"
+
+	data, err = s.RegistryModules.ModuleData(source, cons)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if data.Inputs[0].Name != "cluster_name" || data.Description != "" {
+		t.Fatalf("cached data changed: %#v", data)
+	}
+}
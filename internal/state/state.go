@@ -25,6 +25,7 @@ const (
 	moduleChangesTableName  = "module_changes"
 	providerSchemaTableName = "provider_schema"
 	providerIdsTableName    = "provider_ids"
+	schemaLoadsTableName    = "provider_schema_loads"
 	walkerPathsTableName    = "walker_paths"
 	registryModuleTableName = "registry_module"
 
@@ -175,6 +176,25 @@ var dbSchema = &memdb.DBSchema{
 				},
 			},
 		},
+		schemaLoadsTableName: {
+			Name: schemaLoadsTableName,
+			Indexes: map[string]*memdb.IndexSchema{
+				"id": {
+					Name:   "id",
+					Unique: true,
+					Indexer: &memdb.CompoundIndex{
+						Indexes: []memdb.Indexer{
+							&memdb.StringFieldIndex{Field: "ModulePath"},
+							&StringerFieldIndexer{Field: "Address"},
+						},
+					},
+				},
+				"module_path": {
+					Name:    "module_path",
+					Indexer: &memdb.StringFieldIndex{Field: "ModulePath"},
+				},
+			},
+		},
 		moduleIdsTableName: {
 			Name: moduleIdsTableName,
 			Indexes: map[string]*memdb.IndexSchema{
@@ -244,6 +264,8 @@ type ModuleStore struct {
 	// MaxModuleNesting represents how many nesting levels we'd attempt
 	// to parse provider requirements before returning error.
 	MaxModuleNesting int
+
+	schemaRevisions *schemaRevisions
 }
 
 type ModuleChangeStore struct {
@@ -266,6 +288,8 @@ type ProviderSchemaStore struct {
 	db        *memdb.MemDB
 	tableName string
 	logger    *log.Logger
+
+	revisions *schemaRevisions
 }
 type RegistryModuleStore struct {
 	db        *memdb.MemDB
@@ -283,6 +307,8 @@ func NewStateStore() (*StateStore, error) {
 		return nil, err
 	}
 
+	revisions := &schemaRevisions{}
+
 	return &StateStore{
 		db: db,
 		DocumentStore: &DocumentStore{
@@ -297,6 +323,7 @@ func NewStateStore() (*StateStore, error) {
 			logger:            defaultLogger,
 			nextJobHighPrioMu: &sync.Mutex{},
 			nextJobLowPrioMu:  &sync.Mutex{},
+			focusedDirMu:      &sync.RWMutex{},
 		},
 		Modules: &ModuleStore{
 			db:               db,
@@ -304,11 +331,13 @@ func NewStateStore() (*StateStore, error) {
 			logger:           defaultLogger,
 			TimeProvider:     time.Now,
 			MaxModuleNesting: 50,
+			schemaRevisions:  revisions,
 		},
 		ProviderSchemas: &ProviderSchemaStore{
 			db:        db,
 			tableName: providerSchemaTableName,
 			logger:    defaultLogger,
+			revisions: revisions,
 		},
 		RegistryModules: &RegistryModuleStore{
 			db:        db,
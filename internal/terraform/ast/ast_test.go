@@ -69,3 +69,68 @@ func TestModuleDiags_autoloadedOnly(t *testing.T) {
 		t.Fatalf("unexpected diagnostics: %s", diff)
 	}
 }
+
+func TestSourceModDiags_copy(t *testing.T) {
+	original := SourceModDiags{
+		HCLParsingSource: ModDiagsFromMap(map[string]hcl.Diagnostics{
+			"main.tf": {
+				{
+					Severity: hcl.DiagError,
+					Summary:  "Test error",
+				},
+			},
+		}),
+	}
+
+	copied := original.Copy()
+	copied[HCLParsingSource]["main.tf"][0] = &hcl.Diagnostic{
+		Severity: hcl.DiagWarning,
+		Summary:  "Modified",
+	}
+	copied[HCLParsingSource]["main.tf"] = append(copied[HCLParsingSource]["main.tf"], &hcl.Diagnostic{
+		Severity: hcl.DiagWarning,
+		Summary:  "Appended",
+	})
+	copied[SchemaValidationSource] = ModDiags{}
+
+	expectedDiags := SourceModDiags{
+		HCLParsingSource: ModDiagsFromMap(map[string]hcl.Diagnostics{
+			"main.tf": {
+				{
+					Severity: hcl.DiagError,
+					Summary:  "Test error",
+				},
+			},
+		}),
+	}
+	if diff := cmp.Diff(expectedDiags, original, ctydebug.CmpOptions); diff != "" {
+		t.Fatalf("original diagnostics changed: %s", diff)
+	}
+}
+
+func TestSourceVarsDiags_copy(t *testing.T) {
+	original := SourceVarsDiags{
+		HCLParsingSource: VarsDiagsFromMap(map[string]hcl.Diagnostics{
+			"terraform.tfvars": {
+				{
+					Severity: hcl.DiagError,
+					Summary:  "Test error",
+				},
+			},
+		}),
+	}
+
+	copied := original.Copy()
+	copied[HCLParsingSource]["terraform.tfvars"][0] = &hcl.Diagnostic{
+		Severity: hcl.DiagWarning,
+		Summary:  "Modified",
+	}
+
+	if original[HCLParsingSource]["terraform.tfvars"][0].Summary != "Test error" {
+		t.Fatalf("original diagnostics changed: %#v", original[HCLParsingSource])
+	}
+
+	if SourceVarsDiags(nil).Copy() != nil {
+		t.Fatal("expected copy of nil diagnostics to be nil")
+	}
+}
@@ -4,6 +4,9 @@
 package ast
 
 import (
+	"slices"
+
+	"github.com/hashicorp/hcl/v2"
 	op "github.com/hashicorp/terraform-ls/internal/terraform/module/operation"
 )
 
@@ -15,12 +18,55 @@ const (
 	SchemaValidationSource
 	ReferenceValidationSource
 	TerraformValidateSource
+	OrphanedVariablesSource
 )
 
+var diagnosticSourceNames = map[DiagnosticSource]string{
+	HCLParsingSource:          "HCLParsing",
+	SchemaValidationSource:    "SchemaValidation",
+	ReferenceValidationSource: "ReferenceValidation",
+	TerraformValidateSource:   "TerraformValidate",
+	OrphanedVariablesSource:   "OrphanedVariables",
+}
+
 func (d DiagnosticSource) String() string {
 	return "Terraform"
 }
 
+// Name returns a name which identifies the source,
+// e.g. in settings or when dumping state.
+func (d DiagnosticSource) Name() string {
+	return diagnosticSourceNames[d]
+}
+
+// DiagnosticSourceFromName returns the source identified by the given name.
+func DiagnosticSourceFromName(name string) (DiagnosticSource, bool) {
+	for source, sourceName := range diagnosticSourceNames {
+		if sourceName == name {
+			return source, true
+		}
+	}
+	return 0, false
+}
+
+// DiagnosticSourceNames returns names of all sources
+// except the given ones, ordered by source.
+func DiagnosticSourceNames(except ...DiagnosticSource) []string {
+	sources := make([]DiagnosticSource, 0, len(diagnosticSourceNames))
+	for source := range diagnosticSourceNames {
+		if !slices.Contains(except, source) {
+			sources = append(sources, source)
+		}
+	}
+	slices.Sort(sources)
+
+	names := make([]string, len(sources))
+	for i, source := range sources {
+		names[i] = source.Name()
+	}
+	return names
+}
+
 type DiagnosticSourceState map[DiagnosticSource]op.OpState
 
 func (dss DiagnosticSourceState) Copy() DiagnosticSourceState {
@@ -31,3 +77,33 @@ func (dss DiagnosticSourceState) Copy() DiagnosticSourceState {
 
 	return newDiagnosticSourceState
 }
+
+// copyDiags returns a deep copy of the given per-file diagnostics,
+// such that the copy does not share any slices with the original.
+func copyDiags[K comparable, M ~map[K]hcl.Diagnostics](m M) M {
+	if m == nil {
+		return nil
+	}
+	newMap := make(M, len(m))
+	for name, diags := range m {
+		if diags == nil {
+			newMap[name] = nil
+			continue
+		}
+		newMap[name] = make(hcl.Diagnostics, len(diags))
+		copy(newMap[name], diags)
+	}
+	return newMap
+}
+
+// copySourceDiags returns a deep copy of the given per-source diagnostics.
+func copySourceDiags[K comparable, D ~map[K]hcl.Diagnostics, M ~map[DiagnosticSource]D](m M) M {
+	if m == nil {
+		return nil
+	}
+	newMap := make(M, len(m))
+	for source, diags := range m {
+		newMap[source] = copyDiags(diags)
+	}
+	return newMap
+}
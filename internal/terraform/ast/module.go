@@ -92,11 +92,7 @@ func (md ModDiags) AsMap() map[string]hcl.Diagnostics {
 }
 
 func (md ModDiags) Copy() ModDiags {
-	m := make(ModDiags, len(md))
-	for name, diags := range md {
-		m[name] = diags
-	}
-	return m
+	return copyDiags(md)
 }
 
 func (md ModDiags) Count() int {
@@ -109,6 +105,10 @@ func (md ModDiags) Count() int {
 
 type SourceModDiags map[DiagnosticSource]ModDiags
 
+func (smd SourceModDiags) Copy() SourceModDiags {
+	return copySourceDiags(smd)
+}
+
 func (smd SourceModDiags) Count() int {
 	count := 0
 	for _, diags := range smd {
@@ -71,11 +71,7 @@ func VarsDiagsFromMap(m map[string]hcl.Diagnostics) VarsDiags {
 }
 
 func (vd VarsDiags) Copy() VarsDiags {
-	m := make(VarsDiags, len(vd))
-	for name, file := range vd {
-		m[name] = file
-	}
-	return m
+	return copyDiags(vd)
 }
 
 func (vd VarsDiags) AutoloadedOnly() VarsDiags {
@@ -106,6 +102,10 @@ func (vd VarsDiags) Count() int {
 
 type SourceVarsDiags map[DiagnosticSource]VarsDiags
 
+func (svd SourceVarsDiags) Copy() SourceVarsDiags {
+	return copySourceDiags(svd)
+}
+
 func (svd SourceVarsDiags) Count() int {
 	count := 0
 	for _, diags := range svd {
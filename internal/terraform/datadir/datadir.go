@@ -20,33 +20,30 @@ type WatchablePaths struct {
 	PluginLockFiles []string
 }
 
-func WatchableModulePaths(modPath string) *WatchablePaths {
+func WatchableModulePaths(dir Dir, modPath string) *WatchablePaths {
 	wp := &WatchablePaths{
-		Dirs:            watchableModuleDirs(modPath),
+		Dirs:            watchableModuleDirs(dir, modPath),
 		ModuleManifests: make([]string, 0),
 		PluginLockFiles: make([]string, 0),
 	}
 
-	manifestPath := filepath.Join(append([]string{modPath}, manifestPathElements...)...)
-	wp.ModuleManifests = append(wp.ModuleManifests, manifestPath)
-
-	for _, pathElems := range pluginLockFilePathElements {
-		filePath := filepath.Join(append([]string{modPath}, pathElems...)...)
-		wp.PluginLockFiles = append(wp.PluginLockFiles, filePath)
-	}
+	wp.ModuleManifests = append(wp.ModuleManifests, manifestPath(dir, modPath))
+	wp.PluginLockFiles = append(wp.PluginLockFiles, pluginLockFilePaths(dir, modPath)...)
 
 	return wp
 }
 
 // ModulePath strips known lock file paths to get the path
 // to the (closest) module these files belong to
-func ModulePath(filePath string) (string, bool) {
-	manifestSuffix := filepath.Join(manifestPathElements...)
-	if strings.HasSuffix(filePath, manifestSuffix) {
-		return strings.TrimSuffix(filePath, manifestSuffix), true
+func ModulePath(dir Dir, filePath string) (string, bool) {
+	if !dir.IsAbs() {
+		manifestSuffix := filepath.Join(manifestPathElements(dir)...)
+		if strings.HasSuffix(filePath, manifestSuffix) {
+			return strings.TrimSuffix(filePath, manifestSuffix), true
+		}
 	}
 
-	for _, pathElems := range pluginLockFilePathElements {
+	for _, pathElems := range pluginLockFilePathElements(dir) {
 		suffix := filepath.Join(pathElems...)
 		if strings.HasSuffix(filePath, suffix) {
 			return strings.TrimSuffix(filePath, suffix), true
@@ -56,18 +53,55 @@ func ModulePath(filePath string) (string, bool) {
 	return "", false
 }
 
-func WalkDataDirOfModule(fs fs.StatFS, modPath string) *DataDir {
-	dir := &DataDir{}
+// InstalledModuleRootPath returns path to the root module which
+// installed the module at the given path, i.e. path of the directory
+// containing .terraform/modules, if the module is installed within it.
+//
+// The root module cannot be told for modules installed
+// in a data directory shared by all modules.
+func InstalledModuleRootPath(dir Dir, modPath string) (string, bool) {
+	if dir.IsAbs() {
+		return "", false
+	}
+	sep := string(filepath.Separator)
+	modulesDir := sep + filepath.Join(dir.String(), "modules") + sep
+
+	path := filepath.Clean(modPath) + sep
+	idx := strings.Index(path, modulesDir)
+	if idx < 0 || idx+len(modulesDir) == len(path) {
+		return "", false
+	}
+
+	return path[:idx], true
+}
+
+// IsInstalledModulePath reports whether the module at the given path
+// is installed within a data directory, either of its root module,
+// or the one shared by all modules.
+func IsInstalledModulePath(dir Dir, modPath string) bool {
+	if !dir.IsAbs() {
+		_, ok := InstalledModuleRootPath(dir, modPath)
+		return ok
+	}
+
+	sep := string(filepath.Separator)
+	modulesDir := filepath.Join(dir.String(), "modules") + sep
+	path := filepath.Clean(modPath) + sep
+	return strings.HasPrefix(path, modulesDir) && len(path) > len(modulesDir)
+}
+
+func WalkDataDirOfModule(fs fs.StatFS, dir Dir, modPath string) *DataDir {
+	dataDir := &DataDir{}
 
-	path, ok := ModuleManifestFilePath(fs, modPath)
+	path, ok := ModuleManifestFilePath(fs, dir, modPath)
 	if ok {
-		dir.ModuleManifestPath = path
+		dataDir.ModuleManifestPath = path
 	}
 
-	path, ok = PluginLockFilePath(fs, modPath)
+	path, ok = PluginLockFilePath(fs, dir, modPath)
 	if ok {
-		dir.PluginLockFilePath = path
+		dataDir.PluginLockFilePath = path
 	}
 
-	return dir
+	return dataDir
 }
@@ -0,0 +1,177 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package datadir
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+)
+
+func TestInstalledModuleRootPath(t *testing.T) {
+	root := filepath.Join(string(filepath.Separator), "path", "to", "root")
+
+	testCases := []struct {
+		modPath      string
+		expectedRoot string
+		expectedOk   bool
+	}{
+		{root, "", false},
+		{filepath.Join(root, "modules", "vpc"), "", false},
+		{filepath.Join(root, ".terraform", "modules"), "", false},
+		{filepath.Join(root, ".terraform", "modules", "vpc"), root, true},
+		{filepath.Join(root, ".terraform", "modules", "vpc", "modules", "subnets"), root, true},
+		{filepath.Join(root, ".terraform", "modules", "a", ".terraform", "modules", "b"), root, true},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%d-%s", i, tc.modPath), func(t *testing.T) {
+			rootPath, ok := InstalledModuleRootPath(Dir{}, tc.modPath)
+			if ok != tc.expectedOk {
+				t.Fatalf("expected ok: %t, given: %t", tc.expectedOk, ok)
+			}
+			if rootPath != tc.expectedRoot {
+				t.Fatalf("expected root %q, given: %q", tc.expectedRoot, rootPath)
+			}
+		})
+	}
+}
+
+func TestWalkDataDirOfModule_relativeDataDir(t *testing.T) {
+	dir, err := NewDir(".tfdata")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fs := fstest.MapFS{
+		"foo-module": &fstest.MapFile{Mode: fs.ModeDir},
+		filepath.Join("foo-module", ".tfdata", "modules", "modules.json"):    &fstest.MapFile{Data: []byte(`{"Modules":[]}`)},
+		filepath.Join("foo-module", ".tfdata", "plugins", "selections.json"): &fstest.MapFile{Data: []byte(`{}`)},
+		// stale data directory at the default location
+		filepath.Join("foo-module", ".terraform", "modules", "modules.json"): &fstest.MapFile{Data: []byte(`{"Modules":[]}`)},
+	}
+
+	dataDir := WalkDataDirOfModule(fs, dir, "foo-module")
+	expectedDataDir := &DataDir{
+		ModuleManifestPath: filepath.Join("foo-module", ".tfdata", "modules", "modules.json"),
+		PluginLockFilePath: filepath.Join("foo-module", ".tfdata", "plugins", "selections.json"),
+	}
+	if *dataDir != *expectedDataDir {
+		t.Fatalf("expected data dir %#v, given: %#v", expectedDataDir, dataDir)
+	}
+
+	modPath, ok := ModulePath(dir, dataDir.ModuleManifestPath)
+	if !ok || filepath.Clean(modPath) != "foo-module" {
+		t.Fatalf("expected module path %q, given: %q (ok: %t)", "foo-module", modPath, ok)
+	}
+
+	root := filepath.Join(string(filepath.Separator), "path", "to", "root")
+	rootPath, ok := InstalledModuleRootPath(dir, filepath.Join(root, ".tfdata", "modules", "vpc"))
+	if !ok || rootPath != root {
+		t.Fatalf("expected root %q, given: %q (ok: %t)", root, rootPath, ok)
+	}
+
+	modUri, ok := ModuleUriFromModuleLockFile(dir, "file:///path/to/root/.tfdata/modules/modules.json")
+	if !ok || modUri != "file:///path/to/root" {
+		t.Fatalf("expected module URI %q, given: %q (ok: %t)", "file:///path/to/root", modUri, ok)
+	}
+}
+
+func TestWalkDataDirOfModule_absoluteDataDir(t *testing.T) {
+	tmpDir := t.TempDir()
+	modPath := filepath.Join(tmpDir, "foo-module")
+	dataDirPath := filepath.Join(tmpDir, "tfdata")
+
+	dir, err := NewDir(dataDirPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	files := map[string]string{
+		filepath.Join(modPath, "main.tf"):                               ``,
+		filepath.Join(dataDirPath, "modules", "modules.json"):           `{"Modules":[]}`,
+		filepath.Join(dataDirPath, "plugins", "selections.json"):        `{}`,
+		filepath.Join(modPath, ".terraform", "modules", "modules.json"): `{"Modules":[]}`,
+	}
+	for path, content := range files {
+		err := os.MkdirAll(filepath.Dir(path), 0o755)
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = os.WriteFile(path, []byte(content), 0o644)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	dataDir := WalkDataDirOfModule(osFS{}, dir, modPath)
+	expectedDataDir := &DataDir{
+		ModuleManifestPath: filepath.Join(dataDirPath, "modules", "modules.json"),
+		PluginLockFilePath: filepath.Join(dataDirPath, "plugins", "selections.json"),
+	}
+	if *dataDir != *expectedDataDir {
+		t.Fatalf("expected data dir %#v, given: %#v", expectedDataDir, dataDir)
+	}
+
+	_, ok := ModuleUriFromModuleLockFile(dir, "file:///path/to/root/.terraform/modules/modules.json")
+	if ok {
+		t.Fatal("expected manifest within the module not to be recognized")
+	}
+
+	if !IsInstalledModulePath(dir, filepath.Join(dataDirPath, "modules", "vpc")) {
+		t.Fatal("expected module within the data directory to be recognized as installed")
+	}
+	if IsInstalledModulePath(dir, filepath.Join(modPath, ".terraform", "modules", "vpc")) {
+		t.Fatal("expected module outside of the data directory not to be recognized as installed")
+	}
+}
+
+func TestNewDir(t *testing.T) {
+	absPath := filepath.Join(string(filepath.Separator), "tmp", "tfdata")
+
+	testCases := []struct {
+		path         string
+		expectedPath string
+		expectedErr  bool
+	}{
+		{"", filepath.Join("mod", DataDirName), false},
+		{".tfdata", filepath.Join("mod", ".tfdata"), false},
+		{filepath.Join("nested", ".tfdata"), filepath.Join("mod", "nested", ".tfdata"), false},
+		{absPath, absPath, false},
+		{"..", "", true},
+		{".", "", true},
+		{filepath.Join("..", "tfdata"), "", true},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%d-%s", i, tc.path), func(t *testing.T) {
+			dir, err := NewDir(tc.path)
+			if tc.expectedErr {
+				if err == nil {
+					t.Fatalf("expected %q to be rejected", tc.path)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if path := dir.Path("mod"); path != tc.expectedPath {
+				t.Fatalf("expected data dir path %q, given: %q", tc.expectedPath, path)
+			}
+		})
+	}
+}
+
+type osFS struct{}
+
+func (osFS) Open(name string) (fs.File, error) {
+	return os.Open(name)
+}
+
+func (osFS) Stat(name string) (fs.FileInfo, error) {
+	return os.Stat(name)
+}
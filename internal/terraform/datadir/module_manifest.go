@@ -9,21 +9,25 @@ import (
 	"io/fs"
 	"io/ioutil"
 	"path/filepath"
-	"strings"
 
 	version "github.com/hashicorp/go-version"
 	"github.com/hashicorp/terraform-ls/internal/pathcmp"
 	tfmod "github.com/hashicorp/terraform-schema/module"
 )
 
-var manifestPathElements = []string{
-	DataDirName, "modules", "modules.json",
+// manifestPathElements returns path elements of the module manifest
+// relative to the module, which is only meaningful
+// for data directories relative to the module.
+func manifestPathElements(dir Dir) []string {
+	return []string{dir.String(), "modules", "modules.json"}
 }
 
-func ModuleManifestFilePath(fs fs.StatFS, modulePath string) (string, bool) {
-	manifestPath := filepath.Join(
-		append([]string{modulePath},
-			manifestPathElements...)...)
+func manifestPath(dir Dir, modulePath string) string {
+	return filepath.Join(dir.Path(modulePath), "modules", "modules.json")
+}
+
+func ModuleManifestFilePath(fs fs.StatFS, dir Dir, modulePath string) (string, bool) {
+	manifestPath := manifestPath(dir, modulePath)
 
 	fi, err := fs.Stat(manifestPath)
 	if err == nil && fi.Mode().IsRegular() {
@@ -101,13 +105,24 @@ func (r *ModuleRecord) IsRoot() bool {
 	return r.Key == ""
 }
 
+// IsExternal reports whether the module was installed
+// into the data directory, i.e. whether it has a non-local source.
 func (r *ModuleRecord) IsExternal() bool {
-	modCacheDir := filepath.Join(".terraform", "modules")
-	if strings.HasPrefix(r.Dir, modCacheDir) {
-		return true
+	if r.IsRoot() {
+		return false
 	}
+	_, isLocal := r.SourceAddr.(tfmod.LocalSourceAddr)
+	return !isLocal
+}
 
-	return false
+// Path returns path to the directory where the module is installed,
+// resolving it relative to the given root module unless it is absolute,
+// e.g. when the data directory is shared by all modules.
+func (r *ModuleRecord) Path(rootDir string) string {
+	if filepath.IsAbs(r.Dir) {
+		return r.Dir
+	}
+	return filepath.Join(rootDir, r.Dir)
 }
 
 type ModuleManifest struct {
@@ -150,7 +165,7 @@ func (mm *ModuleManifest) ContainsLocalModule(path string) bool {
 			continue
 		}
 
-		absPath := filepath.Join(mm.RootDir(), mod.Dir)
+		absPath := mod.Path(mm.RootDir())
 		if pathcmp.PathEquals(absPath, path) {
 			return true
 		}
@@ -158,7 +173,34 @@ func (mm *ModuleManifest) ContainsLocalModule(path string) bool {
 	return false
 }
 
-func ParseModuleManifestFromFile(path string) (*ModuleManifest, error) {
+// ChangedRecords returns records which were added or changed
+// (e.g. installed from a different source, or in a different version)
+// compared to the given previous manifest, which may be nil.
+func (mm *ModuleManifest) ChangedRecords(prev *ModuleManifest) []ModuleRecord {
+	prevRecords := make(map[string]ModuleRecord, 0)
+	if prev != nil {
+		for _, record := range prev.Records {
+			prevRecords[record.Key] = record
+		}
+	}
+
+	changed := make([]ModuleRecord, 0)
+	for _, record := range mm.Records {
+		prevRecord, ok := prevRecords[record.Key]
+		if ok && prevRecord.Dir == record.Dir &&
+			prevRecord.RawSourceAddr == record.RawSourceAddr &&
+			prevRecord.VersionStr == record.VersionStr {
+			continue
+		}
+		changed = append(changed, record)
+	}
+
+	return changed
+}
+
+// ParseModuleManifestFromFile parses the module manifest at the given path,
+// which belongs to the root module at the given path.
+func ParseModuleManifestFromFile(path, rootDir string) (*ModuleManifest, error) {
 	b, err := ioutil.ReadFile(path)
 	if err != nil {
 		return nil, err
@@ -168,10 +210,6 @@ func ParseModuleManifestFromFile(path string) (*ModuleManifest, error) {
 	if err != nil {
 		return nil, err
 	}
-	rootDir, ok := ModulePath(path)
-	if !ok {
-		return nil, fmt.Errorf("failed to detect module path: %s", path)
-	}
 	mm.rootDir = filepath.Clean(rootDir)
 
 	return mm, nil
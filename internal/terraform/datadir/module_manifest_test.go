@@ -58,7 +58,7 @@ func TestParseModuleManifestFromFile(t *testing.T) {
 	if err != nil {
 		t.Fatal(err)
 	}
-	mm, err := ParseModuleManifestFromFile(path)
+	mm, err := ParseModuleManifestFromFile(path, modPath)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -69,6 +69,42 @@ func TestParseModuleManifestFromFile(t *testing.T) {
 	}
 }
 
+func TestModuleManifest_ChangedRecords(t *testing.T) {
+	prevManifest, err := parseModuleManifest([]byte(testManifestContent))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// one module added, one upgraded and the rest unchanged
+	newManifest := prevManifest.Copy()
+	newManifest.Records[1] = ModuleRecord{
+		Key:           "web_server_sg2",
+		RawSourceAddr: "terraform-aws-modules/security-group/aws//modules/http-80",
+		VersionStr:    "3.11.0",
+		Dir:           filepath.Join(".terraform", "modules", "web_server_sg", "terraform-aws-security-group-3.11.0", "modules", "http-80"),
+	}
+	addedRecord := ModuleRecord{
+		Key:           "vpc",
+		RawSourceAddr: "terraform-aws-modules/vpc/aws",
+		VersionStr:    "5.0.0",
+		Dir:           filepath.Join(".terraform", "modules", "vpc"),
+	}
+	newManifest.Records = append(newManifest.Records, addedRecord)
+
+	expectedRecords := []ModuleRecord{
+		newManifest.Records[1],
+		addedRecord,
+	}
+	if diff := cmp.Diff(expectedRecords, newManifest.ChangedRecords(prevManifest)); diff != "" {
+		t.Fatalf("unexpected changed records: %s", diff)
+	}
+
+	// all records are considered changed without previous manifest
+	if diff := cmp.Diff(prevManifest.Records, prevManifest.ChangedRecords(nil)); diff != "" {
+		t.Fatalf("unexpected changed records: %s", diff)
+	}
+}
+
 const testManifestContent = `{
     "Modules": [
         {
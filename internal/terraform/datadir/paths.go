@@ -4,20 +4,77 @@
 package datadir
 
 import (
+	"fmt"
 	"path"
 	"path/filepath"
 	"runtime"
 	"strings"
 )
 
+// DataDirName represents the default name of the data directory
+// created by Terraform within each initialized module.
 const DataDirName = ".terraform"
 
-func watchableModuleDirs(modPath string) []string {
+// DataDirEnvVar represents the environment variable
+// which Terraform reads to relocate the data directory.
+const DataDirEnvVar = "TF_DATA_DIR"
+
+// Dir represents location of the data directory, which is either
+// relative to each module, or an absolute path of a single data directory
+// shared by all modules, as configured e.g. via TF_DATA_DIR.
+//
+// The zero value represents the default location, i.e. DataDirName
+// within each module.
+type Dir struct {
+	path string
+}
+
+// NewDir returns location of the data directory at the given path,
+// which Terraform resolves relative to its working directory (the module).
+// An empty path represents the default location.
+func NewDir(path string) (Dir, error) {
+	if path == "" {
+		return Dir{}, nil
+	}
+	path = filepath.Clean(path)
+	if filepath.IsAbs(path) {
+		return Dir{path: path}, nil
+	}
+	if path == "." || path == ".." || strings.HasPrefix(path, ".."+string(filepath.Separator)) {
+		return Dir{}, fmt.Errorf("expected data directory within the module, got %q", path)
+	}
+	return Dir{path: path}, nil
+}
+
+// String returns the configured path of the data directory,
+// or DataDirName for the default location.
+func (d Dir) String() string {
+	if d.path == "" {
+		return DataDirName
+	}
+	return d.path
+}
+
+// IsAbs reports whether the data directory is shared by all modules.
+func (d Dir) IsAbs() bool {
+	return filepath.IsAbs(d.path)
+}
+
+// Path returns path to the data directory of the given module.
+func (d Dir) Path(modPath string) string {
+	if d.IsAbs() {
+		return d.path
+	}
+	return filepath.Join(modPath, d.String())
+}
+
+func watchableModuleDirs(dir Dir, modPath string) []string {
+	dataDirPath := dir.Path(modPath)
 	return []string{
-		filepath.Join(modPath, DataDirName),
-		filepath.Join(modPath, DataDirName, "modules"),
-		filepath.Join(modPath, DataDirName, "plugins"),
-		filepath.Join(modPath, DataDirName, "plugins", runtime.GOOS+"_"+runtime.GOARCH),
+		dataDirPath,
+		filepath.Join(dataDirPath, "modules"),
+		filepath.Join(dataDirPath, "plugins"),
+		filepath.Join(dataDirPath, "plugins", runtime.GOOS+"_"+runtime.GOARCH),
 	}
 }
 
@@ -35,24 +92,31 @@ type WatchPattern struct {
 	EventType EventType
 }
 
-func PathGlobPatternsForWatching() []WatchPattern {
+// PathGlobPatternsForWatching returns patterns of files relevant
+// for indexing, relative to the workspace.
+//
+// Files within a data directory shared by all modules are not included,
+// since they are not part of the workspace.
+func PathGlobPatternsForWatching(dir Dir) []WatchPattern {
 	patterns := make([]WatchPattern, 0)
 
-	// This is necessary because clients may not send delete notifications
-	// for individual nested files when the parent directory is deleted.
-	// VS Code / vscode-languageclient behaves this way.
-	patterns = append(patterns, WatchPattern{
-		Pattern:   "**/" + DataDirName,
-		EventType: DeleteEventType,
-	})
-
-	patterns = append(patterns, WatchPattern{
-		Pattern:   "**/" + path.Join(manifestPathElements...),
-		EventType: AnyEventType,
-	})
-	for _, pElems := range pluginLockFilePathElements {
+	if !dir.IsAbs() {
+		// This is necessary because clients may not send delete notifications
+		// for individual nested files when the parent directory is deleted.
+		// VS Code / vscode-languageclient behaves this way.
+		patterns = append(patterns, WatchPattern{
+			Pattern:   "**/" + filepath.ToSlash(dir.String()),
+			EventType: DeleteEventType,
+		})
+
+		patterns = append(patterns, WatchPattern{
+			Pattern:   "**/" + path.Join(slashElements(manifestPathElements(dir))...),
+			EventType: AnyEventType,
+		})
+	}
+	for _, pElems := range pluginLockFilePathElements(dir) {
 		patterns = append(patterns, WatchPattern{
-			Pattern:   "**/" + path.Join(pElems...),
+			Pattern:   "**/" + path.Join(slashElements(pElems)...),
 			EventType: AnyEventType,
 		})
 	}
@@ -60,17 +124,20 @@ func PathGlobPatternsForWatching() []WatchPattern {
 	return patterns
 }
 
-func ModuleUriFromDataDir(rawUri string) (string, bool) {
-	suffix := "/" + DataDirName
+func ModuleUriFromDataDir(dir Dir, rawUri string) (string, bool) {
+	if dir.IsAbs() {
+		return "", false
+	}
+	suffix := "/" + filepath.ToSlash(dir.String())
 	if strings.HasSuffix(rawUri, suffix) {
 		return strings.TrimSuffix(rawUri, suffix), true
 	}
 	return "", false
 }
 
-func ModuleUriFromPluginLockFile(rawUri string) (string, bool) {
-	for _, pathElems := range pluginLockFilePathElements {
-		suffix := "/" + path.Join(pathElems...)
+func ModuleUriFromPluginLockFile(dir Dir, rawUri string) (string, bool) {
+	for _, pathElems := range pluginLockFilePathElements(dir) {
+		suffix := "/" + path.Join(slashElements(pathElems)...)
 		if strings.HasSuffix(rawUri, suffix) {
 			return strings.TrimSuffix(rawUri, suffix), true
 		}
@@ -78,10 +145,23 @@ func ModuleUriFromPluginLockFile(rawUri string) (string, bool) {
 	return "", false
 }
 
-func ModuleUriFromModuleLockFile(rawUri string) (string, bool) {
-	suffix := "/" + path.Join(manifestPathElements...)
+func ModuleUriFromModuleLockFile(dir Dir, rawUri string) (string, bool) {
+	if dir.IsAbs() {
+		return "", false
+	}
+	suffix := "/" + path.Join(slashElements(manifestPathElements(dir))...)
 	if strings.HasSuffix(rawUri, suffix) {
 		return strings.TrimSuffix(rawUri, suffix), true
 	}
 	return "", false
 }
+
+// slashElements converts path elements, such as the data directory name,
+// to use slashes, so they can be matched against URIs
+func slashElements(elems []string) []string {
+	slashed := make([]string, len(elems))
+	for i, elem := range elems {
+		slashed[i] = filepath.ToSlash(elem)
+	}
+	return slashed
+}
@@ -18,18 +18,43 @@ import (
 	"github.com/zclconf/go-cty/cty"
 )
 
-var pluginLockFilePathElements = [][]string{
-	// Terraform >= 0.14
-	{".terraform.lock.hcl"},
-	// Terraform >= v0.13
-	{DataDirName, "plugins", "selections.json"},
-	// Terraform >= v0.12
-	{DataDirName, "plugins", runtime.GOOS + "_" + runtime.GOARCH, "lock.json"},
+// LockFileName represents the name of the dependency lock file
+// created by Terraform >= 0.14 within the root module.
+const LockFileName = ".terraform.lock.hcl"
+
+// pluginLockFilePathElements returns path elements of lock files
+// relative to the module. Lock files within a data directory
+// shared by all modules are not included.
+func pluginLockFilePathElements(dir Dir) [][]string {
+	if dir.IsAbs() {
+		return [][]string{
+			{LockFileName},
+		}
+	}
+	return [][]string{
+		// Terraform >= 0.14
+		{LockFileName},
+		// Terraform >= v0.13
+		{dir.String(), "plugins", "selections.json"},
+		// Terraform >= v0.12
+		{dir.String(), "plugins", runtime.GOOS + "_" + runtime.GOARCH, "lock.json"},
+	}
+}
+
+func pluginLockFilePaths(dir Dir, modPath string) []string {
+	dataDirPath := dir.Path(modPath)
+	return []string{
+		// Terraform >= 0.14
+		filepath.Join(modPath, LockFileName),
+		// Terraform >= v0.13
+		filepath.Join(dataDirPath, "plugins", "selections.json"),
+		// Terraform >= v0.12
+		filepath.Join(dataDirPath, "plugins", runtime.GOOS+"_"+runtime.GOARCH, "lock.json"),
+	}
 }
 
-func PluginLockFilePath(fs fs.StatFS, modPath string) (string, bool) {
-	for _, pathElems := range pluginLockFilePathElements {
-		fullPath := filepath.Join(append([]string{modPath}, pathElems...)...)
+func PluginLockFilePath(fs fs.StatFS, dir Dir, modPath string) (string, bool) {
+	for _, fullPath := range pluginLockFilePaths(dir, modPath) {
 		fi, err := fs.Stat(fullPath)
 		if err == nil && fi.Mode().IsRegular() {
 			return fullPath, true
@@ -46,7 +71,7 @@ type FS interface {
 	ReadDir(name string) ([]fs.DirEntry, error)
 }
 
-func ParsePluginVersions(filesystem FS, modPath string) (PluginVersionMap, error) {
+func ParsePluginVersions(filesystem FS, dir Dir, modPath string) (PluginVersionMap, error) {
 	pvm, err := parsePluginLockFile_v014(filesystem, modPath)
 	if err != nil {
 		if !errors.Is(err, fs.ErrNotExist) {
@@ -56,7 +81,7 @@ func ParsePluginVersions(filesystem FS, modPath string) (PluginVersionMap, error
 		return pvm, nil
 	}
 
-	pvm, err = parsePluginLockFile_v013(filesystem, modPath)
+	pvm, err = parsePluginLockFile_v013(filesystem, dir, modPath)
 	if err != nil {
 		if !errors.Is(err, fs.ErrNotExist) {
 			return nil, err
@@ -65,15 +90,15 @@ func ParsePluginVersions(filesystem FS, modPath string) (PluginVersionMap, error
 		return pvm, nil
 	}
 
-	return parsePluginDir_v012(filesystem, modPath)
+	return parsePluginDir_v012(filesystem, dir, modPath)
 }
 
 // parsePluginDir_v012 parses the 0.12-style datadir.
 // See https://github.com/hashicorp/terraform/blob/v0.12.0/plugin/discovery/find.go#L45
-func parsePluginDir_v012(filesystem FS, modPath string) (PluginVersionMap, error) {
+func parsePluginDir_v012(filesystem FS, dir Dir, modPath string) (PluginVersionMap, error) {
 	// Unfortunately the lock.json from 0.12 only contains hashes, not versions
 	// so we have to imply the versions from filenames (which is what Terraform 0.12 does too)
-	dirPath := filepath.Join(modPath, DataDirName, "plugins", runtime.GOOS+"_"+runtime.GOARCH)
+	dirPath := filepath.Join(dir.Path(modPath), "plugins", runtime.GOOS+"_"+runtime.GOARCH)
 	entries, err := filesystem.ReadDir(dirPath)
 	if err != nil {
 		return nil, err
@@ -114,8 +139,8 @@ func legacyProviderAddr(name string) tfaddr.Provider {
 	}
 }
 
-func parsePluginLockFile_v013(filesystem FS, modPath string) (PluginVersionMap, error) {
-	fullPath := filepath.Join(modPath, DataDirName, "plugins", "selections.json")
+func parsePluginLockFile_v013(filesystem FS, dir Dir, modPath string) (PluginVersionMap, error) {
+	fullPath := filepath.Join(dir.Path(modPath), "plugins", "selections.json")
 
 	src, err := filesystem.ReadFile(fullPath)
 	if err != nil {
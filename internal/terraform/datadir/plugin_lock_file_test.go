@@ -30,7 +30,7 @@ func TestParsePluginVersions_basic012(t *testing.T) {
 		legacyProviderAddr("aws"):    version.Must(version.NewVersion("4.23.0")),
 		legacyProviderAddr("google"): version.Must(version.NewVersion("4.29.0")),
 	}
-	versions, err := ParsePluginVersions(fs, "foo-module")
+	versions, err := ParsePluginVersions(fs, Dir{}, "foo-module")
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -62,7 +62,7 @@ func TestParsePluginVersions_basic013(t *testing.T) {
 		tfaddr.MustParseProviderSource("hashicorp/aws"):    version.Must(version.NewVersion("4.23.0")),
 		tfaddr.MustParseProviderSource("hashicorp/google"): version.Must(version.NewVersion("4.29.0")),
 	}
-	versions, err := ParsePluginVersions(fs, "foo-module")
+	versions, err := ParsePluginVersions(fs, Dir{}, "foo-module")
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -123,7 +123,7 @@ provider "registry.terraform.io/hashicorp/google" {
 		tfaddr.MustParseProviderSource("hashicorp/aws"):    version.Must(version.NewVersion("4.23.0")),
 		tfaddr.MustParseProviderSource("hashicorp/google"): version.Must(version.NewVersion("4.29.0")),
 	}
-	versions, err := ParsePluginVersions(fs, "foo-module")
+	versions, err := ParsePluginVersions(fs, Dir{}, "foo-module")
 	if err != nil {
 		t.Fatal(err)
 	}
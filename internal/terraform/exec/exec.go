@@ -7,14 +7,18 @@ import (
 	"bytes"
 	"context"
 	"errors"
+	"io"
 	"log"
+	"os"
 	"os/exec"
+	"strings"
 	"time"
 
 	"github.com/hashicorp/go-version"
 	"github.com/hashicorp/terraform-exec/tfexec"
 	tfjson "github.com/hashicorp/terraform-json"
 	"github.com/hashicorp/terraform-ls/internal/logging"
+	"github.com/hashicorp/terraform-ls/internal/terraform/datadir"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/codes"
@@ -31,6 +35,11 @@ type Executor struct {
 	tf         *tfexec.Terraform
 	timeout    time.Duration
 	rawLogPath string
+	logger     *log.Logger
+
+	// env represents environment variables set via [Executor.SetDataDir],
+	// which also apply to commands executed without tfexec
+	env map[string]string
 }
 
 func NewExecutor(workDir, execPath string) (TerraformExecutor, error) {
@@ -41,11 +50,14 @@ func NewExecutor(workDir, execPath string) (TerraformExecutor, error) {
 	return &Executor{
 		timeout: defaultExecTimeout,
 		tf:      tf,
+		logger:  log.New(io.Discard, "", 0),
 	}, nil
 }
 
+// SetLogger sets the logger for output (such as warnings)
+// which terraform writes to stderr on successful executions.
 func (e *Executor) SetLogger(logger *log.Logger) {
-	e.tf.SetLogger(logger)
+	e.logger = logger
 }
 
 func (e *Executor) SetExecLogPath(rawPath string) error {
@@ -57,6 +69,16 @@ func (e *Executor) SetTimeout(duration time.Duration) {
 	e.timeout = duration
 }
 
+// SetDataDir makes Terraform use the given data directory
+// (via TF_DATA_DIR), which is relative to the working directory
+// unless absolute. Any other variables are inherited from the server.
+func (e *Executor) SetDataDir(path string) error {
+	env := environ()
+	env[datadir.DataDirEnvVar] = path
+	e.env = env
+	return e.tf.SetEnv(env)
+}
+
 func (e *Executor) GetExecPath() string {
 	return e.tf.ExecPath()
 }
@@ -99,6 +121,24 @@ func (e *Executor) enrichCtxErr(method string, err error) error {
 	return err
 }
 
+// captureStderr makes the underlying terraform command write its stderr
+// into a buffer, so that any output (such as warnings) of successful
+// executions can be logged rather than treated as a failure.
+// Only a non-zero exit code is considered a failure.
+func (e *Executor) captureStderr() *bytes.Buffer {
+	buf := bytes.NewBuffer([]byte{})
+	e.tf.SetStderr(buf)
+	return buf
+}
+
+func (e *Executor) logStderr(method string, stderr *bytes.Buffer) {
+	out := strings.TrimSpace(stderr.String())
+	if out == "" {
+		return
+	}
+	e.logger.Printf("terraform %q wrote to stderr: %s", method, out)
+}
+
 func (e *Executor) setLogPath(method string) error {
 	logPath, err := logging.ParseExecLogPath(method, e.rawLogPath)
 	if err != nil {
@@ -117,7 +157,9 @@ func (e *Executor) Init(ctx context.Context, opts ...tfexec.InitOption) error {
 	ctx, span := otel.Tracer(tracerName).Start(ctx, "terraform-exec:Init")
 	defer span.End()
 
+	stderr := e.captureStderr()
 	err = e.tf.Init(ctx, opts...)
+	e.logStderr("Init", stderr)
 	e.setSpanStatus(span, err)
 
 	return e.contextfulError(ctx, "Init", err)
@@ -133,7 +175,9 @@ func (e *Executor) Get(ctx context.Context, opts ...tfexec.GetCmdOption) error {
 	ctx, span := otel.Tracer(tracerName).Start(ctx, "terraform-exec:Get")
 	defer span.End()
 
+	stderr := e.captureStderr()
 	err = e.tf.Get(ctx, opts...)
+	e.logStderr("Get", stderr)
 	e.setSpanStatus(span, err)
 
 	return e.contextfulError(ctx, "Get", err)
@@ -157,7 +201,9 @@ func (e *Executor) Format(ctx context.Context, input []byte) ([]byte, error) {
 	br := bytes.NewReader(input)
 	buf := bytes.NewBuffer([]byte{})
 
+	stderr := e.captureStderr()
 	err = e.tf.Format(ctx, br, buf)
+	e.logStderr("Format", stderr)
 	e.setSpanStatus(span, err)
 
 	return buf.Bytes(), e.contextfulError(ctx, "Format", err)
@@ -174,7 +220,9 @@ func (e *Executor) Validate(ctx context.Context) ([]tfjson.Diagnostic, error) {
 	ctx, span := otel.Tracer(tracerName).Start(ctx, "terraform-exec:Validate")
 	defer span.End()
 
+	stderr := e.captureStderr()
 	validation, err := e.tf.Validate(ctx)
+	e.logStderr("Validate", stderr)
 	e.setSpanStatus(span, err)
 	if err != nil {
 		return []tfjson.Diagnostic{}, e.contextfulError(ctx, "Validate", err)
@@ -194,7 +242,9 @@ func (e *Executor) Version(ctx context.Context) (*version.Version, map[string]*v
 	ctx, span := otel.Tracer(tracerName).Start(ctx, "terraform-exec:Version")
 	defer span.End()
 
+	stderr := e.captureStderr()
 	ver, pv, err := e.tf.Version(ctx, true)
+	e.logStderr("Version", stderr)
 	e.setSpanStatus(span, err)
 
 	return ver, pv, e.contextfulError(ctx, "Version", err)
@@ -203,7 +253,7 @@ func (e *Executor) Version(ctx context.Context) (*version.Version, map[string]*v
 func (e *Executor) ProviderSchemas(ctx context.Context) (*tfjson.ProviderSchemas, error) {
 	ctx, cancel := e.withTimeout(ctx)
 	defer cancel()
-	err := e.setLogPath("ProviderSchemas")
+	logPath, err := logging.ParseExecLogPath("ProviderSchemas", e.rawLogPath)
 	if err != nil {
 		return nil, err
 	}
@@ -211,8 +261,73 @@ func (e *Executor) ProviderSchemas(ctx context.Context) (*tfjson.ProviderSchemas
 	ctx, span := otel.Tracer(tracerName).Start(ctx, "terraform-exec:ProviderSchemas")
 	defer span.End()
 
-	ps, err := e.tf.ProvidersSchema(ctx)
+	// The command is executed directly rather than via tfexec,
+	// so that the size of the output is known before it is decoded.
+	stdout := bytes.NewBuffer([]byte{})
+	stderr := bytes.NewBuffer([]byte{})
+	cmd := exec.CommandContext(ctx, e.tf.ExecPath(), "providers", "schema", "-json", "-no-color")
+	cmd.Dir = e.tf.WorkingDir()
+	cmd.Env = e.commandEnv(logPath)
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+	// avoid waiting for any child processes holding the output open
+	// once the command is killed (e.g. due to timeout)
+	cmd.WaitDelay = commandWaitDelay
+
+	err = cmd.Run()
+	e.logStderr("ProviderSchemas", stderr)
+	if err != nil {
+		e.setSpanStatus(span, err)
+		return nil, e.contextfulError(ctx, "ProviderSchemas", err)
+	}
+
+	filter, _ := ProviderSchemasFilterFromContext(ctx)
+	ps, err := decodeProviderSchemas(stdout.Bytes(), filter)
 	e.setSpanStatus(span, err)
 
-	return ps, e.contextfulError(ctx, "ProviderSchemas", err)
+	return ps, err
+}
+
+var commandWaitDelay = 1 * time.Second
+
+// commandEnv returns environment variables for commands
+// executed without tfexec, consistent with those set by tfexec
+func (e *Executor) commandEnv(logPath string) []string {
+	env := e.env
+	if env == nil {
+		env = environ()
+	}
+
+	vars := make([]string, 0, len(env)+3)
+	for key, value := range env {
+		switch key {
+		case "TF_LOG", "TF_LOG_CORE", "TF_LOG_PATH", "TF_LOG_PROVIDER", "TF_IN_AUTOMATION", "TF_WORKSPACE":
+			// avoid logging polluting the output
+			// and other settings affecting it
+			continue
+		}
+		vars = append(vars, key+"="+value)
+	}
+
+	vars = append(vars, "TF_IN_AUTOMATION=1")
+	if logPath != "" {
+		vars = append(vars, "TF_LOG=TRACE", "TF_LOG_PATH="+logPath)
+	}
+
+	return vars
+}
+
+// environ returns environment variables of the current process
+func environ() map[string]string {
+	env := make(map[string]string, 0)
+	for _, rawKeyPair := range os.Environ() {
+		key, value, _ := strings.Cut(rawKeyPair, "=")
+		if key == "" {
+			// Windows may list variables such as "=C:=C:\path"
+			// which cannot be passed on
+			continue
+		}
+		env[key] = value
+	}
+	return env
 }
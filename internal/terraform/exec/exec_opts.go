@@ -5,6 +5,7 @@ package exec
 
 import (
 	"context"
+	"log"
 	"time"
 )
 
@@ -12,6 +13,14 @@ type ExecutorOpts struct {
 	ExecPath    string
 	ExecLogPath string
 	Timeout     time.Duration
+
+	// DataDir is passed to Terraform as TF_DATA_DIR, if set,
+	// so that it uses the same data directory as the server.
+	DataDir string
+
+	// Logger receives any output which Terraform
+	// writes to stderr, such as warnings.
+	Logger *log.Logger
 }
 
 var ctxExecOpts = ctxKey("executor opts")
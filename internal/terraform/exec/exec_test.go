@@ -4,10 +4,14 @@
 package exec
 
 import (
+	"bytes"
 	"context"
 	"errors"
+	"log"
 	"os"
 	"path/filepath"
+	"runtime"
+	"strings"
 	"testing"
 	"time"
 
@@ -63,6 +67,114 @@ func TestExec_cancel(t *testing.T) {
 	t.Fatalf("expected cancel error: %#v, given: %#v", expectedErr, err)
 }
 
+func TestExec_stderrOnSuccess(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("fake terraform binary is a shell script")
+	}
+
+	workDir := TempDir(t)
+	execPath := filepath.Join(workDir, "terraform")
+	fakeTerraform := `#!/bin/sh
+echo "Warning: this is only a warning" >&2
+case "$1" in
+  version)
+    echo '{"terraform_version":"1.5.0","platform":"linux_amd64","provider_selections":{},"terraform_outdated":false}'
+    ;;
+  providers)
+    echo '{"format_version":"1.0","provider_schemas":{}}'
+    ;;
+esac
+exit 0
+`
+	err := os.WriteFile(execPath, []byte(fakeTerraform), 0o755)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	e, err := NewExecutor(workDir, execPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var logBuf bytes.Buffer
+	e.SetLogger(log.New(&logBuf, "", 0))
+
+	ctx := context.Background()
+	v, _, err := e.Version(ctx)
+	if err != nil {
+		t.Fatalf("expected warning on stderr not to fail version: %s", err)
+	}
+	if v.String() != "1.5.0" {
+		t.Fatalf("expected version 1.5.0, given %s", v)
+	}
+
+	_, err = e.ProviderSchemas(ctx)
+	if err != nil {
+		t.Fatalf("expected warning on stderr not to fail schema retrieval: %s", err)
+	}
+
+	if !strings.Contains(logBuf.String(), "Warning: this is only a warning") {
+		t.Fatalf("expected stderr output to be logged, given log:\n%s", logBuf.String())
+	}
+	if strings.Contains(logBuf.String(), "running Terraform command") {
+		t.Fatalf("expected terraform-exec not to log executed commands, given log:\n%s", logBuf.String())
+	}
+}
+
+func TestExec_providerSchemasFilter(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("fake terraform binary is a shell script")
+	}
+
+	workDir := TempDir(t)
+	execPath := filepath.Join(workDir, "terraform")
+	fakeTerraform := `#!/bin/sh
+echo '{"format_version":"1.0","provider_schemas":{` +
+		`"registry.terraform.io/hashicorp/aws":{"resource_schemas":{"aws_instance":{"version":0,"block":{}}}},` +
+		`"registry.terraform.io/hashicorp/google":{"resource_schemas":{"google_compute_instance":{"version":0,"block":{}}}}}}'
+exit 0
+`
+	err := os.WriteFile(execPath, []byte(fakeTerraform), 0o755)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	e, err := NewExecutor(workDir, execPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	isRequired := func(rawAddr string) bool {
+		return rawAddr == "registry.terraform.io/hashicorp/aws"
+	}
+
+	ctx := WithProviderSchemasFilter(context.Background(), &ProviderSchemasFilter{
+		MaxSize:    1024,
+		IsRequired: isRequired,
+	})
+	ps, err := e.ProviderSchemas(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ps.Schemas) != 2 {
+		t.Fatalf("expected all schemas below max size, given: %#v", ps.Schemas)
+	}
+
+	ctx = WithProviderSchemasFilter(context.Background(), &ProviderSchemasFilter{
+		MaxSize:    10,
+		IsRequired: isRequired,
+	})
+	ps, err = e.ProviderSchemas(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ps.Schemas) != 1 {
+		t.Fatalf("expected only required schema above max size, given: %#v", ps.Schemas)
+	}
+	if _, ok := ps.Schemas["registry.terraform.io/hashicorp/aws"].ResourceSchemas["aws_instance"]; !ok {
+		t.Fatalf("expected aws_instance schema, given: %#v", ps.Schemas)
+	}
+}
+
 func newExecutor(t *testing.T) TerraformExecutor {
 	ctx := context.Background()
 	workDir := TempDir(t)
@@ -125,6 +125,20 @@ func (_m *Executor) ProviderSchemas(ctx context.Context) (*tfjson.ProviderSchema
 	return r0, r1
 }
 
+// SetDataDir provides a mock function with given fields: path
+func (_m *Executor) SetDataDir(path string) error {
+	ret := _m.Called(path)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(path)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // SetExecLogPath provides a mock function with given fields: path
 func (_m *Executor) SetExecLogPath(path string) error {
 	ret := _m.Called(path)
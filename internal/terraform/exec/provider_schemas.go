@@ -0,0 +1,83 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package exec
+
+import (
+	"context"
+	"encoding/json"
+
+	tfjson "github.com/hashicorp/terraform-json"
+)
+
+// ProviderSchemasFilter limits which provider schemas are decoded
+// by [TerraformExecutor.ProviderSchemas] when the output of Terraform
+// is too large, as decoding schemas of all providers (such as big cloud
+// providers pulled in by unrelated modules) can cause memory spikes.
+type ProviderSchemasFilter struct {
+	// MaxSize represents the size of the raw output (in bytes)
+	// above which only schemas of required providers are decoded.
+	MaxSize int
+
+	// IsRequired reports whether the schema of the provider
+	// with the given (raw) address is required.
+	IsRequired func(rawAddr string) bool
+}
+
+var ctxProviderSchemasFilter = ctxKey("provider schemas filter")
+
+func ProviderSchemasFilterFromContext(ctx context.Context) (*ProviderSchemasFilter, bool) {
+	filter, ok := ctx.Value(ctxProviderSchemasFilter).(*ProviderSchemasFilter)
+	return filter, ok
+}
+
+func WithProviderSchemasFilter(ctx context.Context, filter *ProviderSchemasFilter) context.Context {
+	return context.WithValue(ctx, ctxProviderSchemasFilter, filter)
+}
+
+// decodeProviderSchemas decodes the output of
+// terraform providers schema -json, which is checked
+// against the given filter (if any) before decoding.
+func decodeProviderSchemas(b []byte, filter *ProviderSchemasFilter) (*tfjson.ProviderSchemas, error) {
+	if filter == nil || len(b) <= filter.MaxSize {
+		var ps tfjson.ProviderSchemas
+		err := json.Unmarshal(b, &ps)
+		if err != nil {
+			return nil, err
+		}
+		return &ps, nil
+	}
+
+	var rawSchemas struct {
+		FormatVersion string                     `json:"format_version"`
+		Schemas       map[string]json.RawMessage `json:"provider_schemas"`
+	}
+	err := json.Unmarshal(b, &rawSchemas)
+	if err != nil {
+		return nil, err
+	}
+
+	ps := &tfjson.ProviderSchemas{
+		FormatVersion: rawSchemas.FormatVersion,
+		Schemas:       make(map[string]*tfjson.ProviderSchema, 0),
+	}
+	err = ps.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	for rawAddr, rawSchema := range rawSchemas.Schemas {
+		if !filter.IsRequired(rawAddr) {
+			continue
+		}
+
+		var pSchema tfjson.ProviderSchema
+		err := json.Unmarshal(rawSchema, &pSchema)
+		if err != nil {
+			return nil, err
+		}
+		ps.Schemas[rawAddr] = &pSchema
+	}
+
+	return ps, nil
+}
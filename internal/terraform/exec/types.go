@@ -25,6 +25,7 @@ type TerraformExecutor interface {
 	SetLogger(logger *log.Logger)
 	SetExecLogPath(path string) error
 	SetTimeout(duration time.Duration)
+	SetDataDir(path string) error
 	GetExecPath() string
 	Init(ctx context.Context, opts ...tfexec.InitOption) error
 	Get(ctx context.Context, opts ...tfexec.GetCmdOption) error
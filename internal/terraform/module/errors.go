@@ -39,3 +39,17 @@ func IsTerraformNotFound(err error) bool {
 	_, ok := err.(NoTerraformExecPathErr)
 	return ok
 }
+
+type NoTerraformExecutorErr struct{}
+
+func (NoTerraformExecutorErr) Error() string {
+	return "no terraform executor provided"
+}
+
+func IsTerraformExecutorMissing(err error) bool {
+	if err == nil {
+		return false
+	}
+	_, ok := err.(NoTerraformExecutorErr)
+	return ok
+}
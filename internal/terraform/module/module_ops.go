@@ -19,7 +19,9 @@ import (
 	"github.com/hashicorp/go-version"
 	"github.com/hashicorp/hcl-lang/decoder"
 	"github.com/hashicorp/hcl-lang/lang"
+	"github.com/hashicorp/hcl-lang/reference"
 	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
 	tfjson "github.com/hashicorp/terraform-json"
 	lsctx "github.com/hashicorp/terraform-ls/internal/context"
 	idecoder "github.com/hashicorp/terraform-ls/internal/decoder"
@@ -33,6 +35,7 @@ import (
 	"github.com/hashicorp/terraform-ls/internal/state"
 	"github.com/hashicorp/terraform-ls/internal/terraform/ast"
 	"github.com/hashicorp/terraform-ls/internal/terraform/datadir"
+	"github.com/hashicorp/terraform-ls/internal/terraform/exec"
 	op "github.com/hashicorp/terraform-ls/internal/terraform/module/operation"
 	"github.com/hashicorp/terraform-ls/internal/terraform/parser"
 	"github.com/hashicorp/terraform-ls/internal/uri"
@@ -159,12 +162,20 @@ func ObtainSchema(ctx context.Context, modStore *state.ModuleStore, schemaStore
 		return job.StateNotChangedErr{Dir: document.DirHandleFromPath(modPath)}
 	}
 
+	// Terraform CLI can only provide schemas of installed providers
+	if !modStore.IsInitialized(modPath) {
+		return nil
+	}
+
 	pReqs, err := modStore.ProviderRequirementsForModule(modPath)
 	if err != nil {
 		return err
 	}
 
-	exist, err := schemaStore.AllSchemasExist(pReqs)
+	// Schemas are looked up across all modules, so we can avoid running
+	// the CLI if schemas of the same provider versions were obtained
+	// for another module already, such as a sibling root module.
+	exist, err := schemaStore.AllSchemasExist(installedProviderRequirements(pReqs, mod.InstalledProviders))
 	if err != nil {
 		return err
 	}
@@ -182,15 +193,41 @@ func ObtainSchema(ctx context.Context, modStore *state.ModuleStore, schemaStore
 		return err
 	}
 
+	// Only required providers are tracked since schemas of any others
+	// are only loaded as a side effect of running the CLI.
+	trackedAddrs := make([]tfaddr.Provider, 0, len(pReqs))
+	for pAddr := range pReqs {
+		if pAddr.IsLegacy() {
+			continue
+		}
+		trackedAddrs = append(trackedAddrs, pAddr)
+	}
+	err = setSchemaLoadStates(schemaStore, modPath, trackedAddrs, state.SchemaLoadStateLoading, nil)
+	if err != nil {
+		return err
+	}
+
+	// Schemas of large providers can take up a lot of memory
+	// once decoded, so we only decode the ones we need
+	// if the output of Terraform exceeds the threshold.
+	ctx = exec.WithProviderSchemasFilter(ctx, &exec.ProviderSchemasFilter{
+		MaxSize:    maxProviderSchemasSize,
+		IsRequired: isRequiredProvider(pReqs, mod.InstalledProviders),
+	})
 	ps, err := tfExec.ProviderSchemas(ctx)
 	if err != nil {
-		sErr := modStore.FinishProviderSchemaLoading(modPath, err)
+		sErr := setSchemaLoadStates(schemaStore, modPath, trackedAddrs, state.SchemaLoadStateFailed, err)
+		if sErr != nil {
+			return sErr
+		}
+		sErr = modStore.FinishProviderSchemaLoading(modPath, err)
 		if sErr != nil {
 			return sErr
 		}
 		return err
 	}
 
+	loadedAddrs := make(map[tfaddr.Provider]bool, 0)
 	for rawAddr, pJsonSchema := range ps.Schemas {
 		pAddr, err := tfaddr.ParseProviderSource(rawAddr)
 		if err != nil {
@@ -202,17 +239,90 @@ func ObtainSchema(ctx context.Context, modStore *state.ModuleStore, schemaStore
 			// TODO: check for migrations via Registry API?
 		}
 
+		// Schemas of providers whose installed version did not change
+		// since the schemas were last obtained (e.g. when only some
+		// providers were upgraded in the lock file) are kept as-is.
+		if pVer, ok := mod.InstalledProviders[pAddr]; ok && pVer != nil {
+			exists, err := schemaStore.LocalSchemaExists(modPath, pAddr, pVer)
+			if err != nil {
+				return err
+			}
+			if exists {
+				loadedAddrs[pAddr] = true
+				continue
+			}
+		}
+
 		pSchema := tfschema.ProviderSchemaFromJson(pJsonSchema, pAddr)
 
 		err = schemaStore.AddLocalSchema(modPath, pAddr, pSchema)
 		if err != nil {
 			return err
 		}
+		loadedAddrs[pAddr] = true
+	}
+
+	for _, pAddr := range trackedAddrs {
+		if loadedAddrs[pAddr] {
+			err = schemaStore.SetSchemaLoadState(modPath, pAddr, state.SchemaLoadStateLoaded, nil)
+		} else {
+			err = schemaStore.SetSchemaLoadState(modPath, pAddr, state.SchemaLoadStateFailed,
+				errors.New("schema not provided by Terraform"))
+		}
+		if err != nil {
+			return err
+		}
 	}
 
 	return nil
 }
 
+func setSchemaLoadStates(schemaStore *state.ProviderSchemaStore, modPath string, addrs []tfaddr.Provider, loadState state.SchemaLoadState, loadErr error) error {
+	for _, pAddr := range addrs {
+		err := schemaStore.SetSchemaLoadState(modPath, pAddr, loadState, loadErr)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// installedProviderRequirements narrows down the given requirements
+// to exact versions of providers installed in the module, if known.
+func installedProviderRequirements(pReqs tfmodule.ProviderRequirements, installed state.InstalledProviders) tfmodule.ProviderRequirements {
+	reqs := make(tfmodule.ProviderRequirements, len(pReqs))
+	for pAddr, pCons := range pReqs {
+		if pVer, ok := installed[pAddr]; ok && pVer != nil {
+			reqs[pAddr] = version.MustConstraints(version.NewConstraint(pVer.String()))
+			continue
+		}
+		reqs[pAddr] = pCons
+	}
+	return reqs
+}
+
+// maxProviderSchemasSize represents the maximum size (in bytes)
+// of schemas obtained via [ObtainSchema], above which only
+// schemas of providers required by the module are decoded.
+var maxProviderSchemasSize = 64 * 1024 * 1024
+
+// isRequiredProvider reports whether a provider is required
+// by the module, or by any of its installed modules, which are
+// recorded in the lock file even if not indexed yet.
+func isRequiredProvider(pReqs tfmodule.ProviderRequirements, installed state.InstalledProviders) func(rawAddr string) bool {
+	return func(rawAddr string) bool {
+		pAddr, err := tfaddr.ParseProviderSource(rawAddr)
+		if err != nil {
+			return false
+		}
+		if _, ok := pReqs[pAddr]; ok {
+			return true
+		}
+		_, ok := installed[pAddr]
+		return ok
+	}
+}
+
 // PreloadEmbeddedSchema loads provider schemas based on
 // provider requirements parsed earlier via [LoadModuleMetadata].
 // This is the cheapest way of getting provider schemas in terms
@@ -232,7 +342,10 @@ func PreloadEmbeddedSchema(ctx context.Context, logger *log.Logger, fs fs.ReadDi
 	if err != nil {
 		return err
 	}
-	defer modStore.SetPreloadEmbeddedSchemaState(modPath, op.OpStateLoaded)
+	var pErr *multierror.Error
+	defer func() {
+		modStore.FinishPreloadEmbeddedSchema(modPath, pErr.ErrorOrNil())
+	}()
 
 	pReqs, err := modStore.ProviderRequirementsForModule(modPath)
 	if err != nil {
@@ -249,17 +362,19 @@ func PreloadEmbeddedSchema(ctx context.Context, logger *log.Logger, fs fs.ReadDi
 	}
 
 	for _, pAddr := range missingReqs {
-		err := preloadSchemaForProviderAddr(ctx, pAddr, fs, schemaStore, logger)
+		err := preloadSchemaForProviderAddr(ctx, modPath, pAddr, fs, schemaStore, logger)
 		if err != nil {
-			return err
+			// A single broken schema should not prevent
+			// preloading schemas of the other providers
+			pErr = multierror.Append(pErr, fmt.Errorf("%s: %w", pAddr.ForDisplay(), err))
 		}
 	}
 
-	return nil
+	return pErr.ErrorOrNil()
 }
 
-func preloadSchemaForProviderAddr(ctx context.Context, pAddr tfaddr.Provider, fs fs.ReadDirFS,
-	schemaStore *state.ProviderSchemaStore, logger *log.Logger) error {
+func preloadSchemaForProviderAddr(ctx context.Context, modPath string, pAddr tfaddr.Provider, fs fs.ReadDirFS,
+	schemaStore *state.ProviderSchemaStore, logger *log.Logger) (pErr error) {
 
 	startTime := time.Now()
 
@@ -286,6 +401,22 @@ func preloadSchemaForProviderAddr(ctx context.Context, pAddr tfaddr.Provider, fs
 			originalAddr.ForDisplay(), pAddr.ForDisplay())
 	}
 
+	err := schemaStore.SetSchemaLoadState(modPath, pAddr, state.SchemaLoadStateLoading, nil)
+	if err != nil {
+		return err
+	}
+	schemaAvailable := true
+	defer func() {
+		loadState := state.SchemaLoadStateLoaded
+		if pErr != nil {
+			loadState = state.SchemaLoadStateFailed
+		} else if !schemaAvailable {
+			// nothing was loaded, which is not a failure either
+			loadState = state.SchemaLoadStateUnknown
+		}
+		schemaStore.SetSchemaLoadState(modPath, pAddr, loadState, pErr)
+	}()
+
 	ctx, rootSpan := otel.Tracer(tracerName).Start(ctx, "preloadProviderSchema",
 		trace.WithAttributes(attribute.KeyValue{
 			Key:   attribute.Key("ProviderAddress"),
@@ -299,6 +430,7 @@ func preloadSchemaForProviderAddr(ctx context.Context, pAddr tfaddr.Provider, fs
 		rootSpan.SetStatus(codes.Error, "schema file not found")
 		if errors.Is(err, schemas.SchemaNotAvailable{Addr: pAddr}) {
 			logger.Printf("preloaded schema not available for %s", pAddr)
+			schemaAvailable = false
 			return nil
 		}
 		return err
@@ -527,7 +659,7 @@ func ParseVariables(ctx context.Context, fs ReadOnlyFS, modStore *state.ModuleSt
 // This is useful for processing any modules which are not local
 // nor hosted in the Registry (which would be handled by
 // [GetModuleDataFromRegistry]).
-func ParseModuleManifest(ctx context.Context, fs ReadOnlyFS, modStore *state.ModuleStore, modPath string) error {
+func ParseModuleManifest(ctx context.Context, fs ReadOnlyFS, dataDir datadir.Dir, modStore *state.ModuleStore, modPath string) error {
 	mod, err := modStore.ModuleByPath(modPath)
 	if err != nil {
 		return err
@@ -543,7 +675,7 @@ func ParseModuleManifest(ctx context.Context, fs ReadOnlyFS, modStore *state.Mod
 		return err
 	}
 
-	manifestPath, ok := datadir.ModuleManifestFilePath(fs, modPath)
+	manifestPath, ok := datadir.ModuleManifestFilePath(fs, dataDir, modPath)
 	if !ok {
 		err := fmt.Errorf("%s: manifest file does not exist", modPath)
 		sErr := modStore.UpdateModManifest(modPath, nil, err)
@@ -553,7 +685,7 @@ func ParseModuleManifest(ctx context.Context, fs ReadOnlyFS, modStore *state.Mod
 		return err
 	}
 
-	mm, err := datadir.ParseModuleManifestFromFile(manifestPath)
+	mm, err := datadir.ParseModuleManifestFromFile(manifestPath, modPath)
 	if err != nil {
 		err := fmt.Errorf("failed to parse manifest: %w", err)
 		sErr := modStore.UpdateModManifest(modPath, nil, err)
@@ -574,7 +706,7 @@ func ParseModuleManifest(ctx context.Context, fs ReadOnlyFS, modStore *state.Mod
 // ParseProviderVersions is a job complimentary to [ObtainSchema]
 // in that it obtains versions of providers/schemas from Terraform
 // CLI's lock file.
-func ParseProviderVersions(ctx context.Context, fs ReadOnlyFS, modStore *state.ModuleStore, modPath string) error {
+func ParseProviderVersions(ctx context.Context, fs ReadOnlyFS, dataDir datadir.Dir, modStore *state.ModuleStore, modPath string) error {
 	mod, err := modStore.ModuleByPath(modPath)
 	if err != nil {
 		return err
@@ -590,7 +722,7 @@ func ParseProviderVersions(ctx context.Context, fs ReadOnlyFS, modStore *state.M
 		return err
 	}
 
-	pvm, err := datadir.ParsePluginVersions(fs, modPath)
+	pvm, err := datadir.ParsePluginVersions(fs, dataDir, modPath)
 
 	sErr := modStore.UpdateInstalledProviders(modPath, pvm, err)
 	if sErr != nil {
@@ -648,6 +780,65 @@ func LoadModuleMetadata(ctx context.Context, modStore *state.ModuleStore, modPat
 	return mErr
 }
 
+// ComputeModuleMetrics counts notable blocks within a module,
+// using previously parsed AST (via [ParseModuleConfiguration])
+// and metadata (via [LoadModuleMetadata]).
+//
+// The counts help to understand the size of configurations,
+// e.g. when diagnosing performance issues.
+func ComputeModuleMetrics(ctx context.Context, modStore *state.ModuleStore, modPath string) error {
+	mod, err := modStore.ModuleByPath(modPath)
+	if err != nil {
+		return err
+	}
+
+	// Avoid computation if it is already in progress or already known
+	if mod.MetricsState != op.OpStateUnknown && !job.IgnoreState(ctx) {
+		return job.StateNotChangedErr{Dir: document.DirHandleFromPath(modPath)}
+	}
+
+	err = modStore.SetMetricsState(modPath, op.OpStateLoading)
+	if err != nil {
+		return err
+	}
+
+	metrics := state.ModuleMetrics{
+		Variables:   len(mod.Meta.Variables),
+		Outputs:     len(mod.Meta.Outputs),
+		ModuleCalls: len(mod.Meta.ModuleCalls),
+		Providers:   len(mod.Meta.ProviderRequirements),
+	}
+
+	var mErr error
+	for _, f := range mod.ParsedModuleFiles {
+		content, _, diags := f.Body.PartialContent(metricsSchema)
+		if diags.HasErrors() {
+			mErr = multierror.Append(mErr, diags)
+		}
+		for _, block := range content.Blocks {
+			switch block.Type {
+			case "resource":
+				metrics.Resources++
+			case "data":
+				metrics.DataSources++
+			}
+		}
+	}
+
+	sErr := modStore.UpdateMetrics(modPath, metrics, mErr)
+	if sErr != nil {
+		return sErr
+	}
+	return mErr
+}
+
+var metricsSchema = &hcl.BodySchema{
+	Blocks: []hcl.BlockHeaderSchema{
+		{Type: "resource", LabelNames: []string{"type", "name"}},
+		{Type: "data", LabelNames: []string{"type", "name"}},
+	},
+}
+
 // DecodeReferenceTargets collects reference targets,
 // using previously parsed AST (via [ParseModuleConfiguration]),
 // core schema of appropriate version (as obtained via [GetTerraformVersion])
@@ -841,7 +1032,10 @@ func SchemaModuleValidation(ctx context.Context, modStore *state.ModuleStore, sc
 		filename := path.Base(rpcContext.URI)
 		// We only revalidate a single file that changed
 		var fileDiags hcl.Diagnostics
-		fileDiags, rErr = moduleDecoder.ValidateFile(ctx, filename)
+		// JSON files are not validated against schema
+		if !isJSONFile(mod.ParsedModuleFiles[ast.ModFilename(filename)]) {
+			fileDiags, rErr = validateFile(ctx, moduleDecoder, filename)
+		}
 
 		modDiags, ok := mod.ModuleDiagnostics[ast.SchemaValidationSource]
 		if !ok {
@@ -854,9 +1048,30 @@ func SchemaModuleValidation(ctx context.Context, modStore *state.ModuleStore, sc
 			return sErr
 		}
 	} else {
-		// We validate the whole module, e.g. on open
-		var diags lang.DiagnosticsMap
-		diags, rErr = moduleDecoder.Validate(ctx)
+		// We validate the whole module, e.g. on open.
+		// Files are validated one by one, so that a failure in one
+		// file does not discard diagnostics of the other files.
+		var errs *multierror.Error
+		diags := make(lang.DiagnosticsMap)
+		for filename, f := range mod.ParsedModuleFiles {
+			if isJSONFile(f) {
+				// JSON files are not validated against schema
+				continue
+			}
+			fileDiags, err := validateFile(ctx, moduleDecoder, filename.String())
+			if err != nil {
+				errs = multierror.Append(errs, err)
+				var noSchemaErr *decoder.NoSchemaError
+				if errors.As(err, &noSchemaErr) {
+					// the schema is shared by all files,
+					// so the others would fail the same way
+					break
+				}
+				continue
+			}
+			diags[filename.String()] = fileDiags
+		}
+		rErr = errs.ErrorOrNil()
 
 		sErr := modStore.UpdateModuleDiagnostics(modPath, ast.SchemaValidationSource, ast.ModDiagsFromMap(diags))
 		if sErr != nil {
@@ -867,6 +1082,29 @@ func SchemaModuleValidation(ctx context.Context, modStore *state.ModuleStore, sc
 	return rErr
 }
 
+// validateFile validates a single file and recovers from any panic,
+// e.g. caused by language constructs unknown to the bundled schema,
+// which would otherwise crash the whole server.
+func validateFile(ctx context.Context, pathDecoder *decoder.PathDecoder, filename string) (diags hcl.Diagnostics, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%s: validation failed: %v", filename, r)
+		}
+	}()
+
+	return pathDecoder.ValidateFile(ctx, filename)
+}
+
+// isJSONFile reports whether the given file was parsed
+// from JSON (i.e. its body is not native HCL syntax)
+func isJSONFile(f *hcl.File) bool {
+	if f == nil {
+		return false
+	}
+	_, ok := f.Body.(*hclsyntax.Body)
+	return !ok
+}
+
 // SchemaVariablesValidation does schema-based validation
 // of variable files (*.tfvars) and produces diagnostics
 // associated with any "invalid" parts of code.
@@ -910,7 +1148,13 @@ func SchemaVariablesValidation(ctx context.Context, modStore *state.ModuleStore,
 		filename := path.Base(rpcContext.URI)
 		// We only revalidate a single file that changed
 		var fileDiags hcl.Diagnostics
-		fileDiags, rErr = moduleDecoder.ValidateFile(ctx, filename)
+		if ast.VarsFilename(filename).IsJSON() {
+			if f, ok := mod.ParsedVarsFiles[ast.VarsFilename(filename)]; ok {
+				fileDiags = validations.JSONVariables(f, mod.Meta.Variables)
+			}
+		} else {
+			fileDiags, rErr = validateFile(ctx, moduleDecoder, filename)
+		}
 
 		varsDiags, ok := mod.VarsDiagnostics[ast.SchemaValidationSource]
 		if !ok {
@@ -927,6 +1171,13 @@ func SchemaVariablesValidation(ctx context.Context, modStore *state.ModuleStore,
 		var diags lang.DiagnosticsMap
 		diags, rErr = moduleDecoder.Validate(ctx)
 
+		// files in JSON syntax are skipped by the decoder
+		for filename, f := range mod.ParsedVarsFiles {
+			if filename.IsJSON() {
+				diags[filename.String()] = validations.JSONVariables(f, mod.Meta.Variables)
+			}
+		}
+
 		sErr := modStore.UpdateVarsDiagnostics(modPath, ast.SchemaValidationSource, ast.VarsDiagsFromMap(diags))
 		if sErr != nil {
 			return sErr
@@ -936,12 +1187,64 @@ func SchemaVariablesValidation(ctx context.Context, modStore *state.ModuleStore,
 	return rErr
 }
 
+// OrphanedVarsFileSummary is reported for variable files
+// in directories without any module files
+const OrphanedVarsFileSummary = "No module found for variable file"
+
+// OrphanedVarsValidation reports variable files (*.tfvars)
+// in directories which contain no module files, and therefore
+// no variable declarations to validate or complete against.
+//
+// It relies on [ParseModuleConfiguration] and [ParseVariables]
+// to supply both module and variable files.
+func OrphanedVarsValidation(ctx context.Context, modStore *state.ModuleStore, modPath string) error {
+	mod, err := modStore.ModuleByPath(modPath)
+	if err != nil {
+		return err
+	}
+
+	// Avoid validation if it is already in progress or already finished
+	if mod.VarsDiagnosticsState[ast.OrphanedVariablesSource] != op.OpStateUnknown && !job.IgnoreState(ctx) {
+		return job.StateNotChangedErr{Dir: document.DirHandleFromPath(modPath)}
+	}
+
+	err = modStore.SetVarsDiagnosticsState(modPath, ast.OrphanedVariablesSource, op.OpStateLoading)
+	if err != nil {
+		return err
+	}
+
+	diags := make(ast.VarsDiags)
+	if len(mod.ParsedModuleFiles) == 0 {
+		for filename := range mod.ParsedVarsFiles {
+			diags[filename] = hcl.Diagnostics{
+				{
+					Severity: hcl.DiagWarning,
+					Summary:  OrphanedVarsFileSummary,
+					Detail: "The directory contains no module files (*.tf) declaring variables, " +
+						"so the variable file cannot be validated and no completion is provided.",
+					Subject: &hcl.Range{
+						Filename: filename.String(),
+						Start:    hcl.InitialPos,
+						End:      hcl.InitialPos,
+					},
+					Extra: ilsp.InformationDiagnostic{},
+				},
+			}
+		}
+	}
+
+	return modStore.UpdateVarsDiagnostics(modPath, ast.OrphanedVariablesSource, diags)
+}
+
 // ReferenceValidation does validation based on (mis)matched
 // reference origins and targets, to flag up "orphaned" references.
 //
 // It relies on [DecodeReferenceTargets] and [DecodeReferenceOrigins]
 // to supply both origins and targets to compare.
-func ReferenceValidation(ctx context.Context, modStore *state.ModuleStore, schemaReader state.SchemaReader, modPath string) error {
+//
+// It also validates module calls against the called local modules,
+// such as their existence, outputs, or configuration aliases.
+func ReferenceValidation(ctx context.Context, fs ReadOnlyFS, modStore *state.ModuleStore, schemaReader state.SchemaReader, modPath string) error {
 	mod, err := modStore.ModuleByPath(modPath)
 	if err != nil {
 		return err
@@ -970,9 +1273,142 @@ func ReferenceValidation(ctx context.Context, modStore *state.ModuleStore, schem
 	}
 
 	diags := validations.UnreferencedOrigins(ctx, pathCtx)
+	diags = diags.Extend(validations.DependsOnReferences(ctx, pathCtx))
+	diags = diags.Extend(validations.SelfReferences(ctx, pathCtx))
+	diags = diags.Extend(validations.MovedBlockAddresses(ctx, pathCtx, localModuleTargets(modStore, modPath)))
+	diags = diags.Extend(validations.SensitiveModuleOutputs(ctx, pathCtx, localModuleOutputs(modStore, modPath)))
+	diags = diags.Extend(validations.ModuleInstanceReferences(ctx, pathCtx))
+	diags = diags.Extend(validations.ImportIDs(ctx, pathCtx))
+	diags = diags.Extend(validations.ResourceAttributeReferences(ctx, pathCtx))
+	diags = diags.Extend(validations.ModuleConfigurationAliases(ctx, pathCtx, localModuleConfigurationAliases(modStore, modPath)))
+	diags = diags.Extend(validations.LocalModuleSources(ctx, pathCtx, localModuleDir(fs, modPath)))
+	diags = diags.Extend(validations.ProviderLocalNameCollisions(ctx, pathCtx, mod.Meta.ProviderReferences,
+		providerTypes(schemaReader, mod)))
 	return modStore.UpdateModuleDiagnostics(modPath, ast.ReferenceValidationSource, ast.ModDiagsFromMap(diags))
 }
 
+// providerTypes looks up resource and data source types
+// declared in schemas of providers required by the given module.
+// Providers whose schema is not available are treated as unknown.
+func providerTypes(schemaReader state.SchemaReader, mod *state.Module) validations.ProviderTypeFunc {
+	return func(pAddr tfaddr.Provider, blockType, typeName string) (bool, bool) {
+		ps, err := schemaReader.ProviderSchema(mod.Path, pAddr, mod.Meta.ProviderRequirements[pAddr])
+		if err != nil || ps == nil {
+			return false, false
+		}
+
+		switch blockType {
+		case "resource":
+			_, ok := ps.Resources[typeName]
+			return ok, true
+		case "data":
+			_, ok := ps.DataSources[typeName]
+			return ok, true
+		}
+		return false, false
+	}
+}
+
+// localModuleTargets looks up reference targets of local modules
+// called (possibly transitively) from the module at the given path.
+// Modules which are not local or not decoded yet are treated as unknown.
+func localModuleTargets(modStore *state.ModuleStore, modPath string) validations.ModuleTargetsFunc {
+	return func(callNames []string) (reference.Targets, bool) {
+		mod, ok := calledLocalModule(modStore, modPath, callNames)
+		if !ok || mod.RefTargetsState != op.OpStateLoaded {
+			return nil, false
+		}
+		return mod.RefTargets, true
+	}
+}
+
+// localModuleOutputs looks up outputs declared in local modules
+// called from the module at the given path.
+// Modules which are not local or not parsed yet are treated as unknown.
+func localModuleOutputs(modStore *state.ModuleStore, modPath string) validations.ModuleOutputsFunc {
+	return func(callName string) (map[string]tfmodule.Output, bool) {
+		mod, ok := calledLocalModule(modStore, modPath, []string{callName})
+		if !ok || mod.MetaState != op.OpStateLoaded {
+			return nil, false
+		}
+		return mod.Meta.Outputs, true
+	}
+}
+
+// localModuleConfigurationAliases looks up provider configuration aliases
+// declared in local modules called from the module at the given path.
+// Modules which are not local or not parsed yet are treated as unknown.
+func localModuleConfigurationAliases(modStore *state.ModuleStore, modPath string) validations.ModuleConfigurationAliasesFunc {
+	return func(callName string) ([]tfmodule.ProviderRef, bool) {
+		mod, ok := calledLocalModule(modStore, modPath, []string{callName})
+		if !ok || mod.MetaState != op.OpStateLoaded {
+			return nil, false
+		}
+
+		aliases := make([]tfmodule.ProviderRef, 0)
+		for _, file := range mod.ParsedModuleFiles {
+			aliases = append(aliases, validations.ConfigurationAliases(file.Body)...)
+		}
+		return aliases, true
+	}
+}
+
+// localModuleDir looks up directories of local modules
+// called from the module at the given path.
+func localModuleDir(fsys ReadOnlyFS, modPath string) validations.LocalModuleDirFunc {
+	return func(source string) (bool, bool, error) {
+		dirPath := filepath.Join(modPath, filepath.FromSlash(source))
+
+		// ReadDir also lists open documents which are not saved yet
+		entries, err := fsys.ReadDir(dirPath)
+		if err != nil {
+			return false, false, err
+		}
+		for _, entry := range entries {
+			if !entry.IsDir() && ast.IsModuleFilename(entry.Name()) {
+				return true, true, nil
+			}
+		}
+
+		_, err = fsys.Stat(dirPath)
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				return false, false, nil
+			}
+			return false, false, err
+		}
+		return true, false, nil
+	}
+}
+
+// calledLocalModule returns the module called via the given chain
+// of module calls from the module at the given path, as long as
+// all of the calls refer to local modules.
+func calledLocalModule(modStore *state.ModuleStore, modPath string, callNames []string) (*state.Module, bool) {
+	path := modPath
+	for _, name := range callNames {
+		mod, err := modStore.ModuleByPath(path)
+		if err != nil {
+			return nil, false
+		}
+		mc, ok := mod.Meta.ModuleCalls[name]
+		if !ok {
+			return nil, false
+		}
+		localAddr, ok := mc.SourceAddr.(tfmodule.LocalSourceAddr)
+		if !ok {
+			return nil, false
+		}
+		path = filepath.Join(path, localAddr.String())
+	}
+
+	mod, err := modStore.ModuleByPath(path)
+	if err != nil {
+		return nil, false
+	}
+	return mod, true
+}
+
 // TerraformValidate uses Terraform CLI to run validate subcommand
 // and turn the provided (JSON) output into diagnostics associated
 // with "invalid" parts of code.
@@ -994,18 +1430,28 @@ func TerraformValidate(ctx context.Context, modStore *state.ModuleStore, modPath
 
 	tfExec, err := TerraformExecutorForModule(ctx, mod.Path)
 	if err != nil {
-		return err
+		return resetTerraformValidateState(modStore, modPath, err)
 	}
 
 	jsonDiags, err := tfExec.Validate(ctx)
 	if err != nil {
-		return err
+		// e.g. execution timed out, in which case we keep any
+		// diagnostics from the last run, but allow validation to re-run
+		return resetTerraformValidateState(modStore, modPath, err)
 	}
 	validateDiags := diagnostics.HCLDiagsFromJSON(jsonDiags)
 
 	return modStore.UpdateModuleDiagnostics(modPath, ast.TerraformValidateSource, ast.ModDiagsFromMap(validateDiags))
 }
 
+func resetTerraformValidateState(modStore *state.ModuleStore, modPath string, err error) error {
+	sErr := modStore.SetModuleDiagnosticsState(modPath, ast.TerraformValidateSource, op.OpStateUnknown)
+	if sErr != nil {
+		return sErr
+	}
+	return err
+}
+
 // GetModuleDataFromRegistry obtains data about any modules (inputs & outputs)
 // from the Registry API based on module calls which were previously parsed
 // via [LoadModuleMetadata]. The same data could be obtained via [ParseModuleManifest]
@@ -1108,7 +1554,7 @@ func GetModuleDataFromRegistry(ctx context.Context, regClient registry.Client, m
 		}
 
 		// if not, cache it
-		err = modRegStore.Cache(sourceAddr, modVersion, inputs, outputs)
+		err = modRegStore.Cache(sourceAddr, modVersion, metaData.Description, inputs, outputs)
 		if err != nil {
 			// A different job which ran in parallel for a different module block
 			// with the same source may have already cached the same module.
@@ -13,7 +13,10 @@ import (
 	"log"
 	"net/http"
 	"net/http/httptest"
+	"os"
 	"path/filepath"
+	"runtime"
+	"strings"
 	"sync"
 	"testing"
 	"testing/fstest"
@@ -22,20 +25,27 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"github.com/hashicorp/go-version"
 	"github.com/hashicorp/hcl-lang/lang"
+	"github.com/hashicorp/hcl-lang/reference"
+	"github.com/hashicorp/hcl-lang/schema"
+	"github.com/hashicorp/hcl/v2"
 	tfjson "github.com/hashicorp/terraform-json"
 	lsctx "github.com/hashicorp/terraform-ls/internal/context"
+	"github.com/hashicorp/terraform-ls/internal/decoder/validations"
 	"github.com/hashicorp/terraform-ls/internal/document"
 	"github.com/hashicorp/terraform-ls/internal/filesystem"
 	"github.com/hashicorp/terraform-ls/internal/job"
 	ilsp "github.com/hashicorp/terraform-ls/internal/lsp"
 	"github.com/hashicorp/terraform-ls/internal/registry"
+	"github.com/hashicorp/terraform-ls/internal/settings"
 	"github.com/hashicorp/terraform-ls/internal/state"
 	"github.com/hashicorp/terraform-ls/internal/terraform/ast"
+	"github.com/hashicorp/terraform-ls/internal/terraform/datadir"
 	"github.com/hashicorp/terraform-ls/internal/terraform/exec"
 	"github.com/hashicorp/terraform-ls/internal/terraform/module/operation"
 	"github.com/hashicorp/terraform-ls/internal/uri"
 	tfaddr "github.com/hashicorp/terraform-registry-address"
 	tfregistry "github.com/hashicorp/terraform-schema/registry"
+	tfschema "github.com/hashicorp/terraform-schema/schema"
 	"github.com/stretchr/testify/mock"
 	"github.com/zclconf/go-cty-debug/ctydebug"
 	"github.com/zclconf/go-cty/cty"
@@ -501,7 +511,7 @@ func TestParseProviderVersions(t *testing.T) {
 	}
 
 	ctx := context.Background()
-	err = ParseProviderVersions(ctx, fs, ss.Modules, modPath)
+	err = ParseProviderVersions(ctx, fs, datadir.Dir{}, ss.Modules, modPath)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -630,7 +640,7 @@ func TestParseProviderVersions_multipleVersions(t *testing.T) {
 	if err != nil {
 		t.Fatal(err)
 	}
-	err = ParseProviderVersions(ctx, fs, ss.Modules, modPathFirst)
+	err = ParseProviderVersions(ctx, fs, datadir.Dir{}, ss.Modules, modPathFirst)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -648,7 +658,7 @@ func TestParseProviderVersions_multipleVersions(t *testing.T) {
 	if err != nil {
 		t.Fatal(err)
 	}
-	err = ParseProviderVersions(ctx, fs, ss.Modules, modPathSecond)
+	err = ParseProviderVersions(ctx, fs, datadir.Dir{}, ss.Modules, modPathSecond)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -746,6 +756,142 @@ func TestParseProviderVersions_multipleVersions(t *testing.T) {
 	}
 }
 
+func TestObtainSchema_sharedAcrossModules(t *testing.T) {
+	lockFile := func(v string) []byte {
+		return []byte(fmt.Sprintf(`provider "registry.terraform.io/hashicorp/aws" {
+  version = %q
+  hashes = [
+    "h1:j6RGCfnoLBpzQVOKUbGyxf4EJtRvQClKplO+WdXL5O0=",
+  ]
+}
+`, v))
+	}
+	cfg := []byte(`terraform {
+	required_providers {
+		aws = {
+			source  = "hashicorp/aws"
+			version = "~> 4.0"
+		}
+	}
+}
+`)
+
+	// first and second use the same provider version,
+	// third uses a different version satisfying the same constraint
+	modPaths := []string{"first", "second", "third"}
+	lockedVersions := map[string]string{
+		"first":  "4.25.0",
+		"second": "4.25.0",
+		"third":  "4.30.0",
+	}
+
+	cfgFS := fstest.MapFS{}
+	for _, modPath := range modPaths {
+		cfgFS[modPath] = &fstest.MapFile{Mode: fs.ModeDir}
+		cfgFS[filepath.Join(modPath, ".terraform.lock.hcl")] = &fstest.MapFile{
+			Data: lockFile(lockedVersions[modPath]),
+		}
+		// These are somewhat awkward two entries
+		// to account for io/fs and our own path separator differences
+		// See https://github.com/hashicorp/terraform-ls/issues/1025
+		cfgFS[modPath+"/main.tf"] = &fstest.MapFile{
+			Data: []byte{},
+		}
+		cfgFS[filepath.Join(modPath, "main.tf")] = &fstest.MapFile{
+			Data: cfg,
+		}
+	}
+
+	ss, err := state.NewStateStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := lsctx.WithDocumentContext(context.Background(), lsctx.Document{})
+	for _, modPath := range modPaths {
+		err = ss.Modules.Add(modPath)
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = ParseModuleConfiguration(ctx, cfgFS, ss.Modules, modPath)
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = LoadModuleMetadata(ctx, ss.Modules, modPath)
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = ParseProviderVersions(ctx, cfgFS, datadir.Dir{}, ss.Modules, modPath)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// calls are shared by all executors, i.e. the CLI is expected
+	// to run once for the first and once for the third module
+	mockFactory := exec.NewMockExecutor(&exec.TerraformMockCalls{
+		AnyWorkDir: []*mock.Call{
+			{
+				Method:        "ProviderSchemas",
+				Repeatability: 2,
+				Arguments: []interface{}{
+					mock.AnythingOfType(""),
+				},
+				ReturnArguments: []interface{}{
+					&tfjson.ProviderSchemas{
+						FormatVersion: "1.0",
+						Schemas: map[string]*tfjson.ProviderSchema{
+							"registry.terraform.io/hashicorp/aws": {
+								ConfigSchema: &tfjson.Schema{
+									Block: &tfjson.SchemaBlock{
+										Attributes: map[string]*tfjson.SchemaAttribute{
+											"region": {
+												AttributeType: cty.String,
+												Optional:      true,
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+					nil,
+				},
+			},
+		},
+	})
+	execDirs := make([]string, 0)
+	ctx = exec.WithExecutorOpts(ctx, &exec.ExecutorOpts{
+		ExecPath: "mock",
+	})
+	ctx = exec.WithExecutorFactory(ctx, func(workDir string, execPath string) (exec.TerraformExecutor, error) {
+		execDirs = append(execDirs, workDir)
+		return mockFactory(workDir, execPath)
+	})
+
+	for _, modPath := range modPaths {
+		err = ObtainSchema(ctx, ss.Modules, ss.ProviderSchemas, modPath)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	expectedExecDirs := []string{"first", "third"}
+	if diff := cmp.Diff(expectedExecDirs, execDirs); diff != "" {
+		t.Fatalf("unexpected CLI executions: %s", diff)
+	}
+
+	pAddr := tfaddr.MustParseProviderSource("hashicorp/aws")
+	vc := version.MustConstraints(version.NewConstraint("4.25.0"))
+	s, err := ss.ProviderSchemas.ProviderSchema("second", pAddr, vc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := s.Provider.Attributes["region"]; !ok {
+		t.Fatalf("expected schema obtained for first module to be shared, given: %#v", s.Provider)
+	}
+}
+
 func TestPreloadEmbeddedSchema_basic(t *testing.T) {
 	ctx := context.Background()
 	dataDir := "data"
@@ -877,6 +1023,132 @@ func TestPreloadEmbeddedSchema_unknownProviderOnly(t *testing.T) {
 	}
 }
 
+func TestPreloadEmbeddedSchema_loadStates(t *testing.T) {
+	ctx := context.Background()
+	dataDir := "data"
+	mapFS := fstest.MapFS{
+		dataDir:                            &fstest.MapFile{Mode: fs.ModeDir},
+		dataDir + "/registry.terraform.io": &fstest.MapFile{Mode: fs.ModeDir},
+		dataDir + "/registry.terraform.io/hashicorp":              &fstest.MapFile{Mode: fs.ModeDir},
+		dataDir + "/registry.terraform.io/hashicorp/random":       &fstest.MapFile{Mode: fs.ModeDir},
+		dataDir + "/registry.terraform.io/hashicorp/random/1.0.0": &fstest.MapFile{Mode: fs.ModeDir},
+		dataDir + "/registry.terraform.io/hashicorp/random/1.0.0/schema.json.gz": &fstest.MapFile{
+			Data: gzipCompressBytes(t, []byte(randomSchemaJSON)),
+		},
+		dataDir + "/registry.terraform.io/hashicorp/broken":       &fstest.MapFile{Mode: fs.ModeDir},
+		dataDir + "/registry.terraform.io/hashicorp/broken/1.0.0": &fstest.MapFile{Mode: fs.ModeDir},
+		dataDir + "/registry.terraform.io/hashicorp/broken/1.0.0/schema.json.gz": &fstest.MapFile{
+			Data: gzipCompressBytes(t, []byte(`{`)),
+		},
+	}
+	schemasFS := &blockingReadDirFS{
+		ReadDirFS: mapFS,
+		blockPath: dataDir + "/registry.terraform.io/hashicorp/random",
+		blocked:   make(chan struct{}),
+		release:   make(chan struct{}),
+	}
+
+	ss, err := state.NewStateStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+	modPath := "testmod"
+
+	cfgFS := fstest.MapFS{
+		// These are somewhat awkward double entries
+		// to account for io/fs and our own path separator differences
+		// See https://github.com/hashicorp/terraform-ls/issues/1025
+		modPath + "/main.tf": &fstest.MapFile{
+			Data: []byte{},
+		},
+		filepath.Join(modPath, "main.tf"): &fstest.MapFile{
+			Data: []byte(`terraform {
+	required_providers {
+		random = {
+			source = "hashicorp/random"
+			version = "1.0.0"
+		}
+		broken = {
+			source = "hashicorp/broken"
+			version = "1.0.0"
+		}
+	}
+}
+`),
+		},
+	}
+
+	err = ss.Modules.Add(modPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx = lsctx.WithDocumentContext(ctx, lsctx.Document{})
+	err = ParseModuleConfiguration(ctx, cfgFS, ss.Modules, modPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = LoadModuleMetadata(ctx, ss.Modules, modPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	randomAddr := tfaddr.MustParseProviderSource("hashicorp/random")
+	brokenAddr := tfaddr.MustParseProviderSource("hashicorp/broken")
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- PreloadEmbeddedSchema(ctx, log.Default(), schemasFS, ss.Modules, ss.ProviderSchemas, modPath)
+	}()
+
+	<-schemasFS.blocked
+	if loadState := schemaLoadState(t, ss.ProviderSchemas, modPath, randomAddr); loadState != state.SchemaLoadStateLoading {
+		t.Fatalf("expected %s to be loading, given state: %s", randomAddr, loadState)
+	}
+	close(schemasFS.release)
+
+	err = <-errCh
+	if err == nil {
+		t.Fatal("expected error for broken schema")
+	}
+
+	if loadState := schemaLoadState(t, ss.ProviderSchemas, modPath, randomAddr); loadState != state.SchemaLoadStateLoaded {
+		t.Fatalf("expected %s to be loaded, given state: %s", randomAddr, loadState)
+	}
+	if loadState := schemaLoadState(t, ss.ProviderSchemas, modPath, brokenAddr); loadState != state.SchemaLoadStateFailed {
+		t.Fatalf("expected %s to fail loading, given state: %s", brokenAddr, loadState)
+	}
+}
+
+func schemaLoadState(t *testing.T, schemaStore *state.ProviderSchemaStore, modPath string, pAddr tfaddr.Provider) state.SchemaLoadState {
+	loads, _, err := schemaStore.SchemaLoads()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, load := range loads {
+		if load.ModulePath == modPath && load.Address.Equals(pAddr) {
+			return load.State
+		}
+	}
+	return state.SchemaLoadStateUnknown
+}
+
+// blockingReadDirFS blocks reading of the given directory
+// until release is closed, signalling that it is blocked via blocked
+type blockingReadDirFS struct {
+	fs.ReadDirFS
+	blockPath string
+	blocked   chan struct{}
+	release   chan struct{}
+}
+
+func (bfs *blockingReadDirFS) ReadDir(name string) ([]fs.DirEntry, error) {
+	if name == bfs.blockPath {
+		close(bfs.blocked)
+		<-bfs.release
+	}
+	return bfs.ReadDirFS.ReadDir(name)
+}
+
 func TestPreloadEmbeddedSchema_idempotency(t *testing.T) {
 	ctx := context.Background()
 	dataDir := "data"
@@ -1342,6 +1614,58 @@ func TestSchemaModuleValidation_FullModule(t *testing.T) {
 	}
 }
 
+func TestSchemaModuleValidation_jsonFiles(t *testing.T) {
+	ctx := context.Background()
+	ss, err := state.NewStateStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+	modPath := "testmod"
+
+	invalidCfg := []byte(`output "foo" {
+  value   = "foo"
+  unknown = "bar"
+}
+`)
+	jsonCfg := []byte(`{"output": {"bar": {"value": "bar", "unknown": "baz"}}}`)
+	cfgFS := fstest.MapFS{
+		// These are somewhat awkward double entries
+		// to account for io/fs and our own path separator differences
+		// See https://github.com/hashicorp/terraform-ls/issues/1025
+		modPath + "/main.tf":                        &fstest.MapFile{Data: invalidCfg},
+		filepath.Join(modPath, "main.tf"):           &fstest.MapFile{Data: invalidCfg},
+		modPath + "/variables.tf.json":              &fstest.MapFile{Data: jsonCfg},
+		filepath.Join(modPath, "variables.tf.json"): &fstest.MapFile{Data: jsonCfg},
+	}
+
+	err = ss.Modules.Add(modPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx = lsctx.WithDocumentContext(ctx, lsctx.Document{})
+	err = ParseModuleConfiguration(ctx, cfgFS, ss.Modules, modPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = SchemaModuleValidation(ctx, ss.Modules, ss.ProviderSchemas, modPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mod, err := ss.Modules.ModuleByPath(modPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	diags := mod.ModuleDiagnostics[ast.SchemaValidationSource]
+	if count := len(diags["main.tf"]); count != 1 {
+		t.Fatalf("expected 1 diagnostic for main.tf, %d given: %#v", count, diags["main.tf"])
+	}
+	if count := len(diags["variables.tf.json"]); count != 0 {
+		t.Fatalf("expected no diagnostics for JSON file, %d given", count)
+	}
+}
+
 func TestSchemaModuleValidation_SingleFile(t *testing.T) {
 	ctx := context.Background()
 	ss, err := state.NewStateStore()
@@ -1497,7 +1821,7 @@ func TestSchemaVarsValidation_SingleFile(t *testing.T) {
 	}
 }
 
-func TestSchemaVarsValidation_outsideOfModule(t *testing.T) {
+func TestSchemaVarsValidation_objectTypedVariable(t *testing.T) {
 	ctx := context.Background()
 	ss, err := state.NewStateStore()
 	if err != nil {
@@ -1508,7 +1832,7 @@ func TestSchemaVarsValidation_outsideOfModule(t *testing.T) {
 	if err != nil {
 		t.Fatal(err)
 	}
-	modPath := filepath.Join(testData, "standalone-tfvars")
+	modPath := filepath.Join(testData, "object-typed-variable")
 
 	err = ss.Modules.Add(modPath)
 	if err != nil {
@@ -1539,9 +1863,1547 @@ func TestSchemaVarsValidation_outsideOfModule(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	expectedCount := 0
-	diagsCount := mod.VarsDiagnostics[ast.SchemaValidationSource].Count()
-	if diagsCount != expectedCount {
-		t.Fatalf("expected %d diagnostics, %d given", expectedCount, diagsCount)
+	diags := mod.VarsDiagnostics[ast.SchemaValidationSource][ast.VarsFilename("terraform.tfvars")]
+	if len(diags) != 1 {
+		t.Fatalf("expected 1 diagnostic, %d given: %s", len(diags), diags)
+	}
+	expectedDetail := `The given value is not suitable for var.server: attribute "port": a number is required.`
+	if diags[0].Detail != expectedDetail {
+		t.Fatalf("unexpected diagnostic detail: %q", diags[0].Detail)
+	}
+	if diags[0].Subject.Start.Line != 1 || diags[0].Subject.End.Line != 4 {
+		t.Fatalf("unexpected diagnostic range: %s", diags[0].Subject)
+	}
+}
+
+func TestSchemaVarsValidation_jsonFile(t *testing.T) {
+	ctx := context.Background()
+	ss, err := state.NewStateStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testData, err := filepath.Abs("testdata")
+	if err != nil {
+		t.Fatal(err)
+	}
+	modPath := filepath.Join(testData, "json-tfvars")
+
+	err = ss.Modules.Add(modPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fs := filesystem.NewFilesystem(ss.DocumentStore)
+	ctx = lsctx.WithDocumentContext(ctx, lsctx.Document{})
+	err = ParseModuleConfiguration(ctx, fs, ss.Modules, modPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = LoadModuleMetadata(ctx, ss.Modules, modPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = ParseVariables(ctx, fs, ss.Modules, modPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = SchemaVariablesValidation(ctx, ss.Modules, ss.ProviderSchemas, modPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mod, err := ss.Modules.ModuleByPath(modPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, ok := mod.ParsedVarsFiles[ast.VarsFilename("prod.tfvars.json")]; !ok {
+		t.Fatal("expected prod.tfvars.json to be parsed")
+	}
+
+	diags := mod.VarsDiagnostics[ast.SchemaValidationSource][ast.VarsFilename("prod.tfvars.json")]
+	expectedSummaries := []string{
+		"Invalid value for input variable",
+		"Unexpected attribute",
+	}
+	summaries := make([]string, 0)
+	for _, diag := range diags {
+		summaries = append(summaries, diag.Summary)
+	}
+	if diff := cmp.Diff(expectedSummaries, summaries); diff != "" {
+		t.Fatalf("unexpected diagnostics: %s", diff)
+	}
+}
+
+func TestSchemaVarsValidation_outsideOfModule(t *testing.T) {
+	ctx := context.Background()
+	ss, err := state.NewStateStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testData, err := filepath.Abs("testdata")
+	if err != nil {
+		t.Fatal(err)
+	}
+	modPath := filepath.Join(testData, "standalone-tfvars")
+
+	err = ss.Modules.Add(modPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fs := filesystem.NewFilesystem(ss.DocumentStore)
+	ctx = lsctx.WithDocumentContext(ctx, lsctx.Document{})
+	err = ParseModuleConfiguration(ctx, fs, ss.Modules, modPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = LoadModuleMetadata(ctx, ss.Modules, modPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = ParseVariables(ctx, fs, ss.Modules, modPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = SchemaVariablesValidation(ctx, ss.Modules, ss.ProviderSchemas, modPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mod, err := ss.Modules.ModuleByPath(modPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedCount := 0
+	diagsCount := mod.VarsDiagnostics[ast.SchemaValidationSource].Count()
+	if diagsCount != expectedCount {
+		t.Fatalf("expected %d diagnostics, %d given", expectedCount, diagsCount)
+	}
+}
+
+func TestOrphanedVarsValidation(t *testing.T) {
+	testData, err := filepath.Abs("testdata")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		dirName       string
+		expectedDiags map[string]int
+	}{
+		{"standalone-tfvars", map[string]int{"terraform.tfvars": 1}},
+		{"invalid-tfvars", map[string]int{}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.dirName, func(t *testing.T) {
+			modPath := filepath.Join(testData, tc.dirName)
+
+			ss, err := state.NewStateStore()
+			if err != nil {
+				t.Fatal(err)
+			}
+			err = ss.Modules.Add(modPath)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			fs := filesystem.NewFilesystem(ss.DocumentStore)
+			ctx := lsctx.WithDocumentContext(context.Background(), lsctx.Document{})
+			err = ParseModuleConfiguration(ctx, fs, ss.Modules, modPath)
+			if err != nil {
+				t.Fatal(err)
+			}
+			err = ParseVariables(ctx, fs, ss.Modules, modPath)
+			if err != nil {
+				t.Fatal(err)
+			}
+			err = OrphanedVarsValidation(ctx, ss.Modules, modPath)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			mod, err := ss.Modules.ModuleByPath(modPath)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			expectedCount := 0
+			for _, count := range tc.expectedDiags {
+				expectedCount += count
+			}
+			varsDiags := mod.VarsDiagnostics[ast.OrphanedVariablesSource]
+			if varsDiags.Count() != expectedCount {
+				t.Fatalf("expected %d diagnostics, %d given: %#v",
+					expectedCount, varsDiags.Count(), varsDiags)
+			}
+			for filename, count := range tc.expectedDiags {
+				diags := varsDiags[ast.VarsFilename(filename)]
+				if len(diags) != count {
+					t.Fatalf("expected %d diagnostics for %s, %d given", count, filename, len(diags))
+				}
+				if diags[0].Summary != OrphanedVarsFileSummary {
+					t.Fatalf("unexpected summary: %q", diags[0].Summary)
+				}
+				if _, ok := diags[0].Extra.(ilsp.InformationDiagnostic); !ok {
+					t.Fatalf("expected informational diagnostic, given extra: %#v", diags[0].Extra)
+				}
+			}
+		})
+	}
+}
+
+func TestDecodeReferenceTargets_forEach(t *testing.T) {
+	ctx := context.Background()
+	ss, err := state.NewStateStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testData, err := filepath.Abs("testdata")
+	if err != nil {
+		t.Fatal(err)
+	}
+	modPath := filepath.Join(testData, "for-each-module")
+
+	err = ss.Modules.Add(modPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fs := filesystem.NewFilesystem(ss.DocumentStore)
+	ctx = lsctx.WithDocumentContext(ctx, lsctx.Document{})
+	err = ParseModuleConfiguration(ctx, fs, ss.Modules, modPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = DecodeReferenceTargets(ctx, ss.Modules, ss.ProviderSchemas, modPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = DecodeReferenceOrigins(ctx, ss.Modules, ss.ProviderSchemas, modPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mod, err := ss.Modules.ModuleByPath(modPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	eachValueAddr := lang.Address{
+		lang.RootStep{Name: "each"},
+		lang.AttrStep{Name: "value"},
+	}
+	for _, origin := range mod.RefOrigins {
+		localOrigin, ok := origin.(reference.LocalOrigin)
+		if !ok || !localOrigin.Address().Equals(eachValueAddr) {
+			continue
+		}
+
+		targets, ok := mod.RefTargets.Match(localOrigin)
+		if !ok {
+			t.Fatalf("expected %q to resolve", eachValueAddr)
+		}
+		if len(targets) != 1 {
+			t.Fatalf("expected exactly 1 target, %d given: %#v", len(targets), targets)
+		}
+		return
+	}
+
+	t.Fatalf("expected %q origin to be collected", eachValueAddr)
+}
+
+func TestSchemaModuleValidation_unknownBlock(t *testing.T) {
+	ctx := context.Background()
+	ss, err := state.NewStateStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testData, err := filepath.Abs("testdata")
+	if err != nil {
+		t.Fatal(err)
+	}
+	modPath := filepath.Join(testData, "unknown-block-module")
+
+	err = ss.Modules.Add(modPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fs := filesystem.NewFilesystem(ss.DocumentStore)
+	ctx = lsctx.WithDocumentContext(ctx, lsctx.Document{})
+	err = ParseModuleConfiguration(ctx, fs, ss.Modules, modPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = SchemaModuleValidation(ctx, ss.Modules, ss.ProviderSchemas, modPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mod, err := ss.Modules.ModuleByPath(modPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	diags := mod.ModuleDiagnostics[ast.SchemaValidationSource][ast.ModFilename("main.tf")]
+	summaries := make([]string, 0)
+	for _, diag := range diags {
+		summaries = append(summaries, diag.Summary)
+	}
+
+	// the unknown block is reported, but its content is not validated,
+	// while the rest of the file still gets validated
+	expectedSummaries := []string{
+		"Unexpected block",
+		"Unexpected attribute",
+	}
+	if diff := cmp.Diff(expectedSummaries, summaries); diff != "" {
+		t.Fatalf("unexpected diagnostics: %s", diff)
+	}
+}
+
+func TestSchemaModuleValidation_providerMeta(t *testing.T) {
+	testData, err := filepath.Abs("testdata")
+	if err != nil {
+		t.Fatal(err)
+	}
+	modPath := filepath.Join(testData, "provider-meta-module")
+
+	ss, err := state.NewStateStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = ss.Modules.Add(modPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fs := filesystem.NewFilesystem(ss.DocumentStore)
+	ctx := lsctx.WithDocumentContext(context.Background(), lsctx.Document{})
+	err = ParseModuleConfiguration(ctx, fs, ss.Modules, modPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = LoadModuleMetadata(ctx, ss.Modules, modPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = SchemaModuleValidation(ctx, ss.Modules, ss.ProviderSchemas, modPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mod, err := ss.Modules.ModuleByPath(modPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// provider_meta is known to the core schema, while its body
+	// is defined by the provider, so its content is not validated
+	diags := mod.ModuleDiagnostics[ast.SchemaValidationSource][ast.ModFilename("main.tf")]
+	if len(diags) > 0 {
+		t.Fatalf("expected no diagnostics, given: %#v", diags)
+	}
+}
+
+func TestSchemaModuleValidation_deprecatedAttribute(t *testing.T) {
+	testData, err := filepath.Abs("testdata")
+	if err != nil {
+		t.Fatal(err)
+	}
+	modPath := filepath.Join(testData, "deprecated-attribute-module")
+
+	ss, err := state.NewStateStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = ss.Modules.Add(modPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pAddr := tfaddr.MustParseProviderSource("acme/fake")
+	err = ss.ProviderSchemas.AddPreloadedSchema(pAddr, version.Must(version.NewVersion("1.0.0")), &tfschema.ProviderSchema{
+		Provider: &schema.BodySchema{},
+		Resources: map[string]*schema.BodySchema{
+			"fake_instance": {
+				Attributes: map[string]*schema.AttributeSchema{
+					"legacy_name": {
+						Constraint:   schema.LiteralType{Type: cty.String},
+						IsOptional:   true,
+						IsDeprecated: true,
+						Description:  lang.PlainText("Use name instead"),
+					},
+					"name": {
+						Constraint: schema.LiteralType{Type: cty.String},
+						IsOptional: true,
+					},
+				},
+			},
+		},
+		DataSources: map[string]*schema.BodySchema{},
+		Functions:   map[string]*schema.FunctionSignature{},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fs := filesystem.NewFilesystem(ss.DocumentStore)
+	ctx := lsctx.WithDocumentContext(context.Background(), lsctx.Document{})
+	err = ParseModuleConfiguration(ctx, fs, ss.Modules, modPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = LoadModuleMetadata(ctx, ss.Modules, modPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = SchemaModuleValidation(ctx, ss.Modules, ss.ProviderSchemas, modPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mod, err := ss.Modules.ModuleByPath(modPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	diags := mod.ModuleDiagnostics[ast.SchemaValidationSource][ast.ModFilename("main.tf")]
+	if len(diags) != 1 {
+		t.Fatalf("expected exactly 1 diagnostic, given: %#v", diags)
+	}
+	expectedDiag := &hcl.Diagnostic{
+		Severity: hcl.DiagWarning,
+		Summary:  `"legacy_name" is deprecated`,
+		Detail:   "Use name instead",
+		Subject: &hcl.Range{
+			Filename: "main.tf",
+			Start:    hcl.Pos{Line: 10, Column: 3, Byte: 124},
+			End:      hcl.Pos{Line: 10, Column: 22, Byte: 143},
+		},
+		Extra: ilsp.HintDiagnostic{},
+	}
+	if diff := cmp.Diff(expectedDiag, diags[0]); diff != "" {
+		t.Fatalf("unexpected diagnostic: %s", diff)
+	}
+}
+
+func TestSchemaModuleValidation_terraformBlock(t *testing.T) {
+	testData, err := filepath.Abs("testdata")
+	if err != nil {
+		t.Fatal(err)
+	}
+	modPath := filepath.Join(testData, "terraform-block-module")
+
+	ss, err := state.NewStateStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = ss.Modules.Add(modPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fs := filesystem.NewFilesystem(ss.DocumentStore)
+	ctx := lsctx.WithDocumentContext(context.Background(), lsctx.Document{})
+	err = ParseModuleConfiguration(ctx, fs, ss.Modules, modPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = LoadModuleMetadata(ctx, ss.Modules, modPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = SchemaModuleValidation(ctx, ss.Modules, ss.ProviderSchemas, modPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mod, err := ss.Modules.ModuleByPath(modPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// all known settings (including cloud and experiments)
+	// are valid, while the unknown block is reported
+	diags := mod.ModuleDiagnostics[ast.SchemaValidationSource][ast.ModFilename("main.tf")]
+	if len(diags) != 1 {
+		t.Fatalf("expected exactly 1 diagnostic, given: %#v", diags)
+	}
+	if diags[0].Summary != "Unexpected block" {
+		t.Fatalf("unexpected diagnostic: %#v", diags[0])
+	}
+	if diags[0].Subject.Start.Line != 20 || diags[0].Subject.Start.Column != 3 {
+		t.Fatalf("unexpected diagnostic range: %#v", diags[0].Subject)
+	}
+}
+
+func TestSchemaModuleValidation_partialBackend(t *testing.T) {
+	testData, err := filepath.Abs("testdata")
+	if err != nil {
+		t.Fatal(err)
+	}
+	modPath := filepath.Join(testData, "partial-backend-module")
+
+	bucketSummary := `Required attribute "bucket" not specified`
+
+	testCases := []struct {
+		name                string
+		strict              bool
+		expectBucketMissing bool
+	}{
+		{
+			name:                "tolerant by default",
+			strict:              false,
+			expectBucketMissing: false,
+		},
+		{
+			name:                "strict",
+			strict:              true,
+			expectBucketMissing: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ss, err := state.NewStateStore()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			err = ss.Modules.Add(modPath)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			fs := filesystem.NewFilesystem(ss.DocumentStore)
+			ctx := lsctx.WithDocumentContext(context.Background(), lsctx.Document{})
+			ctx = lsctx.WithValidationOptions(ctx, &settings.ValidationOptions{
+				StrictBackendValidation: tc.strict,
+			})
+			err = ParseModuleConfiguration(ctx, fs, ss.Modules, modPath)
+			if err != nil {
+				t.Fatal(err)
+			}
+			err = SchemaModuleValidation(ctx, ss.Modules, ss.ProviderSchemas, modPath)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			mod, err := ss.Modules.ModuleByPath(modPath)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			bucketMissing := false
+			for _, diag := range mod.ModuleDiagnostics[ast.SchemaValidationSource][ast.ModFilename("main.tf")] {
+				if diag.Summary == bucketSummary {
+					bucketMissing = true
+				}
+			}
+			if bucketMissing != tc.expectBucketMissing {
+				t.Fatalf("expected missing bucket reported: %t, given: %t", tc.expectBucketMissing, bucketMissing)
+			}
+		})
+	}
+}
+
+func TestSchemaModuleValidation_outputWithoutValue(t *testing.T) {
+	modPath := t.TempDir()
+	cfg := `output "missing" {
+  description = "No value"
+}
+
+output "present" {
+  value = "foo"
+}
+`
+	err := os.WriteFile(filepath.Join(modPath, "main.tf"), []byte(cfg), 0o755)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ss, err := state.NewStateStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = ss.Modules.Add(modPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fs := filesystem.NewFilesystem(ss.DocumentStore)
+	ctx := lsctx.WithDocumentContext(context.Background(), lsctx.Document{})
+	err = ParseModuleConfiguration(ctx, fs, ss.Modules, modPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = SchemaModuleValidation(ctx, ss.Modules, ss.ProviderSchemas, modPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mod, err := ss.Modules.ModuleByPath(modPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	diags := mod.ModuleDiagnostics[ast.SchemaValidationSource][ast.ModFilename("main.tf")]
+	if len(diags) != 1 {
+		t.Fatalf("expected 1 diagnostic, %d given: %s", len(diags), diags)
+	}
+	expectedSummary := `Required attribute "value" not specified`
+	if diags[0].Summary != expectedSummary {
+		t.Fatalf("unexpected summary: %q, expected: %q", diags[0].Summary, expectedSummary)
+	}
+	if diags[0].Subject.Start.Line != 1 || diags[0].Subject.End.Line != 3 {
+		t.Fatalf("expected diagnostic on the output block body, given: %#v", diags[0].Subject)
+	}
+}
+
+func TestReferenceValidation_lifecycleConditions(t *testing.T) {
+	testData, err := filepath.Abs("testdata")
+	if err != nil {
+		t.Fatal(err)
+	}
+	modPath := filepath.Join(testData, "lifecycle-conditions-module")
+
+	ss, err := state.NewStateStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = ss.Modules.Add(modPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fs := filesystem.NewFilesystem(ss.DocumentStore)
+	ctx := lsctx.WithDocumentContext(context.Background(), lsctx.Document{})
+	err = ParseModuleConfiguration(ctx, fs, ss.Modules, modPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = LoadModuleMetadata(ctx, ss.Modules, modPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = DecodeReferenceTargets(ctx, ss.Modules, ss.ProviderSchemas, modPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = DecodeReferenceOrigins(ctx, ss.Modules, ss.ProviderSchemas, modPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = ReferenceValidation(ctx, fs, ss.Modules, ss.ProviderSchemas, modPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mod, err := ss.Modules.ModuleByPath(modPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// var.ami is referenced in the first precondition and the postcondition
+	varAmiOrigins := 0
+	for _, origin := range mod.RefOrigins {
+		localOrigin, ok := origin.(reference.LocalOrigin)
+		if ok && localOrigin.Address().String() == "var.ami" {
+			varAmiOrigins++
+		}
+	}
+	if varAmiOrigins != 2 {
+		t.Fatalf("expected 2 origins of var.ami, %d found", varAmiOrigins)
+	}
+
+	diags := mod.ModuleDiagnostics[ast.ReferenceValidationSource][ast.ModFilename("main.tf")]
+	if len(diags) != 1 {
+		t.Fatalf("expected 1 diagnostic, %d given: %#v", len(diags), diags)
+	}
+	expectedSummary := `No declaration found for "var.undeclared"`
+	if diags[0].Summary != expectedSummary {
+		t.Fatalf("expected summary %q, given %q", expectedSummary, diags[0].Summary)
+	}
+	if diags[0].Subject.Start.Line != 16 {
+		t.Fatalf("expected diagnostic in the second precondition (line 16), given line %d",
+			diags[0].Subject.Start.Line)
+	}
+}
+
+func TestReferenceValidation_terraformWorkspace(t *testing.T) {
+	testData, err := filepath.Abs("testdata")
+	if err != nil {
+		t.Fatal(err)
+	}
+	modPath := filepath.Join(testData, "terraform-workspace-module")
+
+	ss, err := state.NewStateStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = ss.Modules.Add(modPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fs := filesystem.NewFilesystem(ss.DocumentStore)
+	ctx := lsctx.WithDocumentContext(context.Background(), lsctx.Document{})
+	err = ParseModuleConfiguration(ctx, fs, ss.Modules, modPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = LoadModuleMetadata(ctx, ss.Modules, modPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = DecodeReferenceTargets(ctx, ss.Modules, ss.ProviderSchemas, modPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = DecodeReferenceOrigins(ctx, ss.Modules, ss.ProviderSchemas, modPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = ReferenceValidation(ctx, fs, ss.Modules, ss.ProviderSchemas, modPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mod, err := ss.Modules.ModuleByPath(modPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resolved := false
+	for _, origin := range mod.RefOrigins {
+		localOrigin, ok := origin.(reference.LocalOrigin)
+		if !ok || localOrigin.Address().String() != "terraform.workspace" {
+			continue
+		}
+		if _, ok := mod.RefTargets.Match(localOrigin); ok {
+			resolved = true
+		}
+	}
+	if !resolved {
+		t.Fatal("expected terraform.workspace to resolve to the built-in target")
+	}
+
+	// terraform.applying is not known to the schema but must not be reported
+	diags := mod.ModuleDiagnostics[ast.ReferenceValidationSource][ast.ModFilename("main.tf")]
+	if len(diags) != 0 {
+		t.Fatalf("expected no diagnostics, %d given: %#v", len(diags), diags)
+	}
+}
+
+func TestReferenceValidation_pathReferences(t *testing.T) {
+	testData, err := filepath.Abs("testdata")
+	if err != nil {
+		t.Fatal(err)
+	}
+	modPath := filepath.Join(testData, "path-references-module")
+
+	ss, err := state.NewStateStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = ss.Modules.Add(modPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fs := filesystem.NewFilesystem(ss.DocumentStore)
+	ctx := lsctx.WithDocumentContext(context.Background(), lsctx.Document{})
+	err = ParseModuleConfiguration(ctx, fs, ss.Modules, modPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = LoadModuleMetadata(ctx, ss.Modules, modPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = DecodeReferenceTargets(ctx, ss.Modules, ss.ProviderSchemas, modPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = DecodeReferenceOrigins(ctx, ss.Modules, ss.ProviderSchemas, modPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = ReferenceValidation(ctx, fs, ss.Modules, ss.ProviderSchemas, modPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mod, err := ss.Modules.ModuleByPath(modPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resolved := make(map[string]reference.Target, 0)
+	for _, origin := range mod.RefOrigins {
+		localOrigin, ok := origin.(reference.LocalOrigin)
+		if !ok {
+			continue
+		}
+		targets, ok := mod.RefTargets.Match(localOrigin)
+		if ok {
+			resolved[localOrigin.Address().String()] = targets[0]
+		}
+	}
+	for _, addr := range []string{"path.module", "path.root", "path.cwd"} {
+		if _, ok := resolved[addr]; !ok {
+			t.Fatalf("expected %s to resolve to a built-in target", addr)
+		}
+	}
+	// the module path is surfaced on hover
+	if desc := resolved["path.module"].Description.Value; !strings.Contains(desc, modPath) {
+		t.Fatalf("expected path.module description to contain %q, given %q", modPath, desc)
+	}
+
+	diags := mod.ModuleDiagnostics[ast.ReferenceValidationSource][ast.ModFilename("main.tf")]
+	if len(diags) != 0 {
+		t.Fatalf("expected no diagnostics, %d given: %#v", len(diags), diags)
+	}
+}
+
+func TestReferenceValidation_movedAcrossModules(t *testing.T) {
+	testData, err := filepath.Abs("testdata")
+	if err != nil {
+		t.Fatal(err)
+	}
+	modPath := filepath.Join(testData, "moved-module")
+	submodPath := filepath.Join(modPath, "modules", "compute")
+
+	ss, err := state.NewStateStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fs := filesystem.NewFilesystem(ss.DocumentStore)
+	ctx := lsctx.WithDocumentContext(context.Background(), lsctx.Document{})
+	for _, path := range []string{submodPath, modPath} {
+		err = ss.Modules.Add(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = ParseModuleConfiguration(ctx, fs, ss.Modules, path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = LoadModuleMetadata(ctx, ss.Modules, path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = DecodeReferenceTargets(ctx, ss.Modules, ss.ProviderSchemas, path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = DecodeReferenceOrigins(ctx, ss.Modules, ss.ProviderSchemas, path)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	err = ReferenceValidation(ctx, fs, ss.Modules, ss.ProviderSchemas, modPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mod, err := ss.Modules.ModuleByPath(modPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	diags := mod.ModuleDiagnostics[ast.ReferenceValidationSource][ast.ModFilename("main.tf")]
+	if len(diags) != 1 {
+		t.Fatalf("expected 1 diagnostic, %d given: %#v", len(diags), diags)
+	}
+	expectedSummary := `No declaration found for "module.compute.aws_instance.database"`
+	if diags[0].Summary != expectedSummary {
+		t.Fatalf("expected summary %q, given %q", expectedSummary, diags[0].Summary)
+	}
+	if diags[0].Subject.Start.Line != 12 {
+		t.Fatalf("expected diagnostic in the second moved block (line 12), given line %d",
+			diags[0].Subject.Start.Line)
+	}
+}
+
+func TestReferenceValidation_sensitiveModuleOutput(t *testing.T) {
+	testData, err := filepath.Abs("testdata")
+	if err != nil {
+		t.Fatal(err)
+	}
+	modPath := filepath.Join(testData, "sensitive-output-module")
+	submodPath := filepath.Join(modPath, "modules", "db")
+
+	ss, err := state.NewStateStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fs := filesystem.NewFilesystem(ss.DocumentStore)
+	ctx := lsctx.WithDocumentContext(context.Background(), lsctx.Document{})
+	for _, path := range []string{submodPath, modPath} {
+		err = ss.Modules.Add(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = ParseModuleConfiguration(ctx, fs, ss.Modules, path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = LoadModuleMetadata(ctx, ss.Modules, path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = DecodeReferenceTargets(ctx, ss.Modules, ss.ProviderSchemas, path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = DecodeReferenceOrigins(ctx, ss.Modules, ss.ProviderSchemas, path)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	err = ReferenceValidation(ctx, fs, ss.Modules, ss.ProviderSchemas, modPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mod, err := ss.Modules.ModuleByPath(modPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	diags := mod.ModuleDiagnostics[ast.ReferenceValidationSource][ast.ModFilename("main.tf")]
+	if len(diags) != 1 {
+		t.Fatalf("expected 1 diagnostic, %d given: %#v", len(diags), diags)
+	}
+	if diags[0].Summary != validations.SensitiveModuleOutputSummary {
+		t.Fatalf("expected summary %q, given %q", validations.SensitiveModuleOutputSummary, diags[0].Summary)
+	}
+	if diags[0].Subject.Start.Line != 10 {
+		t.Fatalf("expected diagnostic in the db_password output (line 10), given line %d",
+			diags[0].Subject.Start.Line)
+	}
+}
+
+func TestReferenceValidation_missingConfigurationAlias(t *testing.T) {
+	testData, err := filepath.Abs("testdata")
+	if err != nil {
+		t.Fatal(err)
+	}
+	modPath := filepath.Join(testData, "configuration-aliases-module")
+	submodPath := filepath.Join(modPath, "modules", "child")
+
+	ss, err := state.NewStateStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fs := filesystem.NewFilesystem(ss.DocumentStore)
+	ctx := lsctx.WithDocumentContext(context.Background(), lsctx.Document{})
+	for _, path := range []string{submodPath, modPath} {
+		err = ss.Modules.Add(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = ParseModuleConfiguration(ctx, fs, ss.Modules, path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = LoadModuleMetadata(ctx, ss.Modules, path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = DecodeReferenceTargets(ctx, ss.Modules, ss.ProviderSchemas, path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = DecodeReferenceOrigins(ctx, ss.Modules, ss.ProviderSchemas, path)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	err = ReferenceValidation(ctx, fs, ss.Modules, ss.ProviderSchemas, modPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mod, err := ss.Modules.ModuleByPath(modPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	diags := mod.ModuleDiagnostics[ast.ReferenceValidationSource][ast.ModFilename("main.tf")]
+	if len(diags) != 1 {
+		t.Fatalf("expected 1 diagnostic, %d given: %#v", len(diags), diags)
+	}
+	if diags[0].Summary != validations.MissingConfigurationAliasSummary {
+		t.Fatalf("expected summary %q, given %q", validations.MissingConfigurationAliasSummary, diags[0].Summary)
+	}
+	if diags[0].Subject.Start.Line != 6 {
+		t.Fatalf("expected diagnostic in the module block (line 6), given line %d",
+			diags[0].Subject.Start.Line)
+	}
+}
+
+func TestGetTerraformVersion_timeout(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("fake terraform binary is a shell script")
+	}
+
+	ss, err := state.NewStateStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	modPath := t.TempDir()
+	err = ss.Modules.Add(modPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	timeout := 100 * time.Millisecond
+	ctx := withStalledTerraform(t, timeout)
+
+	err = GetTerraformVersion(ctx, ss.Modules, modPath)
+	expectedErr := exec.ExecTimeoutError("Version", timeout)
+	if !errors.Is(err, expectedErr) {
+		t.Fatalf("expected timeout error: %#v, given: %#v", expectedErr, err)
+	}
+
+	mod, err := ss.Modules.ModuleByPath(modPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !errors.Is(mod.TerraformVersionErr, expectedErr) {
+		t.Fatalf("expected timeout error to be recorded: %#v, given: %#v",
+			expectedErr, mod.TerraformVersionErr)
+	}
+	if mod.TerraformVersionState != operation.OpStateLoaded {
+		t.Fatalf("expected version state to be loaded, given: %s", mod.TerraformVersionState)
+	}
+}
+
+func TestTerraformValidate_timeout(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("fake terraform binary is a shell script")
+	}
+
+	ss, err := state.NewStateStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	modPath := t.TempDir()
+	err = ss.Modules.Add(modPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	timeout := 100 * time.Millisecond
+	ctx := withStalledTerraform(t, timeout)
+
+	err = TerraformValidate(ctx, ss.Modules, modPath)
+	expectedErr := exec.ExecTimeoutError("Validate", timeout)
+	if !errors.Is(err, expectedErr) {
+		t.Fatalf("expected timeout error: %#v, given: %#v", expectedErr, err)
+	}
+
+	mod, err := ss.Modules.ModuleByPath(modPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// validation is not stuck in progress and can be retried
+	if vState := mod.ModuleDiagnosticsState[ast.TerraformValidateSource]; vState != operation.OpStateUnknown {
+		t.Fatalf("expected validate state to be unknown, given: %s", vState)
+	}
+}
+
+// withStalledTerraform returns a context with the real executor
+// running a fake terraform binary which takes longer to execute
+// than the given timeout, emulating a stalled terraform binary.
+func withStalledTerraform(t *testing.T, timeout time.Duration) context.Context {
+	execPath := filepath.Join(t.TempDir(), "terraform")
+	fakeTerraform := `#!/bin/sh
+exec sleep 5
+`
+	err := os.WriteFile(execPath, []byte(fakeTerraform), 0o755)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := exec.WithExecutorFactory(context.Background(), exec.NewExecutor)
+	return exec.WithExecutorOpts(ctx, &exec.ExecutorOpts{
+		ExecPath: execPath,
+		Timeout:  timeout,
+	})
+}
+
+func TestComputeModuleMetrics(t *testing.T) {
+	ctx := context.Background()
+	ss, err := state.NewStateStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testData, err := filepath.Abs("testdata")
+	if err != nil {
+		t.Fatal(err)
+	}
+	modPath := filepath.Join(testData, "metrics-module")
+
+	err = ss.Modules.Add(modPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fs := filesystem.NewFilesystem(ss.DocumentStore)
+	ctx = lsctx.WithDocumentContext(ctx, lsctx.Document{})
+	err = ParseModuleConfiguration(ctx, fs, ss.Modules, modPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = LoadModuleMetadata(ctx, ss.Modules, modPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = ComputeModuleMetrics(ctx, ss.Modules, modPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mod, err := ss.Modules.ModuleByPath(modPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedMetrics := state.ModuleMetrics{
+		Resources:   3,
+		DataSources: 1,
+		Variables:   2,
+		Outputs:     2,
+		ModuleCalls: 1,
+		Providers:   2,
+	}
+	if diff := cmp.Diff(expectedMetrics, mod.Metrics); diff != "" {
+		t.Fatalf("unexpected metrics: %s", diff)
+	}
+}
+
+func TestObtainSchema_uninitialized(t *testing.T) {
+	testData, err := filepath.Abs("testdata")
+	if err != nil {
+		t.Fatal(err)
+	}
+	modPath := filepath.Join(testData, "uninitialized-external-module")
+
+	ss, err := state.NewStateStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = ss.Modules.Add(modPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fs := filesystem.NewFilesystem(ss.DocumentStore)
+	ctx := lsctx.WithDocumentContext(context.Background(), lsctx.Document{})
+	err = ParseModuleConfiguration(ctx, fs, ss.Modules, modPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = LoadModuleMetadata(ctx, ss.Modules, modPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// no lock file exists, so no provider versions are known
+	_ = ParseProviderVersions(ctx, fs, datadir.Dir{}, ss.Modules, modPath)
+	mod, err := ss.Modules.ModuleByPath(modPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(mod.InstalledProviders) > 0 {
+		t.Fatalf("expected no installed providers, given: %#v", mod.InstalledProviders)
+	}
+
+	execDirs := make([]string, 0)
+	ctx = exec.WithExecutorOpts(ctx, &exec.ExecutorOpts{
+		ExecPath: "mock",
+	})
+	ctx = exec.WithExecutorFactory(ctx, func(workDir string, execPath string) (exec.TerraformExecutor, error) {
+		execDirs = append(execDirs, workDir)
+		return nil, fmt.Errorf("unexpected CLI execution in %q", workDir)
+	})
+
+	err = ObtainSchema(ctx, ss.Modules, ss.ProviderSchemas, modPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(execDirs) > 0 {
+		t.Fatalf("expected no CLI executions, given: %q", execDirs)
+	}
+}
+
+func TestObtainSchema_largeSchemas(t *testing.T) {
+	modPath := "large"
+
+	fs := fstest.MapFS{
+		modPath: &fstest.MapFile{Mode: fs.ModeDir},
+		// These are somewhat awkward two entries
+		// to account for io/fs and our own path separator differences
+		// See https://github.com/hashicorp/terraform-ls/issues/1025
+		modPath + "/main.tf": &fstest.MapFile{
+			Data: []byte{},
+		},
+		filepath.Join(modPath, "main.tf"): &fstest.MapFile{
+			Data: []byte(`terraform {
+	required_providers {
+		aws = {
+			source = "hashicorp/aws"
+		}
+	}
+}
+`),
+		},
+	}
+
+	ss, err := state.NewStateStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ss.SetLogger(log.Default())
+
+	ctx := context.Background()
+
+	err = ss.Modules.Add(modPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx = lsctx.WithDocumentContext(ctx, lsctx.Document{})
+	err = ParseModuleConfiguration(ctx, fs, ss.Modules, modPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = LoadModuleMetadata(ctx, ss.Modules, modPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// google is required by an installed module
+	// which was not indexed yet, so it is only known from the lock file
+	err = ss.Modules.UpdateInstalledProviders(modPath, map[tfaddr.Provider]*version.Version{
+		tfaddr.MustParseProviderSource("hashicorp/aws"):    version.Must(version.NewVersion("5.0.0")),
+		tfaddr.MustParseProviderSource("hashicorp/google"): version.Must(version.NewVersion("5.0.0")),
+	}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	providerSchema := func() *tfjson.ProviderSchema {
+		return &tfjson.ProviderSchema{
+			ConfigSchema: &tfjson.Schema{
+				Block: &tfjson.SchemaBlock{},
+			},
+		}
+	}
+
+	ctx = exec.WithExecutorOpts(ctx, &exec.ExecutorOpts{
+		ExecPath: "mock",
+	})
+	ctx = exec.WithExecutorFactory(ctx, exec.NewMockExecutor(&exec.TerraformMockCalls{
+		PerWorkDir: map[string][]*mock.Call{
+			modPath: {
+				{
+					Method:        "ProviderSchemas",
+					Repeatability: 1,
+					Arguments: []interface{}{
+						mock.MatchedBy(func(ctx context.Context) bool {
+							filter, ok := exec.ProviderSchemasFilterFromContext(ctx)
+							return ok && filter.MaxSize == maxProviderSchemasSize &&
+								filter.IsRequired("registry.terraform.io/hashicorp/aws") &&
+								filter.IsRequired("registry.terraform.io/hashicorp/google") &&
+								!filter.IsRequired("registry.terraform.io/hashicorp/azurerm")
+						}),
+					},
+					ReturnArguments: []interface{}{
+						&tfjson.ProviderSchemas{
+							FormatVersion: "1.0",
+							Schemas: map[string]*tfjson.ProviderSchema{
+								"registry.terraform.io/hashicorp/aws":    providerSchema(),
+								"registry.terraform.io/hashicorp/google": providerSchema(),
+							},
+						},
+						nil,
+					},
+				},
+			},
+		},
+	}))
+
+	err = ObtainSchema(ctx, ss.Modules, ss.ProviderSchemas, modPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	it, err := ss.ProviderSchemas.ListSchemas()
+	if err != nil {
+		t.Fatal(err)
+	}
+	addrs := make([]string, 0)
+	for ps := it.Next(); ps != nil; ps = it.Next() {
+		if ps.Schema == nil {
+			continue
+		}
+		addrs = append(addrs, ps.Address.String())
+	}
+
+	expectedAddrs := []string{
+		"registry.terraform.io/hashicorp/aws",
+		"registry.terraform.io/hashicorp/google",
+	}
+	if diff := cmp.Diff(expectedAddrs, addrs); diff != "" {
+		t.Fatalf("unexpected schemas: %s", diff)
+	}
+}
+
+func TestObtainSchema_lockFileChange(t *testing.T) {
+	modPath := "upgrade"
+
+	fs := fstest.MapFS{
+		modPath: &fstest.MapFile{Mode: fs.ModeDir},
+		// These are somewhat awkward two entries
+		// to account for io/fs and our own path separator differences
+		// See https://github.com/hashicorp/terraform-ls/issues/1025
+		modPath + "/main.tf": &fstest.MapFile{
+			Data: []byte{},
+		},
+		filepath.Join(modPath, "main.tf"): &fstest.MapFile{
+			Data: []byte(`terraform {
+	required_providers {
+		aws = {
+			source = "hashicorp/aws"
+		}
+		google = {
+			source = "hashicorp/google"
+		}
+	}
+}
+`),
+		},
+	}
+
+	ss, err := state.NewStateStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ss.SetLogger(log.Default())
+
+	ctx := context.Background()
+
+	err = ss.Modules.Add(modPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx = lsctx.WithDocumentContext(ctx, lsctx.Document{})
+	err = ParseModuleConfiguration(ctx, fs, ss.Modules, modPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = LoadModuleMetadata(ctx, ss.Modules, modPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	awsAddr := tfaddr.MustParseProviderSource("hashicorp/aws")
+	googleAddr := tfaddr.MustParseProviderSource("hashicorp/google")
+	jsonSchema := func(resourceName string) *tfjson.ProviderSchema {
+		return &tfjson.ProviderSchema{
+			ConfigSchema: &tfjson.Schema{
+				Block: &tfjson.SchemaBlock{},
+			},
+			ResourceSchemas: map[string]*tfjson.Schema{
+				resourceName: {
+					Block: &tfjson.SchemaBlock{},
+				},
+			},
+		}
+	}
+
+	// schemas obtained before the lock file changed
+	err = ss.Modules.UpdateInstalledProviders(modPath, map[tfaddr.Provider]*version.Version{
+		awsAddr:    version.Must(version.NewVersion("5.0.0")),
+		googleAddr: version.Must(version.NewVersion("4.0.0")),
+	}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = ss.ProviderSchemas.AddLocalSchema(modPath, awsAddr,
+		tfschema.ProviderSchemaFromJson(jsonSchema("aws_instance"), awsAddr))
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = ss.ProviderSchemas.AddLocalSchema(modPath, googleAddr,
+		tfschema.ProviderSchemaFromJson(jsonSchema("google_compute_instance"), googleAddr))
+	if err != nil {
+		t.Fatal(err)
+	}
+	googleSchema, err := ss.ProviderSchemas.ProviderSchema(modPath, googleAddr, version.Constraints{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// lock file upgrading only aws
+	err = ss.Modules.UpdateInstalledProviders(modPath, map[tfaddr.Provider]*version.Version{
+		awsAddr:    version.Must(version.NewVersion("5.1.0")),
+		googleAddr: version.Must(version.NewVersion("4.0.0")),
+	}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx = exec.WithExecutorOpts(ctx, &exec.ExecutorOpts{
+		ExecPath: "mock",
+	})
+	ctx = exec.WithExecutorFactory(ctx, exec.NewMockExecutor(&exec.TerraformMockCalls{
+		PerWorkDir: map[string][]*mock.Call{
+			modPath: {
+				{
+					Method:        "ProviderSchemas",
+					Repeatability: 1,
+					Arguments: []interface{}{
+						mock.AnythingOfType(""),
+					},
+					ReturnArguments: []interface{}{
+						&tfjson.ProviderSchemas{
+							FormatVersion: "1.0",
+							Schemas: map[string]*tfjson.ProviderSchema{
+								"registry.terraform.io/hashicorp/aws":    jsonSchema("aws_instance_v2"),
+								"registry.terraform.io/hashicorp/google": jsonSchema("google_compute_instance_v2"),
+							},
+						},
+						nil,
+					},
+				},
+			},
+		},
+	}))
+	ctx = job.WithIgnoreState(ctx, true)
+
+	err = ObtainSchema(ctx, ss.Modules, ss.ProviderSchemas, modPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	it, err := ss.ProviderSchemas.ListSchemas()
+	if err != nil {
+		t.Fatal(err)
+	}
+	schemas := make([]string, 0)
+	for ps := it.Next(); ps != nil; ps = it.Next() {
+		if ps.Schema == nil {
+			continue
+		}
+		if ps.Address.Equals(googleAddr) && ps.Schema != googleSchema {
+			t.Fatalf("expected schema of unchanged provider to be kept")
+		}
+		schemas = append(schemas, fmt.Sprintf("%s %s", ps.Address.ForDisplay(), ps.Version))
+	}
+
+	expectedSchemas := []string{
+		"hashicorp/aws 5.0.0",
+		"hashicorp/aws 5.1.0",
+		"hashicorp/google 4.0.0",
+	}
+	if diff := cmp.Diff(expectedSchemas, schemas); diff != "" {
+		t.Fatalf("unexpected schemas: %s", diff)
+	}
+
+	awsSchema, err := ss.ProviderSchemas.ProviderSchema(modPath, awsAddr,
+		version.MustConstraints(version.NewConstraint("5.1.0")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := awsSchema.Resources["aws_instance_v2"]; !ok {
+		t.Fatalf("expected schema of upgraded provider to be re-obtained")
+	}
+}
+
+func TestReferenceValidation_localModuleSources(t *testing.T) {
+	testData, err := filepath.Abs("testdata")
+	if err != nil {
+		t.Fatal(err)
+	}
+	modPath := filepath.Join(testData, "local-module-sources")
+
+	ss, err := state.NewStateStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fs := filesystem.NewFilesystem(ss.DocumentStore)
+	ctx := lsctx.WithDocumentContext(context.Background(), lsctx.Document{})
+	err = ss.Modules.Add(modPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = ParseModuleConfiguration(ctx, fs, ss.Modules, modPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = LoadModuleMetadata(ctx, ss.Modules, modPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = DecodeReferenceTargets(ctx, ss.Modules, ss.ProviderSchemas, modPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = DecodeReferenceOrigins(ctx, ss.Modules, ss.ProviderSchemas, modPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = ReferenceValidation(ctx, fs, ss.Modules, ss.ProviderSchemas, modPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mod, err := ss.Modules.ModuleByPath(modPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	diags := mod.ModuleDiagnostics[ast.ReferenceValidationSource][ast.ModFilename("main.tf")]
+	if len(diags) != 2 {
+		t.Fatalf("expected 2 diagnostics, %d given: %#v", len(diags), diags)
+	}
+	expected := []struct {
+		summary string
+		line    int
+	}{
+		{validations.EmptyLocalModuleSummary, 2},
+		{validations.MissingLocalModuleSummary, 6},
+	}
+	for i, diag := range diags {
+		if diag.Summary != expected[i].summary {
+			t.Fatalf("expected summary %q, given %q", expected[i].summary, diag.Summary)
+		}
+		if diag.Subject.Start.Line != expected[i].line {
+			t.Fatalf("expected diagnostic on the source attribute (line %d), given line %d",
+				expected[i].line, diag.Subject.Start.Line)
+		}
 	}
 }
@@ -34,4 +34,6 @@ const (
 	OpTypeSchemaVarsValidation
 	OpTypeReferenceValidation
 	OpTypeTerraformValidate
+	OpTypeComputeModuleMetrics
+	OpTypeOrphanedVarsValidation
 )
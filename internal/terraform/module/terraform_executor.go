@@ -5,7 +5,6 @@ package module
 
 import (
 	"context"
-	"fmt"
 
 	"github.com/hashicorp/terraform-ls/internal/terraform/exec"
 )
@@ -13,7 +12,7 @@ import (
 func TerraformExecutorForModule(ctx context.Context, modPath string) (exec.TerraformExecutor, error) {
 	newExecutor, ok := exec.ExecutorFactoryFromContext(ctx)
 	if !ok {
-		return nil, fmt.Errorf("no terraform executor provided")
+		return nil, NoTerraformExecutorErr{}
 	}
 
 	execPath, err := TerraformExecPath(ctx)
@@ -33,6 +32,15 @@ func TerraformExecutorForModule(ctx context.Context, modPath string) (exec.Terra
 	if ok && opts.Timeout != 0 {
 		tfExec.SetTimeout(opts.Timeout)
 	}
+	if ok && opts.Logger != nil {
+		tfExec.SetLogger(opts.Logger)
+	}
+	if ok && opts.DataDir != "" {
+		err = tfExec.SetDataDir(opts.DataDir)
+		if err != nil {
+			return nil, err
+		}
+	}
 
 	return tfExec, nil
 }
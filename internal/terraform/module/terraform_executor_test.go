@@ -0,0 +1,74 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package module
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-ls/internal/terraform/exec"
+	exec_mock "github.com/hashicorp/terraform-ls/internal/terraform/exec/mock"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestTerraformExecutorForModule_dataDir(t *testing.T) {
+	modPath := t.TempDir()
+
+	ctx := exec.WithExecutorFactory(context.Background(), exec.NewMockExecutor(&exec.TerraformMockCalls{
+		PerWorkDir: map[string][]*mock.Call{
+			modPath: {
+				{
+					Method:        "SetDataDir",
+					Repeatability: 1,
+					Arguments: []interface{}{
+						"custom-data",
+					},
+					ReturnArguments: []interface{}{
+						nil,
+					},
+				},
+			},
+		},
+	}))
+	ctx = exec.WithExecutorOpts(ctx, &exec.ExecutorOpts{
+		ExecPath: "terraform",
+		DataDir:  "custom-data",
+	})
+
+	tfExec, err := TerraformExecutorForModule(ctx, modPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tfExec.(*exec_mock.Executor).AssertCalled(t, "SetDataDir", "custom-data")
+}
+
+func TestTerraformExecutorForModule_defaultDataDir(t *testing.T) {
+	modPath := t.TempDir()
+
+	ctx := exec.WithExecutorFactory(context.Background(), exec.NewMockExecutor(&exec.TerraformMockCalls{
+		PerWorkDir: map[string][]*mock.Call{
+			modPath: {
+				{
+					Method:        "SetDataDir",
+					Repeatability: 1,
+					Arguments: []interface{}{
+						mock.Anything,
+					},
+					ReturnArguments: []interface{}{
+						nil,
+					},
+				},
+			},
+		},
+	}))
+	ctx = exec.WithExecutorOpts(ctx, &exec.ExecutorOpts{
+		ExecPath: "terraform",
+	})
+
+	tfExec, err := TerraformExecutorForModule(ctx, modPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tfExec.(*exec_mock.Executor).AssertNotCalled(t, "SetDataDir", mock.Anything)
+}
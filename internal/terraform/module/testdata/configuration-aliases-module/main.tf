@@ -0,0 +1,8 @@
+provider "aws" {
+  alias  = "usw2"
+  region = "us-west-2"
+}
+
+module "child" {
+  source = "./modules/child"
+}
@@ -0,0 +1,12 @@
+terraform {
+  required_providers {
+    fake = {
+      source = "acme/fake"
+    }
+  }
+}
+
+resource "fake_instance" "test" {
+  legacy_name = "foo"
+  name        = "bar"
+}
@@ -0,0 +1,10 @@
+resource "aws_instance" "example" {
+  for_each = toset(["a", "b"])
+
+  lifecycle {
+    precondition {
+      condition     = each.value != ""
+      error_message = "Value must not be empty."
+    }
+  }
+}
@@ -0,0 +1,10 @@
+variable "region" {
+  type = string
+}
+
+variable "server" {
+  type = object({
+    name = string
+    port = number
+  })
+}
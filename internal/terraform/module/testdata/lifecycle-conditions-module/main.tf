@@ -0,0 +1,24 @@
+terraform {
+  required_version = ">= 1.2.0"
+}
+
+variable "ami" {
+  type = string
+}
+
+resource "aws_instance" "web" {
+  lifecycle {
+    precondition {
+      condition     = var.ami != ""
+      error_message = "AMI must be set."
+    }
+    precondition {
+      condition     = var.undeclared != ""
+      error_message = "Undeclared variable must be set."
+    }
+    postcondition {
+      condition     = self.ami == var.ami
+      error_message = "Instance must use the given AMI."
+    }
+  }
+}
@@ -0,0 +1,7 @@
+module "empty" {
+  source = "./modules/empty"
+}
+
+module "missing" {
+  source = "./modules/missing"
+}
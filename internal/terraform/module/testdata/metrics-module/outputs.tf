@@ -0,0 +1,11 @@
+output "instance_id" {
+  value = aws_instance.web.id
+}
+
+output "name" {
+  value = random_pet.name.id
+}
+
+resource "aws_eip" "web" {
+  instance = aws_instance.web.id
+}
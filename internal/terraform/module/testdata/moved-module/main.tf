@@ -0,0 +1,13 @@
+module "compute" {
+  source = "./modules/compute"
+}
+
+moved {
+  from = aws_instance.web
+  to   = module.compute.aws_instance.web
+}
+
+moved {
+  from = aws_instance.db
+  to   = module.compute.aws_instance.database
+}
@@ -0,0 +1,7 @@
+variable "server" {
+  type = object({
+    name = string
+    port = number
+    tags = optional(list(string), [])
+  })
+}
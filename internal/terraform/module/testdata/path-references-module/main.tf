@@ -0,0 +1,5 @@
+locals {
+  module_file = "${path.module}/files/config.json"
+  root_file   = "${path.root}/files/config.json"
+  cwd         = path.cwd
+}
@@ -0,0 +1,11 @@
+terraform {
+  required_providers {
+    google = {
+      source = "hashicorp/google"
+    }
+  }
+
+  provider_meta "google" {
+    module_name = "blueprints/terraform/example/v1.0.0"
+  }
+}
@@ -0,0 +1,16 @@
+module "db" {
+  source = "./modules/db"
+}
+
+output "db_endpoint" {
+  value = module.db.endpoint
+}
+
+output "db_password" {
+  value = module.db.password
+}
+
+output "db_password_sensitive" {
+  value     = module.db.password
+  sensitive = true
+}
@@ -0,0 +1,8 @@
+output "endpoint" {
+  value = "db.example.com"
+}
+
+output "password" {
+  value     = "secret"
+  sensitive = true
+}
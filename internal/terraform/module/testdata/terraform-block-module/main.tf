@@ -0,0 +1,22 @@
+terraform {
+  required_version = ">= 1.5.0"
+  experiments      = []
+
+  required_providers {
+    aws = {
+      source  = "hashicorp/aws"
+      version = "~> 5.0"
+    }
+  }
+
+  cloud {
+    organization = "example"
+
+    workspaces {
+      name = "example"
+    }
+  }
+
+  unknown {
+  }
+}
@@ -0,0 +1,10 @@
+resource "aws_instance" "web" {
+  count = terraform.workspace == "default" ? 0 : 1
+
+  lifecycle {
+    precondition {
+      condition     = !terraform.applying || terraform.workspace != "default"
+      error_message = "Must not be deployed into the default workspace."
+    }
+  }
+}
@@ -0,0 +1,12 @@
+experimental_feature "foo" {
+  enabled = true
+
+  nested {
+    anything = "goes"
+  }
+}
+
+output "example" {
+  value        = "foo"
+  unknown_attr = true
+}
@@ -0,0 +1,130 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package parser
+
+import (
+	"bytes"
+	"fmt"
+	"unicode/utf8"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+)
+
+const (
+	InvalidHeredocMarkerSummary = "Invalid heredoc closing marker"
+	MissingHeredocMarkerSummary = "Missing heredoc closing marker"
+
+	unterminatedTemplateSummary = "Unterminated template string"
+)
+
+// heredocDiagnostics replaces generic diagnostics about unterminated
+// templates, which HCL reports at the end of the file when a heredoc
+// closing marker is not found, with diagnostics pointing to the likely
+// malformed closing marker, or to the heredoc which is not closed.
+func heredocDiagnostics(src []byte, diags hcl.Diagnostics) hcl.Diagnostics {
+	for i, diag := range diags {
+		if diag.Summary != unterminatedTemplateSummary || diag.Context == nil {
+			continue
+		}
+		if heredocDiag, ok := unterminatedHeredocDiagnostic(src, *diag.Context); ok {
+			diags[i] = heredocDiag
+		}
+	}
+	return diags
+}
+
+// unterminatedHeredocDiagnostic returns a diagnostic for the heredoc
+// whose content starts at the beginning of the given range, i.e.
+// on the line following the introducer, such as <<-EOT.
+func unterminatedHeredocDiagnostic(src []byte, rng hcl.Range) (*hcl.Diagnostic, bool) {
+	contentStart := rng.Start.Byte
+	if contentStart <= 0 || contentStart > len(src) || src[contentStart-1] != '\n' {
+		return nil, false
+	}
+
+	prevLine := bytes.TrimRight(src[:contentStart-1], "\r")
+	introducerStart := bytes.LastIndex(prevLine, []byte("<<"))
+	if introducerStart < 0 {
+		return nil, false
+	}
+	introducer := prevLine[introducerStart:]
+	marker := bytes.TrimPrefix(introducer[2:], []byte("-"))
+	if !hclsyntax.ValidIdentifier(string(marker)) {
+		return nil, false
+	}
+
+	lineStart := bytes.LastIndexByte(prevLine, '\n') + 1
+	introducerCol := utf8.RuneCount(prevLine[lineStart:introducerStart]) + 1
+	introducerRange := hcl.Range{
+		Filename: rng.Filename,
+		Start: hcl.Pos{
+			Line:   rng.Start.Line - 1,
+			Column: introducerCol,
+			Byte:   introducerStart,
+		},
+		End: hcl.Pos{
+			Line:   rng.Start.Line - 1,
+			Column: introducerCol + utf8.RuneCount(introducer),
+			Byte:   introducerStart + len(introducer),
+		},
+	}
+
+	// look for a line starting with the marker followed by other
+	// characters, such as EOT) when the heredoc is passed as an argument
+	offset := contentStart
+	line := rng.Start.Line
+	for offset < len(src) {
+		content := src[offset:]
+		if end := bytes.IndexByte(content, '\n'); end >= 0 {
+			content = content[:end+1]
+		}
+
+		trimmed := bytes.TrimLeft(content, " \t")
+		indent := len(content) - len(trimmed)
+		trimmed = bytes.TrimRight(trimmed, " \t\r\n")
+
+		if len(trimmed) > len(marker) && bytes.HasPrefix(trimmed, marker) &&
+			!isIdentifierByte(trimmed[len(marker)]) {
+			subjectStart := hcl.Pos{
+				Line:   line,
+				Column: utf8.RuneCount(content[:indent]) + 1,
+				Byte:   offset + indent,
+			}
+			return &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  InvalidHeredocMarkerSummary,
+				Detail: fmt.Sprintf("The closing marker %s must be placed on its own line "+
+					"(optionally indented), not followed by any other characters. "+
+					"Move the remaining characters to the next line.", marker),
+				Subject: &hcl.Range{
+					Filename: rng.Filename,
+					Start:    subjectStart,
+					End: hcl.Pos{
+						Line:   line,
+						Column: subjectStart.Column + utf8.RuneCount(trimmed),
+						Byte:   subjectStart.Byte + len(trimmed),
+					},
+				},
+				Context: introducerRange.Ptr(),
+			}, true
+		}
+
+		offset += len(content)
+		line++
+	}
+
+	return &hcl.Diagnostic{
+		Severity: hcl.DiagError,
+		Summary:  MissingHeredocMarkerSummary,
+		Detail: fmt.Sprintf("No line containing only the closing marker %s was found "+
+			"for this heredoc before the end of the file.", marker),
+		Subject: introducerRange.Ptr(),
+	}, true
+}
+
+func isIdentifierByte(b byte) bool {
+	return b == '_' || b == '-' ||
+		(b >= 'a' && b <= 'z') || (b >= 'A' && b <= 'Z') || (b >= '0' && b <= '9')
+}
@@ -0,0 +1,90 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package parser
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/terraform-ls/internal/terraform/ast"
+)
+
+func TestParseFile_heredocMarkers(t *testing.T) {
+	testCases := []struct {
+		name            string
+		src             string
+		expectedSummary string
+		expectedSubject *hcl.Range
+	}{
+		{
+			"indented closing marker",
+			`locals {
+  policy = jsonencode(<<-EOT
+    {"Version": "2012-10-17"}
+    EOT
+  )
+}
+`,
+			"",
+			nil,
+		},
+		{
+			"closing marker followed by other characters",
+			`locals {
+  policy = jsonencode(<<-EOT
+    {"Version": "2012-10-17"}
+    EOT)
+}
+`,
+			InvalidHeredocMarkerSummary,
+			&hcl.Range{
+				Filename: "test.tf",
+				Start:    hcl.Pos{Line: 4, Column: 5, Byte: 72},
+				End:      hcl.Pos{Line: 4, Column: 9, Byte: 76},
+			},
+		},
+		{
+			"missing closing marker",
+			`locals {
+  foo = <<EOT
+  bar
+}
+`,
+			MissingHeredocMarkerSummary,
+			&hcl.Range{
+				Filename: "test.tf",
+				Start:    hcl.Pos{Line: 2, Column: 9, Byte: 17},
+				End:      hcl.Pos{Line: 2, Column: 14, Byte: 22},
+			},
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%d-%s", i, tc.name), func(t *testing.T) {
+			_, diags := parseFile([]byte(tc.src), ast.ModFilename("test.tf"))
+			if tc.expectedSummary == "" {
+				if len(diags) > 0 {
+					t.Fatalf("expected no diagnostics, given: %s", diags)
+				}
+				return
+			}
+
+			for _, diag := range diags {
+				if diag.Summary == unterminatedTemplateSummary {
+					t.Fatalf("expected generic diagnostic to be replaced: %#v", diag)
+				}
+				if diag.Summary != tc.expectedSummary {
+					continue
+				}
+				if diff := cmp.Diff(tc.expectedSubject, diag.Subject); diff != "" {
+					t.Fatalf("unexpected subject: %s", diff)
+				}
+				return
+			}
+			t.Fatalf("expected diagnostic %q, given: %s", tc.expectedSummary, diags)
+		})
+	}
+}
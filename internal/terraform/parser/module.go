@@ -5,11 +5,24 @@ package parser
 
 import (
 	"path/filepath"
+	"runtime"
+	"sync"
 
 	"github.com/hashicorp/hcl/v2"
 	"github.com/hashicorp/terraform-ls/internal/terraform/ast"
 )
 
+// parallelParseThreshold represents the number of module files
+// in a directory from which the files are parsed in parallel.
+// Parsing fewer files in parallel is not worth the overhead.
+const parallelParseThreshold = 32
+
+type parsedModuleFile struct {
+	file  *hcl.File
+	diags hcl.Diagnostics
+	ok    bool
+}
+
 func ParseModuleFiles(fs FS, modPath string) (ast.ModFiles, ast.ModDiags, error) {
 	files := make(ast.ModFiles, 0)
 	diags := make(ast.ModDiags, 0)
@@ -19,6 +32,7 @@ func ParseModuleFiles(fs FS, modPath string) (ast.ModFiles, ast.ModDiags, error)
 		return nil, nil, err
 	}
 
+	names := make([]ast.ModFilename, 0, len(infos))
 	for _, info := range infos {
 		if info.IsDir() {
 			// We only care about files
@@ -32,22 +46,58 @@ func ParseModuleFiles(fs FS, modPath string) (ast.ModFiles, ast.ModDiags, error)
 
 		// TODO: overrides
 
-		fullPath := filepath.Join(modPath, name)
+		names = append(names, ast.ModFilename(name))
+	}
 
-		src, err := fs.ReadFile(fullPath)
+	results := make([]parsedModuleFile, len(names))
+	parse := func(i int) {
+		src, err := fs.ReadFile(filepath.Join(modPath, names[i].String()))
 		if err != nil {
 			// If a file isn't accessible, continue with reading the
 			// remaining module files
-			continue
+			return
 		}
 
-		filename := ast.ModFilename(name)
+		f, pDiags := parseFile(src, names[i])
+		results[i] = parsedModuleFile{file: f, diags: pDiags, ok: true}
+	}
 
-		f, pDiags := parseFile(src, filename)
+	if len(names) < parallelParseThreshold {
+		for i := range names {
+			parse(i)
+		}
+	} else {
+		// Each result is written to its own slot, so results are
+		// merged in the order of directory entries, as if parsed serially
+		workers := runtime.GOMAXPROCS(0)
+		if workers > len(names) {
+			workers = len(names)
+		}
+		indexes := make(chan int)
+		var wg sync.WaitGroup
+		for w := 0; w < workers; w++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for i := range indexes {
+					parse(i)
+				}
+			}()
+		}
+		for i := range names {
+			indexes <- i
+		}
+		close(indexes)
+		wg.Wait()
+	}
 
-		diags[filename] = pDiags
-		if f != nil {
-			files[filename] = f
+	for i, result := range results {
+		if !result.ok {
+			continue
+		}
+		diags[names[i]] = result.diags
+		if result.file != nil {
+			files[names[i]] = result.file
 		}
 	}
 
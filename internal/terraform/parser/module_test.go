@@ -86,6 +86,31 @@ func TestParseModuleFiles(t *testing.T) {
 				},
 			},
 		},
+		{
+			"bom-mod-files",
+			map[string]struct{}{
+				"incomplete-block.tf": {},
+			},
+			map[string]hcl.Diagnostics{
+				"incomplete-block.tf": {
+					{
+						Severity: hcl.DiagError,
+						Summary:  "Invalid block definition",
+						Detail:   `A block definition must have block content delimited by "{" and "}", starting on the same line as the block header.`,
+						Subject: &hcl.Range{
+							Filename: "incomplete-block.tf",
+							Start:    hcl.Pos{Line: 1, Column: 30, Byte: 29},
+							End:      hcl.Pos{Line: 2, Column: 1, Byte: 30},
+						},
+						Context: &hcl.Range{
+							Filename: "incomplete-block.tf",
+							Start:    hcl.InitialPos,
+							End:      hcl.Pos{Line: 2, Column: 1, Byte: 30},
+						},
+					},
+				},
+			},
+		},
 		{
 			"invalid-links",
 			map[string]struct{}{
@@ -120,6 +145,67 @@ func TestParseModuleFiles(t *testing.T) {
 	}
 }
 
+func TestParseModuleFiles_manyFiles(t *testing.T) {
+	modPath := t.TempDir()
+	fileCount := parallelParseThreshold * 4
+	writeManyModuleFiles(t, modPath, fileCount)
+
+	files, diags, err := ParseModuleFiles(osFs{}, modPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(files) != fileCount {
+		t.Fatalf("expected %d files, %d given", fileCount, len(files))
+	}
+	for i := 0; i < fileCount; i++ {
+		filename := ast.ModFilename(fmt.Sprintf("file%04d.tf", i))
+		fileDiags, ok := diags[filename]
+		if !ok {
+			t.Fatalf("expected diagnostics for %s", filename)
+		}
+		// every 10th file is invalid
+		if expectedErr := i%10 == 0; fileDiags.HasErrors() != expectedErr {
+			t.Fatalf("%s: expected errors: %t, given: %s", filename, expectedErr, fileDiags)
+		}
+	}
+}
+
+func BenchmarkParseModuleFiles_manyFiles(b *testing.B) {
+	modPath := b.TempDir()
+	writeManyModuleFiles(b, modPath, 2000)
+
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		_, _, err := ParseModuleFiles(osFs{}, modPath)
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func writeManyModuleFiles(t testing.TB, modPath string, count int) {
+	for i := 0; i < count; i++ {
+		src := fmt.Sprintf(`variable "var%d" {
+  type    = string
+  default = "foo"
+}
+
+resource "aws_instance" "instance%d" {
+  ami           = var.var%d
+  instance_type = "t2.micro"
+}
+`, i, i, i)
+		if i%10 == 0 {
+			src += "output \"broken\" {\n"
+		}
+		err := os.WriteFile(filepath.Join(modPath, fmt.Sprintf("file%04d.tf", i)), []byte(src), 0o644)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
 func mapKeys(mf ast.ModFiles) map[string]struct{} {
 	m := make(map[string]struct{}, len(mf))
 	for name := range mf {
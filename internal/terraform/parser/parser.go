@@ -4,6 +4,7 @@
 package parser
 
 import (
+	"bytes"
 	"io/fs"
 
 	"github.com/hashicorp/hcl/v2"
@@ -22,9 +23,20 @@ type filename interface {
 	String() string
 }
 
+// utf8BOM represents the byte order mark which some editors
+// (typically on Windows) prepend to UTF-8 encoded files.
+var utf8BOM = []byte{0xEF, 0xBB, 0xBF}
+
 func parseFile(src []byte, filename filename) (*hcl.File, hcl.Diagnostics) {
+	// Clients generally strip the BOM before sending us the text
+	// of an open document, so we do the same for files read
+	// from disk, to keep ranges consistent with the client's view.
+	// CRLF line endings are handled by both parsers as-is.
+	src = bytes.TrimPrefix(src, utf8BOM)
+
 	if filename.IsJSON() {
 		return json.Parse(src, filename.String())
 	}
-	return hclsyntax.ParseConfig(src, filename.String(), hcl.InitialPos)
+	f, diags := hclsyntax.ParseConfig(src, filename.String(), hcl.InitialPos)
+	return f, heredocDiagnostics(src, diags)
 }
@@ -0,0 +1,162 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package walker
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+const terraformIgnoreFilename = ".terraformignore"
+
+// TerraformIgnore represents rules from a .terraformignore file,
+// which follow the gitignore syntax, relative to the directory
+// containing the file.
+type TerraformIgnore struct {
+	rootDir string
+	rules   []ignoreRule
+}
+
+type ignoreRule struct {
+	segments []string
+	negated  bool
+	dirOnly  bool
+	anchored bool
+}
+
+// LoadTerraformIgnore parses the .terraformignore file in the given
+// directory. It returns nil if the directory has no such file.
+func LoadTerraformIgnore(rootDir string) (*TerraformIgnore, error) {
+	f, err := os.Open(filepath.Join(rootDir, terraformIgnoreFilename))
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer f.Close()
+
+	return ParseTerraformIgnore(rootDir, f)
+}
+
+// ParseTerraformIgnore parses rules from r, such that they apply
+// to paths within the given root directory.
+func ParseTerraformIgnore(rootDir string, r io.Reader) (*TerraformIgnore, error) {
+	ti := &TerraformIgnore{
+		rootDir: rootDir,
+		rules:   make([]ignoreRule, 0),
+	}
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		rule := ignoreRule{}
+		if strings.HasPrefix(line, "!") {
+			rule.negated = true
+			line = line[1:]
+		}
+		if strings.HasSuffix(line, "/") {
+			rule.dirOnly = true
+			line = strings.TrimSuffix(line, "/")
+		}
+		if strings.HasPrefix(line, "/") {
+			rule.anchored = true
+			line = strings.TrimPrefix(line, "/")
+		}
+		if line == "" {
+			continue
+		}
+		// patterns containing a slash are relative to the root,
+		// while others match at any level
+		if strings.Contains(line, "/") {
+			rule.anchored = true
+		}
+		rule.segments = strings.Split(line, "/")
+
+		ti.rules = append(ti.rules, rule)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return ti, nil
+}
+
+// IsIgnored reports whether the given (absolute) path is excluded.
+// Paths outside of the root directory are never excluded.
+func (ti *TerraformIgnore) IsIgnored(fullPath string, isDir bool) bool {
+	if ti == nil {
+		return false
+	}
+
+	relPath, err := filepath.Rel(ti.rootDir, fullPath)
+	if err != nil || relPath == "." || relPath == ".." ||
+		strings.HasPrefix(relPath, ".."+string(filepath.Separator)) {
+		return false
+	}
+	segments := strings.Split(filepath.ToSlash(relPath), "/")
+
+	// Anything within an excluded directory is excluded too
+	for i := 1; i <= len(segments); i++ {
+		if ti.matches(segments[:i], i < len(segments) || isDir) {
+			return true
+		}
+	}
+	return false
+}
+
+func (ti *TerraformIgnore) matches(segments []string, isDir bool) bool {
+	ignored := false
+	for _, rule := range ti.rules {
+		if rule.dirOnly && !isDir {
+			continue
+		}
+		if rule.matches(segments) {
+			// the last matching rule wins
+			ignored = !rule.negated
+		}
+	}
+	return ignored
+}
+
+func (r ignoreRule) matches(segments []string) bool {
+	if r.anchored {
+		return matchSegments(r.segments, segments)
+	}
+	return matchSegments(r.segments, segments[len(segments)-1:])
+}
+
+func matchSegments(pattern, segments []string) bool {
+	if len(pattern) == 0 {
+		return len(segments) == 0
+	}
+
+	if pattern[0] == "**" {
+		for i := 0; i <= len(segments); i++ {
+			if matchSegments(pattern[1:], segments[i:]) {
+				return true
+			}
+		}
+		return false
+	}
+
+	if len(segments) == 0 {
+		return false
+	}
+	ok, err := path.Match(pattern[0], segments[0])
+	if err != nil || !ok {
+		return false
+	}
+	return matchSegments(pattern[1:], segments[1:])
+}
@@ -0,0 +1,68 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package walker
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestTerraformIgnore_IsIgnored(t *testing.T) {
+	rootDir := filepath.Join(t.TempDir(), "root")
+	rules := `# comment
+excluded/
+/anchored
+**/deep/nested
+*.tfvars
+!keep.tfvars
+`
+	ti, err := ParseTerraformIgnore(rootDir, strings.NewReader(rules))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		path     string
+		isDir    bool
+		expected bool
+	}{
+		{filepath.Join(rootDir), true, false},
+		{filepath.Join(rootDir, "excluded"), true, true},
+		{filepath.Join(rootDir, "excluded"), false, false},
+		{filepath.Join(rootDir, "excluded", "main.tf"), false, true},
+		{filepath.Join(rootDir, "foo", "excluded"), true, true},
+		{filepath.Join(rootDir, "included"), true, false},
+		{filepath.Join(rootDir, "anchored"), true, true},
+		{filepath.Join(rootDir, "foo", "anchored"), true, false},
+		{filepath.Join(rootDir, "deep", "nested"), true, true},
+		{filepath.Join(rootDir, "a", "b", "deep", "nested"), true, true},
+		{filepath.Join(rootDir, "dev.tfvars"), false, true},
+		{filepath.Join(rootDir, "keep.tfvars"), false, false},
+		{filepath.Join(filepath.Dir(rootDir), "excluded"), true, false},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%d-%s", i, tc.path), func(t *testing.T) {
+			ignored := ti.IsIgnored(tc.path, tc.isDir)
+			if ignored != tc.expected {
+				t.Fatalf("expected ignored: %t, given: %t", tc.expected, ignored)
+			}
+		})
+	}
+}
+
+func TestTerraformIgnore_nil(t *testing.T) {
+	ti, err := LoadTerraformIgnore(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ti != nil {
+		t.Fatalf("expected no rules for missing %s", terraformIgnoreFilename)
+	}
+	if ti.IsIgnored(filepath.Join(t.TempDir(), "foo"), true) {
+		t.Fatal("expected nil rules to not ignore anything")
+	}
+}
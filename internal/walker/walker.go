@@ -15,6 +15,7 @@ import (
 	"github.com/hashicorp/terraform-ls/internal/document"
 	"github.com/hashicorp/terraform-ls/internal/job"
 	"github.com/hashicorp/terraform-ls/internal/terraform/ast"
+	"github.com/hashicorp/terraform-ls/internal/terraform/datadir"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/codes"
@@ -50,9 +51,12 @@ type Walker struct {
 	Collector *WalkerCollector
 
 	cancelFunc context.CancelFunc
+	doneCh     chan struct{}
 
 	ignoredPaths          map[string]bool
 	ignoredDirectoryNames map[string]bool
+	terraformIgnore       *TerraformIgnore
+	dataDir               datadir.Dir
 }
 
 type WalkFunc func(ctx context.Context, modHandle document.DirHandle) (job.IDs, error)
@@ -76,6 +80,7 @@ func NewWalker(fs fs.ReadDirFS, pathStore PathStore, modStore ModuleStore, walkF
 		walkFunc:              walkFunc,
 		logger:                discardLogger,
 		ignoredDirectoryNames: skipDirNames,
+		doneCh:                make(chan struct{}),
 	}
 }
 
@@ -99,17 +104,46 @@ func (w *Walker) SetIgnoredDirectoryNames(ignoredDirectoryNames []string) {
 	}
 }
 
+// SetTerraformIgnore sets rules (typically loaded from .terraformignore
+// in the root directory) for excluding paths from the walk
+func (w *Walker) SetTerraformIgnore(terraformIgnore *TerraformIgnore) {
+	if w.cancelFunc != nil {
+		panic("cannot set ignore rules after walking started")
+	}
+	w.terraformIgnore = terraformIgnore
+}
+
+// SetDataDir sets location of the data directory, whose presence
+// indicates an initialized root module
+func (w *Walker) SetDataDir(dataDir datadir.Dir) {
+	if w.cancelFunc != nil {
+		panic("cannot set data directory after walking started")
+	}
+	w.dataDir = dataDir
+}
+
 func (w *Walker) Stop() {
 	if w.cancelFunc != nil {
 		w.cancelFunc()
 	}
 }
 
+// Done returns a channel which is closed once walking
+// has stopped after the walker was stopped via [Walker.Stop].
+func (w *Walker) Done() <-chan struct{} {
+	return w.doneCh
+}
+
 func (w *Walker) StartWalking(ctx context.Context) error {
+	if w.cancelFunc != nil {
+		return errors.New("walker already started")
+	}
+
 	ctx, cancelFunc := context.WithCancel(ctx)
 	w.cancelFunc = cancelFunc
 
 	go func() {
+		defer close(w.doneCh)
 		for {
 			pathCtx, nextDir, err := w.pathStore.AwaitNextDir(ctx)
 			if err != nil {
@@ -133,6 +167,12 @@ func (w *Walker) StartWalking(ctx context.Context) error {
 
 			err = w.walk(ctx, nextDir)
 			if err != nil {
+				if errors.Is(err, context.Canceled) {
+					w.logger.Printf("walker: walking through %q cancelled", nextDir)
+					span.SetStatus(codes.Error, "walking cancelled")
+					span.End()
+					return
+				}
 				w.logger.Printf("walker: walking through %q failed: %s", nextDir, err)
 				w.collectError(err)
 				span.RecordError(err)
@@ -186,10 +226,18 @@ func (w *Walker) isSkippableDir(dirName string) bool {
 }
 
 func (w *Walker) walk(ctx context.Context, dir document.DirHandle) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
 	if _, ok := w.ignoredPaths[dir.Path()]; ok {
 		w.logger.Printf("skipping walk due to dir being excluded: %s", dir.Path())
 		return nil
 	}
+	if w.terraformIgnore.IsIgnored(dir.Path(), true) {
+		w.logger.Printf("skipping walk due to dir being excluded via %s: %s", terraformIgnoreFilename, dir.Path())
+		return nil
+	}
 
 	dirEntries, err := fs.ReadDir(w.fs, dir.Path())
 	if err != nil {
@@ -199,12 +247,13 @@ func (w *Walker) walk(ctx context.Context, dir document.DirHandle) error {
 	}
 
 	dirIndexed := false
+	isRoot := false
 
 	for _, dirEntry := range dirEntries {
 		select {
 		case <-ctx.Done():
 			w.logger.Printf("cancelling walk of %s...", dir)
-			return fmt.Errorf("walk cancelled")
+			return ctx.Err()
 		default:
 		}
 
@@ -213,23 +262,19 @@ func (w *Walker) walk(ctx context.Context, dir document.DirHandle) error {
 			continue
 		}
 
-		if !dirIndexed && ast.IsModuleFilename(dirEntry.Name()) && !ast.IsIgnoredFile(dirEntry.Name()) {
+		if !dirIndexed && isModuleFile(dirEntry) {
 			dirIndexed = true
-			w.logger.Printf("found module %s", dir)
-
-			err := w.modStore.AddIfNotExists(dir.Path())
+			err := w.indexDir(ctx, dir)
 			if err != nil {
 				return err
 			}
-
-			ids, err := w.walkFunc(ctx, dir)
-			if err != nil {
-				w.collectError(fmt.Errorf("walkFunc: %w", err))
-			}
-			w.collectJobIds(ids)
 			continue
 		}
 
+		if isRootDirEntry(w.dataDir, dirEntry) {
+			isRoot = true
+		}
+
 		if dirEntry.IsDir() {
 			path := filepath.Join(dir.Path(), dirEntry.Name())
 			dirHandle := document.DirHandleFromPath(path)
@@ -239,6 +284,50 @@ func (w *Walker) walk(ctx context.Context, dir document.DirHandle) error {
 			}
 		}
 	}
+
+	// Initialized roots are recognized even without any module files,
+	// e.g. when these are yet to be generated or checked out,
+	// so that each nested root is indexed with its own lock file.
+	if !dirIndexed && isRoot {
+		iErr := w.indexDir(ctx, dir)
+		if iErr != nil {
+			return iErr
+		}
+	}
+
 	w.logger.Printf("walking of %s finished", dir)
 	return err
 }
+
+func (w *Walker) indexDir(ctx context.Context, dir document.DirHandle) error {
+	w.logger.Printf("found module %s", dir)
+
+	err := w.modStore.AddIfNotExists(dir.Path())
+	if err != nil {
+		return err
+	}
+
+	ids, err := w.walkFunc(ctx, dir)
+	if err != nil {
+		w.collectError(fmt.Errorf("walkFunc: %w", err))
+	}
+	w.collectJobIds(ids)
+
+	return nil
+}
+
+func isModuleFile(dirEntry fs.DirEntry) bool {
+	name := dirEntry.Name()
+	return !dirEntry.IsDir() && ast.IsModuleFilename(name) && !ast.IsIgnoredFile(name)
+}
+
+// isRootDirEntry reports whether the given entry indicates that
+// its parent directory is an initialized root module, i.e. whether
+// it is the dependency lock file or the data directory.
+func isRootDirEntry(dataDir datadir.Dir, dirEntry fs.DirEntry) bool {
+	name := dirEntry.Name()
+	if dirEntry.IsDir() {
+		return !dataDir.IsAbs() && name == dataDir.String()
+	}
+	return name == datadir.LockFileName
+}
@@ -11,6 +11,7 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/hashicorp/go-version"
@@ -75,6 +76,210 @@ func TestWalker_basic(t *testing.T) {
 	}
 }
 
+func TestWalker_terraformIgnore(t *testing.T) {
+	ss, err := state.NewStateStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	root := t.TempDir()
+	files := map[string]string{
+		filepath.Join(root, ".terraformignore"):           "excluded/\n",
+		filepath.Join(root, "included", "main.tf"):        "",
+		filepath.Join(root, "excluded", "main.tf"):        "",
+		filepath.Join(root, "excluded", "sub", "main.tf"): "",
+	}
+	for path, content := range files {
+		err := os.MkdirAll(filepath.Dir(path), 0o755)
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = os.WriteFile(path, []byte(content), 0o644)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	ti, err := LoadTerraformIgnore(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fs := filesystem.NewFilesystem(ss.DocumentStore)
+	pa := state.NewPathAwaiter(ss.WalkerPaths, false)
+
+	walkFunc := func(ctx context.Context, modHandle document.DirHandle) (job.IDs, error) {
+		return job.IDs{}, nil
+	}
+
+	w := NewWalker(fs, pa, ss.Modules, walkFunc)
+	w.Collector = NewWalkerCollector()
+	w.SetLogger(testLogger())
+	w.SetTerraformIgnore(ti)
+
+	dir := document.DirHandleFromPath(root)
+
+	ctx := context.Background()
+	err = ss.WalkerPaths.EnqueueDir(ctx, dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx = lsctx.WithDocumentContext(ctx, lsctx.Document{})
+	err = w.StartWalking(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = ss.WalkerPaths.WaitForDirs(ctx, []document.DirHandle{dir})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = w.Collector.ErrorOrNil()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	modules, err := ss.Modules.List()
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectedModules := []string{
+		filepath.Join(root, "included"),
+	}
+	if diff := cmp.Diff(expectedModules, modulePaths(modules)); diff != "" {
+		t.Fatalf("unexpected modules: %s", diff)
+	}
+}
+
+func TestWalker_stopMidWalk(t *testing.T) {
+	ss, err := state.NewStateStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	root := t.TempDir()
+	for i := 0; i < 50; i++ {
+		dirPath := filepath.Join(root, fmt.Sprintf("module-%02d", i))
+		err := os.MkdirAll(dirPath, 0o755)
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = os.WriteFile(filepath.Join(dirPath, "main.tf"), []byte{}, 0o644)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	fs := filesystem.NewFilesystem(ss.DocumentStore)
+	pa := state.NewPathAwaiter(ss.WalkerPaths, false)
+
+	var w *Walker
+	walkedModules := 0
+	walkFunc := func(ctx context.Context, modHandle document.DirHandle) (job.IDs, error) {
+		walkedModules++
+		// simulate shutdown in the middle of walking
+		w.Stop()
+		return job.IDs{}, nil
+	}
+
+	w = NewWalker(fs, pa, ss.Modules, walkFunc)
+	w.Collector = NewWalkerCollector()
+	w.SetLogger(testLogger())
+
+	dir := document.DirHandleFromPath(root)
+
+	ctx := context.Background()
+	err = ss.WalkerPaths.EnqueueDir(ctx, dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx = lsctx.WithDocumentContext(ctx, lsctx.Document{})
+	err = w.StartWalking(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case <-w.Done():
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for walker to stop")
+	}
+
+	if walkedModules != 1 {
+		t.Fatalf("expected walk to stop after 1 module, %d walked", walkedModules)
+	}
+	err = w.Collector.ErrorOrNil()
+	if err != nil {
+		t.Fatalf("expected no errors from cancelled walk: %s", err)
+	}
+
+	err = w.StartWalking(ctx)
+	if err == nil {
+		t.Fatal("expected walker not to be started again")
+	}
+}
+
+func TestWalker_nestedRootsWithLockFiles(t *testing.T) {
+	ss, err := state.NewStateStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fs := filesystem.NewFilesystem(ss.DocumentStore)
+	pa := state.NewPathAwaiter(ss.WalkerPaths, false)
+
+	walkFunc := func(ctx context.Context, modHandle document.DirHandle) (job.IDs, error) {
+		return job.IDs{}, nil
+	}
+
+	w := NewWalker(fs, pa, ss.Modules, walkFunc)
+	w.Collector = NewWalkerCollector()
+	w.SetLogger(testLogger())
+
+	root, err := filepath.Abs(filepath.Join("testdata", "multi-root-nested-lock-files"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir := document.DirHandleFromPath(root)
+
+	ctx := context.Background()
+	err = ss.WalkerPaths.EnqueueDir(ctx, dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx = lsctx.WithDocumentContext(ctx, lsctx.Document{})
+	err = w.StartWalking(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = ss.WalkerPaths.WaitForDirs(ctx, []document.DirHandle{dir})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = w.Collector.ErrorOrNil()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	modules, err := ss.Modules.List()
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectedModules := []string{
+		root,
+		filepath.Join(root, "apps", "api"),
+		filepath.Join(root, "apps", "web"),
+		filepath.Join(root, "apps", "worker"),
+		filepath.Join(root, "network"),
+		filepath.Join(root, "network", "vpc"),
+	}
+	if diff := cmp.Diff(expectedModules, modulePaths(modules)); diff != "" {
+		t.Fatalf("modules don't match: %s", diff)
+	}
+}
+
 func TestWalker_complexModules(t *testing.T) {
 	testData, err := filepath.Abs("testdata")
 	if err != nil {